@@ -0,0 +1,85 @@
+package domain
+
+import "math"
+
+type EventAvailability struct {
+	EventID        int
+	OwnerID        int
+	Name           string
+	TotalSeats     *int
+	DirectSold     int
+	PacketReserved int
+	PacketSold     int
+}
+
+func (a *EventAvailability) Remaining() *int {
+	if a.TotalSeats == nil {
+		return nil
+	}
+	remaining := *a.TotalSeats - a.DirectSold - a.PacketReserved
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &remaining
+}
+
+func (a *EventAvailability) SellThrough() *float64 {
+	return sellThrough(a.DirectSold+a.PacketSold, a.TotalSeats)
+}
+
+type EventPacketAvailability struct {
+	PacketID       int
+	OwnerID        int
+	Name           string
+	AllocatedSeats *int
+	Sold           int
+	EventCount     int
+}
+
+func (a *EventPacketAvailability) Remaining() *int {
+	if a.AllocatedSeats == nil {
+		return nil
+	}
+	remaining := *a.AllocatedSeats - a.Sold
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &remaining
+}
+
+func (a *EventPacketAvailability) SellThrough() *float64 {
+	return sellThrough(a.Sold, a.AllocatedSeats)
+}
+
+type OwnerDashboard struct {
+	OwnerID int
+	Events  []*EventAvailability
+	Packets []*EventPacketAvailability
+}
+
+// Totals only take events with seats defined into account, since an event
+// without capacity has no meaningful remaining count or sell-through.
+func (d *OwnerDashboard) Totals() (totalSeats int, totalSold int, totalRemaining int) {
+	for _, event := range d.Events {
+		if event.TotalSeats == nil {
+			continue
+		}
+		totalSeats += *event.TotalSeats
+		totalSold += event.DirectSold + event.PacketSold
+		totalRemaining += *event.Remaining()
+	}
+	return totalSeats, totalSold, totalRemaining
+}
+
+func (d *OwnerDashboard) SellThrough() *float64 {
+	totalSeats, totalSold, _ := d.Totals()
+	return sellThrough(totalSold, &totalSeats)
+}
+
+func sellThrough(sold int, total *int) *float64 {
+	if total == nil || *total == 0 {
+		return nil
+	}
+	percent := math.Round(float64(sold)/float64(*total)*10000) / 100
+	return &percent
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	MaxCategoryNameLength = 64
+	MaxTagLength          = 32
+	MaxTagsPerItem        = 20
+	MaxFilterTags         = 10
+	MaxFacetValues        = 50
+)
+
+// Category is a curated, admin-managed classification (concerts, theatre...).
+type Category struct {
+	ID          int
+	Name        string
+	Slug        string
+	Description *string
+}
+
+// TagSet is what an owner attaches to an event or packet: categories by slug
+// and free-form tags. Attaching replaces the previous set.
+type TagSet struct {
+	Categories []string
+	Tags       []string
+}
+
+type FacetCount struct {
+	Value string
+	Label string
+	Count int
+}
+
+// EventFacets counts the events matching a filter per category and per tag.
+type EventFacets struct {
+	Categories []*FacetCount
+	Tags       []*FacetCount
+}
+
+func (category *Category) Validate() error {
+	if strings.TrimSpace(category.Name) == "" {
+		return &ValidationError{Field: "name", Reason: "name must be set"}
+	}
+	if len(category.Name) > MaxCategoryNameLength {
+		return &ValidationError{Field: "name", Reason: fmt.Sprintf("name must be at most %d characters", MaxCategoryNameLength)}
+	}
+	if category.Slug == "" {
+		category.Slug = Slugify(category.Name)
+	}
+	return ValidateSlug(category.Slug)
+}
+
+// Slugify lowercases value and joins its words with dashes, keeping letters
+// (including diacritics) and digits only.
+func Slugify(value string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && builder.Len() > 0 {
+				builder.WriteRune('-')
+			}
+			builder.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return builder.String()
+}
+
+func ValidateSlug(slug string) error {
+	if slug == "" || Slugify(slug) != slug {
+		return &ValidationError{Field: "slug", Reason: "slug must contain lowercase letters, digits and single dashes"}
+	}
+	if len(slug) > MaxCategoryNameLength {
+		return &ValidationError{Field: "slug", Reason: fmt.Sprintf("slug must be at most %d characters", MaxCategoryNameLength)}
+	}
+	return nil
+}
+
+// NormalizeTags slugifies and deduplicates tags, preserving their order.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = Slugify(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func validateTags(field string, tags []string, limit int) error {
+	if len(tags) > limit {
+		return &ValidationError{Field: field, Reason: fmt.Sprintf("at most %d %s are allowed", limit, field)}
+	}
+	for _, tag := range tags {
+		if len(tag) > MaxTagLength {
+			return &ValidationError{Field: field, Reason: fmt.Sprintf("%q is longer than %d characters", tag, MaxTagLength)}
+		}
+	}
+	return nil
+}
+
+func (set *TagSet) Normalize() {
+	set.Categories = NormalizeTags(set.Categories)
+	set.Tags = NormalizeTags(set.Tags)
+}
+
+func (set *TagSet) Validate() error {
+	if err := validateTags("categories", set.Categories, MaxTagsPerItem); err != nil {
+		return err
+	}
+	return validateTags("tags", set.Tags, MaxTagsPerItem)
+}
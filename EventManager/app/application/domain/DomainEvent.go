@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AggregateEvent = "event"
+	// inclusions are published on the packet they belong to
+	AggregateEventPacket = "event_packet"
+	AggregateTicket      = "ticket"
+)
+
+const (
+	EventCreated = "event.created"
+	EventUpdated = "event.updated"
+	EventDeleted = "event.deleted"
+	// an event called off is kept, so its ticket holders can be told
+	EventCancelled = "event.cancelled"
+
+	EventPacketCreated = "event_packet.created"
+	EventPacketUpdated = "event_packet.updated"
+	EventPacketDeleted = "event_packet.deleted"
+
+	EventPacketInclusionCreated = "event_packet_inclusion.created"
+	EventPacketInclusionUpdated = "event_packet_inclusion.updated"
+	EventPacketInclusionDeleted = "event_packet_inclusion.deleted"
+
+	TicketSold      = "ticket.sold"
+	TicketUpdated   = "ticket.updated"
+	TicketCancelled = "ticket.cancelled"
+)
+
+const (
+	// OutboxBaseRetryDelay is the wait before the second delivery attempt;
+	// every further failure doubles it up to OutboxMaxRetryDelay.
+	OutboxBaseRetryDelay = 2 * time.Second
+	OutboxMaxRetryDelay  = 5 * time.Minute
+	// OutboxClaimTimeout is how long a relay may hold a claimed batch while
+	// it publishes it; after that another relay takes the messages over.
+	OutboxClaimTimeout = 5 * time.Minute
+	// OutboxRetention is how long published messages are kept around for
+	// inspection before they are purged.
+	OutboxRetention = 7 * 24 * time.Hour
+)
+
+// OutboxMessage is a domain event recorded in the same transaction as the
+// change it describes. Messages of one aggregate are delivered in ID order;
+// a message is only handed to the broker once every earlier message of its
+// aggregate has been published.
+type OutboxMessage struct {
+	ID            int64
+	AggregateType string
+	AggregateID   string
+	Type          string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+	PublishedAt   *time.Time
+}
+
+// OutboxRetryDelay returns how long to wait before retrying a message that
+// has failed attempts times.
+func OutboxRetryDelay(attempts int) time.Duration {
+	delay := OutboxBaseRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= OutboxMaxRetryDelay {
+			return OutboxMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+type EventPayload struct {
+	ID          int        `json:"id"`
+	OwnerID     int        `json:"owner_id"`
+	Name        string     `json:"name"`
+	Location    *string    `json:"location,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Seats       *int       `json:"seats,omitempty"`
+	Address     *string    `json:"address,omitempty"`
+	City        *string    `json:"city,omitempty"`
+	Country     *string    `json:"country,omitempty"`
+	Latitude    *float64   `json:"latitude,omitempty"`
+	Longitude   *float64   `json:"longitude,omitempty"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	Sequence    int        `json:"sequence"`
+}
+
+func NewEventPayload(e *Event) *EventPayload {
+	return &EventPayload{
+		ID:          e.ID,
+		OwnerID:     e.OwnerID,
+		Name:        e.Name,
+		Location:    e.Location,
+		Description: e.Description,
+		Seats:       e.Seats,
+		Address:     e.Address,
+		City:        e.City,
+		Country:     e.Country,
+		Latitude:    e.Latitude,
+		Longitude:   e.Longitude,
+		StartsAt:    e.StartsAt,
+		EndsAt:      e.EndsAt,
+		CancelledAt: e.CancelledAt,
+		Sequence:    e.Sequence,
+	}
+}
+
+type EventPacketPayload struct {
+	ID             int      `json:"id"`
+	OwnerID        int      `json:"owner_id"`
+	Name           string   `json:"name"`
+	Location       *string  `json:"location,omitempty"`
+	Description    *string  `json:"description,omitempty"`
+	AllocatedSeats *int     `json:"allocated_seats,omitempty"`
+	Address        *string  `json:"address,omitempty"`
+	City           *string  `json:"city,omitempty"`
+	Country        *string  `json:"country,omitempty"`
+	Latitude       *float64 `json:"latitude,omitempty"`
+	Longitude      *float64 `json:"longitude,omitempty"`
+}
+
+func NewEventPacketPayload(p *EventPacket) *EventPacketPayload {
+	return &EventPacketPayload{
+		ID:             p.ID,
+		OwnerID:        p.OwnerID,
+		Name:           p.Name,
+		Location:       p.Location,
+		Description:    p.Description,
+		AllocatedSeats: p.AllocatedSeats,
+		Address:        p.Address,
+		City:           p.City,
+		Country:        p.Country,
+		Latitude:       p.Latitude,
+		Longitude:      p.Longitude,
+	}
+}
+
+type EventPacketInclusionPayload struct {
+	EventID  int `json:"event_id"`
+	PacketID int `json:"packet_id"`
+}
+
+// TicketPayload carries the owner of the event or packet the ticket was
+// sold for, so consumers can route it without calling back. PreviousCode is
+// only set when the code of the ticket was rotated.
+type TicketPayload struct {
+	Code         string  `json:"code"`
+	PreviousCode *string `json:"previous_code,omitempty"`
+	EventID      *int    `json:"event_id,omitempty"`
+	PacketID     *int    `json:"packet_id,omitempty"`
+	OwnerID      int     `json:"owner_id"`
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type ValidationError struct {
 	Field  string
@@ -14,6 +17,19 @@ func (e *ValidationError) Error() string {
 	return e.Reason
 }
 
+// ValidationErrors collects every failing field of a request body.
+type ValidationErrors struct {
+	Errors []*ValidationError
+}
+
+func (e *ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
 type NotFoundError struct {
 	ID int
 }
@@ -88,3 +104,138 @@ func (e *ForbiddenError) Error() string {
 	}
 	return "forbidden: you don't have permission to access this resource"
 }
+
+// Machine-readable codes for seat-availability failures.
+const (
+	CodeEventSoldOut          = "EVENT_SOLD_OUT"
+	CodePacketSoldOut         = "PACKET_SOLD_OUT"
+	CodeCapacityNotConfigured = "CAPACITY_NOT_CONFIGURED"
+)
+
+// Seat resources a SoldOutError or CapacityNotConfiguredError refers to.
+const (
+	SeatResourceEvent  = "event"
+	SeatResourcePacket = "packet"
+)
+
+// SoldOutError reports that an event or packet has no seat left to sell.
+// Reserved counts the seats an event has handed to its packets.
+type SoldOutError struct {
+	Resource  string
+	ID        int
+	Name      string
+	Capacity  int
+	Sold      int
+	Reserved  int
+	Remaining int
+}
+
+func (e *SoldOutError) Code() string {
+	if e.Resource == SeatResourcePacket {
+		return CodePacketSoldOut
+	}
+	return CodeEventSoldOut
+}
+
+func (e *SoldOutError) Error() string {
+	if e.Resource == SeatResourcePacket {
+		return fmt.Sprintf("packet '%s' is sold out (%d/%d tickets sold)", e.Name, e.Sold, e.Capacity)
+	}
+	return fmt.Sprintf("event '%s' has no available seats (total: %d, direct tickets: %d, packet allocations: %d)",
+		e.Name, e.Capacity, e.Sold, e.Reserved)
+}
+
+// CapacityNotConfiguredError reports a ticket sale for an event without seats
+// or a packet without allocated seats.
+type CapacityNotConfiguredError struct {
+	Resource string
+	ID       int
+}
+
+func (e *CapacityNotConfiguredError) Code() string {
+	return CodeCapacityNotConfigured
+}
+
+func (e *CapacityNotConfiguredError) Error() string {
+	if e.Resource == SeatResourcePacket {
+		return fmt.Sprintf("packet %d does not have allocated seats defined", e.ID)
+	}
+	return fmt.Sprintf("event %d does not have seats defined", e.ID)
+}
+
+const CodeEventCancelled = "EVENT_CANCELLED"
+
+// EventCancelledError reports a ticket sale for an event its owner called
+// off.
+type EventCancelledError struct {
+	ID   int
+	Name string
+}
+
+func (e *EventCancelledError) Code() string {
+	return CodeEventCancelled
+}
+
+func (e *EventCancelledError) Error() string {
+	return fmt.Sprintf("event '%s' was cancelled", e.Name)
+}
+
+const CodeTransfersBlocked = "TRANSFERS_BLOCKED"
+
+// TransfersBlockedError reports a ticket transfer for an event whose owner
+// does not allow them, or for a packet including such an event.
+type TransfersBlockedError struct {
+	Resource string
+	ID       int
+}
+
+func (e *TransfersBlockedError) Code() string {
+	return CodeTransfersBlocked
+}
+
+func (e *TransfersBlockedError) Error() string {
+	if e.Resource == SeatResourcePacket {
+		return fmt.Sprintf("packet %d includes an event that does not allow ticket transfers", e.ID)
+	}
+	return fmt.Sprintf("event %d does not allow ticket transfers", e.ID)
+}
+
+const (
+	CodeResaleNotAllowed    = "RESALE_NOT_ALLOWED"
+	CodeResalePriceAboveCap = "RESALE_PRICE_ABOVE_CAP"
+)
+
+// ResaleNotAllowedError reports a resale for an event whose owner does not
+// allow it, or for a packet including such an event.
+type ResaleNotAllowedError struct {
+	Resource string
+	ID       int
+}
+
+func (e *ResaleNotAllowedError) Code() string {
+	return CodeResaleNotAllowed
+}
+
+func (e *ResaleNotAllowedError) Error() string {
+	if e.Resource == SeatResourcePacket {
+		return fmt.Sprintf("packet %d includes an event that does not allow resale", e.ID)
+	}
+	return fmt.Sprintf("event %d does not allow resale", e.ID)
+}
+
+// ResalePriceAboveCapError reports a resale asking more than the markup the
+// owner allows over the face value.
+type ResalePriceAboveCapError struct {
+	Resource string
+	ID       int
+	Price    int
+	MaxPrice int
+}
+
+func (e *ResalePriceAboveCapError) Code() string {
+	return CodeResalePriceAboveCap
+}
+
+func (e *ResalePriceAboveCapError) Error() string {
+	return fmt.Sprintf("tickets of %s %d cannot be resold for more than %d (asked %d)", e.Resource, e.ID, e.MaxPrice, e.Price)
+}
@@ -1,5 +1,12 @@
 package domain
 
+import "time"
+
+const (
+	EventStateActive    = "active"
+	EventStateCancelled = "cancelled"
+)
+
 type Event struct {
 	ID          int
 	OwnerID     int
@@ -7,15 +14,86 @@ type Event struct {
 	Location    *string
 	Description *string
 	Seats       *int
+
+	Address   *string
+	City      *string
+	Country   *string
+	Latitude  *float64
+	Longitude *float64
+
+	// optional schedule; EndsAt is only set together with StartsAt
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	// set once the owner calls the event off; it then sells no tickets
+	CancelledAt *time.Time
+	// Sequence counts the changes to the event, so calendars that imported
+	// it can tell a newer version from an older one
+	Sequence int
+
+	// set by the owner to stop holders from passing tickets on
+	TransfersBlocked bool
+
+	// face value of a ticket, in bani
+	Price *int
+	// resale on the marketplace; without a markup cap any price goes
+	ResaleAllowed          bool
+	ResaleMaxMarkupPercent *int
+	// refunds can be asked for until this many hours before the start;
+	// without it the event does not refund tickets
+	RefundWindowHours *int
+	// most tickets one user may hold, counting those of packets including
+	// the event; without it there is no limit
+	MaxTicketsPerUser *int
+
+	Categories []*Category
+	Tags       []string
+
+	Match *SearchMatch
+	// distance from the near point of the filter, in kilometres
+	DistanceKm *float64
 }
 
 
 
 func (e *Event) GetState() string {
-	return "active"
+	if e.Cancelled() {
+		return EventStateCancelled
+	}
+	return EventStateActive
+}
+
+func (e *Event) Cancelled() bool {
+	return e.CancelledAt != nil
+}
+
+func (e *Event) ValidateLocation() error {
+	return validateCoordinates(e.Latitude, e.Longitude)
+}
+
+func (e *Event) ValidateSchedule() error {
+	return validateSchedule(e.StartsAt, e.EndsAt)
+}
+
+func (e *Event) ValidatePricing() error {
+	if err := validatePricing(e.Price, e.ResaleMaxMarkupPercent); err != nil {
+		return err
+	}
+	if err := validateRefundWindow(e.RefundWindowHours); err != nil {
+		return err
+	}
+	return validateTicketsPerUser(e.MaxTicketsPerUser)
+}
+
+func (e *Event) ResalePolicy() ResalePolicy {
+	return ResalePolicy{
+		Allowed:          e.ResaleAllowed,
+		MaxMarkupPercent: e.ResaleMaxMarkupPercent,
+		FaceValue:        e.Price,
+	}
 }
 
 type EventFilter struct {
+	Query       *string
 	Location    *string
 	Name        *string
 	Description *string
@@ -24,6 +102,11 @@ type EventFilter struct {
 	Page        *int
 	PerPage     *int
 	OrderBy     *string
+	Cursor      *string
+	Near        *string
+	RadiusKm    *float64
+	Category    *string
+	Tags        []string
 }
 
 func (filter *EventFilter) Default() {
@@ -35,6 +118,19 @@ func (filter *EventFilter) Default() {
 		filter.PerPage = new(int)
 		*filter.PerPage = 10
 	}
+	clampPerPage(filter.PerPage)
+	if filter.Category != nil {
+		*filter.Category = Slugify(*filter.Category)
+	}
+	filter.Tags = NormalizeTags(filter.Tags)
+	if filter.Query != nil && filter.OrderBy == nil {
+		filter.OrderBy = new(string)
+		*filter.OrderBy = "relevance"
+	}
+	if filter.Near != nil && filter.OrderBy == nil {
+		filter.OrderBy = new(string)
+		*filter.OrderBy = "distance"
+	}
 }
 
 func (filter *EventFilter) Validate() error {
@@ -43,10 +139,42 @@ func (filter *EventFilter) Validate() error {
 		"name_desc":  true,
 		"seats_asc":  true,
 		"seats_desc": true,
+		"relevance":  true,
+		"distance":   true,
 	}
 
 	if filter.OrderBy != nil && !validOrderings[*filter.OrderBy] {
-		return &ValidationError{Reason: "invalid order by. valid options: name_asc/desc, seats_asc/desc"}
+		return &ValidationError{Reason: "invalid order by. valid options: name_asc/desc, seats_asc/desc, relevance, distance"}
+	}
+	if err := validateSearchQuery(filter.Query, filter.OrderBy); err != nil {
+		return err
+	}
+	if err := validateNear(filter.Near, filter.RadiusKm, filter.OrderBy); err != nil {
+		return err
+	}
+	if filter.Category != nil {
+		if err := ValidateSlug(*filter.Category); err != nil {
+			return &ValidationError{Field: "category", Reason: "invalid category"}
+		}
+	}
+	if err := validateTags("tags", filter.Tags, MaxFilterTags); err != nil {
+		return err
+	}
+	if err := validatePaging(filter.Page, filter.PerPage, filter.Cursor); err != nil {
+		return err
+	}
+	if filter.Cursor != nil {
+		if _, err := DecodePageCursor(*filter.Cursor, filter.OrderBy); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// NearPoint returns the parsed near point, or nil when no geo filter is set.
+func (filter *EventFilter) NearPoint() (*GeoPoint, error) {
+	if filter.Near == nil {
+		return nil, nil
+	}
+	return ParseGeoPoint(*filter.Near)
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type EventPacket struct {
 	ID             int
 	OwnerID        int
@@ -7,9 +9,63 @@ type EventPacket struct {
 	Location       *string
 	Description    *string
 	AllocatedSeats *int
+
+	Address   *string
+	City      *string
+	Country   *string
+	Latitude  *float64
+	Longitude *float64
+
+	// derived from the included events: the earliest start and the latest
+	// end among them
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	// set when any included event blocks transfers
+	TransfersBlocked bool
+
+	// face value of a ticket, in bani
+	Price *int
+	// resale is allowed only when every included event allows it, and is
+	// capped by the smallest markup among them
+	ResaleAllowed          bool
+	ResaleMaxMarkupPercent *int
+	// set by the owner; counted back from the earliest start among the
+	// included events
+	RefundWindowHours *int
+	// most tickets of the packet one user may hold; packet tickets also
+	// count towards the limits of the included events
+	MaxTicketsPerUser *int
+
+	Categories []*Category
+	Tags       []string
+
+	Match *SearchMatch
+}
+
+func (e *EventPacket) ValidateLocation() error {
+	return validateCoordinates(e.Latitude, e.Longitude)
+}
+
+func (e *EventPacket) ValidatePricing() error {
+	if err := validatePricing(e.Price, nil); err != nil {
+		return err
+	}
+	if err := validateRefundWindow(e.RefundWindowHours); err != nil {
+		return err
+	}
+	return validateTicketsPerUser(e.MaxTicketsPerUser)
+}
+
+func (e *EventPacket) ResalePolicy() ResalePolicy {
+	return ResalePolicy{
+		Allowed:          e.ResaleAllowed,
+		MaxMarkupPercent: e.ResaleMaxMarkupPercent,
+		FaceValue:        e.Price,
+	}
 }
 
 type EventPacketFilter struct {
+	Query       *string
 	Location    *string
 	Name        *string
 	Description *string
@@ -18,6 +74,7 @@ type EventPacketFilter struct {
 	Page        *int
 	PerPage     *int
 	OrderBy     *string
+	Cursor      *string
 }
 
 func (filter *EventPacketFilter) Default() {
@@ -29,6 +86,11 @@ func (filter *EventPacketFilter) Default() {
 		filter.PerPage = new(int)
 		*filter.PerPage = 10
 	}
+	clampPerPage(filter.PerPage)
+	if filter.Query != nil && filter.OrderBy == nil {
+		filter.OrderBy = new(string)
+		*filter.OrderBy = "relevance"
+	}
 }
 
 func (filter *EventPacketFilter) Validate() error {
@@ -37,10 +99,22 @@ func (filter *EventPacketFilter) Validate() error {
 		"name_desc":  true,
 		"seats_asc":  true,
 		"seats_desc": true,
+		"relevance":  true,
 	}
 
 	if filter.OrderBy != nil && !validOrderings[*filter.OrderBy] {
-		return &ValidationError{Reason: "invalid order by. valid options: name_asc/desc, seats_asc/desc"}
+		return &ValidationError{Reason: "invalid order by. valid options: name_asc/desc, seats_asc/desc, relevance"}
+	}
+	if err := validateSearchQuery(filter.Query, filter.OrderBy); err != nil {
+		return err
+	}
+	if err := validatePaging(filter.Page, filter.PerPage, filter.Cursor); err != nil {
+		return err
+	}
+	if filter.Cursor != nil {
+		if _, err := DecodePageCursor(*filter.Cursor, filter.OrderBy); err != nil {
+			return err
+		}
 	}
 	return nil
 }
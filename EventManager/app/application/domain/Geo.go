@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	EarthRadiusKm  = 6371.0
+	MaxNearRadius  = 1000.0
+	kmPerDegreeLat = 111.32
+)
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// BoundingBox is the lat/lng rectangle that contains every point within
+// radiusKm of the point. It is only a prefilter for the haversine distance.
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+
+	// the box covers every longitude (near a pole or for a huge radius)
+	AllLongitudes bool
+	// the box crosses the antimeridian, so MinLng > MaxLng
+	WrapsLongitude bool
+}
+
+func (p GeoPoint) BoundingBox(radiusKm float64) BoundingBox {
+	deltaLat := radiusKm / kmPerDegreeLat
+	box := BoundingBox{
+		MinLat: math.Max(p.Latitude-deltaLat, -90),
+		MaxLat: math.Min(p.Latitude+deltaLat, 90),
+	}
+
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.AllLongitudes = true
+		return box
+	}
+
+	deltaLng := radiusKm / (kmPerDegreeLat * math.Cos(p.Latitude*math.Pi/180))
+	if deltaLng >= 180 {
+		box.AllLongitudes = true
+		return box
+	}
+
+	box.MinLng = p.Longitude - deltaLng
+	box.MaxLng = p.Longitude + deltaLng
+	if box.MinLng < -180 {
+		box.MinLng += 360
+		box.WrapsLongitude = true
+	}
+	if box.MaxLng > 180 {
+		box.MaxLng -= 360
+		box.WrapsLongitude = true
+	}
+	return box
+}
+
+func ParseGeoPoint(value string) (*GeoPoint, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, &ValidationError{Field: "near", Reason: "near must be formatted as lat,lng"}
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return nil, &ValidationError{Field: "near", Reason: "invalid latitude"}
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return nil, &ValidationError{Field: "near", Reason: "invalid longitude"}
+	}
+
+	if err := validateCoordinates(&lat, &lng); err != nil {
+		return nil, err
+	}
+	return &GeoPoint{Latitude: lat, Longitude: lng}, nil
+}
+
+func validateCoordinates(latitude *float64, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return &ValidationError{Reason: "latitude and longitude must be set together"}
+	}
+	if latitude == nil {
+		return nil
+	}
+	if *latitude < -90 || *latitude > 90 {
+		return &ValidationError{Field: "latitude", Reason: "latitude must be between -90 and 90"}
+	}
+	if *longitude < -180 || *longitude > 180 {
+		return &ValidationError{Field: "longitude", Reason: "longitude must be between -180 and 180"}
+	}
+	return nil
+}
+
+// ValidateCoordinateUpdates checks latitude/longitude in a partial update map.
+func ValidateCoordinateUpdates(updates map[string]interface{}) error {
+	rawLat, hasLat := updates["latitude"]
+	rawLng, hasLng := updates["longitude"]
+	if !hasLat && !hasLng {
+		return nil
+	}
+	if hasLat != hasLng {
+		return &ValidationError{Reason: "latitude and longitude must be updated together"}
+	}
+
+	lat, latOk := rawLat.(float64)
+	lng, lngOk := rawLng.(float64)
+	if !latOk || !lngOk {
+		return &ValidationError{Reason: "latitude and longitude must be numbers"}
+	}
+	return validateCoordinates(&lat, &lng)
+}
+
+func validateNear(near *string, radiusKm *float64, orderBy *string) error {
+	if near == nil && radiusKm == nil {
+		if orderBy != nil && *orderBy == "distance" {
+			return &ValidationError{Field: "order_by", Reason: "distance ordering requires near and radius_km"}
+		}
+		return nil
+	}
+	if near == nil || radiusKm == nil {
+		return &ValidationError{Field: "near", Reason: "near and radius_km must be used together"}
+	}
+	if *radiusKm <= 0 || *radiusKm > MaxNearRadius {
+		return &ValidationError{Field: "radius_km", Reason: "radius_km must be greater than 0 and at most 1000"}
+	}
+	_, err := ParseGeoPoint(*near)
+	return err
+}
@@ -0,0 +1,68 @@
+package domain
+
+const (
+	ImportKindEvent  = "event"
+	ImportKindPacket = "packet"
+
+	// MaxImportRows bounds a bulk import, which is created in a single
+	// transaction.
+	MaxImportRows = 1000
+)
+
+// ImportRow is one row of a bulk import: an event or a packet. Ref names the
+// row within the import, so packets can include events created by the same
+// import through EventRefs. A row that could not be read has neither Event
+// nor Packet, only Problems.
+type ImportRow struct {
+	Line      int
+	Ref       string
+	Event     *Event
+	Packet    *EventPacket
+	EventRefs []string
+	Problems  []*ValidationError
+}
+
+func (r *ImportRow) Kind() string {
+	switch {
+	case r.Event != nil:
+		return ImportKindEvent
+	case r.Packet != nil:
+		return ImportKindPacket
+	}
+	return ""
+}
+
+func (r *ImportRow) Reject(field string, reason string) {
+	r.Problems = append(r.Problems, &ValidationError{Field: field, Reason: reason})
+}
+
+// ImportResult reports every row of a bulk import. Nothing is created unless
+// every row is valid; Created is false for dry runs and rejected imports.
+type ImportResult struct {
+	DryRun  bool
+	Created bool
+	Rows    []*ImportRow
+}
+
+func (r *ImportResult) Valid() bool {
+	for _, row := range r.Rows {
+		if len(row.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Counts returns how many events, packets and inclusions the import holds.
+func (r *ImportResult) Counts() (events int, packets int, inclusions int) {
+	for _, row := range r.Rows {
+		switch row.Kind() {
+		case ImportKindEvent:
+			events++
+		case ImportKindPacket:
+			packets++
+			inclusions += len(row.EventRefs)
+		}
+	}
+	return events, packets, inclusions
+}
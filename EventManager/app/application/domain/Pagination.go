@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const MaxPerPage = 100
+
+// PageCursor is the keyset position a listing continues from. It is handed
+// out to clients as an opaque base64 string.
+type PageCursor struct {
+	OrderBy  string      `json:"o,omitempty"`
+	Key      interface{} `json:"k,omitempty"`
+	ID       int         `json:"id"`
+	Backward bool        `json:"b,omitempty"`
+}
+
+type PageInfo struct {
+	NextCursor *string
+	PrevCursor *string
+}
+
+func (cursor *PageCursor) Encode() string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodePageCursor(encoded string, orderBy *string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, &ValidationError{Field: "cursor", Reason: "invalid cursor"}
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID < 1 {
+		return nil, &ValidationError{Field: "cursor", Reason: "invalid cursor"}
+	}
+
+	expected := ""
+	if orderBy != nil {
+		expected = *orderBy
+	}
+	if cursor.OrderBy != expected {
+		return nil, &ValidationError{Field: "cursor", Reason: "cursor was issued for a different order_by"}
+	}
+
+	return &cursor, nil
+}
+
+func clampPerPage(perPage *int) {
+	if perPage != nil && *perPage > MaxPerPage {
+		*perPage = MaxPerPage
+	}
+}
+
+func validatePaging(page *int, perPage *int, cursor *string) error {
+	if page != nil && *page < 1 {
+		return &ValidationError{Field: "page", Reason: "page must be at least 1"}
+	}
+	if perPage != nil && *perPage < 1 {
+		return &ValidationError{Field: "per_page", Reason: "per_page must be at least 1"}
+	}
+	if cursor != nil && page != nil && *page > 1 {
+		return &ValidationError{Field: "cursor", Reason: "cursor cannot be combined with page"}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+// ResalePolicy is what the owner allows when a ticket is sold on by its
+// holder.
+type ResalePolicy struct {
+	Allowed          bool
+	MaxMarkupPercent *int
+	FaceValue        *int
+}
+
+// MaxPrice is the most a ticket may be resold for, or nil when the owner set
+// no markup cap. A ticket without a face value counts as free, so a capped
+// one can only be passed on for nothing.
+func (p ResalePolicy) MaxPrice() *int {
+	if p.MaxMarkupPercent == nil {
+		return nil
+	}
+	faceValue := 0
+	if p.FaceValue != nil {
+		faceValue = *p.FaceValue
+	}
+	maxPrice := faceValue * (100 + *p.MaxMarkupPercent) / 100
+	return &maxPrice
+}
+
+// Check reports whether a ticket of the event or packet id may be resold for
+// price.
+func (p ResalePolicy) Check(resource string, id int, price int) error {
+	if price < 0 {
+		return &ValidationError{Field: "price", Reason: "price cannot be negative"}
+	}
+	if !p.Allowed {
+		return &ResaleNotAllowedError{Resource: resource, ID: id}
+	}
+	if maxPrice := p.MaxPrice(); maxPrice != nil && price > *maxPrice {
+		return &ResalePriceAboveCapError{Resource: resource, ID: id, Price: price, MaxPrice: *maxPrice}
+	}
+	return nil
+}
+
+func validatePricing(price *int, maxMarkupPercent *int) error {
+	if price != nil && *price < 0 {
+		return &ValidationError{Field: "price", Reason: "price cannot be negative"}
+	}
+	if maxMarkupPercent != nil && *maxMarkupPercent < 0 {
+		return &ValidationError{Field: "resale_max_markup_percent", Reason: "resale_max_markup_percent cannot be negative"}
+	}
+	return nil
+}
+
+func validateRefundWindow(hours *int) error {
+	if hours != nil && *hours < 0 {
+		return &ValidationError{Field: "refund_window_hours", Reason: "refund_window_hours cannot be negative"}
+	}
+	return nil
+}
+
+func validateTicketsPerUser(limit *int) error {
+	if limit != nil && *limit < 1 {
+		return &ValidationError{Field: "max_tickets_per_user", Reason: "max_tickets_per_user must be at least 1"}
+	}
+	return nil
+}
+
+// ValidatePricingUpdates checks the price, markup cap, refund window and
+// per-user ticket limit of an update.
+func ValidatePricingUpdates(updates map[string]interface{}) error {
+	var price, maxMarkupPercent, refundWindowHours, maxTicketsPerUser *int
+	if value, ok := updates["price"].(int); ok {
+		price = &value
+	}
+	if value, ok := updates["resale_max_markup_percent"].(int); ok {
+		maxMarkupPercent = &value
+	}
+	if value, ok := updates["refund_window_hours"].(int); ok {
+		refundWindowHours = &value
+	}
+	if value, ok := updates["max_tickets_per_user"].(int); ok {
+		maxTicketsPerUser = &value
+	}
+	if err := validatePricing(price, maxMarkupPercent); err != nil {
+		return err
+	}
+	if err := validateRefundWindow(refundWindowHours); err != nil {
+		return err
+	}
+	return validateTicketsPerUser(maxTicketsPerUser)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxBatchIDs caps the ids a single batch lookup can ask for.
+const MaxBatchIDs = 100
+
+func validateSchedule(startsAt *time.Time, endsAt *time.Time) error {
+	if endsAt == nil {
+		return nil
+	}
+	if startsAt == nil {
+		return &ValidationError{Field: "ends_at", Reason: "ends_at requires starts_at"}
+	}
+	if endsAt.Before(*startsAt) {
+		return &ValidationError{Field: "ends_at", Reason: "ends_at must not be before starts_at"}
+	}
+	return nil
+}
+
+// ValidateScheduleUpdates checks the starts_at and ends_at of an update
+// against the schedule they change.
+func ValidateScheduleUpdates(current *Event, updates map[string]interface{}) error {
+	startsAt, endsAt := current.StartsAt, current.EndsAt
+	if value, ok := updates["starts_at"].(time.Time); ok {
+		startsAt = &value
+	}
+	if value, ok := updates["ends_at"].(time.Time); ok {
+		endsAt = &value
+	}
+	return validateSchedule(startsAt, endsAt)
+}
+
+func ValidateBatchIDs(ids []int) error {
+	if len(ids) == 0 {
+		return &ValidationError{Field: "ids", Reason: "at least one id is required"}
+	}
+	if len(ids) > MaxBatchIDs {
+		return &ValidationError{Field: "ids", Reason: fmt.Sprintf("at most %d ids can be requested at once", MaxBatchIDs)}
+	}
+	for _, id := range ids {
+		if id < 1 {
+			return &ValidationError{Field: "ids", Reason: fmt.Sprintf("id:%d must be positive", id)}
+		}
+	}
+	return nil
+}
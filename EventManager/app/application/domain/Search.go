@@ -0,0 +1,31 @@
+package domain
+
+import "strings"
+
+const maxSearchQueryLength = 200
+
+// SearchMatch carries the full-text search metadata of a result. It is only
+// populated when the filter has a search query. The highlights are safe
+// HTML: escaped text with the matched words wrapped in <mark>.
+type SearchMatch struct {
+	Rank                 float64
+	NameHighlight        string
+	DescriptionHighlight *string
+}
+
+func validateSearchQuery(query *string, orderBy *string) error {
+	if query != nil {
+		trimmed := strings.TrimSpace(*query)
+		if trimmed == "" {
+			return &ValidationError{Field: "q", Reason: "search query cannot be empty"}
+		}
+		if len(trimmed) > maxSearchQueryLength {
+			return &ValidationError{Field: "q", Reason: "search query is too long"}
+		}
+	}
+
+	if orderBy != nil && *orderBy == "relevance" && query == nil {
+		return &ValidationError{Field: "order_by", Reason: "relevance ordering requires a search query (q)"}
+	}
+	return nil
+}
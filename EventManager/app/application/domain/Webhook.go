@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending    = "pending"
+	WebhookDeliveryDelivered  = "delivered"
+	WebhookDeliveryDeadLetter = "dead_letter"
+)
+
+const (
+	// MaxWebhookAttempts failed attempts move a delivery to the dead-letter
+	// list, from where it can only be redelivered by hand.
+	MaxWebhookAttempts     = 8
+	WebhookBaseRetryDelay  = 30 * time.Second
+	WebhookMaxRetryDelay   = time.Hour
+	MinWebhookSecretLength = 16
+	MaxWebhookFilterIDs    = 100
+	// WebhookClaimTimeout is how long a dispatcher may hold a claimed batch
+	// while it sends it; after that another dispatcher takes it over.
+	WebhookClaimTimeout = 5 * time.Minute
+)
+
+// WebhookEventTypes are the domain events owners can subscribe to.
+var WebhookEventTypes = []string{TicketSold, TicketUpdated, TicketCancelled}
+
+// reservedWebhookPrefixes are non-public ranges net/netip has no predicate
+// for.
+var reservedWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// IsPublicWebhookAddress reports whether webhooks may be sent to ip.
+// Loopback, private, link-local (cloud metadata endpoints such as
+// 169.254.169.254 live there), multicast, unspecified and reserved addresses
+// are refused, so a subscription cannot reach into the service's own network.
+func IsPublicWebhookAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range reservedWebhookPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookSubscription sends the ticket events of everything its owner
+// organizes to URL. EventIDs and PacketIDs narrow it down to some of the
+// owner's events and packets; when both are empty every ticket matches.
+type WebhookSubscription struct {
+	ID         int
+	OwnerID    int
+	URL        string
+	Secret     string
+	EventTypes []string
+	EventIDs   []int
+	PacketIDs  []int
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (s *WebhookSubscription) Validate() error {
+	var errs []*ValidationError
+
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs = append(errs, &ValidationError{Field: "url", Reason: "must be an absolute http or https URL"})
+	} else if !isPublicWebhookHost(parsed.Hostname()) {
+		errs = append(errs, &ValidationError{Field: "url", Reason: "must not point to a loopback, private or link-local address"})
+	}
+	if len(s.Secret) < MinWebhookSecretLength {
+		errs = append(errs, &ValidationError{Field: "secret", Reason: fmt.Sprintf("must be at least %d characters", MinWebhookSecretLength)})
+	}
+	if len(s.EventTypes) == 0 {
+		errs = append(errs, &ValidationError{Field: "event_types", Reason: "at least one event type is required"})
+	}
+	for _, eventType := range s.EventTypes {
+		if !slices.Contains(WebhookEventTypes, eventType) {
+			errs = append(errs, &ValidationError{Field: "event_types", Reason: fmt.Sprintf("unknown event type '%s'", eventType)})
+		}
+	}
+	if len(s.EventIDs) > MaxWebhookFilterIDs || len(s.PacketIDs) > MaxWebhookFilterIDs {
+		errs = append(errs, &ValidationError{Field: "event_ids", Reason: fmt.Sprintf("at most %d events and %d packets can be listed", MaxWebhookFilterIDs, MaxWebhookFilterIDs)})
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &ValidationErrors{Errors: errs}
+	}
+}
+
+// isPublicWebhookHost catches the obvious internal hosts early. Names that
+// resolve to internal addresses are refused by the sender when it connects.
+func isPublicWebhookHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return IsPublicWebhookAddress(ip)
+	}
+	return true
+}
+
+// Matches reports whether a ticket event has to be sent to the subscription.
+func (s *WebhookSubscription) Matches(eventType string, ticket *TicketPayload) bool {
+	if !s.Active || ticket.OwnerID != s.OwnerID || !slices.Contains(s.EventTypes, eventType) {
+		return false
+	}
+	if len(s.EventIDs) == 0 && len(s.PacketIDs) == 0 {
+		return true
+	}
+	if ticket.EventID != nil && slices.Contains(s.EventIDs, *ticket.EventID) {
+		return true
+	}
+	return ticket.PacketID != nil && slices.Contains(s.PacketIDs, *ticket.PacketID)
+}
+
+// WebhookSubscriptionPatch holds the fields of a partial update; nil fields
+// are left unchanged.
+type WebhookSubscriptionPatch struct {
+	URL        *string
+	Secret     *string
+	EventTypes []string
+	EventIDs   []int
+	PacketIDs  []int
+	Active     *bool
+}
+
+func (p *WebhookSubscriptionPatch) Apply(s *WebhookSubscription) {
+	if p.URL != nil {
+		s.URL = *p.URL
+	}
+	if p.Secret != nil {
+		s.Secret = *p.Secret
+	}
+	if p.EventTypes != nil {
+		s.EventTypes = p.EventTypes
+	}
+	if p.EventIDs != nil {
+		s.EventIDs = p.EventIDs
+	}
+	if p.PacketIDs != nil {
+		s.PacketIDs = p.PacketIDs
+	}
+	if p.Active != nil {
+		s.Active = *p.Active
+	}
+}
+
+// WebhookDelivery is one domain event on its way to one subscription.
+// MessageID is the outbox message it was created from and stays the same
+// across retries and manual redeliveries.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int
+	MessageID      int64
+	EventType      string
+	Payload        json.RawMessage
+	OccurredAt     time.Time
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookRetryDelay returns how long to wait before retrying a delivery that
+// has failed attempts times.
+func WebhookRetryDelay(attempts int) time.Duration {
+	delay := WebhookBaseRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= WebhookMaxRetryDelay {
+			return WebhookMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+type WebhookDeliveryFilter struct {
+	Status  *string
+	Page    *int
+	PerPage *int
+}
+
+func (filter *WebhookDeliveryFilter) Default() {
+	if filter.Page == nil {
+		filter.Page = new(int)
+		*filter.Page = 1
+	}
+	if filter.PerPage == nil {
+		filter.PerPage = new(int)
+		*filter.PerPage = 20
+	}
+	clampPerPage(filter.PerPage)
+}
+
+func (filter *WebhookDeliveryFilter) Validate() error {
+	if filter.Status != nil {
+		switch *filter.Status {
+		case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDeadLetter:
+		default:
+			return &ValidationError{Field: "status", Reason: "must be one of pending, delivered, dead_letter"}
+		}
+	}
+	return validatePaging(filter.Page, filter.PerPage, nil)
+}
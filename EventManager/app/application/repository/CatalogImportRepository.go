@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"eventManager/application/domain"
+)
+
+type CatalogImportRepository interface {
+	// GetTakenNames returns the names among eventNames and packetNames that
+	// existing events and packets already use.
+	GetTakenNames(ctx context.Context, eventNames []string, packetNames []string) (takenEvents []string, takenPackets []string, err error)
+	// Import creates the events, then the packets and their inclusions of
+	// the rows in a single transaction, setting the ids on the rows. Rows
+	// are expected to be valid.
+	Import(ctx context.Context, rows []*domain.ImportRow) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"eventManager/application/domain"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	GetByID(ctx context.Context, id int) (*domain.Category, error)
+	GetAll(ctx context.Context) ([]*domain.Category, error)
+	GetBySlugs(ctx context.Context, slugs []string) ([]*domain.Category, error)
+	Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.Category, error)
+	Delete(ctx context.Context, id int) (*domain.Category, error)
+	SetEventTags(ctx context.Context, eventID int, categoryIDs []int, tags []string) error
+	SetEventPacketTags(ctx context.Context, packetID int, categoryIDs []int, tags []string) error
+}
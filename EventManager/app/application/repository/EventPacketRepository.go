@@ -8,9 +8,12 @@ import (
 type EventPacketRepository interface {
 	Create(ctx context.Context, eventPacket *domain.EventPacket) (*domain.EventPacket, error)
 	GetByID(ctx context.Context, id int) (*domain.EventPacket, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*domain.EventPacket, error)
 	Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.EventPacket, error)
 	Delete(ctx context.Context, id int) (*domain.EventPacket, error)
 	CountSoldTickets(ctx context.Context, id int) (int, error)
-	FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, error)
+	FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, *domain.PageInfo, error)
 	CountEventPackets(ctx context.Context, filter *domain.EventPacketFilter) (int, error)
+	GetAvailability(ctx context.Context, id int) (*domain.EventPacketAvailability, error)
+	GetAvailabilityByOwner(ctx context.Context, ownerID int) ([]*domain.EventPacketAvailability, error)
 }
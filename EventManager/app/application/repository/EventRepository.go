@@ -3,14 +3,20 @@ package repository
 import (
 	"context"
 	"eventManager/application/domain"
+	"time"
 )
 
 type EventRepository interface {
 	Create(ctx context.Context, event *domain.Event) (*domain.Event, error)
 	GetByID(ctx context.Context, id int) (*domain.Event, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*domain.Event, error)
 	Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.Event, error)
+	Cancel(ctx context.Context, id int, at time.Time) (*domain.Event, error)
 	Delete(ctx context.Context, id int) (*domain.Event, error)
-	FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, error)
+	FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, *domain.PageInfo, error)
 	CountEvents(ctx context.Context, filter *domain.EventFilter) (int, error)
+	GetFacets(ctx context.Context, filter *domain.EventFilter) (*domain.EventFacets, error)
 	CountSoldTickets(ctx context.Context, id int) (int, error)
+	GetAvailability(ctx context.Context, id int) (*domain.EventAvailability, error)
+	GetAvailabilityByOwner(ctx context.Context, ownerID int) ([]*domain.EventAvailability, error)
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"shared/idempotency"
+)
+
+type IdempotencyRepository interface {
+	idempotency.Store
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
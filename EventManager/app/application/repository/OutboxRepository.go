@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"time"
+)
+
+type OutboxRepository interface {
+	// ProcessPending claims up to limit due messages that are first in line
+	// for their aggregate and passes each to publish outside of any database
+	// transaction. Messages publish accepted are marked published; the others
+	// are rescheduled with a backoff. It returns how many messages were
+	// published.
+	ProcessPending(ctx context.Context, limit int, publish func(ctx context.Context, message *domain.OutboxMessage) error) (int, error)
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
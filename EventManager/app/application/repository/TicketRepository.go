@@ -10,5 +10,6 @@ type TicketRepository interface {
 	GetTicketByCode(ctx context.Context, code string) (*domain.Ticket, error)
 	UpdateTicket(ctx context.Context, code string, updates map[string]interface{}) (*domain.Ticket, error)
 	ReplaceTicket(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error)
+	RotateCode(ctx context.Context, code string, newCode string) (*domain.Ticket, error)
 	DeleteEvent(ctx context.Context, code string) (*domain.Ticket, error)
 }
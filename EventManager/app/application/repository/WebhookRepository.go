@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"eventManager/application/domain"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetSubscriptionByID(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+	GetSubscriptionsByOwner(ctx context.Context, ownerID int) ([]*domain.WebhookSubscription, error)
+	GetActiveSubscriptionsByOwner(ctx context.Context, ownerID int) ([]*domain.WebhookSubscription, error)
+	SaveSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+
+	// EnqueueDeliveries stores new deliveries, skipping any whose message was
+	// already enqueued for the same subscription.
+	EnqueueDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, subscriptionID int, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int, error)
+	GetDelivery(ctx context.Context, subscriptionID int, deliveryID int64) (*domain.WebhookDelivery, error)
+	// ResetDelivery puts a delivery back in the queue with a fresh attempt
+	// budget, whatever its status.
+	ResetDelivery(ctx context.Context, subscriptionID int, deliveryID int64) (*domain.WebhookDelivery, error)
+	// ProcessDue claims up to limit pending deliveries of active subscriptions
+	// that are due and passes each to send outside of any database
+	// transaction; send returns the HTTP status the receiver answered with. Successful deliveries are marked delivered,
+	// failed ones are rescheduled or moved to the dead-letter list. It
+	// returns how many deliveries were attempted.
+	ProcessDue(ctx context.Context, limit int, send func(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error)) (int, error)
+}
@@ -18,6 +18,7 @@ type AuthorizationService interface {
 	CanUserDeleteTicket(ctx context.Context, user UserIdentity, ticket *domain.Ticket) (bool, error)
 	CanUserBuyTicket(ctx context.Context, user UserIdentity, event *domain.Event) (bool, error)
 	CanUserCreateTicket(ctx context.Context, user UserIdentity) (bool, error)
+	CanUserRotateTicket(ctx context.Context, user UserIdentity, ticket *domain.Ticket) (bool, error)
 
 	CanUserCreateEventPacket(ctx context.Context, user UserIdentity) (bool, error)
 	CanUserViewEventPacket(ctx context.Context, user UserIdentity, packet *domain.EventPacket) (bool, error)
@@ -29,4 +30,12 @@ type AuthorizationService interface {
 	CanUserViewEventPacketInclusion(ctx context.Context, user UserIdentity, eventID int, packetID int) (bool, error)
 	CanUserUpdateEventPacketInclusion(ctx context.Context, user UserIdentity, eventID int, packetID int) (bool, error)
 	CanUserDeleteEventPacketInclusion(ctx context.Context, user UserIdentity, eventID int, packetID int) (bool, error)
+
+	CanUserViewEventAvailability(ctx context.Context, user UserIdentity, event *domain.Event) (bool, error)
+	CanUserViewEventPacketAvailability(ctx context.Context, user UserIdentity, packet *domain.EventPacket) (bool, error)
+	CanUserViewOwnerDashboard(ctx context.Context, user UserIdentity, ownerID int) (bool, error)
+
+	CanUserManageCategories(ctx context.Context, user UserIdentity) (bool, error)
+
+	CanUserManageWebhooks(ctx context.Context, user UserIdentity, ownerID int) (bool, error)
 }
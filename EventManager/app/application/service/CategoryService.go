@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"eventManager/application/repository"
+	"fmt"
+	"strings"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	GetCategoryByID(ctx context.Context, id int) (*domain.Category, error)
+	GetCategories(ctx context.Context) ([]*domain.Category, error)
+	UpdateCategory(ctx context.Context, id int, updates map[string]interface{}) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, id int) (*domain.Category, error)
+	SetEventTags(ctx context.Context, eventID int, set *domain.TagSet) error
+	SetEventPacketTags(ctx context.Context, packetID int, set *domain.TagSet) error
+}
+
+type categoryService struct {
+	repo repository.CategoryRepository
+}
+
+func NewCategoryService(repo repository.CategoryRepository) CategoryService {
+	return &categoryService{
+		repo: repo,
+	}
+}
+
+func (service *categoryService) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	if category == nil {
+		return nil, &domain.ValidationError{Reason: "invalid object received"}
+	}
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+	return service.repo.Create(ctx, category)
+}
+
+func (service *categoryService) GetCategoryByID(ctx context.Context, id int) (*domain.Category, error) {
+	if id < 1 {
+		return nil, &domain.ValidationError{Reason: fmt.Sprintf("id:%d must be positive", id)}
+	}
+	return service.repo.GetByID(ctx, id)
+}
+
+func (service *categoryService) GetCategories(ctx context.Context) ([]*domain.Category, error) {
+	return service.repo.GetAll(ctx)
+}
+
+// UpdateCategory keeps the slug when only the name changes, so filter links
+// handed out earlier keep working.
+func (service *categoryService) UpdateCategory(ctx context.Context, id int, updates map[string]interface{}) (*domain.Category, error) {
+	if len(updates) == 0 {
+		return nil, &domain.ValidationError{Reason: "no fields to update"}
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		if strings.TrimSpace(name) == "" {
+			return nil, &domain.ValidationError{Field: "name", Reason: "name must be set"}
+		}
+		if len(name) > domain.MaxCategoryNameLength {
+			return nil, &domain.ValidationError{Field: "name", Reason: fmt.Sprintf("name must be at most %d characters", domain.MaxCategoryNameLength)}
+		}
+	}
+
+	if slug, ok := updates["slug"].(string); ok {
+		if err := domain.ValidateSlug(slug); err != nil {
+			return nil, err
+		}
+	}
+
+	return service.repo.Update(ctx, id, updates)
+}
+
+func (service *categoryService) DeleteCategory(ctx context.Context, id int) (*domain.Category, error) {
+	if id < 1 {
+		return nil, &domain.ValidationError{Reason: fmt.Sprintf("id:%d must be positive", id)}
+	}
+	return service.repo.Delete(ctx, id)
+}
+
+func (service *categoryService) SetEventTags(ctx context.Context, eventID int, set *domain.TagSet) error {
+	categoryIDs, err := service.resolveTagSet(ctx, set)
+	if err != nil {
+		return err
+	}
+	return service.repo.SetEventTags(ctx, eventID, categoryIDs, set.Tags)
+}
+
+func (service *categoryService) SetEventPacketTags(ctx context.Context, packetID int, set *domain.TagSet) error {
+	categoryIDs, err := service.resolveTagSet(ctx, set)
+	if err != nil {
+		return err
+	}
+	return service.repo.SetEventPacketTags(ctx, packetID, categoryIDs, set.Tags)
+}
+
+// resolveTagSet normalizes the set and maps its category slugs to ids; owners
+// can only pick from the categories an admin created.
+func (service *categoryService) resolveTagSet(ctx context.Context, set *domain.TagSet) ([]int, error) {
+	if set == nil {
+		return nil, &domain.ValidationError{Reason: "invalid object received"}
+	}
+
+	set.Normalize()
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+
+	categories, err := service.repo.GetBySlugs(ctx, set.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]int, len(categories))
+	for _, category := range categories {
+		known[category.Slug] = category.ID
+	}
+
+	ids := make([]int, 0, len(set.Categories))
+	for _, slug := range set.Categories {
+		id, ok := known[slug]
+		if !ok {
+			return nil, &domain.ValidationError{Field: "categories", Reason: fmt.Sprintf("unknown category %q", slug)}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -9,10 +9,14 @@ import (
 
 type EventPacketService interface {
 	CreateEventPacket(ctx context.Context, event *domain.EventPacket) (*domain.EventPacket, error)
+	// ValidateEventPacket applies the rules CreateEventPacket checks a
+	// packet against.
+	ValidateEventPacket(event *domain.EventPacket) error
 	GetEventPacketByID(ctx context.Context, id int) (*domain.EventPacket, error)
+	GetEventPacketsByIDs(ctx context.Context, ids []int) ([]*domain.EventPacket, error)
 	UpdateEventPacket(ctx context.Context, id int, updates map[string]interface{}) (*domain.EventPacket, error)
 	DeleteEventPacket(ctx context.Context, id int) (*domain.EventPacket, error)
-	FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, error)
+	FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, *domain.PageInfo, error)
 }
 
 type eventPacketService struct {
@@ -37,6 +41,10 @@ func (service *eventPacketService) CreateEventPacket(ctx context.Context, event
 	return service.repo.Create(ctx, event)
 }
 
+func (service *eventPacketService) ValidateEventPacket(event *domain.EventPacket) error {
+	return service.validateEventPacket(event)
+}
+
 func (service *eventPacketService) GetEventPacketByID(ctx context.Context, id int) (*domain.EventPacket, error) {
 	if id < 1 {
 		return nil, &domain.ValidationError{Reason: fmt.Sprintf("id:%d must be positive", id)}
@@ -44,12 +52,27 @@ func (service *eventPacketService) GetEventPacketByID(ctx context.Context, id in
 	return service.repo.GetByID(ctx, id)
 }
 
+func (service *eventPacketService) GetEventPacketsByIDs(ctx context.Context, ids []int) ([]*domain.EventPacket, error) {
+	if err := domain.ValidateBatchIDs(ids); err != nil {
+		return nil, err
+	}
+	return service.repo.GetByIDs(ctx, ids)
+}
+
 func (service *eventPacketService) UpdateEventPacket(ctx context.Context, id int, updates map[string]interface{}) (*domain.EventPacket, error) {
 
 	if len(updates) == 0 {
 		return nil, &domain.ValidationError{Reason: "no fields to update"}
 	}
 
+	if err := domain.ValidateCoordinateUpdates(updates); err != nil {
+		return nil, err
+	}
+
+	if err := domain.ValidatePricingUpdates(updates); err != nil {
+		return nil, err
+	}
+
 	if owner_id, ok := updates["id_owner"]; ok {
 		if owner_idPtr, ok := owner_id.(int); ok && owner_idPtr < 1 {
 			return nil, &domain.ValidationError{Reason: "owner_id must be positive"}
@@ -112,7 +135,11 @@ func (service *eventPacketService) validateEventPacket(event *domain.EventPacket
 		return &domain.ValidationError{Reason: "allocated_seats must be non-negative"}
 	}
 
-	return nil
+	if err := event.ValidatePricing(); err != nil {
+		return err
+	}
+
+	return event.ValidateLocation()
 }
 
 func findMinSeats(events []*domain.Event) *int {
@@ -179,6 +206,6 @@ func (service *eventPacketService) validateAllocatedSeatsConstraint(ctx context.
 	return nil
 }
 
-func (service *eventPacketService) FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, error) {
+func (service *eventPacketService) FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, *domain.PageInfo, error) {
 	return service.repo.FilterEventPackets(ctx, filter)
 }
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"eventManager/application/domain"
+)
+
+// EventPublisher hands a domain event to a message broker. Publish must only
+// return nil once the broker has accepted the message; any error makes the
+// relay retry it later, so consumers can see a message more than once and
+// should deduplicate on its ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, message *domain.OutboxMessage) error
+}
+
+// EventPublisherFunc lets a plain function act as an EventPublisher.
+type EventPublisherFunc func(ctx context.Context, message *domain.OutboxMessage) error
+
+func (f EventPublisherFunc) Publish(ctx context.Context, message *domain.OutboxMessage) error {
+	return f(ctx, message)
+}
@@ -5,14 +5,19 @@ import (
 	"eventManager/application/domain"
 	"eventManager/application/repository"
 	"fmt"
+	"time"
 )
 
 type EventService interface {
 	CreateEvent(ctx context.Context, event *domain.Event) (*domain.Event, error)
+	// ValidateEvent applies the rules CreateEvent checks an event against.
+	ValidateEvent(event *domain.Event) error
 	GetEventByID(ctx context.Context, id int) (*domain.Event, error)
+	GetEventsByIDs(ctx context.Context, ids []int) ([]*domain.Event, error)
 	UpdateEvent(ctx context.Context, id int, updates map[string]interface{}) (*domain.Event, error)
+	CancelEvent(ctx context.Context, id int) (*domain.Event, error)
 	DeleteEvent(ctx context.Context, id int) (*domain.Event, error)
-	FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, error)
+	FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, *domain.PageInfo, error)
 }
 
 type eventService struct {
@@ -39,7 +44,20 @@ func (service *eventService) validateEvent(event *domain.Event) error {
 	if event.Name == "" {
 		return &domain.ValidationError{Reason: "name must be set"}
 	}
-	return nil
+
+	if err := event.ValidateSchedule(); err != nil {
+		return err
+	}
+
+	if err := event.ValidatePricing(); err != nil {
+		return err
+	}
+
+	return event.ValidateLocation()
+}
+
+func (service *eventService) ValidateEvent(event *domain.Event) error {
+	return service.validateEvent(event)
 }
 
 func (service *eventService) CreateEvent(ctx context.Context, event *domain.Event) (*domain.Event, error) {
@@ -56,12 +74,39 @@ func (service *eventService) GetEventByID(ctx context.Context, id int) (*domain.
 	return service.repo.GetByID(ctx, id)
 }
 
+func (service *eventService) GetEventsByIDs(ctx context.Context, ids []int) ([]*domain.Event, error) {
+	if err := domain.ValidateBatchIDs(ids); err != nil {
+		return nil, err
+	}
+	return service.repo.GetByIDs(ctx, ids)
+}
+
 func (service *eventService) UpdateEvent(ctx context.Context, id int, updates map[string]interface{}) (*domain.Event, error) {
 
 	if len(updates) == 0 {
 		return nil, &domain.ValidationError{Reason: "no fields to update"}
 	}
 
+	if err := domain.ValidateCoordinateUpdates(updates); err != nil {
+		return nil, err
+	}
+
+	if err := domain.ValidatePricingUpdates(updates); err != nil {
+		return nil, err
+	}
+
+	_, startsAt := updates["starts_at"]
+	_, endsAt := updates["ends_at"]
+	if startsAt || endsAt {
+		current, err := service.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := domain.ValidateScheduleUpdates(current, updates); err != nil {
+			return nil, err
+		}
+	}
+
 	if seats, ok := updates["seats"]; ok {
 		if seatsPtr, ok := seats.(int); ok {
 			if seatsPtr < 0 {
@@ -122,6 +167,15 @@ func (service *eventService) validateTotalRequiredSeats(ctx context.Context, eve
 	return nil
 }
 
+// CancelEvent calls the event off. It stays listed and its tickets stay
+// valid records, but no more are sold.
+func (service *eventService) CancelEvent(ctx context.Context, id int) (*domain.Event, error) {
+	if id < 1 {
+		return nil, &domain.ValidationError{Reason: fmt.Sprintf("id:%d must be positive", id)}
+	}
+	return service.repo.Cancel(ctx, id, time.Now().UTC())
+}
+
 func (service *eventService) DeleteEvent(ctx context.Context, id int) (*domain.Event, error) {
 	if id < 1 {
 		return nil, &domain.ValidationError{Reason: fmt.Sprintf("id:%d must be positive", id)}
@@ -129,6 +183,6 @@ func (service *eventService) DeleteEvent(ctx context.Context, id int) (*domain.E
 	return service.repo.Delete(ctx, id)
 }
 
-func (service *eventService) FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, error) {
+func (service *eventService) FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, *domain.PageInfo, error) {
 	return service.repo.FilterEvents(ctx, filter)
 }
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"eventManager/application/domain"
+	"eventManager/application/repository"
+	"fmt"
+)
+
+type ImportService interface {
+	// ImportCatalog checks every row and, unless dryRun is set or a row is
+	// invalid, creates all of them at once for ownerID.
+	ImportCatalog(ctx context.Context, ownerID int, rows []*domain.ImportRow, dryRun bool) (*domain.ImportResult, error)
+}
+
+type importService struct {
+	repo               repository.CatalogImportRepository
+	eventService       EventService
+	eventPacketService EventPacketService
+}
+
+func NewImportService(repo repository.CatalogImportRepository, eventService EventService, eventPacketService EventPacketService) ImportService {
+	return &importService{
+		repo:               repo,
+		eventService:       eventService,
+		eventPacketService: eventPacketService,
+	}
+}
+
+func (service *importService) ImportCatalog(ctx context.Context, ownerID int, rows []*domain.ImportRow, dryRun bool) (*domain.ImportResult, error) {
+	if len(rows) == 0 {
+		return nil, &domain.ValidationError{Field: "rows", Reason: "the import has no rows"}
+	}
+	if len(rows) > domain.MaxImportRows {
+		return nil, &domain.ValidationError{Field: "rows", Reason: fmt.Sprintf("at most %d rows can be imported at once", domain.MaxImportRows)}
+	}
+
+	for _, row := range rows {
+		if err := service.validateRow(ownerID, row); err != nil {
+			return nil, err
+		}
+	}
+	if err := service.validateNames(ctx, rows); err != nil {
+		return nil, err
+	}
+	validateInclusions(rows)
+
+	result := &domain.ImportResult{DryRun: dryRun, Rows: rows}
+	if dryRun || !result.Valid() {
+		return result, nil
+	}
+
+	if err := service.repo.Import(ctx, rows); err != nil {
+		return nil, err
+	}
+	result.Created = true
+	return result, nil
+}
+
+// validateRow owns the row to ownerID and checks it the way a single create
+// would. Errors other than validation errors are returned.
+func (service *importService) validateRow(ownerID int, row *domain.ImportRow) error {
+	var err error
+	switch row.Kind() {
+	case domain.ImportKindEvent:
+		row.Event.OwnerID = ownerID
+		err = service.eventService.ValidateEvent(row.Event)
+	case domain.ImportKindPacket:
+		row.Packet.OwnerID = ownerID
+		err = service.eventPacketService.ValidateEventPacket(row.Packet)
+	default:
+		return nil
+	}
+
+	var validationErrs *domain.ValidationErrors
+	var validationErr *domain.ValidationError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &validationErrs):
+		row.Problems = append(row.Problems, validationErrs.Errors...)
+		return nil
+	case errors.As(err, &validationErr):
+		row.Problems = append(row.Problems, validationErr)
+		return nil
+	}
+	return err
+}
+
+// validateNames rejects names used twice in the import or already taken,
+// as events and packets have unique names.
+func (service *importService) validateNames(ctx context.Context, rows []*domain.ImportRow) error {
+	eventRows := make(map[string]*domain.ImportRow)
+	packetRows := make(map[string]*domain.ImportRow)
+	var eventNames, packetNames []string
+	for _, row := range rows {
+		var name string
+		var named map[string]*domain.ImportRow
+		switch row.Kind() {
+		case domain.ImportKindEvent:
+			name, named = row.Event.Name, eventRows
+		case domain.ImportKindPacket:
+			name, named = row.Packet.Name, packetRows
+		default:
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		if other, ok := named[name]; ok {
+			row.Reject("name", fmt.Sprintf("name is already used by line %d", other.Line))
+			continue
+		}
+		named[name] = row
+		if row.Kind() == domain.ImportKindEvent {
+			eventNames = append(eventNames, name)
+		} else {
+			packetNames = append(packetNames, name)
+		}
+	}
+
+	takenEvents, takenPackets, err := service.repo.GetTakenNames(ctx, eventNames, packetNames)
+	if err != nil {
+		return err
+	}
+	for _, name := range takenEvents {
+		eventRows[name].Reject("name", fmt.Sprintf("an event named '%s' already exists", name))
+	}
+	for _, name := range takenPackets {
+		packetRows[name].Reject("name", fmt.Sprintf("a packet named '%s' already exists", name))
+	}
+	return nil
+}
+
+// validateInclusions resolves the events of each packet among the event rows
+// and checks the seats the way adding events to packets does: every event of
+// a packet with allocated seats needs at least as many seats, and the
+// packets of an event cannot allocate more seats than it has.
+func validateInclusions(rows []*domain.ImportRow) {
+	refs := make(map[string]*domain.ImportRow)
+	for _, row := range rows {
+		if row.Ref == "" {
+			continue
+		}
+		if other, ok := refs[row.Ref]; ok {
+			row.Reject("ref", fmt.Sprintf("ref '%s' is already used by line %d", row.Ref, other.Line))
+			continue
+		}
+		refs[row.Ref] = row
+	}
+
+	allocated := make(map[*domain.ImportRow]int)
+	for _, row := range rows {
+		if row.Kind() != domain.ImportKindPacket {
+			continue
+		}
+
+		seen := make(map[string]bool, len(row.EventRefs))
+		for _, ref := range row.EventRefs {
+			if seen[ref] {
+				row.Reject("events", fmt.Sprintf("event '%s' is listed twice", ref))
+				continue
+			}
+			seen[ref] = true
+
+			target, ok := refs[ref]
+			if !ok {
+				row.Reject("events", fmt.Sprintf("no row of this import has ref '%s'", ref))
+				continue
+			}
+			if target.Kind() == "" {
+				// the event row reports its own problems
+				continue
+			}
+			if target.Kind() != domain.ImportKindEvent {
+				row.Reject("events", fmt.Sprintf("ref '%s' is a packet, not an event", ref))
+				continue
+			}
+
+			seats := row.Packet.AllocatedSeats
+			if seats == nil {
+				continue
+			}
+			event := target.Event
+			if event.Seats == nil {
+				row.Reject("events", fmt.Sprintf("event '%s' doesn't have seats defined, cannot be added to packet requiring %d seats", ref, *seats))
+				continue
+			}
+			if *event.Seats < *seats {
+				row.Reject("events", fmt.Sprintf("event '%s' has %d seats but packet requires %d allocated seats", ref, *event.Seats, *seats))
+				continue
+			}
+			if allocated[target]+*seats > *event.Seats {
+				row.Reject("allocated_seats", fmt.Sprintf(
+					"cannot allocate %d seats: event '%s' only has %d available seats (%d total - %d in other packets)",
+					*seats, ref, *event.Seats-allocated[target], *event.Seats, allocated[target],
+				))
+				continue
+			}
+			allocated[target] += *seats
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"eventManager/application/repository"
+	"fmt"
+	"time"
+)
+
+const defaultOutboxBatchSize = 100
+
+// OutboxRelay moves recorded domain events from the outbox to the broker.
+type OutboxRelay interface {
+	// RelayPending publishes every message that is currently due and returns
+	// how many were published.
+	RelayPending(ctx context.Context) (int, error)
+	// Run relays pending messages every interval until ctx is done.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type outboxRelay struct {
+	repo      repository.OutboxRepository
+	publisher EventPublisher
+	batchSize int
+}
+
+func NewOutboxRelay(repo repository.OutboxRepository, publisher EventPublisher) OutboxRelay {
+	return &outboxRelay{
+		repo:      repo,
+		publisher: publisher,
+		batchSize: defaultOutboxBatchSize,
+	}
+}
+
+func (relay *outboxRelay) RelayPending(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		published, err := relay.repo.ProcessPending(ctx, relay.batchSize, relay.publisher.Publish)
+		total += published
+		if err != nil {
+			return total, err
+		}
+		// nothing more went out, so whatever is left is waiting for a retry
+		// or for an earlier message of its aggregate
+		if published == 0 {
+			return total, nil
+		}
+	}
+}
+
+func (relay *outboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastPurge := time.Now()
+	for {
+		if _, err := relay.RelayPending(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Failed to relay domain events: %v\n", err)
+		}
+
+		if time.Since(lastPurge) >= time.Hour {
+			if _, err := relay.repo.DeletePublished(ctx, time.Now().Add(-domain.OutboxRetention)); err != nil && ctx.Err() == nil {
+				fmt.Printf("Failed to purge published domain events: %v\n", err)
+			}
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,9 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"eventManager/application/domain"
 	"eventManager/application/repository"
-	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +15,8 @@ type TicketService interface {
 	GetTicketByCode(ctx context.Context, code string) (*domain.Ticket, error)
 	UpdateTicket(ctx context.Context, code string, updates map[string]interface{}) (*domain.Ticket, error)
 	DeleteTicket(ctx context.Context, code string) (*domain.Ticket, error)
+	RotateTicketCode(ctx context.Context, code string, newCode string) (*domain.Ticket, error)
+	ResellTicket(ctx context.Context, code string, newCode string, price int) (*domain.Ticket, error)
 }
 
 type ticketService struct {
@@ -92,10 +94,12 @@ func (service *ticketService) validateSeatAvailability(ctx context.Context, tick
 			return err
 		}
 
+		if event.Cancelled() {
+			return &domain.EventCancelledError{ID: event.ID, Name: event.Name}
+		}
+
 		if event.Seats == nil {
-			return &domain.ValidationError{
-				Reason: fmt.Sprintf("event %d does not have seats defined", *ticket.EventID),
-			}
+			return &domain.CapacityNotConfiguredError{Resource: domain.SeatResourceEvent, ID: *ticket.EventID}
 		}
 
 		directTicketsSold, err := service.eventRepo.CountSoldTickets(ctx, *ticket.EventID)
@@ -120,9 +124,14 @@ func (service *ticketService) validateSeatAvailability(ctx context.Context, tick
 		availableSeats := totalSeats - reservedSeats
 
 		if availableSeats <= 0 {
-			return &domain.ValidationError{
-				Reason: fmt.Sprintf("event '%s' has no available seats (total: %d, direct tickets: %d, packet allocations: %d)",
-					event.Name, totalSeats, directTicketsSold, totalPacketSeats),
+			return &domain.SoldOutError{
+				Resource:  domain.SeatResourceEvent,
+				ID:        event.ID,
+				Name:      event.Name,
+				Capacity:  totalSeats,
+				Sold:      directTicketsSold,
+				Reserved:  totalPacketSeats,
+				Remaining: max(availableSeats, 0),
 			}
 		}
 	}
@@ -135,8 +144,16 @@ func (service *ticketService) validateSeatAvailability(ctx context.Context, tick
 		}
 
 		if packet.AllocatedSeats == nil {
-			return &domain.ValidationError{
-				Reason: fmt.Sprintf("packet %d does not have allocated seats defined", *ticket.PacketID),
+			return &domain.CapacityNotConfiguredError{Resource: domain.SeatResourcePacket, ID: *ticket.PacketID}
+		}
+
+		events, err := service.inclusionRepo.GetEventsByPacketID(ctx, *ticket.PacketID)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if event.Cancelled() {
+				return &domain.EventCancelledError{ID: event.ID, Name: event.Name}
 			}
 		}
 
@@ -148,9 +165,13 @@ func (service *ticketService) validateSeatAvailability(ctx context.Context, tick
 		availableSeats := *packet.AllocatedSeats - soldTickets
 
 		if availableSeats <= 0 {
-			return &domain.ValidationError{
-				Reason: fmt.Sprintf("packet '%s' is sold out (%d/%d tickets sold)",
-					packet.Name, soldTickets, *packet.AllocatedSeats),
+			return &domain.SoldOutError{
+				Resource:  domain.SeatResourcePacket,
+				ID:        packet.ID,
+				Name:      packet.Name,
+				Capacity:  *packet.AllocatedSeats,
+				Sold:      soldTickets,
+				Remaining: max(availableSeats, 0),
 			}
 		}
 	}
@@ -239,3 +260,87 @@ func (service *ticketService) DeleteTicket(ctx context.Context, code string) (*d
 	}
 	return service.repo.DeleteEvent(ctx, code)
 }
+
+// RotateTicketCode moves a ticket to newCode when it changes hands. The
+// caller picks newCode so a retry after a lost response rotates to the same
+// code instead of minting another one.
+func (service *ticketService) RotateTicketCode(ctx context.Context, code string, newCode string) (*domain.Ticket, error) {
+	return service.rotate(ctx, code, newCode, service.validateTransferAllowed)
+}
+
+// ResellTicket reissues a ticket sold on the marketplace under newCode, once
+// the owner's resale policy allows price. Like RotateTicketCode it is safe to
+// retry.
+func (service *ticketService) ResellTicket(ctx context.Context, code string, newCode string, price int) (*domain.Ticket, error) {
+	return service.rotate(ctx, code, newCode, func(ctx context.Context, ticket *domain.Ticket) error {
+		return service.validateResaleAllowed(ctx, ticket, price)
+	})
+}
+
+// rotate moves the ticket to newCode once allowed accepts it.
+func (service *ticketService) rotate(ctx context.Context, code string, newCode string, allowed func(ctx context.Context, ticket *domain.Ticket) error) (*domain.Ticket, error) {
+	if code == "" {
+		return nil, &domain.ValidationError{Reason: "ticket code is required"}
+	}
+	if _, err := uuid.Parse(newCode); err != nil {
+		return nil, &domain.ValidationError{Field: "new_code", Reason: "must be a UUID"}
+	}
+	if newCode == code {
+		return nil, &domain.ValidationError{Field: "new_code", Reason: "must differ from the current code"}
+	}
+
+	ticket, err := service.repo.GetTicketByCode(ctx, code)
+	var notFound *domain.NotFoundError
+	if errors.As(err, &notFound) {
+		// already rotated by an earlier attempt
+		return service.repo.RotateCode(ctx, code, newCode)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := allowed(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	return service.repo.RotateCode(ctx, code, newCode)
+}
+
+func (service *ticketService) validateTransferAllowed(ctx context.Context, ticket *domain.Ticket) error {
+	if ticket.EventID != nil {
+		event, err := service.eventRepo.GetByID(ctx, *ticket.EventID)
+		if err != nil {
+			return err
+		}
+		if event.TransfersBlocked {
+			return &domain.TransfersBlockedError{Resource: domain.SeatResourceEvent, ID: event.ID}
+		}
+	}
+
+	if ticket.PacketID != nil {
+		packet, err := service.packetRepo.GetByID(ctx, *ticket.PacketID)
+		if err != nil {
+			return err
+		}
+		if packet.TransfersBlocked {
+			return &domain.TransfersBlockedError{Resource: domain.SeatResourcePacket, ID: packet.ID}
+		}
+	}
+
+	return nil
+}
+
+func (service *ticketService) validateResaleAllowed(ctx context.Context, ticket *domain.Ticket, price int) error {
+	if ticket.EventID != nil {
+		event, err := service.eventRepo.GetByID(ctx, *ticket.EventID)
+		if err != nil {
+			return err
+		}
+		return event.ResalePolicy().Check(domain.SeatResourceEvent, event.ID, price)
+	}
+
+	packet, err := service.packetRepo.GetByID(ctx, *ticket.PacketID)
+	if err != nil {
+		return err
+	}
+	return packet.ResalePolicy().Check(domain.SeatResourcePacket, packet.ID, price)
+}
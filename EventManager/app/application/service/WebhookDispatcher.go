@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"eventManager/application/repository"
+	"fmt"
+	"time"
+)
+
+const defaultWebhookBatchSize = 20
+
+// WebhookDispatcher sends queued webhook deliveries and schedules retries.
+type WebhookDispatcher interface {
+	// DeliverDue attempts every delivery that is currently due and returns
+	// how many were attempted.
+	DeliverDue(ctx context.Context) (int, error)
+	// Run delivers due webhooks every interval until ctx is done.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type webhookDispatcher struct {
+	repo      repository.WebhookRepository
+	sender    WebhookSender
+	batchSize int
+}
+
+func NewWebhookDispatcher(repo repository.WebhookRepository, sender WebhookSender) WebhookDispatcher {
+	return &webhookDispatcher{
+		repo:      repo,
+		sender:    sender,
+		batchSize: defaultWebhookBatchSize,
+	}
+}
+
+func (dispatcher *webhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		attempted, err := dispatcher.repo.ProcessDue(ctx, dispatcher.batchSize, dispatcher.sender.Send)
+		total += attempted
+		if err != nil || attempted < dispatcher.batchSize {
+			return total, err
+		}
+	}
+}
+
+func (dispatcher *webhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := dispatcher.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Failed to deliver webhooks: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"eventManager/application/domain"
+)
+
+// WebhookSender posts a delivery to the URL of its subscription. It returns
+// the status the receiver answered with, or 0 when no response came back,
+// and an error unless the receiver acknowledged it with a 2xx.
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"eventManager/application/domain"
+	"eventManager/application/repository"
+	"slices"
+	"time"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetSubscriptionByID(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+	GetSubscriptionsByOwner(ctx context.Context, ownerID int) ([]*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id int, patch *domain.WebhookSubscriptionPatch) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+	GetDeliveries(ctx context.Context, subscriptionID int, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int, error)
+	Redeliver(ctx context.Context, subscriptionID int, deliveryID int64) (*domain.WebhookDelivery, error)
+	// Enqueue creates a delivery for every subscription interested in a
+	// domain event. It is safe to call more than once for the same message.
+	Enqueue(ctx context.Context, message *domain.OutboxMessage) error
+}
+
+type webhookService struct {
+	repo       repository.WebhookRepository
+	eventRepo  repository.EventRepository
+	packetRepo repository.EventPacketRepository
+}
+
+func NewWebhookService(repo repository.WebhookRepository, eventRepo repository.EventRepository, packetRepo repository.EventPacketRepository) WebhookService {
+	return &webhookService{
+		repo:       repo,
+		eventRepo:  eventRepo,
+		packetRepo: packetRepo,
+	}
+}
+
+func newWebhookSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(raw), nil
+}
+
+// validateFilter checks that the subscription only narrows down to events and
+// packets its owner organizes.
+func (service *webhookService) validateFilter(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	for _, eventID := range subscription.EventIDs {
+		event, err := service.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			if _, ok := err.(*domain.NotFoundError); ok {
+				return &domain.ValidationError{Field: "event_ids", Reason: "event not found"}
+			}
+			return err
+		}
+		if event.OwnerID != subscription.OwnerID {
+			return &domain.ForbiddenError{Reason: "webhooks can only filter on your own events"}
+		}
+	}
+	for _, packetID := range subscription.PacketIDs {
+		packet, err := service.packetRepo.GetByID(ctx, packetID)
+		if err != nil {
+			if _, ok := err.(*domain.NotFoundError); ok {
+				return &domain.ValidationError{Field: "packet_ids", Reason: "event packet not found"}
+			}
+			return err
+		}
+		if packet.OwnerID != subscription.OwnerID {
+			return &domain.ForbiddenError{Reason: "webhooks can only filter on your own event packets"}
+		}
+	}
+	return nil
+}
+
+func (service *webhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	if subscription == nil {
+		return nil, &domain.ValidationError{Reason: "invalid object received"}
+	}
+
+	if subscription.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, &domain.InternalError{Msg: "failed to generate webhook secret", Err: err}
+		}
+		subscription.Secret = secret
+	}
+	subscription.EventTypes = compactStrings(subscription.EventTypes)
+	subscription.EventIDs = compactInts(subscription.EventIDs)
+	subscription.PacketIDs = compactInts(subscription.PacketIDs)
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	if err := service.validateFilter(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	subscription.Active = true
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	return service.repo.CreateSubscription(ctx, subscription)
+}
+
+func (service *webhookService) GetSubscriptionByID(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	return service.repo.GetSubscriptionByID(ctx, id)
+}
+
+func (service *webhookService) GetSubscriptionsByOwner(ctx context.Context, ownerID int) ([]*domain.WebhookSubscription, error) {
+	return service.repo.GetSubscriptionsByOwner(ctx, ownerID)
+}
+
+func (service *webhookService) UpdateSubscription(ctx context.Context, id int, patch *domain.WebhookSubscriptionPatch) (*domain.WebhookSubscription, error) {
+	if patch == nil {
+		return nil, &domain.ValidationError{Reason: "invalid object received"}
+	}
+
+	subscription, err := service.repo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.Apply(subscription)
+	subscription.EventTypes = compactStrings(subscription.EventTypes)
+	subscription.EventIDs = compactInts(subscription.EventIDs)
+	subscription.PacketIDs = compactInts(subscription.PacketIDs)
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	if patch.EventIDs != nil || patch.PacketIDs != nil {
+		if err := service.validateFilter(ctx, subscription); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription.UpdatedAt = time.Now().UTC()
+	return service.repo.SaveSubscription(ctx, subscription)
+}
+
+func (service *webhookService) DeleteSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	return service.repo.DeleteSubscription(ctx, id)
+}
+
+func (service *webhookService) GetDeliveries(ctx context.Context, subscriptionID int, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int, error) {
+	if filter == nil {
+		filter = &domain.WebhookDeliveryFilter{}
+	}
+	filter.Default()
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+	return service.repo.GetDeliveries(ctx, subscriptionID, filter)
+}
+
+func (service *webhookService) Redeliver(ctx context.Context, subscriptionID int, deliveryID int64) (*domain.WebhookDelivery, error) {
+	return service.repo.ResetDelivery(ctx, subscriptionID, deliveryID)
+}
+
+func (service *webhookService) Enqueue(ctx context.Context, message *domain.OutboxMessage) error {
+	if !slices.Contains(domain.WebhookEventTypes, message.Type) {
+		return nil
+	}
+
+	var ticket domain.TicketPayload
+	if err := json.Unmarshal(message.Payload, &ticket); err != nil {
+		// a payload that cannot be read will not get better on retry
+		return nil
+	}
+	if ticket.OwnerID == 0 {
+		return nil
+	}
+
+	subscriptions, err := service.repo.GetActiveSubscriptionsByOwner(ctx, ticket.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var deliveries []*domain.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(message.Type, &ticket) {
+			continue
+		}
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			MessageID:      message.ID,
+			EventType:      message.Type,
+			Payload:        message.Payload,
+			OccurredAt:     message.OccurredAt,
+			Status:         domain.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+
+	return service.repo.EnqueueDeliveries(ctx, deliveries)
+}
+
+func compactStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(ret, value) {
+			ret = append(ret, value)
+		}
+	}
+	return ret
+}
+
+func compactInts(values []int) []int {
+	if values == nil {
+		return nil
+	}
+	ret := make([]int, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(ret, value) {
+			ret = append(ret, value)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"eventManager/application/repository"
+	"eventManager/application/service"
+	"fmt"
+)
+
+type AvailabilityUseCase interface {
+	GetEventAvailability(ctx context.Context, token string, eventID int) (*domain.EventAvailability, error)
+	GetEventPacketAvailability(ctx context.Context, token string, packetID int) (*domain.EventPacketAvailability, error)
+	GetOwnerDashboard(ctx context.Context, token string, ownerID int) (*domain.OwnerDashboard, error)
+}
+
+type availabilityUseCase struct {
+	eventRepo    repository.EventRepository
+	packetRepo   repository.EventPacketRepository
+	authNService service.AuthenticationService
+	authZService service.AuthorizationService
+}
+
+func NewAvailabilityUseCase(
+	eventRepo repository.EventRepository,
+	packetRepo repository.EventPacketRepository,
+	authNService service.AuthenticationService,
+	authZService service.AuthorizationService,
+) *availabilityUseCase {
+	return &availabilityUseCase{
+		eventRepo:    eventRepo,
+		packetRepo:   packetRepo,
+		authNService: authNService,
+		authZService: authZService,
+	}
+}
+
+func (uc *availabilityUseCase) authenticate(ctx context.Context, token string) (*service.UserIdentity, error) {
+	identity, err := uc.authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return nil, &domain.ValidationError{Reason: "invalid or expired token"}
+	}
+	return identity, nil
+}
+
+func (uc *availabilityUseCase) GetEventAvailability(ctx context.Context, token string, eventID int) (*domain.EventAvailability, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserViewEventAvailability(ctx, *identity, event)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "only the event owner can view its availability"}
+	}
+
+	return uc.eventRepo.GetAvailability(ctx, eventID)
+}
+
+func (uc *availabilityUseCase) GetEventPacketAvailability(ctx context.Context, token string, packetID int) (*domain.EventPacketAvailability, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	packet, err := uc.packetRepo.GetByID(ctx, packetID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserViewEventPacketAvailability(ctx, *identity, packet)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "only the packet owner can view its availability"}
+	}
+
+	return uc.packetRepo.GetAvailability(ctx, packetID)
+}
+
+func (uc *availabilityUseCase) GetOwnerDashboard(ctx context.Context, token string, ownerID int) (*domain.OwnerDashboard, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserViewOwnerDashboard(ctx, *identity, ownerID)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you can only view your own dashboard"}
+	}
+
+	events, err := uc.eventRepo.GetAvailabilityByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	packets, err := uc.packetRepo.GetAvailabilityByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OwnerDashboard{
+		OwnerID: ownerID,
+		Events:  events,
+		Packets: packets,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"eventManager/application/repository"
+	"eventManager/application/service"
+	"fmt"
+)
+
+type CategoryUseCase interface {
+	GetCategories(ctx context.Context) ([]*domain.Category, error)
+	GetCategoryByID(ctx context.Context, id int) (*domain.Category, error)
+	CreateCategory(ctx context.Context, token string, category *domain.Category) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, token string, id int) (*domain.Category, error)
+	SetEventTags(ctx context.Context, token string, eventID int, set *domain.TagSet) (*domain.Event, error)
+	SetEventPacketTags(ctx context.Context, token string, packetID int, set *domain.TagSet) (*domain.EventPacket, error)
+}
+
+type categoryUseCase struct {
+	categoryService service.CategoryService
+	eventRepo       repository.EventRepository
+	packetRepo      repository.EventPacketRepository
+	authNService    service.AuthenticationService
+	authZService    service.AuthorizationService
+}
+
+func NewCategoryUseCase(
+	categoryService service.CategoryService,
+	eventRepo repository.EventRepository,
+	packetRepo repository.EventPacketRepository,
+	authNService service.AuthenticationService,
+	authZService service.AuthorizationService,
+) *categoryUseCase {
+	return &categoryUseCase{
+		categoryService: categoryService,
+		eventRepo:       eventRepo,
+		packetRepo:      packetRepo,
+		authNService:    authNService,
+		authZService:    authZService,
+	}
+}
+
+func (uc *categoryUseCase) authenticate(ctx context.Context, token string) (*service.UserIdentity, error) {
+	identity, err := uc.authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return nil, &domain.ValidationError{Reason: "invalid or expired token"}
+	}
+	return identity, nil
+}
+
+func (uc *categoryUseCase) requireCategoryAdmin(ctx context.Context, token string) error {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := uc.authZService.CanUserManageCategories(ctx, *identity)
+	if err != nil {
+		return &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return &domain.ForbiddenError{Reason: "only admins can manage categories"}
+	}
+	return nil
+}
+
+func (uc *categoryUseCase) GetCategories(ctx context.Context) ([]*domain.Category, error) {
+	return uc.categoryService.GetCategories(ctx)
+}
+
+func (uc *categoryUseCase) GetCategoryByID(ctx context.Context, id int) (*domain.Category, error) {
+	return uc.categoryService.GetCategoryByID(ctx, id)
+}
+
+func (uc *categoryUseCase) CreateCategory(ctx context.Context, token string, category *domain.Category) (*domain.Category, error) {
+	if err := uc.requireCategoryAdmin(ctx, token); err != nil {
+		return nil, err
+	}
+	return uc.categoryService.CreateCategory(ctx, category)
+}
+
+func (uc *categoryUseCase) UpdateCategory(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.Category, error) {
+	if err := uc.requireCategoryAdmin(ctx, token); err != nil {
+		return nil, err
+	}
+	return uc.categoryService.UpdateCategory(ctx, id, updates)
+}
+
+func (uc *categoryUseCase) DeleteCategory(ctx context.Context, token string, id int) (*domain.Category, error) {
+	if err := uc.requireCategoryAdmin(ctx, token); err != nil {
+		return nil, err
+	}
+	return uc.categoryService.DeleteCategory(ctx, id)
+}
+
+func (uc *categoryUseCase) SetEventTags(ctx context.Context, token string, eventID int, set *domain.TagSet) (*domain.Event, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserEditEvent(ctx, *identity, event)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you don't have permission to tag this event"}
+	}
+
+	if err := uc.categoryService.SetEventTags(ctx, eventID, set); err != nil {
+		return nil, err
+	}
+
+	return uc.eventRepo.GetByID(ctx, eventID)
+}
+
+func (uc *categoryUseCase) SetEventPacketTags(ctx context.Context, token string, packetID int, set *domain.TagSet) (*domain.EventPacket, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	packet, err := uc.packetRepo.GetByID(ctx, packetID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserEditEventPacket(ctx, *identity, packet)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you don't have permission to tag this event packet"}
+	}
+
+	if err := uc.categoryService.SetEventPacketTags(ctx, packetID, set); err != nil {
+		return nil, err
+	}
+
+	return uc.packetRepo.GetByID(ctx, packetID)
+}
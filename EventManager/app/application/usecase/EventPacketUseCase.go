@@ -11,9 +11,10 @@ import (
 type EventPacketUseCase interface {
 	CreateEventPacket(ctx context.Context, token string, event *domain.EventPacket) (*domain.EventPacket, error)
 	GetEventPacketByID(ctx context.Context, token string, id int) (*domain.EventPacket, error)
+	GetEventPacketsByIDs(ctx context.Context, token string, ids []int) ([]*domain.EventPacket, error)
 	UpdateEventPacket(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.EventPacket, error)
 	DeleteEventPacket(ctx context.Context, token string, id int) (*domain.EventPacket, error)
-	FilterEventPackets(ctx context.Context, token string, filter *domain.EventPacketFilter) ([]*domain.EventPacket, error)
+	FilterEventPackets(ctx context.Context, token string, filter *domain.EventPacketFilter) ([]*domain.EventPacket, *domain.PageInfo, error)
 }
 
 type eventPacketUseCase struct {
@@ -84,6 +85,12 @@ func (uc *eventPacketUseCase) GetEventPacketByID(ctx context.Context, token stri
 	return packet, nil
 }
 
+// GetEventPacketsByIDs is public like the packet listing; it only hands out
+// what FilterEventPackets would.
+func (uc *eventPacketUseCase) GetEventPacketsByIDs(ctx context.Context, token string, ids []int) ([]*domain.EventPacket, error) {
+	return uc.eventPacketService.GetEventPacketsByIDs(ctx, ids)
+}
+
 func (uc *eventPacketUseCase) UpdateEventPacket(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.EventPacket, error) {
 	identity, err := uc.authenticate(ctx, token)
 	if err != nil {
@@ -128,12 +135,12 @@ func (uc *eventPacketUseCase) DeleteEventPacket(ctx context.Context, token strin
 	return uc.eventPacketService.DeleteEventPacket(ctx, id)
 }
 
-func (uc *eventPacketUseCase) FilterEventPackets(ctx context.Context, token string, filter *domain.EventPacketFilter) ([]*domain.EventPacket, error) {
+func (uc *eventPacketUseCase) FilterEventPackets(ctx context.Context, token string, filter *domain.EventPacketFilter) ([]*domain.EventPacket, *domain.PageInfo, error) {
 
-	packets, err := uc.eventPacketService.FilterEventPackets(ctx, filter)
+	packets, pageInfo, err := uc.eventPacketService.FilterEventPackets(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return packets, nil
+	return packets, pageInfo, nil
 
 }
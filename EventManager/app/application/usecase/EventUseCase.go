@@ -11,9 +11,12 @@ import (
 type EventUseCase interface {
 	CreateEvent(ctx context.Context, token string, event *domain.Event) (*domain.Event, error)
 	GetEventByID(ctx context.Context, token string, id int) (*domain.Event, error)
+	GetEventsByIDs(ctx context.Context, token string, ids []int) ([]*domain.Event, error)
 	UpdateEvent(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.Event, error)
+	CancelEvent(ctx context.Context, token string, id int) (*domain.Event, error)
 	DeleteEvent(ctx context.Context, token string, id int) (*domain.Event, error)
-	FilterEvents(ctx context.Context, token string, filter *domain.EventFilter) ([]*domain.Event, int, error)
+	FilterEvents(ctx context.Context, token string, filter *domain.EventFilter) ([]*domain.Event, *domain.PageInfo, int, error)
+	GetEventFacets(ctx context.Context, token string, filter *domain.EventFilter) (*domain.EventFacets, error)
 }
 
 type eventUseCase struct {
@@ -66,6 +69,10 @@ func (uc *eventUseCase) GetEventByID(ctx context.Context, token string, id int)
 	return uc.eventService.GetEventByID(ctx, id)
 }
 
+func (uc *eventUseCase) GetEventsByIDs(ctx context.Context, token string, ids []int) ([]*domain.Event, error) {
+	return uc.eventService.GetEventsByIDs(ctx, ids)
+}
+
 func (uc *eventUseCase) UpdateEvent(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.Event, error) {
 	identity, err := uc.authenticate(ctx, token)
 	if err != nil {
@@ -88,6 +95,29 @@ func (uc *eventUseCase) UpdateEvent(ctx context.Context, token string, id int, u
 	return uc.eventService.UpdateEvent(ctx, id, updates)
 }
 
+// CancelEvent is allowed to whoever may edit the event.
+func (uc *eventUseCase) CancelEvent(ctx context.Context, token string, id int) (*domain.Event, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := uc.eventService.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserEditEvent(ctx, *identity, event)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you don't have permission to cancel this event"}
+	}
+
+	return uc.eventService.CancelEvent(ctx, id)
+}
+
 func (uc *eventUseCase) DeleteEvent(ctx context.Context, token string, id int) (*domain.Event, error) {
 	identity, err := uc.authenticate(ctx, token)
 	if err != nil {
@@ -110,16 +140,20 @@ func (uc *eventUseCase) DeleteEvent(ctx context.Context, token string, id int) (
 	return uc.eventService.DeleteEvent(ctx, id)
 }
 
-func (uc *eventUseCase) FilterEvents(ctx context.Context, token string, filter *domain.EventFilter) ([]*domain.Event, int, error) {
-	events, err := uc.eventService.FilterEvents(ctx, filter)
+func (uc *eventUseCase) FilterEvents(ctx context.Context, token string, filter *domain.EventFilter) ([]*domain.Event, *domain.PageInfo, int, error) {
+	events, pageInfo, err := uc.eventService.FilterEvents(ctx, filter)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, 0, err
 	}
 
 	totalCount, err := uc.repo.CountEvents(ctx, filter)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, 0, err
 	}
 
-	return events, totalCount, nil
+	return events, pageInfo, totalCount, nil
+}
+
+func (uc *eventUseCase) GetEventFacets(ctx context.Context, token string, filter *domain.EventFilter) (*domain.EventFacets, error) {
+	return uc.repo.GetFacets(ctx, filter)
 }
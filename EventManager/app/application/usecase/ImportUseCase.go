@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"eventManager/application/service"
+	"fmt"
+)
+
+type ImportUseCase interface {
+	ImportCatalog(ctx context.Context, token string, rows []*domain.ImportRow, dryRun bool) (*domain.ImportResult, error)
+}
+
+type importUseCase struct {
+	importService service.ImportService
+	authNService  service.AuthenticationService
+	authZService  service.AuthorizationService
+}
+
+func NewImportUseCase(
+	importService service.ImportService,
+	authNService service.AuthenticationService,
+	authZService service.AuthorizationService,
+) *importUseCase {
+	return &importUseCase{
+		importService: importService,
+		authNService:  authNService,
+		authZService:  authZService,
+	}
+}
+
+func (uc *importUseCase) authenticate(ctx context.Context, token string) (*service.UserIdentity, error) {
+	identity, err := uc.authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return nil, &domain.ValidationError{Reason: "invalid or expired token"}
+	}
+	return identity, nil
+}
+
+// ImportCatalog imports the rows as the caller's own events and packets,
+// provided the caller may create them one by one.
+func (uc *importUseCase) ImportCatalog(ctx context.Context, token string, rows []*domain.ImportRow, dryRun bool) (*domain.ImportResult, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserCreateEvent(ctx, *identity)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you don't have permission to create events"}
+	}
+
+	for _, row := range rows {
+		if row.Kind() != domain.ImportKindPacket {
+			continue
+		}
+		allowed, err := uc.authZService.CanUserCreateEventPacket(ctx, *identity)
+		if err != nil {
+			return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+		}
+		if !allowed {
+			return nil, &domain.ForbiddenError{Reason: "you don't have permission to create event packets"}
+		}
+		break
+	}
+
+	return uc.importService.ImportCatalog(ctx, int(identity.UserID), rows, dryRun)
+}
@@ -14,6 +14,8 @@ type TicketUseCase interface {
 	GetTicketByCode(ctx context.Context, token string, code string) (*domain.Ticket, error)
 	UpdateTicket(ctx context.Context, token string, code string, updates map[string]interface{}) (*domain.Ticket, error)
 	DeleteTicket(ctx context.Context, token string, code string) (*domain.Ticket, error)
+	RotateTicketCode(ctx context.Context, token string, code string, newCode string) (*domain.Ticket, error)
+	ResellTicket(ctx context.Context, token string, code string, newCode string, price int) (*domain.Ticket, error)
 }
 
 type ticketUseCase struct {
@@ -149,3 +151,37 @@ func (uc *ticketUseCase) DeleteTicket(ctx context.Context, token string, code st
 
 	return uc.ticketService.DeleteTicket(ctx, code)
 }
+
+func (uc *ticketUseCase) RotateTicketCode(ctx context.Context, token string, code string, newCode string) (*domain.Ticket, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserRotateTicket(ctx, *identity, &domain.Ticket{Code: code})
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you don't have permission to rotate this ticket"}
+	}
+
+	return uc.ticketService.RotateTicketCode(ctx, code, newCode)
+}
+
+func (uc *ticketUseCase) ResellTicket(ctx context.Context, token string, code string, newCode string, price int) (*domain.Ticket, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserRotateTicket(ctx, *identity, &domain.Ticket{Code: code})
+	if err != nil {
+		return nil, &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, &domain.ForbiddenError{Reason: "you don't have permission to resell this ticket"}
+	}
+
+	return uc.ticketService.ResellTicket(ctx, code, newCode, price)
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"eventManager/application/domain"
+	"eventManager/application/service"
+	"fmt"
+)
+
+type WebhookUseCase interface {
+	CreateSubscription(ctx context.Context, token string, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context, token string) ([]*domain.WebhookSubscription, error)
+	GetSubscriptionByID(ctx context.Context, token string, id int) (*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, token string, id int, patch *domain.WebhookSubscriptionPatch) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, token string, id int) (*domain.WebhookSubscription, error)
+	GetDeliveries(ctx context.Context, token string, id int, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int, error)
+	Redeliver(ctx context.Context, token string, id int, deliveryID int64) (*domain.WebhookDelivery, error)
+}
+
+type webhookUseCase struct {
+	webhookService service.WebhookService
+	authNService   service.AuthenticationService
+	authZService   service.AuthorizationService
+}
+
+func NewWebhookUseCase(
+	webhookService service.WebhookService,
+	authNService service.AuthenticationService,
+	authZService service.AuthorizationService,
+) *webhookUseCase {
+	return &webhookUseCase{
+		webhookService: webhookService,
+		authNService:   authNService,
+		authZService:   authZService,
+	}
+}
+
+func (uc *webhookUseCase) authenticate(ctx context.Context, token string) (*service.UserIdentity, error) {
+	identity, err := uc.authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return nil, &domain.ValidationError{Reason: "invalid or expired token"}
+	}
+	return identity, nil
+}
+
+func (uc *webhookUseCase) requireOwner(ctx context.Context, identity *service.UserIdentity, ownerID int) error {
+	allowed, err := uc.authZService.CanUserManageWebhooks(ctx, *identity, ownerID)
+	if err != nil {
+		return &domain.InternalError{Msg: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return &domain.ForbiddenError{Reason: "you don't have permission to manage these webhooks"}
+	}
+	return nil
+}
+
+// ownedSubscription loads a subscription the caller is allowed to manage.
+func (uc *webhookUseCase) ownedSubscription(ctx context.Context, token string, id int) (*domain.WebhookSubscription, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.webhookService.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.requireOwner(ctx, identity, subscription.OwnerID); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (uc *webhookUseCase) CreateSubscription(ctx context.Context, token string, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, &domain.ValidationError{Reason: "invalid object received"}
+	}
+
+	subscription.OwnerID = int(identity.UserID)
+	if err := uc.requireOwner(ctx, identity, subscription.OwnerID); err != nil {
+		return nil, err
+	}
+
+	return uc.webhookService.CreateSubscription(ctx, subscription)
+}
+
+func (uc *webhookUseCase) GetSubscriptions(ctx context.Context, token string) ([]*domain.WebhookSubscription, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := int(identity.UserID)
+	if err := uc.requireOwner(ctx, identity, ownerID); err != nil {
+		return nil, err
+	}
+
+	return uc.webhookService.GetSubscriptionsByOwner(ctx, ownerID)
+}
+
+func (uc *webhookUseCase) GetSubscriptionByID(ctx context.Context, token string, id int) (*domain.WebhookSubscription, error) {
+	return uc.ownedSubscription(ctx, token, id)
+}
+
+func (uc *webhookUseCase) UpdateSubscription(ctx context.Context, token string, id int, patch *domain.WebhookSubscriptionPatch) (*domain.WebhookSubscription, error) {
+	if _, err := uc.ownedSubscription(ctx, token, id); err != nil {
+		return nil, err
+	}
+	return uc.webhookService.UpdateSubscription(ctx, id, patch)
+}
+
+func (uc *webhookUseCase) DeleteSubscription(ctx context.Context, token string, id int) (*domain.WebhookSubscription, error) {
+	if _, err := uc.ownedSubscription(ctx, token, id); err != nil {
+		return nil, err
+	}
+	return uc.webhookService.DeleteSubscription(ctx, id)
+}
+
+func (uc *webhookUseCase) GetDeliveries(ctx context.Context, token string, id int, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int, error) {
+	if _, err := uc.ownedSubscription(ctx, token, id); err != nil {
+		return nil, 0, err
+	}
+	return uc.webhookService.GetDeliveries(ctx, id, filter)
+}
+
+func (uc *webhookUseCase) Redeliver(ctx context.Context, token string, id int, deliveryID int64) (*domain.WebhookDelivery, error) {
+	if _, err := uc.ownedSubscription(ctx, token, id); err != nil {
+		return nil, err
+	}
+	return uc.webhookService.Redeliver(ctx, id, deliveryID)
+}
@@ -15,6 +15,309 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/categories": {
+            "get": {
+                "description": "Get every category, ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "List categories",
+                "responses": {
+                    "200": {
+                        "description": "List of categories",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCategoryList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new category (admin only). The slug is derived from the name when omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Create a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Category details",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Category created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - name or slug already exists",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid name or slug",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Retrieve a specific category by its identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get category by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category details",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a category (admin only); it is removed from every event and packet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Delete a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update a category (admin only). Renaming keeps the slug unless a new one is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Update a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpUpdateCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or category ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - name or slug already exists",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
         "/event-packet-inclusions/event/{event_id}": {
             "get": {
                 "description": "Retrieve all event packets linked to a specific event",
@@ -54,28 +357,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid event ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -136,46 +430,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid parameters or request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event or packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "409": {
                         "description": "Inclusion already exists",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -225,37 +504,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid parameters",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Inclusion not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -314,37 +581,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid parameters or request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Inclusion not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -389,28 +644,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid packet ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -438,13 +684,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by packet name (partial match)",
-                        "name": "name",
+                        "description": "Full-text search over name, location and description (case and diacritic insensitive)",
+                        "name": "q",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by location (partial match)",
+                        "description": "Filter by packet name (partial match)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by location (partial match)",
                         "name": "location",
                         "in": "query"
                     },
@@ -468,7 +720,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Page number (default: 1)",
+                        "description": "Page number (default: 1, deprecated in favour of cursor)",
                         "name": "page",
                         "in": "query"
                     },
@@ -480,9 +732,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Sort field (e.g., 'name_asc', 'seats_desc')",
+                        "description": "Sort order: name_asc/desc, seats_asc/desc, relevance (default when q is set)",
                         "name": "order_by",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor taken from the next/prev links",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated ids (max: 100) to look up in one call; cannot be combined with other parameters, unknown ids are left out",
+                        "name": "ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -495,19 +759,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid query parameters",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -552,37 +810,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "409": {
                         "description": "Event packet already exists",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -626,28 +872,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid event packet ID format",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -690,37 +927,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid event packet ID format",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -772,37 +997,178 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body or event packet ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/event-packets/{id}/availability": {
+            "get": {
+                "description": "Allocated seats, tickets sold, remaining seats and sell-through percentage (owner and client service only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Get seat availability for an event packet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event Packet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event packet availability",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseEventPacketAvailability"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event packet ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the packet owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Event packet not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/event-packets/{id}/tags": {
+            "put": {
+                "description": "Attach categories (by slug) and free-form tags to an event packet, replacing the previous ones (owner only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Replace the categories and tags of an event packet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event Packet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Categories and tags",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpTagSet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event packet with its new categories and tags",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseEventPacket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or event packet ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the packet owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Event packet not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown category or invalid tag",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -828,6 +1194,12 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header"
                     },
+                    {
+                        "type": "string",
+                        "description": "Full-text search over name, location and description (case and diacritic insensitive)",
+                        "name": "q",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Filter by event name (partial match)",
@@ -858,9 +1230,33 @@ const docTemplate = `{
                         "name": "max_seats",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Only events near this point, formatted as lat,lng (requires radius_km)",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius around near in kilometres (max: 1000)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events in this category (slug)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tags; events must carry all of them",
+                        "name": "tags",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Page number (default: 1)",
+                        "description": "Page number (default: 1, deprecated in favour of cursor)",
                         "name": "page",
                         "in": "query"
                     },
@@ -872,35 +1268,40 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Sort field (e.g., 'name', 'created_at')",
+                        "description": "Sort order: name_asc/desc, seats_asc/desc, relevance (default when q is set), distance (default when near is set)",
                         "name": "order_by",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor taken from the next/prev links",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated ids (max: 100) to look up in one call; cannot be combined with other parameters, unknown ids are left out",
+                        "name": "ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Paginated list of events",
+                        "description": "Paginated list of events with category and tag facet counts",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/httpdto.HttpResponseEventList"
                         }
                     },
                     "400": {
                         "description": "Invalid query parameters",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -945,56 +1346,42 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden - insufficient permissions",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "409": {
                         "description": "Conflict - event already exists",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/events/{id}": {
-            "get": {
-                "description": "Retrieve a specific event by its unique identifier",
+        "/events/import": {
+            "post": {
+                "description": "Create the caller's events and packets from a CSV file (text/csv) or JSON lines (application/x-ndjson). Each row has a type (event or packet), the fields of POST /events or POST /event-packets, and an optional ref; a packet lists the refs of the events it includes in events (separated by ';' in CSV). Every row is checked like a single create. Nothing is created unless every row is valid, and then all rows are created in a single transaction. With dry_run=true the rows are only checked. The report lists the problems of each row by line.",
                 "consumes": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "produces": [
                     "application/json"
@@ -1002,60 +1389,98 @@ const docTemplate = `{
                 "tags": [
                     "events"
                 ],
-                "summary": "Get event by ID",
+                "summary": "Import events and packets in bulk",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID (UUID)",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Bearer token (optional)",
-                        "name": "Authorization",
-                        "in": "header"
+                        "type": "boolean",
+                        "description": "Only check the rows (default: false)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "CSV with a header row, or one JSON object per line",
+                        "name": "rows",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event details",
+                        "description": "Dry run report",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseEvent"
+                            "$ref": "#/definitions/httpdto.HttpResponseImport"
+                        }
+                    },
+                    "201": {
+                        "description": "Rows created",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseImport"
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID format",
+                        "description": "Unreadable file, unknown CSV column or unknown query parameter",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Event not found",
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A name was taken while the rows were being created",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Content-Type is neither text/csv nor application/x-ndjson",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows are invalid; nothing was created",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseImport"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an event by its ID (only the owner can delete)",
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "description": "Retrieve a specific event by its unique identifier",
                 "consumes": [
                     "application/json"
                 ],
@@ -1065,7 +1490,61 @@ const docTemplate = `{
                 "tags": [
                     "events"
                 ],
-                "summary": "Delete an event",
+                "summary": "Get event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer token (optional)",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event details",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID format",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an event by its ID (only the owner can delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Delete an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1092,46 +1571,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid event ID format",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden - not the event owner",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -1183,54 +1647,39 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body or event ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden - not the event owner",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/tickets": {
-            "post": {
-                "description": "Create a new ticket for an event and packet",
+        "/events/{id}/availability": {
+            "get": {
+                "description": "Total seats, direct tickets sold, seats reserved by packets, remaining seats and sell-through percentage (owner and client service only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1238,9 +1687,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "statistics"
                 ],
-                "summary": "Create a new ticket",
+                "summary": "Get seat availability for an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1250,73 +1699,56 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Ticket details",
-                        "name": "ticket",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpdto.HttpCreateTicket"
-                        }
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Ticket created successfully",
+                    "200": {
+                        "description": "Event availability",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                            "$ref": "#/definitions/httpdto.HttpResponseEventAvailability"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid event ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Event or packet not found",
+                    "403": {
+                        "description": "Forbidden - not the event owner or the client service",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "409": {
-                        "description": "Ticket already exists",
+                    "404": {
+                        "description": "Event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/tickets/{code}": {
-            "get": {
-                "description": "Retrieve a specific ticket by its unique code",
+        "/events/{id}/cancel": {
+            "post": {
+                "description": "Call an event off (only the owner can cancel). The event stays listed with its cancelled_at, calendars show it as cancelled and no more tickets are sold for it or for the packets including it. Cancelling a cancelled event changes nothing.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1324,9 +1756,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "events"
                 ],
-                "summary": "Get ticket by code",
+                "summary": "Cancel an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1337,66 +1769,125 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ticket code (UUID)",
-                        "name": "code",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ticket details",
+                        "description": "Event cancelled",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                            "$ref": "#/definitions/httpdto.HttpResponseEvent"
                         }
                     },
                     "400": {
-                        "description": "Invalid ticket code",
+                        "description": "Invalid event ID format",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the event owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
-                        "description": "Ticket not found",
+                        "description": "Event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/events/{id}/ics": {
+            "get": {
+                "description": "Download the event as an RFC 5545 calendar with one VEVENT: name, place, start and end, STATUS:CANCELLED once the event is cancelled, and a SEQUENCE that grows with every change",
+                "produces": [
+                    "text/calendar",
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get an event as an iCalendar file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer token (optional)",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID format",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "The event has no start time",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/tags": {
             "put": {
-                "description": "Create a new ticket with a specified code or replace an existing one (idempotent)",
+                "description": "Attach categories (by slug) and free-form tags to an event, replacing the previous ones (owner only)",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "tickets"
+                    "categories"
                 ],
-                "summary": "Create or replace a ticket with specific code",
+                "summary": "Replace the categories and tags of an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1406,66 +1897,71 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Ticket code (UUID)",
-                        "name": "code",
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Ticket details",
-                        "name": "ticket",
+                        "description": "Categories and tags",
+                        "name": "tags",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpCreateTicket"
+                            "$ref": "#/definitions/httpdto.HttpTagSet"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Ticket created or updated successfully"
+                    "200": {
+                        "description": "Event with its new categories and tags",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseEvent"
+                        }
                     },
                     "400": {
-                        "description": "Invalid request body or ticket code",
+                        "description": "Invalid request body or event ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the event owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
-                        "description": "Event or packet not found",
+                        "description": "Event not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown category or invalid tag",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a ticket by its code",
+            }
+        },
+        "/owners/{owner_id}/dashboard": {
+            "get": {
+                "description": "Availability of every event and packet of the owner plus aggregated totals",
                 "consumes": [
                     "application/json"
                 ],
@@ -1473,9 +1969,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "statistics"
                 ],
-                "summary": "Delete a ticket",
+                "summary": "Get the sales dashboard of an owner",
                 "parameters": [
                     {
                         "type": "string",
@@ -1485,60 +1981,50 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Ticket code (UUID)",
-                        "name": "code",
+                        "type": "integer",
+                        "description": "Owner ID",
+                        "name": "owner_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ticket deleted successfully",
+                        "description": "Owner dashboard",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                            "$ref": "#/definitions/httpdto.HttpResponseOwnerDashboard"
                         }
                     },
                     "400": {
-                        "description": "Invalid ticket code",
+                        "description": "Invalid owner ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Ticket not found",
+                    "403": {
+                        "description": "Forbidden - not the same owner",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update ticket details (PATCH - only provided fields are updated)",
+            }
+        },
+        "/tickets": {
+            "post": {
+                "description": "Create a new ticket for an event and packet",
                 "consumes": [
                     "application/json"
                 ],
@@ -1548,7 +2034,7 @@ const docTemplate = `{
                 "tags": [
                     "tickets"
                 ],
-                "summary": "Update a ticket",
+                "summary": "Create a new ticket",
                 "parameters": [
                     {
                         "type": "string",
@@ -1559,179 +2045,2164 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ticket code (UUID)",
-                        "name": "code",
-                        "in": "path",
-                        "required": true
+                        "description": "Key making retries safe; the first response is replayed for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
-                        "description": "Fields to update",
+                        "description": "Ticket details",
                         "name": "ticket",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpUpdateTicket"
+                            "$ref": "#/definitions/httpdto.HttpCreateTicket"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Ticket updated successfully",
+                    "201": {
+                        "description": "Ticket created successfully",
                         "schema": {
                             "$ref": "#/definitions/httpdto.HttpResponseTicket"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or ticket code",
+                        "description": "Invalid request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
-                        "description": "Ticket not found",
+                        "description": "Event or packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already exists, sold out (code EVENT_SOLD_OUT/PACKET_SOLD_OUT), no capacity configured (code CAPACITY_NOT_CONFIGURED), event cancelled (code EVENT_CANCELLED) or a request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reused with a different body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "hateoas.Link": {
-            "type": "object",
-            "properties": {
-                "href": {
-                    "type": "string"
-                },
-                "method": {
-                    "type": "string"
-                },
-                "rel": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
         },
-        "httpdto.HttpCreateEvent": {
-            "type": "object",
-            "required": [
-                "id_owner",
-                "name"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 1000
+        "/tickets/{code}": {
+            "get": {
+                "description": "Retrieve a specific ticket by its unique code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get ticket by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket code (UUID)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Create a new ticket with a specified code or replace an existing one (idempotent)",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Create or replace a ticket with specific code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket code (UUID)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe; the first response is replayed for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Ticket details",
+                        "name": "ticket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateTicket"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Ticket created or updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request body or ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Event or packet not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Sold out (code EVENT_SOLD_OUT/PACKET_SOLD_OUT), no capacity configured (code CAPACITY_NOT_CONFIGURED), event cancelled (code EVENT_CANCELLED) or a request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reused with a different body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a ticket by its code, giving its seat back. Only the client service voids tickets, when a refund is approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Void a ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket code (UUID)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - only the client service can void tickets",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update ticket details (PATCH - only provided fields are updated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Update a ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket code (UUID)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "ticket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpUpdateTicket"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{code}/resell": {
+            "post": {
+                "description": "Move a ticket sold on the resale marketplace to the buyer's new code. The event owner must allow resale and the price must stay within their markup cap over the face value. Retrying with the same new code returns the reissued ticket.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Reissue a resold ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current ticket code (UUID)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New ticket code and resale price",
+                        "name": "resale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResellTicket"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket under its new code",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "New code already taken or resale not allowed by the event owner (code RESALE_NOT_ALLOWED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Price above the owner's markup cap (code RESALE_PRICE_ABOVE_CAP)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{code}/rotate": {
+            "post": {
+                "description": "Move a ticket to a new code when it changes hands, so the old code stops being valid. Retrying with the same new code returns the rotated ticket.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Rotate a ticket code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current ticket code (UUID)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New ticket code",
+                        "name": "rotation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpRotateTicket"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket under its new code",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "New code already taken or transfers blocked by the event owner (code TRANSFERS_BLOCKED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Get every webhook subscription of the authenticated owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List your webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of webhooks",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookSubscriptionList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not an event owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a URL that receives signed notifications when tickets for your events or packets are sold, updated or cancelled (owner only). A secret is generated when none is given; it is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Subscribe to ticket events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook subscription",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateWebhookSubscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook created, including its secret",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not an event owner, or filtering on someone else's event",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid URL, secret, event type or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Retrieve one of your webhook subscriptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook details",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not your webhook",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a webhook subscription together with its delivery log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not your webhook",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the URL, secret, event types or filter of a webhook, or pause it with active=false",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpUpdateWebhookSubscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not your webhook",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid URL, secret, event type or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "List the deliveries of a webhook, newest first. status=dead_letter lists the deliveries that ran out of retries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get the delivery log of a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only deliveries in this status: pending, delivered, dead_letter",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paginated delivery log",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookDeliveryList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not your webhook",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid status or paging",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Queue a delivery again with a fresh retry budget, e.g. to replay a dead letter once the receiver is fixed. The receiver gets the same message_id as before.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Delivery queued",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook or delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not your webhook",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "hateoas.Link": {
+            "type": "object",
+            "properties": {
+                "href": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "rel": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpCreateCategory": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                }
+            }
+        },
+        "httpdto.HttpCreateEvent": {
+            "type": "object",
+            "required": [
+                "id_owner",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id_owner": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "max_tickets_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "refund_window_hours": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "resale_allowed": {
+                    "type": "boolean"
+                },
+                "resale_max_markup_percent": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "seats": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "transfers_blocked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "httpdto.HttpCreateEventPacket": {
+            "type": "object",
+            "required": [
+                "id_owner",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "allocated_seats": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "id_owner": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "max_tickets_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "refund_window_hours": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "httpdto.HttpCreateEventPacketInclusion": {
+            "type": "object"
+        },
+        "httpdto.HttpCreateTicket": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "packet_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "httpdto.HttpCreateWebhookSubscription": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "event_types": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "packet_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "httpdto.HttpEventFacets": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpFacetValue"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpFacetValue"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResellTicket": {
+            "type": "object",
+            "required": [
+                "new_code",
+                "price"
+            ],
+            "properties": {
+                "new_code": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "httpdto.HttpResponseCategory": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/httpdto.httpResponseCategory"
+                }
+            }
+        },
+        "httpdto.HttpResponseCategoryList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseCategory"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseEvent": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/httpdto.httpResponseEvent"
+                }
+            }
+        },
+        "httpdto.HttpResponseEventAvailability": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "$ref": "#/definitions/httpdto.httpEventAvailability"
+                }
+            }
+        },
+        "httpdto.HttpResponseEventList": {
+            "type": "object",
+            "properties": {
+                "_facets": {
+                    "$ref": "#/definitions/httpdto.HttpEventFacets"
+                },
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "_metadata": {
+                    "$ref": "#/definitions/httpdto.PaginationMetadata"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseEvent"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseEventPacket": {
+            "type": "object",
+            "properties": {
+                "event_packet": {
+                    "$ref": "#/definitions/httpdto.httpResponseEventPacket"
+                }
+            }
+        },
+        "httpdto.HttpResponseEventPacketAvailability": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "$ref": "#/definitions/httpdto.httpEventPacketAvailability"
+                }
+            }
+        },
+        "httpdto.HttpResponseEventPacketInclusion": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "id_event": {
+                    "type": "integer"
+                },
+                "id_packet": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.HttpResponseEventPacketList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "_metadata": {
+                    "$ref": "#/definitions/httpdto.PaginationMetadata"
+                },
+                "event_packets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseEventPacket"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseImport": {
+            "type": "object",
+            "properties": {
+                "import": {
+                    "$ref": "#/definitions/httpdto.httpResponseImport"
+                }
+            }
+        },
+        "httpdto.HttpResponseOwnerDashboard": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "event_packets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpEventPacketAvailability"
+                    }
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpEventAvailability"
+                    }
+                },
+                "id_owner": {
+                    "type": "integer"
+                },
+                "totals": {
+                    "$ref": "#/definitions/httpdto.httpDashboardTotals"
+                }
+            }
+        },
+        "httpdto.HttpResponseTicket": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "packet_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.HttpResponseWebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "$ref": "#/definitions/httpdto.httpResponseWebhookDelivery"
+                }
+            }
+        },
+        "httpdto.HttpResponseWebhookDeliveryList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseWebhookDelivery"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/httpdto.PaginationMetadata"
+                }
+            }
+        },
+        "httpdto.HttpResponseWebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "webhook": {
+                    "$ref": "#/definitions/httpdto.httpResponseWebhookSubscription"
+                }
+            }
+        },
+        "httpdto.HttpResponseWebhookSubscriptionList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseWebhookSubscription"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpRotateTicket": {
+            "type": "object",
+            "required": [
+                "new_code"
+            ],
+            "properties": {
+                "new_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpTagSet": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpUpdateCategory": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                }
+            }
+        },
+        "httpdto.HttpUpdateEvent": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id_owner": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "max_tickets_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "refund_window_hours": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "resale_allowed": {
+                    "type": "boolean"
+                },
+                "resale_max_markup_percent": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "seats": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "transfers_blocked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "httpdto.HttpUpdateEventPacket": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "allocated_seats": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "id_owner": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "max_tickets_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "refund_window_hours": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "httpdto.HttpUpdateEventPacketInclusion": {
+            "type": "object"
+        },
+        "httpdto.HttpUpdateTicket": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "packet_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "httpdto.HttpUpdateWebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "event_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "event_types": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "packet_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "httpdto.PaginationMetadata": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpCategoryRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpDashboardTotals": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "integer"
+                },
+                "packets": {
+                    "type": "integer"
+                },
+                "sell_through_percent": {
+                    "type": "number"
+                },
+                "total_remaining": {
+                    "type": "integer"
+                },
+                "total_seats": {
+                    "type": "integer"
+                },
+                "total_sold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpEventAvailability": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "direct_sold": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "packet_reserved": {
+                    "type": "integer"
+                },
+                "packet_sold": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "sell_through_percent": {
+                    "type": "number"
+                },
+                "total_seats": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpEventPacketAvailability": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "allocated_seats": {
+                    "type": "integer"
+                },
+                "event_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "sell_through_percent": {
+                    "type": "number"
+                },
+                "sold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpFacetValue": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "selected": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpImportError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpImportRowResult": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpImportError"
+                    }
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpResponseCategory": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpResponseEvent": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "address": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpCategoryRef"
+                    }
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "id_owner": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "max_tickets_per_user": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "refund_window_hours": {
+                    "type": "integer"
+                },
+                "resale_allowed": {
+                    "type": "boolean"
+                },
+                "resale_max_markup_percent": {
+                    "type": "integer"
+                },
+                "search": {
+                    "$ref": "#/definitions/httpdto.httpSearchMatch"
+                },
+                "seats": {
+                    "type": "integer"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transfers_blocked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "httpdto.httpResponseEventPacket": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hateoas.Link"
+                    }
+                },
+                "address": {
+                    "type": "string"
+                },
+                "allocated_seats": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpCategoryRef"
+                    }
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
                 },
                 "id_owner": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
                 },
                 "location": {
-                    "type": "string",
-                    "maxLength": 500
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "max_tickets_per_user": {
+                    "type": "integer"
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "type": "string"
                 },
-                "seats": {
-                    "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "httpdto.HttpCreateEventPacket": {
-            "type": "object",
-            "required": [
-                "id_owner",
-                "name"
-            ],
-            "properties": {
-                "allocated_seats": {
-                    "type": "integer",
-                    "minimum": 1
+                "price": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 1000
+                "refund_window_hours": {
+                    "type": "integer"
                 },
-                "id_owner": {
-                    "type": "integer",
-                    "minimum": 1
+                "resale_allowed": {
+                    "type": "boolean"
                 },
-                "location": {
-                    "type": "string",
-                    "maxLength": 500
+                "resale_max_markup_percent": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                }
-            }
-        },
-        "httpdto.HttpCreateEventPacketInclusion": {
-            "type": "object"
-        },
-        "httpdto.HttpCreateTicket": {
-            "type": "object",
-            "properties": {
-                "event_id": {
-                    "type": "integer",
-                    "minimum": 1
+                "search": {
+                    "$ref": "#/definitions/httpdto.httpSearchMatch"
                 },
-                "packet_id": {
-                    "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "httpdto.HttpResponseEvent": {
-            "type": "object",
-            "properties": {
-                "event": {
-                    "$ref": "#/definitions/httpdto.httpResponseEvent"
-                }
-            }
-        },
-        "httpdto.HttpResponseEventPacket": {
-            "type": "object",
-            "properties": {
-                "event_packet": {
-                    "$ref": "#/definitions/httpdto.httpResponseEventPacket"
+                "starts_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transfers_blocked": {
+                    "type": "boolean"
                 }
             }
         },
-        "httpdto.HttpResponseEventPacketInclusion": {
+        "httpdto.httpResponseImport": {
             "type": "object",
             "properties": {
                 "_links": {
@@ -1740,15 +4211,36 @@ const docTemplate = `{
                         "$ref": "#/definitions/hateoas.Link"
                     }
                 },
-                "id_event": {
+                "created": {
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error_count": {
                     "type": "integer"
                 },
-                "id_packet": {
+                "events": {
+                    "type": "integer"
+                },
+                "inclusions": {
+                    "type": "integer"
+                },
+                "packets": {
                     "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpImportRowResult"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
                 }
             }
         },
-        "httpdto.HttpResponseEventPacketList": {
+        "httpdto.httpResponseWebhookDelivery": {
             "type": "object",
             "properties": {
                 "_links": {
@@ -1757,18 +4249,42 @@ const docTemplate = `{
                         "$ref": "#/definitions/hateoas.Link"
                     }
                 },
-                "_metadata": {
-                    "$ref": "#/definitions/httpdto.PaginationMetadata"
+                "attempts": {
+                    "type": "integer"
                 },
-                "event_packets": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/httpdto.httpResponseEventPacket"
-                    }
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "message_id": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.HttpResponseTicket": {
+        "httpdto.httpResponseWebhookSubscription": {
             "type": "object",
             "properties": {
                 "_links": {
@@ -1777,157 +4293,131 @@ const docTemplate = `{
                         "$ref": "#/definitions/hateoas.Link"
                     }
                 },
-                "code": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "event_id": {
+                "event_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "integer"
                 },
-                "packet_id": {
+                "owner_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "httpdto.HttpUpdateEvent": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 1000
                 },
-                "id_owner": {
-                    "type": "integer",
-                    "minimum": 1
+                "packet_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "location": {
-                    "type": "string",
-                    "maxLength": 500
+                "secret": {
+                    "description": "Secret is only returned when the subscription is created.",
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "updated_at": {
+                    "type": "string"
                 },
-                "seats": {
-                    "type": "integer",
-                    "minimum": 1
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.HttpUpdateEventPacket": {
+        "httpdto.httpSearchMatch": {
             "type": "object",
             "properties": {
-                "allocated_seats": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "description": {
-                    "type": "string",
-                    "maxLength": 1000
-                },
-                "id_owner": {
-                    "type": "integer",
-                    "minimum": 1
+                "description_highlight": {
+                    "type": "string"
                 },
-                "location": {
-                    "type": "string",
-                    "maxLength": 500
+                "name_highlight": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "rank": {
+                    "type": "number"
                 }
             }
         },
-        "httpdto.HttpUpdateEventPacketInclusion": {
-            "type": "object"
-        },
-        "httpdto.HttpUpdateTicket": {
+        "problem.FieldError": {
             "type": "object",
             "properties": {
-                "event_id": {
-                    "type": "integer",
-                    "minimum": 1
+                "field": {
+                    "type": "string"
                 },
-                "packet_id": {
-                    "type": "integer",
-                    "minimum": 1
+                "reason": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.PaginationMetadata": {
+        "problem.Problem": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer"
+                "availability": {
+                    "$ref": "#/definitions/problem.SeatAvailability"
                 },
-                "per_page": {
-                    "type": "integer"
+                "code": {
+                    "type": "string"
                 },
-                "total_items": {
-                    "type": "integer"
+                "detail": {
+                    "type": "string"
                 },
-                "total_pages": {
-                    "type": "integer"
-                }
-            }
-        },
-        "httpdto.httpResponseEvent": {
-            "type": "object",
-            "properties": {
-                "_links": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/hateoas.Link"
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/problem.FieldError"
                     }
                 },
-                "description": {
+                "instance": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "id_owner": {
+                "status": {
                     "type": "integer"
                 },
-                "location": {
+                "title": {
                     "type": "string"
                 },
-                "name": {
+                "trace_id": {
                     "type": "string"
                 },
-                "seats": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.httpResponseEventPacket": {
+        "problem.SeatAvailability": {
             "type": "object",
             "properties": {
-                "_links": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/hateoas.Link"
-                    }
-                },
-                "allocated_seats": {
+                "capacity": {
                     "type": "integer"
                 },
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "id_owner": {
+                "remaining": {
                     "type": "integer"
                 },
-                "location": {
-                    "type": "string"
+                "reserved": {
+                    "type": "integer"
                 },
-                "name": {
+                "resource": {
                     "type": "string"
+                },
+                "sold": {
+                    "type": "integer"
                 }
             }
         }
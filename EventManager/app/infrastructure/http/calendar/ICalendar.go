@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"eventManager/application/domain"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"shared/ical"
+)
+
+// WriteEvent writes a calendar holding the event as a single VEVENT. An
+// event without a start time cannot be put in a calendar.
+func WriteEvent(w io.Writer, event *domain.Event, url string, now time.Time) error {
+	if event.StartsAt == nil {
+		return &domain.ValidationError{Field: "starts_at", Reason: "the event has no start time to put in a calendar"}
+	}
+
+	var out ical.Writer
+	out.Line("BEGIN", "VCALENDAR")
+	out.Line("VERSION", "2.0")
+	out.Line("PRODID", "-//POS//EventManager//EN")
+	out.Line("CALSCALE", "GREGORIAN")
+	out.Line("METHOD", "PUBLISH")
+
+	out.Line("BEGIN", "VEVENT")
+	out.Line("UID", ical.EventUID(event.ID))
+	out.Line("DTSTAMP", ical.FormatTime(now))
+	out.Line("DTSTART", ical.FormatTime(*event.StartsAt))
+	if event.EndsAt != nil {
+		out.Line("DTEND", ical.FormatTime(*event.EndsAt))
+	}
+	out.Line("SUMMARY", ical.EscapeText(event.Name))
+	if location := joinNonEmpty(event.Location, event.Address, event.City, event.Country); location != "" {
+		out.Line("LOCATION", ical.EscapeText(location))
+	}
+	if event.Latitude != nil && event.Longitude != nil {
+		out.Line("GEO", fmt.Sprintf("%.6f;%.6f", *event.Latitude, *event.Longitude))
+	}
+	if event.Description != nil && strings.TrimSpace(*event.Description) != "" {
+		out.Line("DESCRIPTION", ical.EscapeText(*event.Description))
+	}
+	if url != "" {
+		out.Line("URL", url)
+	}
+	if event.Cancelled() {
+		out.Line("STATUS", "CANCELLED")
+	} else {
+		out.Line("STATUS", "CONFIRMED")
+	}
+	out.Line("SEQUENCE", fmt.Sprint(event.Sequence))
+	out.Line("END", "VEVENT")
+
+	out.Line("END", "VCALENDAR")
+
+	_, err := out.WriteTo(w)
+	return err
+}
+
+func joinNonEmpty(parts ...*string) string {
+	var values []string
+	for _, part := range parts {
+		if part != nil && strings.TrimSpace(*part) != "" {
+			values = append(values, strings.TrimSpace(*part))
+		}
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"eventManager/application/usecase"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/gin/middleware"
+	"eventManager/infrastructure/http/httpdto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinAvailabilityHandler struct {
+	usecase     usecase.AvailabilityUseCase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinAvailabilityHandler(usecase usecase.AvailabilityUseCase, serviceURLs *config.ServiceURLs) *GinAvailabilityHandler {
+	return &GinAvailabilityHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// GetEventAvailability godoc
+// @Summary Get seat availability for an event
+// @Description Total seats, direct tickets sold, seats reserved by packets, remaining seats and sell-through percentage (owner and client service only)
+// @Tags statistics
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Event ID"
+// @Success 200 {object} httpdto.HttpResponseEventAvailability "Event availability"
+// @Failure 400 {object} problem.Problem "Invalid event ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the event owner or the client service"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /events/{id}/availability [get]
+func (h *GinAvailabilityHandler) GetEventAvailability(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	availability, err := h.usecase.GetEventAvailability(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseEventAvailability(availability, h.serviceURLs))
+}
+
+// GetEventPacketAvailability godoc
+// @Summary Get seat availability for an event packet
+// @Description Allocated seats, tickets sold, remaining seats and sell-through percentage (owner and client service only)
+// @Tags statistics
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Event Packet ID"
+// @Success 200 {object} httpdto.HttpResponseEventPacketAvailability "Event packet availability"
+// @Failure 400 {object} problem.Problem "Invalid event packet ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the packet owner or the client service"
+// @Failure 404 {object} problem.Problem "Event packet not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /event-packets/{id}/availability [get]
+func (h *GinAvailabilityHandler) GetEventPacketAvailability(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	availability, err := h.usecase.GetEventPacketAvailability(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseEventPacketAvailability(availability, h.serviceURLs))
+}
+
+// GetOwnerDashboard godoc
+// @Summary Get the sales dashboard of an owner
+// @Description Availability of every event and packet of the owner plus aggregated totals
+// @Tags statistics
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param owner_id path int true "Owner ID"
+// @Success 200 {object} httpdto.HttpResponseOwnerDashboard "Owner dashboard"
+// @Failure 400 {object} problem.Problem "Invalid owner ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the same owner"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /owners/{owner_id}/dashboard [get]
+func (h *GinAvailabilityHandler) GetOwnerDashboard(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ownerID, err := middleware.ParseIDParam(c, "owner_id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	dashboard, err := h.usecase.GetOwnerDashboard(c.Request.Context(), token, ownerID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseOwnerDashboard(dashboard, h.serviceURLs))
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"eventManager/application/usecase"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/gin/middleware"
+	"eventManager/infrastructure/http/httpdto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinCategoryHandler struct {
+	usecase     usecase.CategoryUseCase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinCategoryHandler(usecase usecase.CategoryUseCase, serviceURLs *config.ServiceURLs) *GinCategoryHandler {
+	return &GinCategoryHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// GetCategories godoc
+// @Summary List categories
+// @Description Get every category, ordered by name
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {object} httpdto.HttpResponseCategoryList "List of categories"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /categories [get]
+func (h *GinCategoryHandler) GetCategories(c *gin.Context) {
+	categories, err := h.usecase.GetCategories(c.Request.Context())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseCategoryList(categories, h.serviceURLs))
+}
+
+// GetCategoryByID godoc
+// @Summary Get category by ID
+// @Description Retrieve a specific category by its identifier
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} httpdto.HttpResponseCategory "Category details"
+// @Failure 400 {object} problem.Problem "Invalid category ID"
+// @Failure 404 {object} problem.Problem "Category not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /categories/{id} [get]
+func (h *GinCategoryHandler) GetCategoryByID(c *gin.Context) {
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	category, err := h.usecase.GetCategoryByID(c.Request.Context(), id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseCategory(category, h.serviceURLs))
+}
+
+// CreateCategory godoc
+// @Summary Create a category
+// @Description Create a new category (admin only). The slug is derived from the name when omitted.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param category body httpdto.HttpCreateCategory true "Category details"
+// @Success 201 {object} httpdto.HttpResponseCategory "Category created successfully"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not an admin"
+// @Failure 409 {object} problem.Problem "Conflict - name or slug already exists"
+// @Failure 422 {object} problem.Problem "Invalid name or slug"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /categories [post]
+func (h *GinCategoryHandler) CreateCategory(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.HttpCreateCategory
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	category, err := h.usecase.CreateCategory(c.Request.Context(), token, req.ToCategory())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponseCategory(category, h.serviceURLs))
+}
+
+// UpdateCategory godoc
+// @Summary Update a category
+// @Description Partially update a category (admin only). Renaming keeps the slug unless a new one is given.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Category ID"
+// @Param category body httpdto.HttpUpdateCategory true "Fields to update"
+// @Success 200 {object} httpdto.HttpResponseCategory "Category updated successfully"
+// @Failure 400 {object} problem.Problem "Invalid request body or category ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not an admin"
+// @Failure 404 {object} problem.Problem "Category not found"
+// @Failure 409 {object} problem.Problem "Conflict - name or slug already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /categories/{id} [patch]
+func (h *GinCategoryHandler) UpdateCategory(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpUpdateCategory
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	category, err := h.usecase.UpdateCategory(c.Request.Context(), token, id, req.ToUpdateMap())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseCategory(category, h.serviceURLs))
+}
+
+// DeleteCategory godoc
+// @Summary Delete a category
+// @Description Delete a category (admin only); it is removed from every event and packet
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Category ID"
+// @Success 200 {object} httpdto.HttpResponseCategory "Category deleted successfully"
+// @Failure 400 {object} problem.Problem "Invalid category ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not an admin"
+// @Failure 404 {object} problem.Problem "Category not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /categories/{id} [delete]
+func (h *GinCategoryHandler) DeleteCategory(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	category, err := h.usecase.DeleteCategory(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseCategory(category, h.serviceURLs))
+}
+
+// SetEventTags godoc
+// @Summary Replace the categories and tags of an event
+// @Description Attach categories (by slug) and free-form tags to an event, replacing the previous ones (owner only)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Event ID"
+// @Param tags body httpdto.HttpTagSet true "Categories and tags"
+// @Success 200 {object} httpdto.HttpResponseEvent "Event with its new categories and tags"
+// @Failure 400 {object} problem.Problem "Invalid request body or event ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the event owner"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 422 {object} problem.Problem "Unknown category or invalid tag"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /events/{id}/tags [put]
+func (h *GinCategoryHandler) SetEventTags(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpTagSet
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	event, err := h.usecase.SetEventTags(c.Request.Context(), token, id, req.ToTagSet())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseEvent(event, h.serviceURLs))
+}
+
+// SetEventPacketTags godoc
+// @Summary Replace the categories and tags of an event packet
+// @Description Attach categories (by slug) and free-form tags to an event packet, replacing the previous ones (owner only)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Event Packet ID"
+// @Param tags body httpdto.HttpTagSet true "Categories and tags"
+// @Success 200 {object} httpdto.HttpResponseEventPacket "Event packet with its new categories and tags"
+// @Failure 400 {object} problem.Problem "Invalid request body or event packet ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the packet owner"
+// @Failure 404 {object} problem.Problem "Event packet not found"
+// @Failure 422 {object} problem.Problem "Unknown category or invalid tag"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /event-packets/{id}/tags [put]
+func (h *GinCategoryHandler) SetEventPacketTags(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpTagSet
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	packet, err := h.usecase.SetEventPacketTags(c.Request.Context(), token, id, req.ToTagSet())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseEventPacket(packet, h.serviceURLs))
+}
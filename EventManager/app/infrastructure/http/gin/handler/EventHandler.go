@@ -1,16 +1,21 @@
 package handler
 
 import (
-	"errors"
-	"eventManager/application/domain"
+	"bytes"
 	"eventManager/application/usecase"
+	"eventManager/infrastructure/http/calendar"
 	"eventManager/infrastructure/http/config"
 	"eventManager/infrastructure/http/gin/middleware"
 	"eventManager/infrastructure/http/httpdto"
+	"eventManager/infrastructure/http/problem"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"shared/ical"
 )
 
 type GinEventHandler struct {
@@ -30,61 +35,7 @@ func handleError(c *gin.Context, err error) bool {
 		return false
 	}
 
-	var validationErr *domain.ValidationError
-	if errors.As(err, &validationErr) {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
-		return true
-	}
-
-	var invalidReqErr *domain.InvalidRequestError
-	if errors.As(err, &invalidReqErr) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return true
-	}
-
-	var unauthorizedErr *domain.UnauthorizedError
-	if errors.As(err, &unauthorizedErr) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": unauthorizedErr.Error()})
-		return true
-	}
-
-	var forbiddenErr *domain.ForbiddenError
-	if errors.As(err, &forbiddenErr) {
-		c.JSON(http.StatusForbidden, gin.H{"error": forbiddenErr.Error()})
-		return true
-	}
-
-	var notFoundErr *domain.NotFoundError
-	if errors.As(err, &notFoundErr) {
-		c.JSON(http.StatusNotFound, gin.H{"error": notFoundErr.Error()})
-		return true
-	}
-
-	var existsErr *domain.AlreadyExistsError
-	if errors.As(err, &existsErr) {
-		c.JSON(http.StatusConflict, gin.H{"error": existsErr.Error()})
-		return true
-	}
-
-	var uniqueNameErr *domain.UniqueNameError
-	if errors.As(err, &uniqueNameErr) {
-		c.JSON(http.StatusConflict, gin.H{"error": uniqueNameErr.Error()})
-		return true
-	}
-
-	var foreignKeyErr *domain.ForeignKeyError
-	if errors.As(err, &foreignKeyErr) {
-		c.JSON(http.StatusNotFound, gin.H{"error": foreignKeyErr.Error()})
-		return true
-	}
-
-	var internalErr *domain.InternalError
-	if errors.As(err, &internalErr) {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return true
-	}
-
-	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	problem.WriteError(c, err)
 	return true
 }
 
@@ -105,7 +56,7 @@ func getTokenFromHeader(c *gin.Context) string {
 func requireAuth(c *gin.Context) (string, bool) {
 	token := getTokenFromHeader(c)
 	if token == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		problem.Write(c, problem.New(http.StatusUnauthorized, problem.TypeUnauthorized, "Authorization header required"))
 		return "", false
 	}
 	return token, true
@@ -120,11 +71,11 @@ func requireAuth(c *gin.Context) (string, bool) {
 // @Param Authorization header string true "Bearer token"
 // @Param event body httpdto.HttpCreateEvent true "Event details"
 // @Success 201 {object} httpdto.HttpResponseEvent "Event created successfully"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - insufficient permissions"
-// @Failure 409 {object} map[string]string "Conflict - event already exists"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - insufficient permissions"
+// @Failure 409 {object} problem.Problem "Conflict - event already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /events [post]
 func (h *GinEventHandler) CreateEvent(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -158,9 +109,9 @@ func (h *GinEventHandler) CreateEvent(c *gin.Context) {
 // @Param id path string true "Event ID (UUID)"
 // @Param Authorization header string false "Bearer token (optional)"
 // @Success 200 {object} httpdto.HttpResponseEvent "Event details"
-// @Failure 400 {object} map[string]string "Invalid event ID format"
-// @Failure 404 {object} map[string]string "Event not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid event ID format"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /events/{id} [get]
 func (h *GinEventHandler) GetEventByID(c *gin.Context) {
 	id, err := middleware.ParseIDParam(c, "id")
@@ -189,11 +140,11 @@ func (h *GinEventHandler) GetEventByID(c *gin.Context) {
 // @Param id path string true "Event ID (UUID)"
 // @Param event body httpdto.HttpUpdateEvent true "Fields to update"
 // @Success 200 {object} httpdto.HttpResponseEvent "Event updated successfully"
-// @Failure 400 {object} map[string]string "Invalid request body or event ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - not the event owner"
-// @Failure 404 {object} map[string]string "Event not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body or event ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the event owner"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /events/{id} [patch]
 func (h *GinEventHandler) UpdateEvent(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -233,11 +184,11 @@ func (h *GinEventHandler) UpdateEvent(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Event ID (UUID)"
 // @Success 200 {object} httpdto.HttpResponseEvent "Event deleted successfully"
-// @Failure 400 {object} map[string]string "Invalid event ID format"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - not the event owner"
-// @Failure 404 {object} map[string]string "Event not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid event ID format"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the event owner"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /events/{id} [delete]
 func (h *GinEventHandler) DeleteEvent(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -260,6 +211,78 @@ func (h *GinEventHandler) DeleteEvent(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// CancelEvent godoc
+// @Summary Cancel an event
+// @Description Call an event off (only the owner can cancel). The event stays listed with its cancelled_at, calendars show it as cancelled and no more tickets are sold for it or for the packets including it. Cancelling a cancelled event changes nothing.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Event ID (UUID)"
+// @Success 200 {object} httpdto.HttpResponseEvent "Event cancelled"
+// @Failure 400 {object} problem.Problem "Invalid event ID format"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the event owner"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /events/{id}/cancel [post]
+func (h *GinEventHandler) CancelEvent(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	event, err := h.usecase.CancelEvent(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	resp := httpdto.ToHttpResponseEvent(event, h.serviceURLs)
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetEventCalendar godoc
+// @Summary Get an event as an iCalendar file
+// @Description Download the event as an RFC 5545 calendar with one VEVENT: name, place, start and end, STATUS:CANCELLED once the event is cancelled, and a SEQUENCE that grows with every change
+// @Tags events
+// @Produce text/calendar
+// @Produce json
+// @Param id path string true "Event ID (UUID)"
+// @Param Authorization header string false "Bearer token (optional)"
+// @Success 200 {file} file "iCalendar file"
+// @Failure 400 {object} problem.Problem "Invalid event ID format"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 422 {object} problem.Problem "The event has no start time"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /events/{id}/ics [get]
+func (h *GinEventHandler) GetEventCalendar(c *gin.Context) {
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	token := getTokenFromHeader(c)
+	event, err := h.usecase.GetEventByID(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	var ics bytes.Buffer
+	url := fmt.Sprintf("%s/events/%d", h.serviceURLs.EventManager, event.ID)
+	if err := calendar.WriteEvent(&ics, event, url, time.Now()); handleError(c, err) {
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%d.ics"`, event.ID))
+	c.Data(http.StatusOK, ical.ContentType, ics.Bytes())
+}
+
 // FilterEvents godoc
 // @Summary List and filter events
 // @Description Get a paginated list of events with optional filters
@@ -267,21 +290,33 @@ func (h *GinEventHandler) DeleteEvent(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string false "Bearer token (optional)"
+// @Param q query string false "Full-text search over name, location and description (case and diacritic insensitive)"
 // @Param name query string false "Filter by event name (partial match)"
 // @Param location query string false "Filter by location (partial match)"
 // @Param description query string false "Filter by description (partial match)"
 // @Param min_seats query int false "Minimum number of seats"
 // @Param max_seats query int false "Maximum number of seats"
-// @Param page query int false "Page number (default: 1)"
+// @Param near query string false "Only events near this point, formatted as lat,lng (requires radius_km)"
+// @Param radius_km query number false "Search radius around near in kilometres (max: 1000)"
+// @Param category query string false "Only events in this category (slug)"
+// @Param tags query string false "Comma-separated tags; events must carry all of them"
+// @Param page query int false "Page number (default: 1, deprecated in favour of cursor)"
 // @Param per_page query int false "Items per page (default: 10, max: 100)"
-// @Param order_by query string false "Sort field (e.g., 'name', 'created_at')"
-// @Success 200 {object} map[string]interface{} "Paginated list of events"
-// @Failure 400 {object} map[string]string "Invalid query parameters"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param order_by query string false "Sort order: name_asc/desc, seats_asc/desc, relevance (default when q is set), distance (default when near is set)"
+// @Param cursor query string false "Opaque cursor taken from the next/prev links"
+// @Param ids query string false "Comma-separated ids (max: 100) to look up in one call; cannot be combined with other parameters, unknown ids are left out"
+// @Success 200 {object} httpdto.HttpResponseEventList "Paginated list of events with category and tag facet counts"
+// @Failure 400 {object} problem.Problem "Invalid query parameters"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /events [get]
 func (h *GinEventHandler) FilterEvents(c *gin.Context) {
+	if _, ok := c.GetQuery("ids"); ok {
+		h.getEventsByIDs(c)
+		return
+	}
+
 	var filter httpdto.HttpFilterEvent
-	allowedParams := []string{"name", "location", "description", "min_seats", "max_seats", "page", "per_page", "order_by"}
+	allowedParams := []string{"q", "name", "location", "description", "min_seats", "max_seats", "near", "radius_km", "category", "tags", "page", "per_page", "order_by", "cursor"}
 	if err := middleware.StrictBindQuery(c, &filter, allowedParams); err != nil {
 		handleError(c, err)
 		return
@@ -290,16 +325,45 @@ func (h *GinEventHandler) FilterEvents(c *gin.Context) {
 	domainFilter := filter.ToEventFilter()
 	domainFilter.Default()
 	if err := domainFilter.Validate(); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		handleError(c, err)
+		return
+	}
+
+	token := getTokenFromHeader(c)
+	events, pageInfo, totalCount, err := h.usecase.FilterEvents(c.Request.Context(), token, domainFilter)
+	if handleError(c, err) {
+		return
+	}
+
+	facets, err := h.usecase.GetEventFacets(c.Request.Context(), token, domainFilter)
+	if handleError(c, err) {
+		return
+	}
+
+	resp := httpdto.ToHttpResponseEventListWithPagination(events, domainFilter, pageInfo, totalCount, facets, h.serviceURLs)
+	c.JSON(http.StatusOK, resp)
+}
+
+// getEventsByIDs serves GET /events?ids=..., letting other services resolve
+// many events in one request.
+func (h *GinEventHandler) getEventsByIDs(c *gin.Context) {
+	var lookup httpdto.HttpBatchLookup
+	if err := middleware.StrictBindQuery(c, &lookup, []string{"ids"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	ids, err := lookup.ToIDs()
+	if handleError(c, err) {
 		return
 	}
 
 	token := getTokenFromHeader(c)
-	events, totalCount, err := h.usecase.FilterEvents(c.Request.Context(), token, domainFilter)
+	events, err := h.usecase.GetEventsByIDs(c.Request.Context(), token, ids)
 	if handleError(c, err) {
 		return
 	}
 
-	resp := httpdto.ToHttpResponseEventListWithPagination(events, domainFilter, totalCount, h.serviceURLs)
+	resp := httpdto.ToHttpResponseEventListCustom(events, "/events?ids="+url.QueryEscape(*lookup.IDs), h.serviceURLs)
 	c.JSON(http.StatusOK, resp)
 }
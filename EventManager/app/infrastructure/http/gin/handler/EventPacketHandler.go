@@ -8,6 +8,7 @@ import (
 	"eventManager/infrastructure/http/gin/middleware"
 	"eventManager/infrastructure/http/httpdto"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,10 +36,10 @@ func NewGinEventPacketHandler(usecase usecase.EventPacketUseCase, repo repositor
 // @Param Authorization header string true "Bearer token"
 // @Param packet body httpdto.HttpCreateEventPacket true "Event packet details"
 // @Success 201 {object} httpdto.HttpResponseEventPacket "Event packet created successfully"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 409 {object} map[string]string "Event packet already exists"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 409 {object} problem.Problem "Event packet already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packets [post]
 func (h *GinEventPacketHandler) CreateEventPacket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -72,9 +73,9 @@ func (h *GinEventPacketHandler) CreateEventPacket(c *gin.Context) {
 // @Param id path string true "Event Packet ID (UUID)"
 // @Param Authorization header string false "Bearer token (optional)"
 // @Success 200 {object} httpdto.HttpResponseEventPacket "Event packet details"
-// @Failure 400 {object} map[string]string "Invalid event packet ID format"
-// @Failure 404 {object} map[string]string "Event packet not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid event packet ID format"
+// @Failure 404 {object} problem.Problem "Event packet not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packets/{id} [get]
 func (h *GinEventPacketHandler) GetEventPacketByID(c *gin.Context) {
 	id, err := middleware.ParseIDParam(c, "id")
@@ -103,10 +104,10 @@ func (h *GinEventPacketHandler) GetEventPacketByID(c *gin.Context) {
 // @Param id path string true "Event Packet ID (UUID)"
 // @Param packet body httpdto.HttpUpdateEventPacket true "Fields to update"
 // @Success 200 {object} httpdto.HttpResponseEventPacket "Event packet updated successfully"
-// @Failure 400 {object} map[string]string "Invalid request body or event packet ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Event packet not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body or event packet ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Event packet not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packets/{id} [patch]
 func (h *GinEventPacketHandler) UpdateEventPacket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -146,10 +147,10 @@ func (h *GinEventPacketHandler) UpdateEventPacket(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Event Packet ID (UUID)"
 // @Success 200 {object} httpdto.HttpResponseEventPacket "Event packet deleted successfully"
-// @Failure 400 {object} map[string]string "Invalid event packet ID format"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Event packet not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid event packet ID format"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Event packet not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packets/{id} [delete]
 func (h *GinEventPacketHandler) DeleteEventPacket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -179,19 +180,27 @@ func (h *GinEventPacketHandler) DeleteEventPacket(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string false "Bearer token (optional)"
+// @Param q query string false "Full-text search over name, location and description (case and diacritic insensitive)"
 // @Param name query string false "Filter by packet name (partial match)"
 // @Param location query string false "Filter by location (partial match)"
 // @Param description query string false "Filter by description (partial match)"
 // @Param min_seats query int false "Minimum allocated seats"
 // @Param max_seats query int false "Maximum allocated seats"
-// @Param page query int false "Page number (default: 1)"
+// @Param page query int false "Page number (default: 1, deprecated in favour of cursor)"
 // @Param per_page query int false "Items per page (default: 10, max: 100)"
-// @Param order_by query string false "Sort field (e.g., 'name_asc', 'seats_desc')"
+// @Param order_by query string false "Sort order: name_asc/desc, seats_asc/desc, relevance (default when q is set)"
+// @Param cursor query string false "Opaque cursor taken from the next/prev links"
+// @Param ids query string false "Comma-separated ids (max: 100) to look up in one call; cannot be combined with other parameters, unknown ids are left out"
 // @Success 200 {object} httpdto.HttpResponseEventPacketList "Paginated list of event packets"
-// @Failure 400 {object} map[string]string "Invalid query parameters"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid query parameters"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packets [get]
 func (h *GinEventPacketHandler) FilterEventPackets(c *gin.Context) {
+	if _, ok := c.GetQuery("ids"); ok {
+		h.getEventPacketsByIDs(c)
+		return
+	}
+
 	var filter httpdto.HttpFilterEventPacket
 	if err := c.ShouldBindQuery(&filter); err != nil {
 		handleError(c, &domain.ValidationError{Reason: "invalid query parameters"})
@@ -206,7 +215,7 @@ func (h *GinEventPacketHandler) FilterEventPackets(c *gin.Context) {
 	}
 
 	token := getTokenFromHeader(c)
-	packets, err := h.usecase.FilterEventPackets(c.Request.Context(), token, domainFilter)
+	packets, pageInfo, err := h.usecase.FilterEventPackets(c.Request.Context(), token, domainFilter)
 	if handleError(c, err) {
 		return
 	}
@@ -216,6 +225,30 @@ func (h *GinEventPacketHandler) FilterEventPackets(c *gin.Context) {
 		return
 	}
 
-	resp := httpdto.ToHttpResponseEventPacketListWithPagination(packets, domainFilter, totalCount, h.serviceURLs)
+	resp := httpdto.ToHttpResponseEventPacketListWithPagination(packets, domainFilter, pageInfo, totalCount, h.serviceURLs)
+	c.JSON(http.StatusOK, resp)
+}
+
+// getEventPacketsByIDs serves GET /event-packets?ids=..., letting other
+// services resolve many packets in one request.
+func (h *GinEventPacketHandler) getEventPacketsByIDs(c *gin.Context) {
+	var lookup httpdto.HttpBatchLookup
+	if err := middleware.StrictBindQuery(c, &lookup, []string{"ids"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	ids, err := lookup.ToIDs()
+	if handleError(c, err) {
+		return
+	}
+
+	token := getTokenFromHeader(c)
+	packets, err := h.usecase.GetEventPacketsByIDs(c.Request.Context(), token, ids)
+	if handleError(c, err) {
+		return
+	}
+
+	resp := httpdto.ToHttpResponseEventPacketList(packets, "/event-packets?ids="+url.QueryEscape(*lookup.IDs), h.serviceURLs)
 	c.JSON(http.StatusOK, resp)
 }
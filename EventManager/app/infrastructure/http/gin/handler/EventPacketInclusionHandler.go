@@ -34,11 +34,11 @@ func NewGinEventPacketInclusionHandler(usecase usecase.EventPacketInclusionUseCa
 // @Param packet_id path int true "Packet ID"
 // @Param inclusion body httpdto.HttpCreateEventPacketInclusion true "Inclusion details"
 // @Success 201 {object} httpdto.HttpResponseEventPacketInclusion "Inclusion created successfully"
-// @Failure 400 {object} map[string]string "Invalid parameters or request body"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Event or packet not found"
-// @Failure 409 {object} map[string]string "Inclusion already exists"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid parameters or request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Event or packet not found"
+// @Failure 409 {object} problem.Problem "Inclusion already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packet-inclusions/event/{event_id}/packet/{packet_id} [post]
 func (h *GinEventPacketInclusionHandler) CreateEventPacketInclusion(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -85,9 +85,9 @@ func (h *GinEventPacketInclusionHandler) CreateEventPacketInclusion(c *gin.Conte
 // @Param Authorization header string false "Bearer token (optional)"
 // @Param event_id path int true "Event ID"
 // @Success 200 {object} map[string]interface{} "List of event packets"
-// @Failure 400 {object} map[string]string "Invalid event ID"
-// @Failure 404 {object} map[string]string "Event not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid event ID"
+// @Failure 404 {object} problem.Problem "Event not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packet-inclusions/event/{event_id} [get]
 func (h *GinEventPacketInclusionHandler) GetEventPacketsByEventID(c *gin.Context) {
 	eventID, err := middleware.ParseIDParam(c, "event_id")
@@ -117,9 +117,9 @@ func (h *GinEventPacketInclusionHandler) GetEventPacketsByEventID(c *gin.Context
 // @Param Authorization header string false "Bearer token (optional)"
 // @Param packet_id path int true "Packet ID"
 // @Success 200 {object} map[string]interface{} "List of events"
-// @Failure 400 {object} map[string]string "Invalid packet ID"
-// @Failure 404 {object} map[string]string "Packet not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid packet ID"
+// @Failure 404 {object} problem.Problem "Packet not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packet-inclusions/packet/{packet_id} [get]
 func (h *GinEventPacketInclusionHandler) GetEventsByPacketID(c *gin.Context) {
 	packetID, err := middleware.ParseIDParam(c, "packet_id")
@@ -151,10 +151,10 @@ func (h *GinEventPacketInclusionHandler) GetEventsByPacketID(c *gin.Context) {
 // @Param packet_id path int true "Packet ID"
 // @Param inclusion body httpdto.HttpUpdateEventPacketInclusion true "Fields to update"
 // @Success 200 {object} httpdto.HttpResponseEventPacketInclusion "Inclusion updated successfully"
-// @Failure 400 {object} map[string]string "Invalid parameters or request body"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Inclusion not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid parameters or request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Inclusion not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packet-inclusions/event/{event_id}/packet/{packet_id} [patch]
 func (h *GinEventPacketInclusionHandler) UpdateEventPacketInclusion(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -199,10 +199,10 @@ func (h *GinEventPacketInclusionHandler) UpdateEventPacketInclusion(c *gin.Conte
 // @Param event_id path int true "Event ID"
 // @Param packet_id path int true "Packet ID"
 // @Success 200 {object} httpdto.HttpResponseEventPacketInclusion "Inclusion deleted successfully"
-// @Failure 400 {object} map[string]string "Invalid parameters"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Inclusion not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid parameters"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Inclusion not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /event-packet-inclusions/event/{event_id}/packet/{packet_id} [delete]
 func (h *GinEventPacketInclusionHandler) DeleteEventPacketInclusion(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"eventManager/application/domain"
+	"eventManager/application/usecase"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/gin/middleware"
+	"eventManager/infrastructure/http/httpdto"
+	"eventManager/infrastructure/http/problem"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportBodyBytes bounds the file of a bulk import.
+const maxImportBodyBytes = 10 << 20
+
+type GinImportHandler struct {
+	usecase     usecase.ImportUseCase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinImportHandler(usecase usecase.ImportUseCase, serviceURLs *config.ServiceURLs) *GinImportHandler {
+	return &GinImportHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// ImportCatalog godoc
+// @Summary Import events and packets in bulk
+// @Description Create the caller's events and packets from a CSV file (text/csv) or JSON lines (application/x-ndjson). Each row has a type (event or packet), the fields of POST /events or POST /event-packets, and an optional ref; a packet lists the refs of the events it includes in events (separated by ';' in CSV). Every row is checked like a single create. Nothing is created unless every row is valid, and then all rows are created in a single transaction. With dry_run=true the rows are only checked. The report lists the problems of each row by line.
+// @Tags events
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param dry_run query bool false "Only check the rows (default: false)"
+// @Param rows body string true "CSV with a header row, or one JSON object per line"
+// @Success 200 {object} httpdto.HttpResponseImport "Dry run report"
+// @Success 201 {object} httpdto.HttpResponseImport "Rows created"
+// @Failure 400 {object} problem.Problem "Unreadable file, unknown CSV column or unknown query parameter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - insufficient permissions"
+// @Failure 409 {object} problem.Problem "A name was taken while the rows were being created"
+// @Failure 413 {object} problem.Problem "File too large"
+// @Failure 415 {object} problem.Problem "Content-Type is neither text/csv nor application/x-ndjson"
+// @Failure 422 {object} httpdto.HttpResponseImport "Some rows are invalid; nothing was created"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /events/import [post]
+func (h *GinImportHandler) ImportCatalog(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var query httpdto.HttpImportQuery
+	if err := middleware.StrictBindQuery(c, &query, []string{"dry_run"}); err != nil {
+		handleError(c, err)
+		return
+	}
+	dryRun := query.DryRun != nil && *query.DryRun
+
+	var read func(io.Reader) ([]*domain.ImportRow, error)
+	switch c.ContentType() {
+	case httpdto.ContentTypeCSV:
+		read = httpdto.ReadImportCSV
+	case httpdto.ContentTypeJSONLines:
+		read = httpdto.ReadImportJSONLines
+	default:
+		problem.Write(c, problem.New(http.StatusUnsupportedMediaType, problem.TypeUnsupportedMediaType,
+			fmt.Sprintf("Content-Type must be %s or %s", httpdto.ContentTypeCSV, httpdto.ContentTypeJSONLines)))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBodyBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		problem.Write(c, problem.New(http.StatusRequestEntityTooLarge, problem.TypeInvalidRequest,
+			fmt.Sprintf("the file must be at most %d bytes", maxImportBodyBytes)))
+		return
+	} else if err != nil {
+		handleError(c, &domain.InvalidRequestError{Reason: "failed to read request body"})
+		return
+	}
+
+	rows, err := read(bytes.NewReader(body))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	result, err := h.usecase.ImportCatalog(c.Request.Context(), token, rows, dryRun)
+	if handleError(c, err) {
+		return
+	}
+
+	status := http.StatusOK
+	switch {
+	case result.Created:
+		status = http.StatusCreated
+	case !result.Valid() && !dryRun:
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, httpdto.ToHttpResponseImport(result, h.serviceURLs))
+}
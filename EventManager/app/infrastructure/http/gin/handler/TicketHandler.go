@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"eventManager/application/domain"
 	"eventManager/application/usecase"
 	"eventManager/infrastructure/http/config"
 	"eventManager/infrastructure/http/gin/middleware"
@@ -29,13 +30,15 @@ func NewGinTicketHandler(usecase usecase.TicketUseCase, serviceURLs *config.Serv
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param Idempotency-Key header string false "Key making retries safe; the first response is replayed for 24h"
 // @Param ticket body httpdto.HttpCreateTicket true "Ticket details"
 // @Success 201 {object} httpdto.HttpResponseTicket "Ticket created successfully"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Event or packet not found"
-// @Failure 409 {object} map[string]string "Ticket already exists"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Event or packet not found"
+// @Failure 409 {object} problem.Problem "Ticket already exists, sold out (code EVENT_SOLD_OUT/PACKET_SOLD_OUT), no capacity configured (code CAPACITY_NOT_CONFIGURED), event cancelled (code EVENT_CANCELLED) or a request with the same Idempotency-Key is in progress"
+// @Failure 422 {object} problem.Problem "Idempotency-Key reused with a different body"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /tickets [post]
 func (h *GinTicketHandler) CreateTicket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -67,12 +70,15 @@ func (h *GinTicketHandler) CreateTicket(c *gin.Context) {
 // @Accept json
 // @Param Authorization header string true "Bearer token"
 // @Param code path string true "Ticket code (UUID)"
+// @Param Idempotency-Key header string false "Key making retries safe; the first response is replayed for 24h"
 // @Param ticket body httpdto.HttpCreateTicket true "Ticket details"
 // @Success 204 "Ticket created or updated successfully"
-// @Failure 400 {object} map[string]string "Invalid request body or ticket code"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Event or packet not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body or ticket code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Event or packet not found"
+// @Failure 409 {object} problem.Problem "Sold out (code EVENT_SOLD_OUT/PACKET_SOLD_OUT), no capacity configured (code CAPACITY_NOT_CONFIGURED), event cancelled (code EVENT_CANCELLED) or a request with the same Idempotency-Key is in progress"
+// @Failure 422 {object} problem.Problem "Idempotency-Key reused with a different body"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /tickets/{code} [put]
 func (h *GinTicketHandler) PutTicket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -82,7 +88,7 @@ func (h *GinTicketHandler) PutTicket(c *gin.Context) {
 
 	code := c.Param("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ticket code is required"})
+		handleError(c, &domain.InvalidRequestError{Reason: "ticket code is required"})
 		return
 	}
 
@@ -111,10 +117,10 @@ func (h *GinTicketHandler) PutTicket(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param code path string true "Ticket code (UUID)"
 // @Success 200 {object} httpdto.HttpResponseTicket "Ticket details"
-// @Failure 400 {object} map[string]string "Invalid ticket code"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Ticket not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid ticket code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Ticket not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /tickets/{code} [get]
 func (h *GinTicketHandler) GetTicketByCode(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -124,7 +130,7 @@ func (h *GinTicketHandler) GetTicketByCode(c *gin.Context) {
 
 	code := c.Param("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ticket code is required"})
+		handleError(c, &domain.InvalidRequestError{Reason: "ticket code is required"})
 		return
 	}
 
@@ -147,10 +153,10 @@ func (h *GinTicketHandler) GetTicketByCode(c *gin.Context) {
 // @Param code path string true "Ticket code (UUID)"
 // @Param ticket body httpdto.HttpUpdateTicket true "Fields to update"
 // @Success 200 {object} httpdto.HttpResponseTicket "Ticket updated successfully"
-// @Failure 400 {object} map[string]string "Invalid request body or ticket code"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Ticket not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body or ticket code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Ticket not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /tickets/{code} [patch]
 func (h *GinTicketHandler) UpdateTicket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -160,7 +166,7 @@ func (h *GinTicketHandler) UpdateTicket(c *gin.Context) {
 
 	code := c.Param("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ticket code is required"})
+		handleError(c, &domain.InvalidRequestError{Reason: "ticket code is required"})
 		return
 	}
 
@@ -182,18 +188,19 @@ func (h *GinTicketHandler) UpdateTicket(c *gin.Context) {
 }
 
 // DeleteTicket godoc
-// @Summary Delete a ticket
-// @Description Delete a ticket by its code
+// @Summary Void a ticket
+// @Description Delete a ticket by its code, giving its seat back. Only the client service voids tickets, when a refund is approved.
 // @Tags tickets
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Param code path string true "Ticket code (UUID)"
 // @Success 200 {object} httpdto.HttpResponseTicket "Ticket deleted successfully"
-// @Failure 400 {object} map[string]string "Invalid ticket code"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "Ticket not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid ticket code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - only the client service can void tickets"
+// @Failure 404 {object} problem.Problem "Ticket not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /tickets/{code} [delete]
 func (h *GinTicketHandler) DeleteTicket(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -203,7 +210,7 @@ func (h *GinTicketHandler) DeleteTicket(c *gin.Context) {
 
 	code := c.Param("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ticket code is required"})
+		handleError(c, &domain.InvalidRequestError{Reason: "ticket code is required"})
 		return
 	}
 
@@ -215,3 +222,92 @@ func (h *GinTicketHandler) DeleteTicket(c *gin.Context) {
 	resp := httpdto.ToHttpResponseTicket(ret, h.serviceURLs)
 	c.JSON(http.StatusOK, resp)
 }
+
+// RotateTicketCode godoc
+// @Summary Rotate a ticket code
+// @Description Move a ticket to a new code when it changes hands, so the old code stops being valid. Retrying with the same new code returns the rotated ticket.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param code path string true "Current ticket code (UUID)"
+// @Param rotation body httpdto.HttpRotateTicket true "New ticket code"
+// @Success 200 {object} httpdto.HttpResponseTicket "Ticket under its new code"
+// @Failure 400 {object} problem.Problem "Invalid request body or ticket code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - insufficient permissions"
+// @Failure 404 {object} problem.Problem "Ticket not found"
+// @Failure 409 {object} problem.Problem "New code already taken or transfers blocked by the event owner (code TRANSFERS_BLOCKED)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /tickets/{code}/rotate [post]
+func (h *GinTicketHandler) RotateTicketCode(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	code := c.Param("code")
+	if code == "" {
+		handleError(c, &domain.InvalidRequestError{Reason: "ticket code is required"})
+		return
+	}
+
+	var req httpdto.HttpRotateTicket
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	ticket, err := h.usecase.RotateTicketCode(c.Request.Context(), token, code, req.NewCode)
+	if handleError(c, err) {
+		return
+	}
+
+	resp := httpdto.ToHttpResponseTicket(ticket, h.serviceURLs)
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResellTicket godoc
+// @Summary Reissue a resold ticket
+// @Description Move a ticket sold on the resale marketplace to the buyer's new code. The event owner must allow resale and the price must stay within their markup cap over the face value. Retrying with the same new code returns the reissued ticket.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param code path string true "Current ticket code (UUID)"
+// @Param resale body httpdto.HttpResellTicket true "New ticket code and resale price"
+// @Success 200 {object} httpdto.HttpResponseTicket "Ticket under its new code"
+// @Failure 400 {object} problem.Problem "Invalid request body or ticket code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - insufficient permissions"
+// @Failure 404 {object} problem.Problem "Ticket not found"
+// @Failure 409 {object} problem.Problem "New code already taken or resale not allowed by the event owner (code RESALE_NOT_ALLOWED)"
+// @Failure 422 {object} problem.Problem "Price above the owner's markup cap (code RESALE_PRICE_ABOVE_CAP)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /tickets/{code}/resell [post]
+func (h *GinTicketHandler) ResellTicket(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	code := c.Param("code")
+	if code == "" {
+		handleError(c, &domain.InvalidRequestError{Reason: "ticket code is required"})
+		return
+	}
+
+	var req httpdto.HttpResellTicket
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	ticket, err := h.usecase.ResellTicket(c.Request.Context(), token, code, req.NewCode, *req.Price)
+	if handleError(c, err) {
+		return
+	}
+
+	resp := httpdto.ToHttpResponseTicket(ticket, h.serviceURLs)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"eventManager/application/usecase"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/gin/middleware"
+	"eventManager/infrastructure/http/httpdto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinWebhookHandler struct {
+	usecase     usecase.WebhookUseCase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinWebhookHandler(usecase usecase.WebhookUseCase, serviceURLs *config.ServiceURLs) *GinWebhookHandler {
+	return &GinWebhookHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Subscribe to ticket events
+// @Description Register a URL that receives signed notifications when tickets for your events or packets are sold, updated or cancelled (owner only). A secret is generated when none is given; it is only returned in this response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param webhook body httpdto.HttpCreateWebhookSubscription true "Webhook subscription"
+// @Success 201 {object} httpdto.HttpResponseWebhookSubscription "Webhook created, including its secret"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not an event owner, or filtering on someone else's event"
+// @Failure 422 {object} problem.Problem "Invalid URL, secret, event type or filter"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks [post]
+func (h *GinWebhookHandler) CreateWebhook(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.HttpCreateWebhookSubscription
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	subscription, err := h.usecase.CreateSubscription(c.Request.Context(), token, req.ToWebhookSubscription())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponseCreatedWebhookSubscription(subscription, h.serviceURLs))
+}
+
+// GetWebhooks godoc
+// @Summary List your webhooks
+// @Description Get every webhook subscription of the authenticated owner
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} httpdto.HttpResponseWebhookSubscriptionList "List of webhooks"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not an event owner"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks [get]
+func (h *GinWebhookHandler) GetWebhooks(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.usecase.GetSubscriptions(c.Request.Context(), token)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWebhookSubscriptionList(subscriptions, h.serviceURLs))
+}
+
+// GetWebhookByID godoc
+// @Summary Get a webhook
+// @Description Retrieve one of your webhook subscriptions
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} httpdto.HttpResponseWebhookSubscription "Webhook details"
+// @Failure 400 {object} problem.Problem "Invalid webhook ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not your webhook"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks/{id} [get]
+func (h *GinWebhookHandler) GetWebhookByID(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	subscription, err := h.usecase.GetSubscriptionByID(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWebhookSubscription(subscription, h.serviceURLs))
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Change the URL, secret, event types or filter of a webhook, or pause it with active=false
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Webhook ID"
+// @Param webhook body httpdto.HttpUpdateWebhookSubscription true "Fields to update"
+// @Success 200 {object} httpdto.HttpResponseWebhookSubscription "Webhook updated successfully"
+// @Failure 400 {object} problem.Problem "Invalid request body or webhook ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not your webhook"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 422 {object} problem.Problem "Invalid URL, secret, event type or filter"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks/{id} [patch]
+func (h *GinWebhookHandler) UpdateWebhook(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpUpdateWebhookSubscription
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	subscription, err := h.usecase.UpdateSubscription(c.Request.Context(), token, id, req.ToPatch())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWebhookSubscription(subscription, h.serviceURLs))
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook subscription together with its delivery log
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} httpdto.HttpResponseWebhookSubscription "Webhook deleted successfully"
+// @Failure 400 {object} problem.Problem "Invalid webhook ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not your webhook"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (h *GinWebhookHandler) DeleteWebhook(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	subscription, err := h.usecase.DeleteSubscription(c.Request.Context(), token, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWebhookSubscription(subscription, h.serviceURLs))
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get the delivery log of a webhook
+// @Description List the deliveries of a webhook, newest first. status=dead_letter lists the deliveries that ran out of retries.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Only deliveries in this status: pending, delivered, dead_letter"
+// @Param page query int false "Page number (default: 1)"
+// @Param per_page query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} httpdto.HttpResponseWebhookDeliveryList "Paginated delivery log"
+// @Failure 400 {object} problem.Problem "Invalid query parameters or webhook ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not your webhook"
+// @Failure 404 {object} problem.Problem "Webhook not found"
+// @Failure 422 {object} problem.Problem "Invalid status or paging"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *GinWebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var filter httpdto.HttpFilterWebhookDelivery
+	if err := middleware.StrictBindQuery(c, &filter, []string{"status", "page", "per_page"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	domainFilter := filter.ToWebhookDeliveryFilter()
+	deliveries, total, err := h.usecase.GetDeliveries(c.Request.Context(), token, id, domainFilter)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWebhookDeliveryList(id, deliveries, domainFilter, total, h.serviceURLs))
+}
+
+// RedeliverWebhook godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queue a delivery again with a fresh retry budget, e.g. to replay a dead letter once the receiver is fixed. The receiver gets the same message_id as before.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 202 {object} httpdto.HttpResponseWebhookDelivery "Delivery queued"
+// @Failure 400 {object} problem.Problem "Invalid webhook or delivery ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not your webhook"
+// @Failure 404 {object} problem.Problem "Webhook or delivery not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *GinWebhookHandler) RedeliverWebhook(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	deliveryID, err := middleware.ParseIDParam(c, "delivery_id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	delivery, err := h.usecase.Redeliver(c.Request.Context(), token, id, int64(deliveryID))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, httpdto.ToHttpResponseWebhookDelivery(delivery, h.serviceURLs))
+}
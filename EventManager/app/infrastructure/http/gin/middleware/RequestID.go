@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"eventManager/infrastructure/http/problem"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID tags every request with a trace id, reused from X-Request-ID or
+// a W3C traceparent header when the caller sent one, and echoes it back so
+// problem responses can be matched with the logs.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimSpace(c.GetHeader(RequestIDHeader))
+		if id == "" || len(id) > 128 {
+			id = traceIDFromTraceparent(c.GetHeader("traceparent"))
+		}
+		if id == "" {
+			id = newRequestID()
+		}
+
+		c.Set(problem.TraceIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// traceIDFromTraceparent returns the trace-id field of a
+// "version-traceid-parentid-flags" header.
+func traceIDFromTraceparent(header string) string {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return ""
+	}
+	return parts[1]
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
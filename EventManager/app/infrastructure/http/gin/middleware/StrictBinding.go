@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"eventManager/application/domain"
+	"eventManager/infrastructure/http/problem"
 	"fmt"
 	"io"
 	"net/http"
@@ -74,12 +75,14 @@ func StrictBindJSON(c *gin.Context, obj interface{}) error {
 			var validationErrs validator.ValidationErrors
 			if errors.As(err, &validationErrs) {
 
+				fieldErrors := make([]*domain.ValidationError, 0, len(validationErrs))
 				for _, fieldErr := range validationErrs {
-					return &domain.ValidationError{
+					fieldErrors = append(fieldErrors, &domain.ValidationError{
 						Field:  fieldErr.Field(),
 						Reason: fmt.Sprintf("validation failed on '%s' tag", fieldErr.Tag()),
-					}
+					})
 				}
+				return &domain.ValidationErrors{Errors: fieldErrors}
 			}
 			return &domain.ValidationError{Reason: err.Error()}
 		}
@@ -136,9 +139,7 @@ func RejectUnknownJSONMiddleware() gin.HandlerFunc {
 		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodDelete {
 			contentType := c.ContentType()
 			if contentType != "" && contentType != "application/json" {
-				c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
-					"error": "Content-Type must be application/json",
-				})
+				problem.Write(c, problem.New(http.StatusUnsupportedMediaType, problem.TypeUnsupportedMediaType, "Content-Type must be application/json"))
 				return
 			}
 		}
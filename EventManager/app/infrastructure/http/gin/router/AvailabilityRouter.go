@@ -0,0 +1,14 @@
+package router
+
+import (
+	"eventManager/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAvailabilityRoutes(router *gin.RouterGroup, handler *handler.GinAvailabilityHandler) {
+	router.GET("/events/:id/availability", handler.GetEventAvailability)
+	router.GET("/event-packets/:id/availability", handler.GetEventPacketAvailability)
+
+	router.GET("/owners/:owner_id/dashboard", handler.GetOwnerDashboard)
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"eventManager/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCategoryRoutes(router *gin.RouterGroup, handler *handler.GinCategoryHandler) {
+	router.GET("/categories", handler.GetCategories)
+	router.GET("/categories/:id", handler.GetCategoryByID)
+
+	router.POST("/categories", handler.CreateCategory)
+	router.PATCH("/categories/:id", handler.UpdateCategory)
+	router.DELETE("/categories/:id", handler.DeleteCategory)
+
+	router.PUT("/events/:id/tags", handler.SetEventTags)
+	router.PUT("/event-packets/:id/tags", handler.SetEventPacketTags)
+}
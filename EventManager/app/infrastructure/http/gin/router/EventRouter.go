@@ -11,10 +11,12 @@ func RegisterEventRoutes(router *gin.RouterGroup, handler *handler.GinEventHandl
 	router.POST("/events/", handler.CreateEvent)
 
 	router.GET("/events/:id", handler.GetEventByID)
+	router.GET("/events/:id/ics", handler.GetEventCalendar)
 	router.GET("/events", handler.FilterEvents)
 	router.GET("/events/", handler.FilterEvents)
 
 	router.PATCH("/events/:id", handler.UpdateEvent)
+	router.POST("/events/:id/cancel", handler.CancelEvent)
 
 	router.DELETE("/events/:id", handler.DeleteEvent)
 }
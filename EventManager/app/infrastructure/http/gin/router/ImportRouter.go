@@ -0,0 +1,11 @@
+package router
+
+import (
+	"eventManager/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterImportRoutes(router *gin.RouterGroup, handler *handler.GinImportHandler) {
+	router.POST("/events/import", handler.ImportCatalog)
+}
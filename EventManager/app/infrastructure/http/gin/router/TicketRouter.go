@@ -6,12 +6,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterTicketRoutes(router *gin.RouterGroup, handler *handler.GinTicketHandler) {
-	router.POST("/tickets", handler.CreateTicket)
-	router.POST("/tickets/", handler.CreateTicket)
+// idempotency guards the routes that create tickets, so retried requests
+// carrying an Idempotency-Key do not sell the same seat twice.
+func RegisterTicketRoutes(router *gin.RouterGroup, handler *handler.GinTicketHandler, idempotency gin.HandlerFunc) {
+	router.POST("/tickets", idempotency, handler.CreateTicket)
+	router.POST("/tickets/", idempotency, handler.CreateTicket)
 
 	router.GET("/tickets/:code", handler.GetTicketByCode)
 	router.PATCH("/tickets/:code", handler.UpdateTicket)
-	router.PUT("/tickets/:code", handler.PutTicket)
+	router.PUT("/tickets/:code", idempotency, handler.PutTicket)
 	router.DELETE("/tickets/:code", handler.DeleteTicket)
+	router.POST("/tickets/:code/rotate", handler.RotateTicketCode)
+	router.POST("/tickets/:code/resell", handler.ResellTicket)
 }
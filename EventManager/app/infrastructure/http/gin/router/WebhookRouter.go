@@ -0,0 +1,19 @@
+package router
+
+import (
+	"eventManager/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWebhookRoutes(router *gin.RouterGroup, handler *handler.GinWebhookHandler) {
+	router.GET("/webhooks", handler.GetWebhooks)
+	router.GET("/webhooks/:id", handler.GetWebhookByID)
+
+	router.POST("/webhooks", handler.CreateWebhook)
+	router.PATCH("/webhooks/:id", handler.UpdateWebhook)
+	router.DELETE("/webhooks/:id", handler.DeleteWebhook)
+
+	router.GET("/webhooks/:id/deliveries", handler.GetWebhookDeliveries)
+	router.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", handler.RedeliverWebhook)
+}
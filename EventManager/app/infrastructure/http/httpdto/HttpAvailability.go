@@ -0,0 +1,164 @@
+package httpdto
+
+import (
+	"eventManager/application/domain"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/hateoas"
+	"fmt"
+)
+
+type httpEventAvailability struct {
+	EventID        int                     `json:"event_id"`
+	Name           string                  `json:"name"`
+	TotalSeats     *int                    `json:"total_seats"`
+	DirectSold     int                     `json:"direct_sold"`
+	PacketReserved int                     `json:"packet_reserved"`
+	PacketSold     int                     `json:"packet_sold"`
+	Remaining      *int                    `json:"remaining"`
+	SellThrough    *float64                `json:"sell_through_percent"`
+	Links          map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpResponseEventAvailability struct {
+	Availability *httpEventAvailability `json:"availability"`
+}
+
+type httpEventPacketAvailability struct {
+	PacketID       int                     `json:"packet_id"`
+	Name           string                  `json:"name"`
+	AllocatedSeats *int                    `json:"allocated_seats"`
+	Sold           int                     `json:"sold"`
+	Remaining      *int                    `json:"remaining"`
+	SellThrough    *float64                `json:"sell_through_percent"`
+	EventCount     int                     `json:"event_count"`
+	Links          map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpResponseEventPacketAvailability struct {
+	Availability *httpEventPacketAvailability `json:"availability"`
+}
+
+type httpDashboardTotals struct {
+	Events         int      `json:"events"`
+	Packets        int      `json:"packets"`
+	TotalSeats     int      `json:"total_seats"`
+	TotalSold      int      `json:"total_sold"`
+	TotalRemaining int      `json:"total_remaining"`
+	SellThrough    *float64 `json:"sell_through_percent"`
+}
+
+type HttpResponseOwnerDashboard struct {
+	OwnerID int                            `json:"id_owner"`
+	Totals  *httpDashboardTotals           `json:"totals"`
+	Events  []*httpEventAvailability       `json:"events"`
+	Packets []*httpEventPacketAvailability `json:"event_packets"`
+	Links   map[string]hateoas.Link        `json:"_links"`
+}
+
+func toHttpEventAvailability(availability *domain.EventAvailability, serviceURLs *config.ServiceURLs) *httpEventAvailability {
+	eventPath := fmt.Sprintf("/events/%d", availability.EventID)
+
+	return &httpEventAvailability{
+		EventID:        availability.EventID,
+		Name:           availability.Name,
+		TotalSeats:     availability.TotalSeats,
+		DirectSold:     availability.DirectSold,
+		PacketReserved: availability.PacketReserved,
+		PacketSold:     availability.PacketSold,
+		Remaining:      availability.Remaining(),
+		SellThrough:    availability.SellThrough(),
+		Links: map[string]hateoas.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.EventManager, eventPath+"/availability"),
+			"event": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s%s", serviceURLs.EventManager, eventPath),
+				"event",
+				"GET",
+				"Get the event",
+			),
+			"customers": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s%s/customers", serviceURLs.UserManager, eventPath),
+				"customers",
+				"GET",
+				"Get customers for this event",
+			),
+		},
+	}
+}
+
+func toHttpEventPacketAvailability(availability *domain.EventPacketAvailability, serviceURLs *config.ServiceURLs) *httpEventPacketAvailability {
+	packetPath := fmt.Sprintf("/event-packets/%d", availability.PacketID)
+
+	return &httpEventPacketAvailability{
+		PacketID:       availability.PacketID,
+		Name:           availability.Name,
+		AllocatedSeats: availability.AllocatedSeats,
+		Sold:           availability.Sold,
+		Remaining:      availability.Remaining(),
+		SellThrough:    availability.SellThrough(),
+		EventCount:     availability.EventCount,
+		Links: map[string]hateoas.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.EventManager, packetPath+"/availability"),
+			"packet": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s%s", serviceURLs.EventManager, packetPath),
+				"packet",
+				"GET",
+				"Get the event packet",
+			),
+			"customers": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/packets/%d/customers", serviceURLs.UserManager, availability.PacketID),
+				"customers",
+				"GET",
+				"Get customers for this packet",
+			),
+		},
+	}
+}
+
+func ToHttpResponseEventAvailability(availability *domain.EventAvailability, serviceURLs *config.ServiceURLs) *HttpResponseEventAvailability {
+	return &HttpResponseEventAvailability{
+		Availability: toHttpEventAvailability(availability, serviceURLs),
+	}
+}
+
+func ToHttpResponseEventPacketAvailability(availability *domain.EventPacketAvailability, serviceURLs *config.ServiceURLs) *HttpResponseEventPacketAvailability {
+	return &HttpResponseEventPacketAvailability{
+		Availability: toHttpEventPacketAvailability(availability, serviceURLs),
+	}
+}
+
+func ToHttpResponseOwnerDashboard(dashboard *domain.OwnerDashboard, serviceURLs *config.ServiceURLs) *HttpResponseOwnerDashboard {
+	events := make([]*httpEventAvailability, 0, len(dashboard.Events))
+	for _, event := range dashboard.Events {
+		events = append(events, toHttpEventAvailability(event, serviceURLs))
+	}
+
+	packets := make([]*httpEventPacketAvailability, 0, len(dashboard.Packets))
+	for _, packet := range dashboard.Packets {
+		packets = append(packets, toHttpEventPacketAvailability(packet, serviceURLs))
+	}
+
+	totalSeats, totalSold, totalRemaining := dashboard.Totals()
+
+	return &HttpResponseOwnerDashboard{
+		OwnerID: dashboard.OwnerID,
+		Totals: &httpDashboardTotals{
+			Events:         len(dashboard.Events),
+			Packets:        len(dashboard.Packets),
+			TotalSeats:     totalSeats,
+			TotalSold:      totalSold,
+			TotalRemaining: totalRemaining,
+			SellThrough:    dashboard.SellThrough(),
+		},
+		Events:  events,
+		Packets: packets,
+		Links: map[string]hateoas.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.EventManager, fmt.Sprintf("/owners/%d/dashboard", dashboard.OwnerID)),
+			"owner": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d", serviceURLs.UserManager, dashboard.OwnerID),
+				"owner",
+				"GET",
+				"Get the owner",
+			),
+		},
+	}
+}
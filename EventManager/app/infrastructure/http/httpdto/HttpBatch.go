@@ -0,0 +1,32 @@
+package httpdto
+
+import (
+	"eventManager/application/domain"
+	"strconv"
+	"strings"
+)
+
+type HttpBatchLookup struct {
+	IDs *string `json:"ids,omitempty" form:"ids"`
+}
+
+// ToIDs parses the comma-separated ids, dropping repeated ones.
+func (lookup *HttpBatchLookup) ToIDs() ([]int, error) {
+	if lookup.IDs == nil {
+		return nil, nil
+	}
+
+	seen := make(map[int]bool)
+	var ids []int
+	for _, raw := range strings.Split(*lookup.IDs, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, &domain.ValidationError{Field: "ids", Reason: "ids must be a comma-separated list of integers"}
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,219 @@
+package httpdto
+
+import (
+	"eventManager/application/domain"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/hateoas"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type httpResponseCategory struct {
+	ID          int                     `json:"id"`
+	Name        string                  `json:"name"`
+	Slug        string                  `json:"slug"`
+	Description *string                 `json:"description,omitempty"`
+	Links       map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpResponseCategory struct {
+	Category *httpResponseCategory `json:"category"`
+}
+
+type HttpResponseCategoryList struct {
+	Categories []*httpResponseCategory `json:"categories"`
+	Links      map[string]hateoas.Link `json:"_links"`
+}
+
+// httpCategoryRef is the short form of a category embedded in events and
+// packets.
+type httpCategoryRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func toHttpCategory(category *domain.Category, serviceURLs *config.ServiceURLs) *httpResponseCategory {
+	resourcePath := fmt.Sprintf("/categories/%d", category.ID)
+
+	return &httpResponseCategory{
+		ID:          category.ID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+		Links: map[string]hateoas.Link{
+			"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
+			"parent": hateoas.BuildParentLink(serviceURLs.EventManager, "/categories"),
+			"update": hateoas.BuildUpdateLink(serviceURLs.EventManager, resourcePath),
+			"delete": hateoas.BuildDeleteLink(serviceURLs.EventManager, resourcePath),
+			"events": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/events?category=%s", serviceURLs.EventManager, category.Slug),
+				"events",
+				"GET",
+				"Get events in this category",
+			),
+		},
+	}
+}
+
+func toHttpCategoryRefs(categories []*domain.Category) []*httpCategoryRef {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	refs := make([]*httpCategoryRef, 0, len(categories))
+	for _, category := range categories {
+		refs = append(refs, &httpCategoryRef{
+			ID:   category.ID,
+			Name: category.Name,
+			Slug: category.Slug,
+		})
+	}
+	return refs
+}
+
+func ToHttpResponseCategory(category *domain.Category, serviceURLs *config.ServiceURLs) *HttpResponseCategory {
+	if category == nil {
+		return &HttpResponseCategory{}
+	}
+	return &HttpResponseCategory{Category: toHttpCategory(category, serviceURLs)}
+}
+
+func ToHttpResponseCategoryList(categories []*domain.Category, serviceURLs *config.ServiceURLs) *HttpResponseCategoryList {
+	httpCategories := make([]*httpResponseCategory, 0, len(categories))
+	for _, category := range categories {
+		httpCategories = append(httpCategories, toHttpCategory(category, serviceURLs))
+	}
+
+	return &HttpResponseCategoryList{
+		Categories: httpCategories,
+		Links: map[string]hateoas.Link{
+			"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, "/categories"),
+			"create": hateoas.BuildCreateLink(serviceURLs.EventManager, "/categories"),
+		},
+	}
+}
+
+type HttpCreateCategory struct {
+	Name        string  `json:"name" binding:"required,min=1,max=64"`
+	Slug        *string `json:"slug" binding:"omitempty,min=1,max=64"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+func (category *HttpCreateCategory) ToCategory() *domain.Category {
+	ret := &domain.Category{
+		Name:        category.Name,
+		Description: category.Description,
+	}
+	if category.Slug != nil {
+		ret.Slug = *category.Slug
+	}
+	return ret
+}
+
+type HttpUpdateCategory struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=64"`
+	Slug        *string `json:"slug" binding:"omitempty,min=1,max=64"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+func (category *HttpUpdateCategory) ToUpdateMap() map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	if category.Name != nil {
+		updates["name"] = *category.Name
+	}
+	if category.Slug != nil {
+		updates["slug"] = *category.Slug
+	}
+	if category.Description != nil {
+		updates["description"] = *category.Description
+	}
+
+	return updates
+}
+
+// HttpTagSet replaces the categories (by slug) and free-form tags of an event
+// or packet. An empty list removes them all.
+type HttpTagSet struct {
+	Categories []string `json:"categories" binding:"omitempty,max=20"`
+	Tags       []string `json:"tags" binding:"omitempty,max=20"`
+}
+
+func (set *HttpTagSet) ToTagSet() *domain.TagSet {
+	return &domain.TagSet{
+		Categories: set.Categories,
+		Tags:       set.Tags,
+	}
+}
+
+type httpFacetValue struct {
+	Value    string                  `json:"value"`
+	Label    string                  `json:"label"`
+	Count    int                     `json:"count"`
+	Selected bool                    `json:"selected"`
+	Links    map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpEventFacets struct {
+	Categories []*httpFacetValue `json:"categories"`
+	Tags       []*httpFacetValue `json:"tags"`
+}
+
+// ToHttpEventFacets links every facet value to the listing narrowed by it:
+// picking a category replaces the current one, picking a tag adds to the
+// current tags.
+func ToHttpEventFacets(facets *domain.EventFacets, filter *domain.EventFilter, serviceURLs *config.ServiceURLs) *HttpEventFacets {
+	if facets == nil {
+		return nil
+	}
+
+	var selectedTags []string
+	if filter != nil {
+		selectedTags = filter.Tags
+	}
+
+	ret := &HttpEventFacets{
+		Categories: make([]*httpFacetValue, 0, len(facets.Categories)),
+		Tags:       make([]*httpFacetValue, 0, len(facets.Tags)),
+	}
+
+	for _, facet := range facets.Categories {
+		params := buildEventFilterQuery(filter)
+		params.Set("category", facet.Value)
+
+		ret.Categories = append(ret.Categories, &httpFacetValue{
+			Value:    facet.Value,
+			Label:    facet.Label,
+			Count:    facet.Count,
+			Selected: filter != nil && filter.Category != nil && *filter.Category == facet.Value,
+			Links: map[string]hateoas.Link{
+				"filter": hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", params.Encode(), "filter", "Filter by this category"),
+			},
+		})
+	}
+
+	for _, facet := range facets.Tags {
+		selected := slices.Contains(selectedTags, facet.Value)
+		tags := selectedTags
+		if !selected {
+			tags = append(slices.Clone(selectedTags), facet.Value)
+		}
+
+		params := buildEventFilterQuery(filter)
+		params.Set("tags", strings.Join(tags, ","))
+
+		ret.Tags = append(ret.Tags, &httpFacetValue{
+			Value:    facet.Value,
+			Label:    facet.Label,
+			Count:    facet.Count,
+			Selected: selected,
+			Links: map[string]hateoas.Link{
+				"filter": hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", params.Encode(), "filter", "Filter by this tag"),
+			},
+		})
+	}
+
+	return ret
+}
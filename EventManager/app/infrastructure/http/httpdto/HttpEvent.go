@@ -7,16 +7,38 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type httpResponseEvent struct {
-	ID          int                     `json:"id"`
-	OwnerID     int                     `json:"id_owner"`
-	Name        string                  `json:"name"`
-	Location    *string                 `json:"location,omitempty"`
-	Description *string                 `json:"description,omitempty"`
-	Seats       *int                    `json:"seats,omitempty"`
-	Links       map[string]hateoas.Link `json:"_links"`
+	ID                     int                     `json:"id"`
+	OwnerID                int                     `json:"id_owner"`
+	Name                   string                  `json:"name"`
+	Location               *string                 `json:"location,omitempty"`
+	Description            *string                 `json:"description,omitempty"`
+	Seats                  *int                    `json:"seats,omitempty"`
+	Address                *string                 `json:"address,omitempty"`
+	City                   *string                 `json:"city,omitempty"`
+	Country                *string                 `json:"country,omitempty"`
+	Latitude               *float64                `json:"latitude,omitempty"`
+	Longitude              *float64                `json:"longitude,omitempty"`
+	StartsAt               *time.Time              `json:"starts_at,omitempty"`
+	EndsAt                 *time.Time              `json:"ends_at,omitempty"`
+	State                  string                  `json:"state"`
+	CancelledAt            *time.Time              `json:"cancelled_at,omitempty"`
+	Sequence               int                     `json:"sequence"`
+	TransfersBlocked       bool                    `json:"transfers_blocked"`
+	Price                  *int                    `json:"price,omitempty"`
+	ResaleAllowed          bool                    `json:"resale_allowed"`
+	ResaleMaxMarkupPercent *int                    `json:"resale_max_markup_percent,omitempty"`
+	RefundWindowHours      *int                    `json:"refund_window_hours,omitempty"`
+	MaxTicketsPerUser      *int                    `json:"max_tickets_per_user,omitempty"`
+	DistanceKm             *float64                `json:"distance_km,omitempty"`
+	Categories             []*httpCategoryRef      `json:"categories,omitempty"`
+	Tags                   []string                `json:"tags,omitempty"`
+	Search                 *httpSearchMatch        `json:"search,omitempty"`
+	Links                  map[string]hateoas.Link `json:"_links"`
 }
 
 type HttpResponseEvent struct {
@@ -31,12 +53,30 @@ func ToHttpResponseEvent(event *domain.Event, serviceURLs *config.ServiceURLs) *
 	resourcePath := fmt.Sprintf("/events/%d", event.ID)
 
 	dto := &httpResponseEvent{
-		ID:          event.ID,
-		OwnerID:     event.OwnerID,
-		Name:        event.Name,
-		Location:    event.Location,
-		Description: event.Description,
-		Seats:       event.Seats,
+		ID:                     event.ID,
+		OwnerID:                event.OwnerID,
+		Name:                   event.Name,
+		Location:               event.Location,
+		Description:            event.Description,
+		Seats:                  event.Seats,
+		Address:                event.Address,
+		City:                   event.City,
+		Country:                event.Country,
+		Latitude:               event.Latitude,
+		Longitude:              event.Longitude,
+		StartsAt:               event.StartsAt,
+		EndsAt:                 event.EndsAt,
+		State:                  event.GetState(),
+		CancelledAt:            event.CancelledAt,
+		Sequence:               event.Sequence,
+		TransfersBlocked:       event.TransfersBlocked,
+		Price:                  event.Price,
+		ResaleAllowed:          event.ResaleAllowed,
+		ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+		RefundWindowHours:      event.RefundWindowHours,
+		MaxTicketsPerUser:      event.MaxTicketsPerUser,
+		Categories:             toHttpCategoryRefs(event.Categories),
+		Tags:                   event.Tags,
 		Links: map[string]hateoas.Link{
 			"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 			"parent": hateoas.BuildParentLink(serviceURLs.EventManager, "/events"),
@@ -60,8 +100,28 @@ func ToHttpResponseEvent(event *domain.Event, serviceURLs *config.ServiceURLs) *
 				"GET",
 				"Get tickets for this event",
 			),
+			"tags": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s%s/tags", serviceURLs.EventManager, resourcePath),
+				"tags",
+				"PUT",
+				"Replace the categories and tags of this event",
+			),
+			"calendar": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s%s/ics", serviceURLs.EventManager, resourcePath),
+				"calendar",
+				"GET",
+				"Download this event as an iCalendar file",
+			),
 		},
 	}
+	if !event.Cancelled() {
+		dto.Links["cancel"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s%s/cancel", serviceURLs.EventManager, resourcePath),
+			"cancel",
+			"POST",
+			"Cancel this event",
+		)
+	}
 
 	return &HttpResponseEvent{
 		Event: dto,
@@ -72,22 +132,18 @@ type HttpResponseEventList struct {
 	Events   []*httpResponseEvent    `json:"events"`
 	Links    map[string]hateoas.Link `json:"_links"`
 	Metadata *PaginationMetadata     `json:"_metadata,omitempty"`
+	Facets   *HttpEventFacets        `json:"_facets,omitempty"`
 }
 
-type PaginationMetadata struct {
-	Page       int `json:"page"`
-	PerPage    int `json:"per_page"`
-	TotalItems int `json:"total_items,omitempty"`
-	TotalPages int `json:"total_pages,omitempty"`
-}
-
-func buildEventFilterQuery(filter *domain.EventFilter, page int) string {
+func buildEventFilterQuery(filter *domain.EventFilter) url.Values {
+	params := url.Values{}
 	if filter == nil {
-		return ""
+		return params
 	}
 
-	params := url.Values{}
-
+	if filter.Query != nil {
+		params.Add("q", *filter.Query)
+	}
 	if filter.Name != nil {
 		params.Add("name", *filter.Name)
 	}
@@ -103,6 +159,18 @@ func buildEventFilterQuery(filter *domain.EventFilter, page int) string {
 	if filter.MaxSeats != nil {
 		params.Add("max_seats", strconv.Itoa(*filter.MaxSeats))
 	}
+	if filter.Near != nil {
+		params.Add("near", *filter.Near)
+	}
+	if filter.RadiusKm != nil {
+		params.Add("radius_km", strconv.FormatFloat(*filter.RadiusKm, 'f', -1, 64))
+	}
+	if filter.Category != nil {
+		params.Add("category", *filter.Category)
+	}
+	if len(filter.Tags) > 0 {
+		params.Add("tags", strings.Join(filter.Tags, ","))
+	}
 	if filter.OrderBy != nil {
 		params.Add("order_by", *filter.OrderBy)
 	}
@@ -111,9 +179,7 @@ func buildEventFilterQuery(filter *domain.EventFilter, page int) string {
 		params.Add("per_page", strconv.Itoa(*filter.PerPage))
 	}
 
-	params.Add("page", strconv.Itoa(page))
-
-	return params.Encode()
+	return params
 }
 
 func ToHttpResponseEventList(events []*domain.Event, serviceURLs *config.ServiceURLs) *HttpResponseEventList {
@@ -131,12 +197,30 @@ func ToHttpResponseEventList(events []*domain.Event, serviceURLs *config.Service
 	for _, event := range events {
 		resourcePath := fmt.Sprintf("/events/%d", event.ID)
 		httpEvents = append(httpEvents, &httpResponseEvent{
-			ID:          event.ID,
-			OwnerID:     event.OwnerID,
-			Name:        event.Name,
-			Location:    event.Location,
-			Description: event.Description,
-			Seats:       event.Seats,
+			ID:                     event.ID,
+			OwnerID:                event.OwnerID,
+			Name:                   event.Name,
+			Location:               event.Location,
+			Description:            event.Description,
+			Seats:                  event.Seats,
+			Address:                event.Address,
+			City:                   event.City,
+			Country:                event.Country,
+			Latitude:               event.Latitude,
+			Longitude:              event.Longitude,
+			StartsAt:               event.StartsAt,
+			EndsAt:                 event.EndsAt,
+			State:                  event.GetState(),
+			CancelledAt:            event.CancelledAt,
+			Sequence:               event.Sequence,
+			TransfersBlocked:       event.TransfersBlocked,
+			Price:                  event.Price,
+			ResaleAllowed:          event.ResaleAllowed,
+			ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+			RefundWindowHours:      event.RefundWindowHours,
+			MaxTicketsPerUser:      event.MaxTicketsPerUser,
+			Categories:             toHttpCategoryRefs(event.Categories),
+			Tags:                   event.Tags,
 			Links: map[string]hateoas.Link{
 				"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 				"parent": hateoas.BuildParentLink(serviceURLs.EventManager, "/events"),
@@ -171,12 +255,30 @@ func ToHttpResponseEventListCustom(events []*domain.Event, selfPath string, serv
 	for _, event := range events {
 		resourcePath := fmt.Sprintf("/events/%d", event.ID)
 		httpEvents = append(httpEvents, &httpResponseEvent{
-			ID:          event.ID,
-			OwnerID:     event.OwnerID,
-			Name:        event.Name,
-			Location:    event.Location,
-			Description: event.Description,
-			Seats:       event.Seats,
+			ID:                     event.ID,
+			OwnerID:                event.OwnerID,
+			Name:                   event.Name,
+			Location:               event.Location,
+			Description:            event.Description,
+			Seats:                  event.Seats,
+			Address:                event.Address,
+			City:                   event.City,
+			Country:                event.Country,
+			Latitude:               event.Latitude,
+			Longitude:              event.Longitude,
+			StartsAt:               event.StartsAt,
+			EndsAt:                 event.EndsAt,
+			State:                  event.GetState(),
+			CancelledAt:            event.CancelledAt,
+			Sequence:               event.Sequence,
+			TransfersBlocked:       event.TransfersBlocked,
+			Price:                  event.Price,
+			ResaleAllowed:          event.ResaleAllowed,
+			ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+			RefundWindowHours:      event.RefundWindowHours,
+			MaxTicketsPerUser:      event.MaxTicketsPerUser,
+			Categories:             toHttpCategoryRefs(event.Categories),
+			Tags:                   event.Tags,
 			Links: map[string]hateoas.Link{
 				"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 				"parent": hateoas.BuildParentLink(serviceURLs.EventManager, "/events"),
@@ -200,7 +302,7 @@ func ToHttpResponseEventListCustom(events []*domain.Event, selfPath string, serv
 	}
 }
 
-func ToHttpResponseEventListWithPagination(events []*domain.Event, filter *domain.EventFilter, totalCount int, serviceURLs *config.ServiceURLs) *HttpResponseEventList {
+func ToHttpResponseEventListWithPagination(events []*domain.Event, filter *domain.EventFilter, pageInfo *domain.PageInfo, totalCount int, facets *domain.EventFacets, serviceURLs *config.ServiceURLs) *HttpResponseEventList {
 	if events == nil {
 		events = []*domain.Event{}
 	}
@@ -210,12 +312,32 @@ func ToHttpResponseEventListWithPagination(events []*domain.Event, filter *domai
 	for _, event := range events {
 		resourcePath := fmt.Sprintf("/events/%d", event.ID)
 		httpEvents = append(httpEvents, &httpResponseEvent{
-			ID:          event.ID,
-			OwnerID:     event.OwnerID,
-			Name:        event.Name,
-			Location:    event.Location,
-			Description: event.Description,
-			Seats:       event.Seats,
+			ID:                     event.ID,
+			OwnerID:                event.OwnerID,
+			Name:                   event.Name,
+			Location:               event.Location,
+			Description:            event.Description,
+			Seats:                  event.Seats,
+			Address:                event.Address,
+			City:                   event.City,
+			Country:                event.Country,
+			Latitude:               event.Latitude,
+			Longitude:              event.Longitude,
+			StartsAt:               event.StartsAt,
+			EndsAt:                 event.EndsAt,
+			State:                  event.GetState(),
+			CancelledAt:            event.CancelledAt,
+			Sequence:               event.Sequence,
+			TransfersBlocked:       event.TransfersBlocked,
+			Price:                  event.Price,
+			ResaleAllowed:          event.ResaleAllowed,
+			ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+			RefundWindowHours:      event.RefundWindowHours,
+			MaxTicketsPerUser:      event.MaxTicketsPerUser,
+			Categories:             toHttpCategoryRefs(event.Categories),
+			Tags:                   event.Tags,
+			DistanceKm:             event.DistanceKm,
+			Search:                 toHttpSearchMatch(event.Match),
 			Links: map[string]hateoas.Link{
 				"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 				"parent": hateoas.BuildParentLink(serviceURLs.EventManager, "/events"),
@@ -249,65 +371,92 @@ func ToHttpResponseEventListWithPagination(events []*domain.Event, filter *domai
 		totalPages = 1
 	}
 
-	selfQuery := buildEventFilterQuery(filter, currentPage)
-	links["self"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", selfQuery, "self", "Current page")
-
-	firstQuery := buildEventFilterQuery(filter, 1)
-	links["first"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", firstQuery, "first", "First page")
-
-	if currentPage > 1 {
-		prevQuery := buildEventFilterQuery(filter, currentPage-1)
-		links["prev"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", prevQuery, "prev", "Previous page")
-	}
-
-	if currentPage < totalPages {
-		nextQuery := buildEventFilterQuery(filter, currentPage+1)
-		links["next"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", nextQuery, "next", "Next page")
-	}
-
-	if totalPages > 1 {
-		lastQuery := buildEventFilterQuery(filter, totalPages)
-		links["last"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/events", lastQuery, "last", "Last page")
+	var cursor *string
+	if filter != nil {
+		cursor = filter.Cursor
 	}
+	buildCursorLinks(links, serviceURLs.EventManager, "/events", buildEventFilterQuery(filter), currentPage, cursor, pageInfo)
 
 	metadata := &PaginationMetadata{
-		Page:       currentPage,
 		PerPage:    perPage,
 		TotalItems: totalCount,
 		TotalPages: totalPages,
 	}
+	if cursor == nil {
+		metadata.Page = currentPage
+	}
 
 	return &HttpResponseEventList{
 		Events:   httpEvents,
 		Links:    links,
 		Metadata: metadata,
+		Facets:   ToHttpEventFacets(facets, filter, serviceURLs),
 	}
 }
 
 type HttpCreateEvent struct {
-	OwnerID     int     `json:"id_owner" binding:"required,min=1"`
-	Name        string  `json:"name" binding:"required,min=1,max=255"`
-	Location    *string `json:"location" binding:"omitempty,max=500"`
-	Description *string `json:"description" binding:"omitempty,max=1000"`
-	Seats       *int    `json:"seats" binding:"omitempty,min=1"`
+	OwnerID                int        `json:"id_owner" binding:"required,min=1"`
+	Name                   string     `json:"name" binding:"required,min=1,max=255"`
+	Location               *string    `json:"location" binding:"omitempty,max=500"`
+	Description            *string    `json:"description" binding:"omitempty,max=1000"`
+	Seats                  *int       `json:"seats" binding:"omitempty,min=1"`
+	Address                *string    `json:"address" binding:"omitempty,max=500"`
+	City                   *string    `json:"city" binding:"omitempty,max=255"`
+	Country                *string    `json:"country" binding:"omitempty,max=255"`
+	Latitude               *float64   `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude              *float64   `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	StartsAt               *time.Time `json:"starts_at" binding:"omitempty"`
+	EndsAt                 *time.Time `json:"ends_at" binding:"omitempty"`
+	TransfersBlocked       bool       `json:"transfers_blocked"`
+	Price                  *int       `json:"price" binding:"omitempty,min=0"`
+	ResaleAllowed          bool       `json:"resale_allowed"`
+	ResaleMaxMarkupPercent *int       `json:"resale_max_markup_percent" binding:"omitempty,min=0"`
+	RefundWindowHours      *int       `json:"refund_window_hours" binding:"omitempty,min=0"`
+	MaxTicketsPerUser      *int       `json:"max_tickets_per_user" binding:"omitempty,min=1"`
 }
 
 func (event *HttpCreateEvent) ToEvent() *domain.Event {
 	return &domain.Event{
-		OwnerID:     event.OwnerID,
-		Name:        event.Name,
-		Location:    event.Location,
-		Description: event.Description,
-		Seats:       event.Seats,
+		OwnerID:                event.OwnerID,
+		Name:                   event.Name,
+		Location:               event.Location,
+		Description:            event.Description,
+		Seats:                  event.Seats,
+		Address:                event.Address,
+		City:                   event.City,
+		Country:                event.Country,
+		Latitude:               event.Latitude,
+		Longitude:              event.Longitude,
+		StartsAt:               event.StartsAt,
+		EndsAt:                 event.EndsAt,
+		TransfersBlocked:       event.TransfersBlocked,
+		Price:                  event.Price,
+		ResaleAllowed:          event.ResaleAllowed,
+		ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+		RefundWindowHours:      event.RefundWindowHours,
+		MaxTicketsPerUser:      event.MaxTicketsPerUser,
 	}
 }
 
 type HttpUpdateEvent struct {
-	OwnerID     *int    `json:"id_owner" binding:"omitempty,min=1"`
-	Name        *string `json:"name" binding:"omitempty,min=1,max=255"`
-	Location    *string `json:"location" binding:"omitempty,max=500"`
-	Description *string `json:"description" binding:"omitempty,max=1000"`
-	Seats       *int    `json:"seats" binding:"omitempty,min=1"`
+	OwnerID                *int       `json:"id_owner" binding:"omitempty,min=1"`
+	Name                   *string    `json:"name" binding:"omitempty,min=1,max=255"`
+	Location               *string    `json:"location" binding:"omitempty,max=500"`
+	Description            *string    `json:"description" binding:"omitempty,max=1000"`
+	Seats                  *int       `json:"seats" binding:"omitempty,min=1"`
+	Address                *string    `json:"address" binding:"omitempty,max=500"`
+	City                   *string    `json:"city" binding:"omitempty,max=255"`
+	Country                *string    `json:"country" binding:"omitempty,max=255"`
+	Latitude               *float64   `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude              *float64   `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	StartsAt               *time.Time `json:"starts_at" binding:"omitempty"`
+	EndsAt                 *time.Time `json:"ends_at" binding:"omitempty"`
+	TransfersBlocked       *bool      `json:"transfers_blocked"`
+	Price                  *int       `json:"price" binding:"omitempty,min=0"`
+	ResaleAllowed          *bool      `json:"resale_allowed"`
+	ResaleMaxMarkupPercent *int       `json:"resale_max_markup_percent" binding:"omitempty,min=0"`
+	RefundWindowHours      *int       `json:"refund_window_hours" binding:"omitempty,min=0"`
+	MaxTicketsPerUser      *int       `json:"max_tickets_per_user" binding:"omitempty,min=1"`
 }
 
 func (event *HttpUpdateEvent) ToUpdateMap() map[string]interface{} {
@@ -328,25 +477,78 @@ func (event *HttpUpdateEvent) ToUpdateMap() map[string]interface{} {
 	if event.Seats != nil {
 		updates["seats"] = *event.Seats
 	}
+	if event.Address != nil {
+		updates["address"] = *event.Address
+	}
+	if event.City != nil {
+		updates["city"] = *event.City
+	}
+	if event.Country != nil {
+		updates["country"] = *event.Country
+	}
+	if event.Latitude != nil {
+		updates["latitude"] = *event.Latitude
+	}
+	if event.Longitude != nil {
+		updates["longitude"] = *event.Longitude
+	}
+	if event.StartsAt != nil {
+		updates["starts_at"] = event.StartsAt.UTC()
+	}
+	if event.EndsAt != nil {
+		updates["ends_at"] = event.EndsAt.UTC()
+	}
+	if event.TransfersBlocked != nil {
+		updates["transfers_blocked"] = *event.TransfersBlocked
+	}
+	if event.Price != nil {
+		updates["price"] = *event.Price
+	}
+	if event.ResaleAllowed != nil {
+		updates["resale_allowed"] = *event.ResaleAllowed
+	}
+	if event.ResaleMaxMarkupPercent != nil {
+		updates["resale_max_markup_percent"] = *event.ResaleMaxMarkupPercent
+	}
+	if event.RefundWindowHours != nil {
+		updates["refund_window_hours"] = *event.RefundWindowHours
+	}
+	if event.MaxTicketsPerUser != nil {
+		updates["max_tickets_per_user"] = *event.MaxTicketsPerUser
+	}
 
 	return updates
 }
 
 type HttpFilterEvent struct {
+	Query       *string `json:"q,omitempty"           form:"q"`
 	Name        *string `json:"name,omitempty"        form:"name"`
 	Location    *string `json:"location,omitempty"    form:"location"`
 	Description *string `json:"description,omitempty" form:"description"`
 	MinSeats    *int    `json:"min_seats,omitempty"   form:"min_seats"`
 	MaxSeats    *int    `json:"max_seats,omitempty"   form:"max_seats"`
 
+	Near     *string  `json:"near,omitempty"      form:"near"`
+	RadiusKm *float64 `json:"radius_km,omitempty" form:"radius_km"`
+
+	Category *string `json:"category,omitempty" form:"category"`
+	Tags     *string `json:"tags,omitempty"     form:"tags"`
+
 	Page    *int `json:"page,omitempty"        form:"page"`
 	PerPage *int `json:"per_page,omitempty"    form:"per_page"`
 
 	OrderBy *string `json:"order_by,omitempty"    form:"order_by"`
+	Cursor  *string `json:"cursor,omitempty"      form:"cursor"`
 }
 
 func (filter *HttpFilterEvent) ToEventFilter() *domain.EventFilter {
+	var tags []string
+	if filter.Tags != nil {
+		tags = strings.Split(*filter.Tags, ",")
+	}
+
 	return &domain.EventFilter{
+		Query:       filter.Query,
 		Name:        filter.Name,
 		Location:    filter.Location,
 		Description: filter.Description,
@@ -354,5 +556,11 @@ func (filter *HttpFilterEvent) ToEventFilter() *domain.EventFilter {
 		PerPage:     filter.PerPage,
 		MinSeats:    filter.MinSeats,
 		MaxSeats:    filter.MaxSeats,
+		OrderBy:     filter.OrderBy,
+		Cursor:      filter.Cursor,
+		Near:        filter.Near,
+		RadiusKm:    filter.RadiusKm,
+		Category:    filter.Category,
+		Tags:        tags,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 type HttpResponseEventPacket struct {
@@ -14,25 +15,56 @@ type HttpResponseEventPacket struct {
 }
 
 type httpResponseEventPacket struct {
-	ID             int                     `json:"id"`
-	OwnerID        int                     `json:"id_owner"`
-	Name           string                  `json:"name"`
-	Location       *string                 `json:"location"`
-	Description    *string                 `json:"description"`
-	AllocatedSeats *int                    `json:"allocated_seats"`
-	Links          map[string]hateoas.Link `json:"_links"`
+	ID                     int                     `json:"id"`
+	OwnerID                int                     `json:"id_owner"`
+	Name                   string                  `json:"name"`
+	Location               *string                 `json:"location"`
+	Description            *string                 `json:"description"`
+	AllocatedSeats         *int                    `json:"allocated_seats"`
+	Address                *string                 `json:"address,omitempty"`
+	City                   *string                 `json:"city,omitempty"`
+	Country                *string                 `json:"country,omitempty"`
+	Latitude               *float64                `json:"latitude,omitempty"`
+	Longitude              *float64                `json:"longitude,omitempty"`
+	StartsAt               *time.Time              `json:"starts_at,omitempty"`
+	EndsAt                 *time.Time              `json:"ends_at,omitempty"`
+	TransfersBlocked       bool                    `json:"transfers_blocked"`
+	Price                  *int                    `json:"price,omitempty"`
+	ResaleAllowed          bool                    `json:"resale_allowed"`
+	ResaleMaxMarkupPercent *int                    `json:"resale_max_markup_percent,omitempty"`
+	RefundWindowHours      *int                    `json:"refund_window_hours,omitempty"`
+	MaxTicketsPerUser      *int                    `json:"max_tickets_per_user,omitempty"`
+	Categories             []*httpCategoryRef      `json:"categories,omitempty"`
+	Tags                   []string                `json:"tags,omitempty"`
+	Search                 *httpSearchMatch        `json:"search,omitempty"`
+	Links                  map[string]hateoas.Link `json:"_links"`
 }
 
 func ToHttpResponseEventPacket(event *domain.EventPacket, serviceURLs *config.ServiceURLs) *HttpResponseEventPacket {
 	resourcePath := fmt.Sprintf("/packets/%d", event.ID)
 
 	dto := &httpResponseEventPacket{
-		ID:             event.ID,
-		OwnerID:        event.OwnerID,
-		Name:           event.Name,
-		Location:       event.Location,
-		Description:    event.Description,
-		AllocatedSeats: event.AllocatedSeats,
+		ID:                     event.ID,
+		OwnerID:                event.OwnerID,
+		Name:                   event.Name,
+		Location:               event.Location,
+		Description:            event.Description,
+		AllocatedSeats:         event.AllocatedSeats,
+		Address:                event.Address,
+		City:                   event.City,
+		Country:                event.Country,
+		Latitude:               event.Latitude,
+		Longitude:              event.Longitude,
+		StartsAt:               event.StartsAt,
+		EndsAt:                 event.EndsAt,
+		TransfersBlocked:       event.TransfersBlocked,
+		Price:                  event.Price,
+		ResaleAllowed:          event.ResaleAllowed,
+		ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+		RefundWindowHours:      event.RefundWindowHours,
+		MaxTicketsPerUser:      event.MaxTicketsPerUser,
+		Categories:             toHttpCategoryRefs(event.Categories),
+		Tags:                   event.Tags,
 		Links: map[string]hateoas.Link{
 			"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 			"update": hateoas.BuildUpdateLink(serviceURLs.EventManager, resourcePath),
@@ -78,12 +110,27 @@ func ToHttpResponseEventPacketList(packets []*domain.EventPacket, selfPath strin
 	for _, packet := range packets {
 		resourcePath := fmt.Sprintf("/packets/%d", packet.ID)
 		httpPackets = append(httpPackets, &httpResponseEventPacket{
-			ID:             packet.ID,
-			OwnerID:        packet.OwnerID,
-			Name:           packet.Name,
-			Location:       packet.Location,
-			Description:    packet.Description,
-			AllocatedSeats: packet.AllocatedSeats,
+			ID:                     packet.ID,
+			OwnerID:                packet.OwnerID,
+			Name:                   packet.Name,
+			Location:               packet.Location,
+			Description:            packet.Description,
+			AllocatedSeats:         packet.AllocatedSeats,
+			Address:                packet.Address,
+			City:                   packet.City,
+			Country:                packet.Country,
+			Latitude:               packet.Latitude,
+			Longitude:              packet.Longitude,
+			StartsAt:               packet.StartsAt,
+			EndsAt:                 packet.EndsAt,
+			TransfersBlocked:       packet.TransfersBlocked,
+			Price:                  packet.Price,
+			ResaleAllowed:          packet.ResaleAllowed,
+			ResaleMaxMarkupPercent: packet.ResaleMaxMarkupPercent,
+			RefundWindowHours:      packet.RefundWindowHours,
+			MaxTicketsPerUser:      packet.MaxTicketsPerUser,
+			Categories:             toHttpCategoryRefs(packet.Categories),
+			Tags:                   packet.Tags,
 			Links: map[string]hateoas.Link{
 				"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 				"update": hateoas.BuildUpdateLink(serviceURLs.EventManager, resourcePath),
@@ -106,13 +153,15 @@ func ToHttpResponseEventPacketList(packets []*domain.EventPacket, selfPath strin
 	}
 }
 
-func buildEventPacketFilterQuery(filter *domain.EventPacketFilter, page int) string {
+func buildEventPacketFilterQuery(filter *domain.EventPacketFilter) url.Values {
+	params := url.Values{}
 	if filter == nil {
-		return ""
+		return params
 	}
 
-	params := url.Values{}
-
+	if filter.Query != nil {
+		params.Add("q", *filter.Query)
+	}
 	if filter.Name != nil {
 		params.Add("name", *filter.Name)
 	}
@@ -136,12 +185,10 @@ func buildEventPacketFilterQuery(filter *domain.EventPacketFilter, page int) str
 		params.Add("per_page", strconv.Itoa(*filter.PerPage))
 	}
 
-	params.Add("page", strconv.Itoa(page))
-
-	return params.Encode()
+	return params
 }
 
-func ToHttpResponseEventPacketListWithPagination(packets []*domain.EventPacket, filter *domain.EventPacketFilter, totalCount int, serviceURLs *config.ServiceURLs) *HttpResponseEventPacketList {
+func ToHttpResponseEventPacketListWithPagination(packets []*domain.EventPacket, filter *domain.EventPacketFilter, pageInfo *domain.PageInfo, totalCount int, serviceURLs *config.ServiceURLs) *HttpResponseEventPacketList {
 	if packets == nil {
 		packets = []*domain.EventPacket{}
 	}
@@ -151,12 +198,28 @@ func ToHttpResponseEventPacketListWithPagination(packets []*domain.EventPacket,
 	for _, packet := range packets {
 		resourcePath := fmt.Sprintf("/packets/%d", packet.ID)
 		httpPackets = append(httpPackets, &httpResponseEventPacket{
-			ID:             packet.ID,
-			OwnerID:        packet.OwnerID,
-			Name:           packet.Name,
-			Location:       packet.Location,
-			Description:    packet.Description,
-			AllocatedSeats: packet.AllocatedSeats,
+			ID:                     packet.ID,
+			OwnerID:                packet.OwnerID,
+			Name:                   packet.Name,
+			Location:               packet.Location,
+			Description:            packet.Description,
+			AllocatedSeats:         packet.AllocatedSeats,
+			Address:                packet.Address,
+			City:                   packet.City,
+			Country:                packet.Country,
+			Latitude:               packet.Latitude,
+			Longitude:              packet.Longitude,
+			StartsAt:               packet.StartsAt,
+			EndsAt:                 packet.EndsAt,
+			TransfersBlocked:       packet.TransfersBlocked,
+			Price:                  packet.Price,
+			ResaleAllowed:          packet.ResaleAllowed,
+			ResaleMaxMarkupPercent: packet.ResaleMaxMarkupPercent,
+			RefundWindowHours:      packet.RefundWindowHours,
+			MaxTicketsPerUser:      packet.MaxTicketsPerUser,
+			Categories:             toHttpCategoryRefs(packet.Categories),
+			Tags:                   packet.Tags,
+			Search:                 toHttpSearchMatch(packet.Match),
 			Links: map[string]hateoas.Link{
 				"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
 				"parent": hateoas.BuildParentLink(serviceURLs.EventManager, "/event-packets"),
@@ -190,33 +253,20 @@ func ToHttpResponseEventPacketListWithPagination(packets []*domain.EventPacket,
 		totalPages = 1
 	}
 
-	selfQuery := buildEventPacketFilterQuery(filter, currentPage)
-	links["self"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/event-packets", selfQuery, "self", "Current page")
-
-	firstQuery := buildEventPacketFilterQuery(filter, 1)
-	links["first"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/event-packets", firstQuery, "first", "First page")
-
-	if currentPage > 1 {
-		prevQuery := buildEventPacketFilterQuery(filter, currentPage-1)
-		links["prev"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/event-packets", prevQuery, "prev", "Previous page")
-	}
-
-	if currentPage < totalPages {
-		nextQuery := buildEventPacketFilterQuery(filter, currentPage+1)
-		links["next"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/event-packets", nextQuery, "next", "Next page")
-	}
-
-	if totalPages > 1 {
-		lastQuery := buildEventPacketFilterQuery(filter, totalPages)
-		links["last"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, "/event-packets", lastQuery, "last", "Last page")
+	var cursor *string
+	if filter != nil {
+		cursor = filter.Cursor
 	}
+	buildCursorLinks(links, serviceURLs.EventManager, "/event-packets", buildEventPacketFilterQuery(filter), currentPage, cursor, pageInfo)
 
 	metadata := &PaginationMetadata{
-		Page:       currentPage,
 		PerPage:    perPage,
 		TotalItems: totalCount,
 		TotalPages: totalPages,
 	}
+	if cursor == nil {
+		metadata.Page = currentPage
+	}
 
 	return &HttpResponseEventPacketList{
 		EventPackets: httpPackets,
@@ -226,30 +276,54 @@ func ToHttpResponseEventPacketListWithPagination(packets []*domain.EventPacket,
 }
 
 type HttpCreateEventPacket struct {
-	OwnerID        int     `json:"id_owner" binding:"required,min=1"`
-	Name           string  `json:"name" binding:"required,min=1,max=255"`
-	Location       *string `json:"location" binding:"omitempty,max=500"`
-	Description    *string `json:"description" binding:"omitempty,max=1000"`
-	AllocatedSeats *int    `json:"allocated_seats" binding:"omitempty,min=1"`
+	OwnerID           int      `json:"id_owner" binding:"required,min=1"`
+	Name              string   `json:"name" binding:"required,min=1,max=255"`
+	Location          *string  `json:"location" binding:"omitempty,max=500"`
+	Description       *string  `json:"description" binding:"omitempty,max=1000"`
+	AllocatedSeats    *int     `json:"allocated_seats" binding:"omitempty,min=1"`
+	Address           *string  `json:"address" binding:"omitempty,max=500"`
+	City              *string  `json:"city" binding:"omitempty,max=255"`
+	Country           *string  `json:"country" binding:"omitempty,max=255"`
+	Latitude          *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Price             *int     `json:"price" binding:"omitempty,min=0"`
+	RefundWindowHours *int     `json:"refund_window_hours" binding:"omitempty,min=0"`
+	MaxTicketsPerUser *int     `json:"max_tickets_per_user" binding:"omitempty,min=1"`
 }
 
 func (event *HttpCreateEventPacket) ToEventPacket() *domain.EventPacket {
 
 	return &domain.EventPacket{
-		OwnerID:        event.OwnerID,
-		Name:           event.Name,
-		Location:       event.Location,
-		Description:    event.Description,
-		AllocatedSeats: event.AllocatedSeats,
+		OwnerID:           event.OwnerID,
+		Name:              event.Name,
+		Location:          event.Location,
+		Description:       event.Description,
+		AllocatedSeats:    event.AllocatedSeats,
+		Address:           event.Address,
+		City:              event.City,
+		Country:           event.Country,
+		Latitude:          event.Latitude,
+		Longitude:         event.Longitude,
+		Price:             event.Price,
+		RefundWindowHours: event.RefundWindowHours,
+		MaxTicketsPerUser: event.MaxTicketsPerUser,
 	}
 }
 
 type HttpUpdateEventPacket struct {
-	OwnerID        *int    `json:"id_owner" binding:"omitempty,min=1"`
-	Name           *string `json:"name" binding:"omitempty,min=1,max=255"`
-	Location       *string `json:"location" binding:"omitempty,max=500"`
-	Description    *string `json:"description" binding:"omitempty,max=1000"`
-	AllocatedSeats *int    `json:"allocated_seats" binding:"omitempty,min=1"`
+	OwnerID           *int     `json:"id_owner" binding:"omitempty,min=1"`
+	Name              *string  `json:"name" binding:"omitempty,min=1,max=255"`
+	Location          *string  `json:"location" binding:"omitempty,max=500"`
+	Description       *string  `json:"description" binding:"omitempty,max=1000"`
+	AllocatedSeats    *int     `json:"allocated_seats" binding:"omitempty,min=1"`
+	Address           *string  `json:"address" binding:"omitempty,max=500"`
+	City              *string  `json:"city" binding:"omitempty,max=255"`
+	Country           *string  `json:"country" binding:"omitempty,max=255"`
+	Latitude          *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude         *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Price             *int     `json:"price" binding:"omitempty,min=0"`
+	RefundWindowHours *int     `json:"refund_window_hours" binding:"omitempty,min=0"`
+	MaxTicketsPerUser *int     `json:"max_tickets_per_user" binding:"omitempty,min=1"`
 }
 
 func (event *HttpUpdateEventPacket) ToUpdateMap() map[string]interface{} {
@@ -270,11 +344,36 @@ func (event *HttpUpdateEventPacket) ToUpdateMap() map[string]interface{} {
 	if event.AllocatedSeats != nil {
 		updates["allocated_seats"] = *event.AllocatedSeats
 	}
+	if event.Address != nil {
+		updates["address"] = *event.Address
+	}
+	if event.City != nil {
+		updates["city"] = *event.City
+	}
+	if event.Country != nil {
+		updates["country"] = *event.Country
+	}
+	if event.Latitude != nil {
+		updates["latitude"] = *event.Latitude
+	}
+	if event.Longitude != nil {
+		updates["longitude"] = *event.Longitude
+	}
+	if event.Price != nil {
+		updates["price"] = *event.Price
+	}
+	if event.RefundWindowHours != nil {
+		updates["refund_window_hours"] = *event.RefundWindowHours
+	}
+	if event.MaxTicketsPerUser != nil {
+		updates["max_tickets_per_user"] = *event.MaxTicketsPerUser
+	}
 
 	return updates
 }
 
 type HttpFilterEventPacket struct {
+	Query       *string `json:"q,omitempty"           form:"q"`
 	Name        *string `json:"name,omitempty"        form:"name"`
 	Location    *string `json:"location,omitempty"    form:"location"`
 	Description *string `json:"description,omitempty" form:"description"`
@@ -285,10 +384,12 @@ type HttpFilterEventPacket struct {
 	PerPage *int `json:"per_page,omitempty"    form:"per_page"`
 
 	OrderBy *string `json:"order_by,omitempty"    form:"order_by"`
+	Cursor  *string `json:"cursor,omitempty"      form:"cursor"`
 }
 
 func (filter *HttpFilterEventPacket) ToEventPacketFilter() *domain.EventPacketFilter {
 	return &domain.EventPacketFilter{
+		Query:       filter.Query,
 		Name:        filter.Name,
 		Location:    filter.Location,
 		Description: filter.Description,
@@ -297,5 +398,6 @@ func (filter *HttpFilterEventPacket) ToEventPacketFilter() *domain.EventPacketFi
 		MinSeats:    filter.MinSeats,
 		MaxSeats:    filter.MaxSeats,
 		OrderBy:     filter.OrderBy,
+		Cursor:      filter.Cursor,
 	}
 }
@@ -0,0 +1,240 @@
+package httpdto
+
+import (
+	"errors"
+	"eventManager/application/domain"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/hateoas"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+type HttpImportQuery struct {
+	DryRun *bool `form:"dry_run"`
+}
+
+// HttpImportRow is one row of a bulk import, read from a JSON line or from a
+// CSV record under a header of the same names. The fields are those of
+// creating an event or a packet; ref names the row so packets can list the
+// events of the import they include in events.
+type HttpImportRow struct {
+	Type                   string     `json:"type" binding:"required,oneof=event packet"`
+	Ref                    *string    `json:"ref" binding:"omitempty,min=1,max=100"`
+	Name                   string     `json:"name" binding:"required,min=1,max=255"`
+	Location               *string    `json:"location" binding:"omitempty,max=500"`
+	Description            *string    `json:"description" binding:"omitempty,max=1000"`
+	Seats                  *int       `json:"seats" binding:"omitempty,min=1"`
+	AllocatedSeats         *int       `json:"allocated_seats" binding:"omitempty,min=1"`
+	Address                *string    `json:"address" binding:"omitempty,max=500"`
+	City                   *string    `json:"city" binding:"omitempty,max=255"`
+	Country                *string    `json:"country" binding:"omitempty,max=255"`
+	Latitude               *float64   `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude              *float64   `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	StartsAt               *time.Time `json:"starts_at" binding:"omitempty"`
+	EndsAt                 *time.Time `json:"ends_at" binding:"omitempty"`
+	TransfersBlocked       bool       `json:"transfers_blocked"`
+	Price                  *int       `json:"price" binding:"omitempty,min=0"`
+	ResaleAllowed          bool       `json:"resale_allowed"`
+	ResaleMaxMarkupPercent *int       `json:"resale_max_markup_percent" binding:"omitempty,min=0"`
+	RefundWindowHours      *int       `json:"refund_window_hours" binding:"omitempty,min=0"`
+	MaxTicketsPerUser      *int       `json:"max_tickets_per_user" binding:"omitempty,min=1"`
+	Events                 []string   `json:"events" binding:"omitempty,dive,min=1,max=100"`
+}
+
+// ToImportRow checks the row with the same binding rules as creating an
+// event or packet does. A row breaking them carries only its problems.
+func (row *HttpImportRow) ToImportRow(line int) *domain.ImportRow {
+	importRow := &domain.ImportRow{Line: line}
+	if row.Ref != nil {
+		importRow.Ref = *row.Ref
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := v.Struct(row); err != nil {
+			var validationErrs validator.ValidationErrors
+			if errors.As(err, &validationErrs) {
+				for _, fieldErr := range validationErrs {
+					importRow.Reject(importFieldName(fieldErr), fmt.Sprintf("validation failed on '%s' tag", fieldErr.Tag()))
+				}
+			} else {
+				importRow.Reject("", err.Error())
+			}
+			return importRow
+		}
+	}
+
+	if row.Type == domain.ImportKindEvent {
+		if row.AllocatedSeats != nil {
+			importRow.Reject("allocated_seats", "only packets have allocated_seats")
+		}
+		if len(row.Events) > 0 {
+			importRow.Reject("events", "only packets include events")
+		}
+		if len(importRow.Problems) > 0 {
+			return importRow
+		}
+
+		importRow.Event = &domain.Event{
+			Name:                   row.Name,
+			Location:               row.Location,
+			Description:            row.Description,
+			Seats:                  row.Seats,
+			Address:                row.Address,
+			City:                   row.City,
+			Country:                row.Country,
+			Latitude:               row.Latitude,
+			Longitude:              row.Longitude,
+			StartsAt:               row.StartsAt,
+			EndsAt:                 row.EndsAt,
+			TransfersBlocked:       row.TransfersBlocked,
+			Price:                  row.Price,
+			ResaleAllowed:          row.ResaleAllowed,
+			ResaleMaxMarkupPercent: row.ResaleMaxMarkupPercent,
+			RefundWindowHours:      row.RefundWindowHours,
+			MaxTicketsPerUser:      row.MaxTicketsPerUser,
+		}
+		return importRow
+	}
+
+	// a packet takes its schedule, transfer and resale rules from its events
+	eventOnly := []struct {
+		field string
+		set   bool
+	}{
+		{"seats", row.Seats != nil},
+		{"starts_at", row.StartsAt != nil},
+		{"ends_at", row.EndsAt != nil},
+		{"transfers_blocked", row.TransfersBlocked},
+		{"resale_allowed", row.ResaleAllowed},
+		{"resale_max_markup_percent", row.ResaleMaxMarkupPercent != nil},
+	}
+	for _, field := range eventOnly {
+		if field.set {
+			importRow.Reject(field.field, fmt.Sprintf("only events have %s; packets take it from their events", field.field))
+		}
+	}
+	if len(importRow.Problems) > 0 {
+		return importRow
+	}
+
+	importRow.Packet = &domain.EventPacket{
+		Name:              row.Name,
+		Location:          row.Location,
+		Description:       row.Description,
+		AllocatedSeats:    row.AllocatedSeats,
+		Address:           row.Address,
+		City:              row.City,
+		Country:           row.Country,
+		Latitude:          row.Latitude,
+		Longitude:         row.Longitude,
+		Price:             row.Price,
+		RefundWindowHours: row.RefundWindowHours,
+		MaxTicketsPerUser: row.MaxTicketsPerUser,
+	}
+	importRow.EventRefs = row.Events
+	return importRow
+}
+
+// importFieldName names a failing field as it is spelled in the import,
+// such as max_tickets_per_user or events[2].
+func importFieldName(fieldErr validator.FieldError) string {
+	name := fieldErr.StructField()
+	index := ""
+	if i := strings.Index(name, "["); i >= 0 {
+		name, index = name[:i], name[i:]
+	}
+	if field, ok := reflect.TypeOf(HttpImportRow{}).FieldByName(name); ok {
+		name = strings.Split(field.Tag.Get("json"), ",")[0]
+	}
+	return name + index
+}
+
+type httpImportError struct {
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+type httpImportRowResult struct {
+	Line   int                     `json:"line"`
+	Type   string                  `json:"type,omitempty"`
+	Ref    string                  `json:"ref,omitempty"`
+	Name   string                  `json:"name,omitempty"`
+	ID     *int                    `json:"id,omitempty"`
+	Events []string                `json:"events,omitempty"`
+	Errors []httpImportError       `json:"errors,omitempty"`
+	Links  map[string]hateoas.Link `json:"_links,omitempty"`
+}
+
+// httpResponseImport reports every row; created is only true when the rows
+// were written, which happens for all of them or none.
+type httpResponseImport struct {
+	DryRun     bool                    `json:"dry_run"`
+	Valid      bool                    `json:"valid"`
+	Created    bool                    `json:"created"`
+	Events     int                     `json:"events"`
+	Packets    int                     `json:"packets"`
+	Inclusions int                     `json:"inclusions"`
+	ErrorCount int                     `json:"error_count"`
+	Rows       []*httpImportRowResult  `json:"rows"`
+	Links      map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpResponseImport struct {
+	Import *httpResponseImport `json:"import"`
+}
+
+func ToHttpResponseImport(result *domain.ImportResult, serviceURLs *config.ServiceURLs) *HttpResponseImport {
+	events, packets, inclusions := result.Counts()
+	response := &httpResponseImport{
+		DryRun:     result.DryRun,
+		Valid:      result.Valid(),
+		Created:    result.Created,
+		Events:     events,
+		Packets:    packets,
+		Inclusions: inclusions,
+		Rows:       make([]*httpImportRowResult, 0, len(result.Rows)),
+		Links: map[string]hateoas.Link{
+			"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, "/events/import"),
+			"events": hateoas.BuildParentLink(serviceURLs.EventManager, "/events"),
+		},
+	}
+
+	for _, row := range result.Rows {
+		rowResult := &httpImportRowResult{
+			Line:   row.Line,
+			Type:   row.Kind(),
+			Ref:    row.Ref,
+			Events: row.EventRefs,
+		}
+		switch {
+		case row.Event != nil:
+			rowResult.Name = row.Event.Name
+			if result.Created {
+				rowResult.ID = &row.Event.ID
+				rowResult.Links = map[string]hateoas.Link{
+					"self": hateoas.BuildSelfLink(serviceURLs.EventManager, fmt.Sprintf("/events/%d", row.Event.ID)),
+				}
+			}
+		case row.Packet != nil:
+			rowResult.Name = row.Packet.Name
+			if result.Created {
+				rowResult.ID = &row.Packet.ID
+				rowResult.Links = map[string]hateoas.Link{
+					"self": hateoas.BuildSelfLink(serviceURLs.EventManager, fmt.Sprintf("/event-packets/%d", row.Packet.ID)),
+				}
+			}
+		}
+		for _, problem := range row.Problems {
+			rowResult.Errors = append(rowResult.Errors, httpImportError{Field: problem.Field, Reason: problem.Reason})
+		}
+		response.ErrorCount += len(row.Problems)
+		response.Rows = append(response.Rows, rowResult)
+	}
+
+	return &HttpResponseImport{Import: response}
+}
@@ -0,0 +1,213 @@
+package httpdto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"eventManager/application/domain"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ContentTypeCSV       = "text/csv"
+	ContentTypeJSONLines = "application/x-ndjson"
+
+	// importListSeparator separates the refs of the events column in CSV
+	importListSeparator = ";"
+	maxImportLineBytes  = 64 * 1024
+)
+
+// importColumns maps the JSON names of HttpImportRow, which are also the
+// CSV column names, to the index of their field.
+var importColumns = func() map[string]int {
+	columns := make(map[string]int)
+	rowType := reflect.TypeOf(HttpImportRow{})
+	for i := 0; i < rowType.NumField(); i++ {
+		columns[strings.Split(rowType.Field(i).Tag.Get("json"), ",")[0]] = i
+	}
+	return columns
+}()
+
+// ReadImportJSONLines reads one JSON object per line; blank lines are
+// skipped. A line that cannot be decoded becomes a row with the problem.
+func ReadImportJSONLines(r io.Reader) ([]*domain.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxImportLineBytes)
+
+	var rows []*domain.ImportRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var row HttpImportRow
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row); err != nil {
+			rows = append(rows, &domain.ImportRow{Line: line, Problems: []*domain.ValidationError{jsonLineProblem(err)}})
+			continue
+		}
+		if decoder.More() {
+			rows = append(rows, &domain.ImportRow{Line: line, Problems: []*domain.ValidationError{{Reason: "a line must hold a single JSON object"}}})
+			continue
+		}
+		rows = append(rows, row.ToImportRow(line))
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return nil, &domain.InvalidRequestError{Reason: fmt.Sprintf("line %d is longer than %d bytes", line+1, maxImportLineBytes)}
+	} else if scanner.Err() != nil {
+		return nil, &domain.InvalidRequestError{Reason: "failed to read request body"}
+	}
+	return rows, nil
+}
+
+func jsonLineProblem(err error) *domain.ValidationError {
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxError):
+		return &domain.ValidationError{Reason: fmt.Sprintf("malformed JSON at position %d", syntaxError.Offset)}
+	case errors.As(err, &unmarshalTypeError):
+		return &domain.ValidationError{Field: unmarshalTypeError.Field, Reason: fmt.Sprintf("invalid value (expected %s)", unmarshalTypeError.Type)}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &domain.ValidationError{Reason: "malformed JSON"}
+	}
+	return &domain.ValidationError{Reason: strings.TrimPrefix(err.Error(), "json: ")}
+}
+
+// ReadImportCSV reads a header of HttpImportRow field names, in any order,
+// and a row per record. Empty cells are left unset; the events column lists
+// refs separated by semicolons.
+func ReadImportCSV(r io.Reader) ([]*domain.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &domain.InvalidRequestError{Reason: "request body cannot be empty"}
+	} else if err != nil {
+		return nil, csvError(err)
+	}
+
+	fields := make([]int, len(header))
+	names := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		field, ok := importColumns[name]
+		if !ok {
+			return nil, &domain.InvalidRequestError{Reason: fmt.Sprintf("unknown column: %s", name)}
+		}
+		if seen[name] {
+			return nil, &domain.InvalidRequestError{Reason: fmt.Sprintf("column %s appears twice", name)}
+		}
+		seen[name] = true
+		fields[i] = field
+		names[i] = name
+	}
+	for _, required := range []string{"type", "name"} {
+		if !seen[required] {
+			return nil, &domain.InvalidRequestError{Reason: fmt.Sprintf("missing column: %s", required)}
+		}
+	}
+
+	var rows []*domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if errors.Is(err, csv.ErrFieldCount) {
+			rows = append(rows, &domain.ImportRow{Line: line, Problems: []*domain.ValidationError{
+				{Reason: fmt.Sprintf("the record has %d fields, the header %d", len(record), len(header))},
+			}})
+			continue
+		} else if err != nil {
+			return nil, csvError(err)
+		}
+
+		var row HttpImportRow
+		var problems []*domain.ValidationError
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if err := setImportCell(&row, fields[i], value); err != nil {
+				problems = append(problems, &domain.ValidationError{Field: names[i], Reason: err.Error()})
+			}
+		}
+		if len(problems) > 0 {
+			importRow := &domain.ImportRow{Line: line, Problems: problems}
+			if row.Ref != nil {
+				importRow.Ref = *row.Ref
+			}
+			rows = append(rows, importRow)
+			continue
+		}
+		rows = append(rows, row.ToImportRow(line))
+	}
+	return rows, nil
+}
+
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &domain.InvalidRequestError{Reason: fmt.Sprintf("malformed CSV on line %d: %v", parseErr.Line, parseErr.Err)}
+	}
+	return &domain.InvalidRequestError{Reason: "failed to read request body"}
+}
+
+// setImportCell parses value into the field of row by the field's type.
+func setImportCell(row *HttpImportRow, field int, value string) error {
+	switch target := reflect.ValueOf(row).Elem().Field(field).Addr().Interface().(type) {
+	case *string:
+		*target = value
+	case **string:
+		*target = &value
+	case **int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		*target = &number
+	case **float64:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		*target = &number
+	case **time.Time:
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.New("must be an RFC 3339 time")
+		}
+		*target = &at
+	case *bool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*target = flag
+	case *[]string:
+		for _, ref := range strings.Split(value, importListSeparator) {
+			if ref = strings.TrimSpace(ref); ref != "" {
+				*target = append(*target, ref)
+			}
+		}
+	default:
+		return errors.New("cannot be set from CSV")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package httpdto
+
+import (
+	"eventManager/application/domain"
+	"eventManager/infrastructure/http/hateoas"
+	"net/url"
+	"strconv"
+)
+
+type PaginationMetadata struct {
+	Page       int `json:"page,omitempty"`
+	PerPage    int `json:"per_page"`
+	TotalItems int `json:"total_items,omitempty"`
+	TotalPages int `json:"total_pages,omitempty"`
+}
+
+func withCursor(params url.Values, cursor *string) string {
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	if cursor != nil {
+		query.Set("cursor", *cursor)
+	}
+	return query.Encode()
+}
+
+// buildCursorLinks adds the self/first/prev/next links of a keyset paginated
+// listing. params holds the filter without page and cursor.
+func buildCursorLinks(links map[string]hateoas.Link, baseURL string, path string, params url.Values, page int, cursor *string, pageInfo *domain.PageInfo) {
+	selfParams := params
+	if cursor == nil && page > 1 {
+		selfParams = url.Values{}
+		for key, values := range params {
+			selfParams[key] = values
+		}
+		selfParams.Set("page", strconv.Itoa(page))
+	}
+	links["self"] = hateoas.BuildPaginationLink(baseURL, path, withCursor(selfParams, cursor), "self", "Current page")
+	links["first"] = hateoas.BuildPaginationLink(baseURL, path, withCursor(params, nil), "first", "First page")
+
+	if pageInfo == nil {
+		return
+	}
+	if pageInfo.PrevCursor != nil {
+		links["prev"] = hateoas.BuildPaginationLink(baseURL, path, withCursor(params, pageInfo.PrevCursor), "prev", "Previous page")
+	}
+	if pageInfo.NextCursor != nil {
+		links["next"] = hateoas.BuildPaginationLink(baseURL, path, withCursor(params, pageInfo.NextCursor), "next", "Next page")
+	}
+}
@@ -0,0 +1,25 @@
+package httpdto
+
+import (
+	"eventManager/application/domain"
+)
+
+// httpSearchMatch holds the highlights as safe HTML: escaped text with the
+// matched words wrapped in <mark>.
+type httpSearchMatch struct {
+	Rank                 float64 `json:"rank"`
+	NameHighlight        string  `json:"name_highlight"`
+	DescriptionHighlight *string `json:"description_highlight,omitempty"`
+}
+
+func toHttpSearchMatch(match *domain.SearchMatch) *httpSearchMatch {
+	if match == nil {
+		return nil
+	}
+
+	return &httpSearchMatch{
+		Rank:                 match.Rank,
+		NameHighlight:        match.NameHighlight,
+		DescriptionHighlight: match.DescriptionHighlight,
+	}
+}
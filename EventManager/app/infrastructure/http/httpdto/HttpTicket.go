@@ -24,6 +24,19 @@ type HttpUpdateTicket struct {
 	EventID  *int `json:"event_id" binding:"omitempty,min=1"`
 }
 
+// HttpRotateTicket names the code a ticket moves to; the caller generates it
+// so that retries land on the same code.
+type HttpRotateTicket struct {
+	NewCode string `json:"new_code" binding:"required,uuid"`
+}
+
+// HttpResellTicket is a rotation for a ticket sold on the marketplace; price
+// is what the buyer pays, in bani, checked against the owner's resale policy.
+type HttpResellTicket struct {
+	NewCode string `json:"new_code" binding:"required,uuid"`
+	Price   *int   `json:"price" binding:"required,min=0"`
+}
+
 func (dto *HttpCreateTicket) ToTicket() *domain.Ticket {
 	return &domain.Ticket{
 		PacketID: dto.PacketID,
@@ -0,0 +1,255 @@
+package httpdto
+
+import (
+	"encoding/json"
+	"eventManager/application/domain"
+	"eventManager/infrastructure/http/config"
+	"eventManager/infrastructure/http/hateoas"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type httpResponseWebhookSubscription struct {
+	ID         int      `json:"id"`
+	OwnerID    int      `json:"owner_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	EventIDs   []int    `json:"event_ids,omitempty"`
+	PacketIDs  []int    `json:"packet_ids,omitempty"`
+	Active     bool     `json:"active"`
+	// Secret is only returned when the subscription is created.
+	Secret    *string                 `json:"secret,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+	UpdatedAt time.Time               `json:"updated_at"`
+	Links     map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpResponseWebhookSubscription struct {
+	Webhook *httpResponseWebhookSubscription `json:"webhook"`
+}
+
+type HttpResponseWebhookSubscriptionList struct {
+	Webhooks []*httpResponseWebhookSubscription `json:"webhooks"`
+	Links    map[string]hateoas.Link            `json:"_links"`
+}
+
+func toHttpWebhookSubscription(subscription *domain.WebhookSubscription, serviceURLs *config.ServiceURLs) *httpResponseWebhookSubscription {
+	resourcePath := fmt.Sprintf("/webhooks/%d", subscription.ID)
+	deliveriesPath := resourcePath + "/deliveries"
+
+	return &httpResponseWebhookSubscription{
+		ID:         subscription.ID,
+		OwnerID:    subscription.OwnerID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		EventIDs:   subscription.EventIDs,
+		PacketIDs:  subscription.PacketIDs,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+		Links: map[string]hateoas.Link{
+			"self":       hateoas.BuildSelfLink(serviceURLs.EventManager, resourcePath),
+			"parent":     hateoas.BuildParentLink(serviceURLs.EventManager, "/webhooks"),
+			"update":     hateoas.BuildUpdateLink(serviceURLs.EventManager, resourcePath),
+			"delete":     hateoas.BuildDeleteLink(serviceURLs.EventManager, resourcePath),
+			"deliveries": hateoas.BuildPaginationLink(serviceURLs.EventManager, deliveriesPath, "", "deliveries", "Get the delivery log"),
+			"dead_letters": hateoas.BuildPaginationLink(serviceURLs.EventManager, deliveriesPath,
+				"status="+domain.WebhookDeliveryDeadLetter, "dead_letters", "Get deliveries that ran out of retries"),
+		},
+	}
+}
+
+func ToHttpResponseWebhookSubscription(subscription *domain.WebhookSubscription, serviceURLs *config.ServiceURLs) *HttpResponseWebhookSubscription {
+	if subscription == nil {
+		return &HttpResponseWebhookSubscription{}
+	}
+	return &HttpResponseWebhookSubscription{Webhook: toHttpWebhookSubscription(subscription, serviceURLs)}
+}
+
+// ToHttpResponseCreatedWebhookSubscription includes the signing secret, which
+// is not shown again afterwards.
+func ToHttpResponseCreatedWebhookSubscription(subscription *domain.WebhookSubscription, serviceURLs *config.ServiceURLs) *HttpResponseWebhookSubscription {
+	resp := ToHttpResponseWebhookSubscription(subscription, serviceURLs)
+	if resp.Webhook != nil {
+		resp.Webhook.Secret = &subscription.Secret
+	}
+	return resp
+}
+
+func ToHttpResponseWebhookSubscriptionList(subscriptions []*domain.WebhookSubscription, serviceURLs *config.ServiceURLs) *HttpResponseWebhookSubscriptionList {
+	httpSubscriptions := make([]*httpResponseWebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		httpSubscriptions = append(httpSubscriptions, toHttpWebhookSubscription(subscription, serviceURLs))
+	}
+
+	return &HttpResponseWebhookSubscriptionList{
+		Webhooks: httpSubscriptions,
+		Links: map[string]hateoas.Link{
+			"self":   hateoas.BuildSelfLink(serviceURLs.EventManager, "/webhooks"),
+			"create": hateoas.BuildCreateLink(serviceURLs.EventManager, "/webhooks"),
+		},
+	}
+}
+
+type HttpCreateWebhookSubscription struct {
+	URL        string   `json:"url" binding:"required,url,max=2048"`
+	Secret     *string  `json:"secret" binding:"omitempty,min=16,max=256"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,max=10"`
+	EventIDs   []int    `json:"event_ids" binding:"omitempty,max=100,dive,min=1"`
+	PacketIDs  []int    `json:"packet_ids" binding:"omitempty,max=100,dive,min=1"`
+}
+
+func (req *HttpCreateWebhookSubscription) ToWebhookSubscription() *domain.WebhookSubscription {
+	ret := &domain.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		EventIDs:   req.EventIDs,
+		PacketIDs:  req.PacketIDs,
+	}
+	if req.Secret != nil {
+		ret.Secret = *req.Secret
+	}
+	return ret
+}
+
+type HttpUpdateWebhookSubscription struct {
+	URL        *string  `json:"url" binding:"omitempty,url,max=2048"`
+	Secret     *string  `json:"secret" binding:"omitempty,min=16,max=256"`
+	EventTypes []string `json:"event_types" binding:"omitempty,min=1,max=10"`
+	EventIDs   []int    `json:"event_ids" binding:"omitempty,max=100,dive,min=1"`
+	PacketIDs  []int    `json:"packet_ids" binding:"omitempty,max=100,dive,min=1"`
+	Active     *bool    `json:"active"`
+}
+
+func (req *HttpUpdateWebhookSubscription) ToPatch() *domain.WebhookSubscriptionPatch {
+	return &domain.WebhookSubscriptionPatch{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		EventIDs:   req.EventIDs,
+		PacketIDs:  req.PacketIDs,
+		Active:     req.Active,
+	}
+}
+
+type httpResponseWebhookDelivery struct {
+	ID             int64                   `json:"id"`
+	MessageID      int64                   `json:"message_id"`
+	EventType      string                  `json:"event_type"`
+	Payload        json.RawMessage         `json:"payload" swaggertype:"object"`
+	Status         string                  `json:"status"`
+	Attempts       int                     `json:"attempts"`
+	NextAttemptAt  *time.Time              `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int                    `json:"last_status_code,omitempty"`
+	LastError      *string                 `json:"last_error,omitempty"`
+	CreatedAt      time.Time               `json:"created_at"`
+	DeliveredAt    *time.Time              `json:"delivered_at,omitempty"`
+	Links          map[string]hateoas.Link `json:"_links"`
+}
+
+type HttpResponseWebhookDelivery struct {
+	Delivery *httpResponseWebhookDelivery `json:"delivery"`
+}
+
+type HttpResponseWebhookDeliveryList struct {
+	Deliveries []*httpResponseWebhookDelivery `json:"deliveries"`
+	Pagination *PaginationMetadata            `json:"pagination"`
+	Links      map[string]hateoas.Link        `json:"_links"`
+}
+
+func toHttpWebhookDelivery(delivery *domain.WebhookDelivery, serviceURLs *config.ServiceURLs) *httpResponseWebhookDelivery {
+	webhookPath := fmt.Sprintf("/webhooks/%d", delivery.SubscriptionID)
+
+	ret := &httpResponseWebhookDelivery{
+		ID:             delivery.ID,
+		MessageID:      delivery.MessageID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		Links: map[string]hateoas.Link{
+			"webhook": hateoas.BuildRelatedLink(serviceURLs.EventManager+webhookPath, "webhook", "GET", "Get the webhook"),
+			"redeliver": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s%s/deliveries/%d/redeliver", serviceURLs.EventManager, webhookPath, delivery.ID),
+				"redeliver",
+				"POST",
+				"Send this delivery again",
+			),
+		},
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		ret.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return ret
+}
+
+func ToHttpResponseWebhookDelivery(delivery *domain.WebhookDelivery, serviceURLs *config.ServiceURLs) *HttpResponseWebhookDelivery {
+	if delivery == nil {
+		return &HttpResponseWebhookDelivery{}
+	}
+	return &HttpResponseWebhookDelivery{Delivery: toHttpWebhookDelivery(delivery, serviceURLs)}
+}
+
+func ToHttpResponseWebhookDeliveryList(subscriptionID int, deliveries []*domain.WebhookDelivery, filter *domain.WebhookDeliveryFilter, total int, serviceURLs *config.ServiceURLs) *HttpResponseWebhookDeliveryList {
+	httpDeliveries := make([]*httpResponseWebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		httpDeliveries = append(httpDeliveries, toHttpWebhookDelivery(delivery, serviceURLs))
+	}
+
+	page, perPage := *filter.Page, *filter.PerPage
+	totalPages := (total + perPage - 1) / perPage
+
+	path := fmt.Sprintf("/webhooks/%d/deliveries", subscriptionID)
+	pageQuery := func(page int) string {
+		params := url.Values{}
+		if filter.Status != nil {
+			params.Set("status", *filter.Status)
+		}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("per_page", strconv.Itoa(perPage))
+		return params.Encode()
+	}
+
+	links := map[string]hateoas.Link{
+		"self":    hateoas.BuildPaginationLink(serviceURLs.EventManager, path, pageQuery(page), "self", "Current page"),
+		"first":   hateoas.BuildPaginationLink(serviceURLs.EventManager, path, pageQuery(1), "first", "First page"),
+		"webhook": hateoas.BuildRelatedLink(fmt.Sprintf("%s/webhooks/%d", serviceURLs.EventManager, subscriptionID), "webhook", "GET", "Get the webhook"),
+	}
+	if page > 1 {
+		links["prev"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, path, pageQuery(page-1), "prev", "Previous page")
+	}
+	if page < totalPages {
+		links["next"] = hateoas.BuildPaginationLink(serviceURLs.EventManager, path, pageQuery(page+1), "next", "Next page")
+	}
+
+	return &HttpResponseWebhookDeliveryList{
+		Deliveries: httpDeliveries,
+		Pagination: &PaginationMetadata{
+			Page:       page,
+			PerPage:    perPage,
+			TotalItems: total,
+			TotalPages: totalPages,
+		},
+		Links: links,
+	}
+}
+
+type HttpFilterWebhookDelivery struct {
+	Status  *string `json:"status,omitempty"   form:"status"`
+	Page    *int    `json:"page,omitempty"     form:"page"`
+	PerPage *int    `json:"per_page,omitempty" form:"per_page"`
+}
+
+func (filter *HttpFilterWebhookDelivery) ToWebhookDeliveryFilter() *domain.WebhookDeliveryFilter {
+	return &domain.WebhookDeliveryFilter{
+		Status:  filter.Status,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}
+}
@@ -0,0 +1,41 @@
+package messaging
+
+import (
+	"eventManager/application/service"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	OutboxBroker     = "OUTBOX_BROKER"
+	NATSURL          = "NATS_URL"
+	OutboxWebhookURL = "OUTBOX_WEBHOOK_URL"
+)
+
+// NewPublisherFromEnv builds the broker selected by OUTBOX_BROKER: "inprocess"
+// (the default), "nats" or "webhook".
+func NewPublisherFromEnv() (service.EventPublisher, error) {
+	switch kind := strings.ToLower(strings.TrimSpace(os.Getenv(OutboxBroker))); kind {
+	case "", "inprocess":
+		return NewInProcessBroker(), nil
+	case "nats":
+		natsURL := os.Getenv(NATSURL)
+		if natsURL == "" {
+			natsURL = "nats://localhost:4222"
+		}
+		broker, err := NewNATSBroker(natsURL)
+		if err != nil {
+			return nil, err
+		}
+		return broker, nil
+	case "webhook":
+		webhookURL := os.Getenv(OutboxWebhookURL)
+		if webhookURL == "" {
+			return nil, fmt.Errorf("%s must be set when %s is 'webhook'", OutboxWebhookURL, OutboxBroker)
+		}
+		return NewWebhookBroker(webhookURL), nil
+	default:
+		return nil, fmt.Errorf("unknown %s '%s'", OutboxBroker, kind)
+	}
+}
@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"encoding/json"
+	"eventManager/application/domain"
+	"time"
+)
+
+// SubjectPrefix namespaces the subjects this service publishes on, e.g.
+// "eventmanager.ticket.sold".
+const SubjectPrefix = "eventmanager."
+
+// Envelope is the wire format of a published domain event. ID is unique and
+// stable across redeliveries, so consumers can drop duplicates with it.
+type Envelope struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func NewEnvelope(message *domain.OutboxMessage) *Envelope {
+	return &Envelope{
+		ID:            message.ID,
+		Type:          message.Type,
+		AggregateType: message.AggregateType,
+		AggregateID:   message.AggregateID,
+		OccurredAt:    message.OccurredAt,
+		Payload:       message.Payload,
+	}
+}
+
+func Subject(message *domain.OutboxMessage) string {
+	return SubjectPrefix + message.Type
+}
@@ -0,0 +1,23 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"eventManager/application/domain"
+	"eventManager/application/service"
+)
+
+// FanOut publishes every message to all of its publishers. A failure in any
+// of them makes the relay retry the message on all of them, so each one has
+// to tolerate duplicates.
+type FanOut []service.EventPublisher
+
+func (f FanOut) Publish(ctx context.Context, message *domain.OutboxMessage) error {
+	var errs []error
+	for _, publisher := range f {
+		if err := publisher.Publish(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,100 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"eventManager/application/domain"
+	"eventManager/application/service"
+	"strings"
+	"sync"
+)
+
+// Handler consumes a message delivered on subject.
+type Handler func(ctx context.Context, subject string, envelope *Envelope) error
+
+type subscription struct {
+	id      int
+	pattern []string
+	handler Handler
+}
+
+// InProcessBroker delivers messages to handlers registered in the same
+// process. Subjects and patterns follow NATS rules: tokens are separated by
+// dots, "*" matches one token and a trailing ">" matches one or more.
+type InProcessBroker struct {
+	mu            sync.RWMutex
+	nextID        int
+	subscriptions []*subscription
+}
+
+func NewInProcessBroker() *InProcessBroker {
+	return &InProcessBroker{}
+}
+
+var _ service.EventPublisher = (*InProcessBroker)(nil)
+
+// Subscribe registers handler for every subject matching pattern and returns
+// a function that removes it again.
+func (b *InProcessBroker) Subscribe(pattern string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, &subscription{
+		id:      id,
+		pattern: strings.Split(pattern, "."),
+		handler: handler,
+	})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, sub := range b.subscriptions {
+			if sub.id == id {
+				b.subscriptions = append(b.subscriptions[:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish runs the matching handlers one after the other. The message counts
+// as delivered only if all of them succeed.
+func (b *InProcessBroker) Publish(ctx context.Context, message *domain.OutboxMessage) error {
+	subject := Subject(message)
+	tokens := strings.Split(subject, ".")
+
+	b.mu.RLock()
+	var handlers []Handler
+	for _, sub := range b.subscriptions {
+		if subjectMatches(sub.pattern, tokens) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	envelope := NewEnvelope(message)
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, subject, envelope); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func subjectMatches(pattern []string, subject []string) bool {
+	for i, token := range pattern {
+		if token == ">" {
+			return i == len(pattern)-1 && len(subject) > i
+		}
+		if i >= len(subject) {
+			return false
+		}
+		if token != "*" && token != subject[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(subject)
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"eventManager/application/domain"
+	"eventManager/application/service"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shared/httpclient"
+)
+
+// WebhookBroker publishes messages by POSTing their envelope to a single
+// endpoint. Any 2xx response acknowledges the message.
+type WebhookBroker struct {
+	url    string
+	client *httpclient.Client
+}
+
+func NewWebhookBroker(url string) *WebhookBroker {
+	return &WebhookBroker{
+		url: url,
+		client: httpclient.New(httpclient.Config{
+			Name:           "outbox-webhook",
+			AttemptTimeout: 5 * time.Second,
+		}),
+	}
+}
+
+var _ service.EventPublisher = (*WebhookBroker)(nil)
+
+func (b *WebhookBroker) Publish(ctx context.Context, message *domain.OutboxMessage) error {
+	body, err := json.Marshal(NewEnvelope(message))
+	if err != nil {
+		return fmt.Errorf("failed to encode message %d: %w", message.ID, err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Idempotency-Key", "outbox-"+strconv.FormatInt(message.ID, 10))
+	header.Set("X-Event-Type", message.Type)
+	header.Set("X-Event-Subject", Subject(message))
+
+	resp, err := b.client.Do(ctx, &httpclient.Request{
+		Method:     http.MethodPost,
+		URL:        b.url,
+		Header:     header,
+		Body:       body,
+		Idempotent: true,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %d for message %d", resp.StatusCode, message.ID)
+	}
+	return nil
+}
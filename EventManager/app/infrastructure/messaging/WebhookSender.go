@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"eventManager/application/domain"
+	"eventManager/application/service"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookTimeout          = 10 * time.Second
+	maxWebhookResponseBytes = 64 << 10
+)
+
+// errWebhookAddressRefused is all the delivery log learns about a receiver
+// that resolves to an internal address; the address itself is not recorded.
+var errWebhookAddressRefused = errors.New("receiver resolves to a loopback, private or link-local address")
+
+// webhookBody is what receivers get. message_id stays the same across
+// retries and redeliveries and is the key to deduplicate on.
+type webhookBody struct {
+	MessageID  int64           `json:"message_id"`
+	DeliveryID int64           `json:"delivery_id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// HMACWebhookSender POSTs deliveries signed with the subscription secret.
+// X-Webhook-Signature is "sha256=" followed by the hex HMAC-SHA256 of
+// "<X-Webhook-Timestamp>.<body>", so receivers can reject stale or replayed
+// requests by checking the timestamp.
+type HMACWebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHMACWebhookSender checks every address it connects to, after DNS
+// resolution, so a name that is re-pointed at an internal address after the
+// subscription was validated is refused as well. Redirects are not followed
+// and no proxy is used, since either would connect somewhere unchecked.
+func NewHMACWebhookSender() *HMACWebhookSender {
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: refuseInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HMACWebhookSender{
+		client: &http.Client{
+			Timeout:   webhookTimeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func refuseInternalAddress(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !domain.IsPublicWebhookAddress(addrPort.Addr()) {
+		return errWebhookAddressRefused
+	}
+	return nil
+}
+
+var _ service.WebhookSender = (*HMACWebhookSender)(nil)
+
+// SignWebhook returns the signature of body sent at timestamp.
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HMACWebhookSender) Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	if subscription == nil {
+		return 0, fmt.Errorf("webhook subscription %d not found", delivery.SubscriptionID)
+	}
+
+	body, err := json.Marshal(&webhookBody{
+		MessageID:  delivery.MessageID,
+		DeliveryID: delivery.ID,
+		Type:       delivery.EventType,
+		OccurredAt: delivery.OccurredAt,
+		Payload:    delivery.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode delivery %d: %w", delivery.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EventManager-Webhooks/1.0")
+	req.Header.Set("X-Webhook-Id", strconv.FormatInt(delivery.MessageID, 10))
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", SignWebhook(subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if errors.Is(err, errWebhookAddressRefused) {
+			return 0, errWebhookAddressRefused
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// the body is drained for connection reuse but never recorded, so the
+	// delivery log cannot be used to read responses
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -40,7 +40,7 @@ func buildDSN() string {
 func InitDB() *gorm.DB {
 	dsn := buildDSN()
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("FATAL: Failed to connect to database: %v", err)
 	}
@@ -54,11 +54,20 @@ func InitDB() *gorm.DB {
 		sqlDB.SetConnMaxLifetime(time.Hour)
 	}
 
-	err = db.AutoMigrate(&gormmodel.GormEvent{}, &gormmodel.GormEventPacket{}, &gormmodel.GormEventPacketInclusion{}, &gormmodel.GormTicket{})
+	err = db.AutoMigrate(&gormmodel.GormEvent{}, &gormmodel.GormEventPacket{}, &gormmodel.GormEventPacketInclusion{}, &gormmodel.GormTicket{},
+		&gormmodel.GormCategory{}, &gormmodel.GormTag{},
+		&gormmodel.GormEventCategory{}, &gormmodel.GormEventTag{},
+		&gormmodel.GormEventPacketCategory{}, &gormmodel.GormEventPacketTag{},
+		&gormmodel.GormIdempotencyKey{}, &gormmodel.GormOutboxMessage{},
+		&gormmodel.GormWebhookSubscription{}, &gormmodel.GormWebhookDelivery{})
 	if err != nil {
 		log.Fatalf("FATAL: Failed to run migrations: %v", err)
 	}
 
+	if err := migrateSearch(db); err != nil {
+		log.Fatalf("FATAL: Failed to set up full-text search: %v", err)
+	}
+
 	fmt.Println("Database schema migrated successfully.")
 	return db
 }
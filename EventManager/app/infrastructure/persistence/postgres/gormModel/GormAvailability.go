@@ -0,0 +1,49 @@
+package gormmodel
+
+import (
+	"eventManager/application/domain"
+)
+
+// Read models scanned from the aggregate availability queries; they are not
+// backed by a table and must not be passed to AutoMigrate.
+type GormEventAvailability struct {
+	EventID        int    `gorm:"column:event_id"`
+	OwnerID        int    `gorm:"column:id_owner"`
+	Name           string `gorm:"column:name"`
+	TotalSeats     *int   `gorm:"column:total_seats"`
+	DirectSold     int    `gorm:"column:direct_sold"`
+	PacketReserved int    `gorm:"column:packet_reserved"`
+	PacketSold     int    `gorm:"column:packet_sold"`
+}
+
+func (ga *GormEventAvailability) ToDomain() *domain.EventAvailability {
+	return &domain.EventAvailability{
+		EventID:        ga.EventID,
+		OwnerID:        ga.OwnerID,
+		Name:           ga.Name,
+		TotalSeats:     ga.TotalSeats,
+		DirectSold:     ga.DirectSold,
+		PacketReserved: ga.PacketReserved,
+		PacketSold:     ga.PacketSold,
+	}
+}
+
+type GormEventPacketAvailability struct {
+	PacketID       int    `gorm:"column:packet_id"`
+	OwnerID        int    `gorm:"column:id_owner"`
+	Name           string `gorm:"column:name"`
+	AllocatedSeats *int   `gorm:"column:allocated_seats"`
+	Sold           int    `gorm:"column:sold"`
+	EventCount     int    `gorm:"column:event_count"`
+}
+
+func (ga *GormEventPacketAvailability) ToDomain() *domain.EventPacketAvailability {
+	return &domain.EventPacketAvailability{
+		PacketID:       ga.PacketID,
+		OwnerID:        ga.OwnerID,
+		Name:           ga.Name,
+		AllocatedSeats: ga.AllocatedSeats,
+		Sold:           ga.Sold,
+		EventCount:     ga.EventCount,
+	}
+}
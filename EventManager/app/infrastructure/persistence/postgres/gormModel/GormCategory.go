@@ -0,0 +1,111 @@
+package gormmodel
+
+import (
+	"eventManager/application/domain"
+)
+
+type GormCategory struct {
+	ID          int     `gorm:"primaryKey;autoIncrement"`
+	Name        string  `gorm:"column:name;unique;not null"`
+	Slug        string  `gorm:"column:slug;unique;not null"`
+	Description *string `gorm:"column:description"`
+}
+
+func (GormCategory) TableName() string {
+	return "categories"
+}
+
+func (gc *GormCategory) ToDomain() *domain.Category {
+	return &domain.Category{
+		ID:          gc.ID,
+		Name:        gc.Name,
+		Slug:        gc.Slug,
+		Description: gc.Description,
+	}
+}
+
+func FromCategory(c *domain.Category) *GormCategory {
+	return &GormCategory{
+		ID:          c.ID,
+		Name:        c.Name,
+		Slug:        c.Slug,
+		Description: c.Description,
+	}
+}
+
+type GormTag struct {
+	ID   int    `gorm:"primaryKey;autoIncrement"`
+	Name string `gorm:"column:name;unique;not null"`
+}
+
+func (GormTag) TableName() string {
+	return "tags"
+}
+
+type GormEventCategory struct {
+	EventID    int          `gorm:"primaryKey;column:event_id"`
+	CategoryID int          `gorm:"primaryKey;column:category_id;index"`
+	Event      GormEvent    `gorm:"foreignKey:EventID;references:ID;constraint:OnDelete:CASCADE"`
+	Category   GormCategory `gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (GormEventCategory) TableName() string {
+	return "event_categories"
+}
+
+type GormEventTag struct {
+	EventID int       `gorm:"primaryKey;column:event_id"`
+	TagID   int       `gorm:"primaryKey;column:tag_id;index"`
+	Event   GormEvent `gorm:"foreignKey:EventID;references:ID;constraint:OnDelete:CASCADE"`
+	Tag     GormTag   `gorm:"foreignKey:TagID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (GormEventTag) TableName() string {
+	return "event_tags"
+}
+
+type GormEventPacketCategory struct {
+	PacketID   int             `gorm:"primaryKey;column:packet_id"`
+	CategoryID int             `gorm:"primaryKey;column:category_id;index"`
+	Packet     GormEventPacket `gorm:"foreignKey:PacketID;references:ID;constraint:OnDelete:CASCADE"`
+	Category   GormCategory    `gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (GormEventPacketCategory) TableName() string {
+	return "events_packet_categories"
+}
+
+type GormEventPacketTag struct {
+	PacketID int             `gorm:"primaryKey;column:packet_id"`
+	TagID    int             `gorm:"primaryKey;column:tag_id;index"`
+	Packet   GormEventPacket `gorm:"foreignKey:PacketID;references:ID;constraint:OnDelete:CASCADE"`
+	Tag      GormTag         `gorm:"foreignKey:TagID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (GormEventPacketTag) TableName() string {
+	return "events_packet_tags"
+}
+
+// GormTaxonomyRow is one category or tag of an event or packet, as loaded in
+// bulk for a page of results.
+type GormTaxonomyRow struct {
+	OwnerID     int     `gorm:"column:owner_id"`
+	CategoryID  *int    `gorm:"column:category_id"`
+	Name        string  `gorm:"column:name"`
+	Slug        *string `gorm:"column:slug"`
+	Description *string `gorm:"column:description"`
+}
+
+type GormFacetCount struct {
+	Value string `gorm:"column:value"`
+	Label string `gorm:"column:label"`
+	Count int    `gorm:"column:count"`
+}
+
+func (gf *GormFacetCount) ToDomain() *domain.FacetCount {
+	return &domain.FacetCount{
+		Value: gf.Value,
+		Label: gf.Label,
+		Count: gf.Count,
+	}
+}
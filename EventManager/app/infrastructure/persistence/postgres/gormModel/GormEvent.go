@@ -2,6 +2,7 @@ package gormmodel
 
 import (
 	"eventManager/application/domain"
+	"time"
 )
 
 type GormEvent struct {
@@ -11,6 +12,34 @@ type GormEvent struct {
 	Location    *string `gorm:"column:location"`
 	Description *string `gorm:"column:description"`
 	Seats       *int    `gorm:"column:seats"`
+
+	Address   *string  `gorm:"column:address"`
+	City      *string  `gorm:"column:city"`
+	Country   *string  `gorm:"column:country"`
+	Latitude  *float64 `gorm:"column:latitude;index:idx_events_lat_lng,priority:1"`
+	Longitude *float64 `gorm:"column:longitude;index:idx_events_lat_lng,priority:2"`
+
+	StartsAt *time.Time `gorm:"column:starts_at;index"`
+	EndsAt   *time.Time `gorm:"column:ends_at"`
+
+	CancelledAt *time.Time `gorm:"column:cancelled_at"`
+	Sequence    int        `gorm:"column:sequence;not null;default:0"`
+
+	TransfersBlocked bool `gorm:"column:transfers_blocked;not null;default:false"`
+
+	Price                  *int `gorm:"column:price"`
+	ResaleAllowed          bool `gorm:"column:resale_allowed;not null;default:false"`
+	ResaleMaxMarkupPercent *int `gorm:"column:resale_max_markup_percent"`
+	RefundWindowHours      *int `gorm:"column:refund_window_hours"`
+	MaxTicketsPerUser      *int `gorm:"column:max_tickets_per_user"`
+
+	// populated only by full-text search queries
+	SearchRank           *float64 `gorm:"column:search_rank;->;-:migration"`
+	NameHighlight        *string  `gorm:"column:name_highlight;->;-:migration"`
+	DescriptionHighlight *string  `gorm:"column:description_highlight;->;-:migration"`
+
+	// populated only by near queries
+	DistanceKm *float64 `gorm:"column:distance_km;->;-:migration"`
 }
 
 func (GormEvent) TableName() string {
@@ -19,23 +48,55 @@ func (GormEvent) TableName() string {
 
 func (ge *GormEvent) ToDomain() *domain.Event {
 	return &domain.Event{
-		ID:          ge.ID,
-		OwnerID:     ge.OwnerID,
-		Name:        ge.Name,
-		Location:    ge.Location,
-		Description: ge.Description,
-		Seats:       ge.Seats,
+		ID:                     ge.ID,
+		OwnerID:                ge.OwnerID,
+		Name:                   ge.Name,
+		Location:               ge.Location,
+		Description:            ge.Description,
+		Seats:                  ge.Seats,
+		Address:                ge.Address,
+		City:                   ge.City,
+		Country:                ge.Country,
+		Latitude:               ge.Latitude,
+		Longitude:              ge.Longitude,
+		StartsAt:               ge.StartsAt,
+		EndsAt:                 ge.EndsAt,
+		CancelledAt:            ge.CancelledAt,
+		Sequence:               ge.Sequence,
+		TransfersBlocked:       ge.TransfersBlocked,
+		Price:                  ge.Price,
+		ResaleAllowed:          ge.ResaleAllowed,
+		ResaleMaxMarkupPercent: ge.ResaleMaxMarkupPercent,
+		RefundWindowHours:      ge.RefundWindowHours,
+		MaxTicketsPerUser:      ge.MaxTicketsPerUser,
+		Match:                  toSearchMatch(ge.SearchRank, ge.NameHighlight, ge.DescriptionHighlight),
+		DistanceKm:             ge.DistanceKm,
 	}
 }
 
 func FromEvent(e *domain.Event) *GormEvent {
 
 	return &GormEvent{
-		ID:          e.ID,
-		OwnerID:     e.OwnerID,
-		Name:        e.Name,
-		Location:    e.Location,
-		Description: e.Description,
-		Seats:       e.Seats,
+		ID:                     e.ID,
+		OwnerID:                e.OwnerID,
+		Name:                   e.Name,
+		Location:               e.Location,
+		Description:            e.Description,
+		Seats:                  e.Seats,
+		Address:                e.Address,
+		City:                   e.City,
+		Country:                e.Country,
+		Latitude:               e.Latitude,
+		Longitude:              e.Longitude,
+		StartsAt:               e.StartsAt,
+		EndsAt:                 e.EndsAt,
+		CancelledAt:            e.CancelledAt,
+		Sequence:               e.Sequence,
+		TransfersBlocked:       e.TransfersBlocked,
+		Price:                  e.Price,
+		ResaleAllowed:          e.ResaleAllowed,
+		ResaleMaxMarkupPercent: e.ResaleMaxMarkupPercent,
+		RefundWindowHours:      e.RefundWindowHours,
+		MaxTicketsPerUser:      e.MaxTicketsPerUser,
 	}
 }
@@ -2,6 +2,7 @@ package gormmodel
 
 import (
 	"eventManager/application/domain"
+	"time"
 )
 
 type GormEventPacket struct {
@@ -11,6 +12,21 @@ type GormEventPacket struct {
 	Location       *string `gorm:"column:location"`
 	Description    *string `gorm:"column:description"`
 	AllocatedSeats *int    `gorm:"column:allocated_seats"`
+
+	Address   *string  `gorm:"column:address"`
+	City      *string  `gorm:"column:city"`
+	Country   *string  `gorm:"column:country"`
+	Latitude  *float64 `gorm:"column:latitude;index:idx_events_packet_lat_lng,priority:1"`
+	Longitude *float64 `gorm:"column:longitude;index:idx_events_packet_lat_lng,priority:2"`
+
+	Price             *int `gorm:"column:price"`
+	RefundWindowHours *int `gorm:"column:refund_window_hours"`
+	MaxTicketsPerUser *int `gorm:"column:max_tickets_per_user"`
+
+	// populated only by full-text search queries
+	SearchRank           *float64 `gorm:"column:search_rank;->;-:migration"`
+	NameHighlight        *string  `gorm:"column:name_highlight;->;-:migration"`
+	DescriptionHighlight *string  `gorm:"column:description_highlight;->;-:migration"`
 }
 
 func (GormEventPacket) TableName() string {
@@ -19,23 +35,52 @@ func (GormEventPacket) TableName() string {
 
 func (ge *GormEventPacket) ToDomain() *domain.EventPacket {
 	return &domain.EventPacket{
-		ID:             ge.ID,
-		OwnerID:        ge.OwnerID,
-		Name:           ge.Name,
-		Location:       ge.Location,
-		Description:    ge.Description,
-		AllocatedSeats: ge.AllocatedSeats,
+		ID:                ge.ID,
+		OwnerID:           ge.OwnerID,
+		Name:              ge.Name,
+		Location:          ge.Location,
+		Description:       ge.Description,
+		AllocatedSeats:    ge.AllocatedSeats,
+		Address:           ge.Address,
+		City:              ge.City,
+		Country:           ge.Country,
+		Latitude:          ge.Latitude,
+		Longitude:         ge.Longitude,
+		Price:             ge.Price,
+		RefundWindowHours: ge.RefundWindowHours,
+		MaxTicketsPerUser: ge.MaxTicketsPerUser,
+		Match:             toSearchMatch(ge.SearchRank, ge.NameHighlight, ge.DescriptionHighlight),
 	}
 }
 
 func FromEventPacket(e *domain.EventPacket) *GormEventPacket {
 
 	return &GormEventPacket{
-		ID:             e.ID,
-		OwnerID:        e.OwnerID,
-		Name:           e.Name,
-		Location:       e.Location,
-		Description:    e.Description,
-		AllocatedSeats: e.AllocatedSeats,
+		ID:                e.ID,
+		OwnerID:           e.OwnerID,
+		Name:              e.Name,
+		Location:          e.Location,
+		Description:       e.Description,
+		AllocatedSeats:    e.AllocatedSeats,
+		Address:           e.Address,
+		City:              e.City,
+		Country:           e.Country,
+		Latitude:          e.Latitude,
+		Longitude:         e.Longitude,
+		Price:             e.Price,
+		RefundWindowHours: e.RefundWindowHours,
+		MaxTicketsPerUser: e.MaxTicketsPerUser,
 	}
 }
+
+// GormEventPacketSchedule is the span of the events included in a packet,
+// whether any of them blocks ticket transfers and the resale policy they
+// leave the packet with.
+type GormEventPacketSchedule struct {
+	PacketID               int        `gorm:"column:packet_id"`
+	StartsAt               *time.Time `gorm:"column:starts_at"`
+	EndsAt                 *time.Time `gorm:"column:ends_at"`
+	TransfersBlocked       bool       `gorm:"column:transfers_blocked"`
+	ResaleAllowed          bool       `gorm:"column:resale_allowed"`
+	ResaleMaxMarkupPercent *int       `gorm:"column:resale_max_markup_percent"`
+}
@@ -0,0 +1,48 @@
+package gormmodel
+
+import (
+	"time"
+
+	"shared/idempotency"
+)
+
+type GormIdempotencyKey struct {
+	Scope        string    `gorm:"primaryKey;column:scope"`
+	Key          string    `gorm:"primaryKey;column:idempotency_key;size:255"`
+	Fingerprint  string    `gorm:"column:fingerprint;not null"`
+	StatusCode   int       `gorm:"column:status_code;not null;default:0"`
+	ContentType  string    `gorm:"column:content_type"`
+	ResponseBody []byte    `gorm:"column:response_body"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null"`
+	ExpiresAt    time.Time `gorm:"column:expires_at;not null;index"`
+}
+
+func (GormIdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+func (gk *GormIdempotencyKey) ToDomain() *idempotency.Record {
+	return &idempotency.Record{
+		Key:          gk.Key,
+		Scope:        gk.Scope,
+		Fingerprint:  gk.Fingerprint,
+		StatusCode:   gk.StatusCode,
+		ContentType:  gk.ContentType,
+		ResponseBody: gk.ResponseBody,
+		CreatedAt:    gk.CreatedAt,
+		ExpiresAt:    gk.ExpiresAt,
+	}
+}
+
+func FromIdempotencyRecord(r *idempotency.Record) *GormIdempotencyKey {
+	return &GormIdempotencyKey{
+		Scope:        r.Scope,
+		Key:          r.Key,
+		Fingerprint:  r.Fingerprint,
+		StatusCode:   r.StatusCode,
+		ContentType:  r.ContentType,
+		ResponseBody: r.ResponseBody,
+		CreatedAt:    r.CreatedAt,
+		ExpiresAt:    r.ExpiresAt,
+	}
+}
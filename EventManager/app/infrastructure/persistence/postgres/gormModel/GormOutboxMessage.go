@@ -0,0 +1,38 @@
+package gormmodel
+
+import (
+	"eventManager/application/domain"
+	"time"
+)
+
+type GormOutboxMessage struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement;column:id"`
+	AggregateType string     `gorm:"column:aggregate_type;not null;index:idx_outbox_aggregate,priority:1"`
+	AggregateID   string     `gorm:"column:aggregate_id;not null;index:idx_outbox_aggregate,priority:2"`
+	Type          string     `gorm:"column:type;not null"`
+	Payload       []byte     `gorm:"column:payload;type:jsonb;not null"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at;not null"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0"`
+	LastError     *string    `gorm:"column:last_error"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;index"`
+	PublishedAt   *time.Time `gorm:"column:published_at;index"`
+}
+
+func (GormOutboxMessage) TableName() string {
+	return "outbox_messages"
+}
+
+func (gm *GormOutboxMessage) ToDomain() *domain.OutboxMessage {
+	return &domain.OutboxMessage{
+		ID:            gm.ID,
+		AggregateType: gm.AggregateType,
+		AggregateID:   gm.AggregateID,
+		Type:          gm.Type,
+		Payload:       gm.Payload,
+		OccurredAt:    gm.OccurredAt,
+		Attempts:      gm.Attempts,
+		LastError:     gm.LastError,
+		NextAttemptAt: gm.NextAttemptAt,
+		PublishedAt:   gm.PublishedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package gormmodel
+
+import (
+	"eventManager/application/domain"
+)
+
+func toSearchMatch(rank *float64, nameHighlight *string, descriptionHighlight *string) *domain.SearchMatch {
+	if rank == nil {
+		return nil
+	}
+
+	match := &domain.SearchMatch{
+		Rank:                 *rank,
+		DescriptionHighlight: descriptionHighlight,
+	}
+	if nameHighlight != nil {
+		match.NameHighlight = *nameHighlight
+	}
+	return match
+}
@@ -4,10 +4,13 @@ import (
 	"eventManager/application/domain"
 )
 
+// GormTicket is keyed by its code. ID is a stable surrogate that survives
+// code rotations, so the ticket's outbox messages keep their order.
 type GormTicket struct {
 	Code     string           `gorm:"primaryKey;column:code"`
-	PacketID *int             `gorm:"column:packet_id"`
-	EventID  *int             `gorm:"column:event_id"`
+	ID       int              `gorm:"column:id;autoIncrement;uniqueIndex"`
+	PacketID *int             `gorm:"column:packet_id;index"`
+	EventID  *int             `gorm:"column:event_id;index"`
 	Packet   *GormEventPacket `gorm:"foreignKey:PacketID;references:ID"`
 	Event    *GormEvent       `gorm:"foreignKey:EventID;references:ID"`
 }
@@ -0,0 +1,110 @@
+package gormmodel
+
+import (
+	"eventManager/application/domain"
+	"time"
+)
+
+type GormWebhookSubscription struct {
+	ID         int       `gorm:"primaryKey;autoIncrement;column:id"`
+	OwnerID    int       `gorm:"column:id_owner;not null;index"`
+	URL        string    `gorm:"column:url;not null"`
+	Secret     string    `gorm:"column:secret;not null"`
+	EventTypes []string  `gorm:"column:event_types;type:jsonb;serializer:json;not null"`
+	EventIDs   []int     `gorm:"column:event_ids;type:jsonb;serializer:json"`
+	PacketIDs  []int     `gorm:"column:packet_ids;type:jsonb;serializer:json"`
+	Active     bool      `gorm:"column:active;not null;default:true"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (GormWebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (gs *GormWebhookSubscription) ToDomain() *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:         gs.ID,
+		OwnerID:    gs.OwnerID,
+		URL:        gs.URL,
+		Secret:     gs.Secret,
+		EventTypes: gs.EventTypes,
+		EventIDs:   gs.EventIDs,
+		PacketIDs:  gs.PacketIDs,
+		Active:     gs.Active,
+		CreatedAt:  gs.CreatedAt,
+		UpdatedAt:  gs.UpdatedAt,
+	}
+}
+
+func FromWebhookSubscription(s *domain.WebhookSubscription) *GormWebhookSubscription {
+	return &GormWebhookSubscription{
+		ID:         s.ID,
+		OwnerID:    s.OwnerID,
+		URL:        s.URL,
+		Secret:     s.Secret,
+		EventTypes: s.EventTypes,
+		EventIDs:   s.EventIDs,
+		PacketIDs:  s.PacketIDs,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+type GormWebhookDelivery struct {
+	ID             int64                    `gorm:"primaryKey;autoIncrement;column:id"`
+	SubscriptionID int                      `gorm:"column:subscription_id;not null;uniqueIndex:idx_webhook_delivery_message,priority:1"`
+	Subscription   *GormWebhookSubscription `gorm:"foreignKey:SubscriptionID;references:ID;constraint:OnDelete:CASCADE"`
+	MessageID      int64                    `gorm:"column:message_id;not null;uniqueIndex:idx_webhook_delivery_message,priority:2"`
+	EventType      string                   `gorm:"column:event_type;not null"`
+	Payload        []byte                   `gorm:"column:payload;type:jsonb;not null"`
+	OccurredAt     time.Time                `gorm:"column:occurred_at;not null"`
+	Status         string                   `gorm:"column:status;not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int                      `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt  time.Time                `gorm:"column:next_attempt_at;not null;index:idx_webhook_delivery_due,priority:2"`
+	LastStatusCode *int                     `gorm:"column:last_status_code"`
+	LastError      *string                  `gorm:"column:last_error"`
+	CreatedAt      time.Time                `gorm:"column:created_at;not null"`
+	DeliveredAt    *time.Time               `gorm:"column:delivered_at"`
+}
+
+func (GormWebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (gd *GormWebhookDelivery) ToDomain() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             gd.ID,
+		SubscriptionID: gd.SubscriptionID,
+		MessageID:      gd.MessageID,
+		EventType:      gd.EventType,
+		Payload:        gd.Payload,
+		OccurredAt:     gd.OccurredAt,
+		Status:         gd.Status,
+		Attempts:       gd.Attempts,
+		NextAttemptAt:  gd.NextAttemptAt,
+		LastStatusCode: gd.LastStatusCode,
+		LastError:      gd.LastError,
+		CreatedAt:      gd.CreatedAt,
+		DeliveredAt:    gd.DeliveredAt,
+	}
+}
+
+func FromWebhookDelivery(d *domain.WebhookDelivery) *GormWebhookDelivery {
+	return &GormWebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		MessageID:      d.MessageID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		OccurredAt:     d.OccurredAt,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
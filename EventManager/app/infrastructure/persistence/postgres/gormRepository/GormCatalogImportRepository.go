@@ -0,0 +1,95 @@
+package gormrepository
+
+import (
+	"context"
+	"errors"
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+
+	"gorm.io/gorm"
+)
+
+type GormCatalogImportRepository struct {
+	DB *gorm.DB
+}
+
+func (r *GormCatalogImportRepository) GetTakenNames(ctx context.Context, eventNames []string, packetNames []string) ([]string, []string, error) {
+	var takenEvents, takenPackets []string
+	if len(eventNames) > 0 {
+		if err := r.DB.WithContext(ctx).Model(&gormmodel.GormEvent{}).
+			Where("name IN ?", eventNames).Pluck("name", &takenEvents).Error; err != nil {
+			return nil, nil, &domain.InternalError{Msg: "failed to look up event names", Err: err}
+		}
+	}
+	if len(packetNames) > 0 {
+		if err := r.DB.WithContext(ctx).Model(&gormmodel.GormEventPacket{}).
+			Where("name IN ?", packetNames).Pluck("name", &takenPackets).Error; err != nil {
+			return nil, nil, &domain.InternalError{Msg: "failed to look up packet names", Err: err}
+		}
+	}
+	return takenEvents, takenPackets, nil
+}
+
+// Import writes the rows and their domain events in one transaction, so a
+// failing row, such as a name taken since the rows were checked, leaves
+// nothing behind.
+func (r *GormCatalogImportRepository) Import(ctx context.Context, rows []*domain.ImportRow) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		eventIDs := make(map[string]int)
+		for _, row := range rows {
+			if row.Kind() != domain.ImportKindEvent {
+				continue
+			}
+			gormEvent := gormmodel.FromEvent(row.Event)
+			if err := tx.Create(gormEvent).Error; err != nil {
+				return importError(err, gormEvent.Name)
+			}
+			row.Event = gormEvent.ToDomain()
+			if row.Ref != "" {
+				eventIDs[row.Ref] = gormEvent.ID
+			}
+
+			if err := appendOutbox(tx, domain.AggregateEvent, aggregateID(gormEvent.ID), domain.EventCreated, domain.NewEventPayload(row.Event)); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			if row.Kind() != domain.ImportKindPacket {
+				continue
+			}
+			gormPacket := gormmodel.FromEventPacket(row.Packet)
+			if err := tx.Create(gormPacket).Error; err != nil {
+				return importError(err, gormPacket.Name)
+			}
+			row.Packet = gormPacket.ToDomain()
+
+			if err := appendOutbox(tx, domain.AggregateEventPacket, aggregateID(gormPacket.ID), domain.EventPacketCreated, domain.NewEventPacketPayload(row.Packet)); err != nil {
+				return err
+			}
+
+			for _, ref := range row.EventRefs {
+				inclusion := &gormmodel.GormEventPacketInclusion{PacketID: gormPacket.ID, EventID: eventIDs[ref]}
+				if err := tx.Create(inclusion).Error; err != nil {
+					if errors.Is(err, gorm.ErrForeignKeyViolated) {
+						return &domain.ForeignKeyError{}
+					}
+					return &domain.InternalError{Msg: "failed to create event-packet inclusion", Err: err}
+				}
+
+				if err := appendOutbox(tx, domain.AggregateEventPacket, aggregateID(gormPacket.ID), domain.EventPacketInclusionCreated,
+					&domain.EventPacketInclusionPayload{EventID: inclusion.EventID, PacketID: inclusion.PacketID}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func importError(err error, name string) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return &domain.AlreadyExistsError{Name: name}
+	}
+	return &domain.InternalError{Msg: "failed to persist imported row", Err: err}
+}
@@ -0,0 +1,133 @@
+package gormrepository
+
+import (
+	"context"
+	"errors"
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormCategoryRepository struct {
+	DB *gorm.DB
+}
+
+func (r *GormCategoryRepository) Create(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	gormCategory := gormmodel.FromCategory(category)
+
+	if err := r.DB.WithContext(ctx).Create(gormCategory).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, &domain.UniqueNameError{Msg: gormCategory.Name}
+		}
+		return nil, &domain.InternalError{Msg: "failed to persist category", Err: err}
+	}
+
+	return gormCategory.ToDomain(), nil
+}
+
+func (r *GormCategoryRepository) GetByID(ctx context.Context, id int) (*domain.Category, error) {
+	var ret gormmodel.GormCategory
+	result := r.DB.WithContext(ctx).Where("id = ?", id).First(&ret)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, &domain.NotFoundError{ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "could not find the category", Err: result.Error}
+	}
+
+	return ret.ToDomain(), nil
+}
+
+func (r *GormCategoryRepository) GetAll(ctx context.Context) ([]*domain.Category, error) {
+	var gormCategories []gormmodel.GormCategory
+	if err := r.DB.WithContext(ctx).Order("name").Find(&gormCategories).Error; err != nil {
+		return nil, &domain.InternalError{Msg: "failed to list categories", Err: err}
+	}
+
+	result := make([]*domain.Category, 0, len(gormCategories))
+	for _, gormCategory := range gormCategories {
+		result = append(result, gormCategory.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *GormCategoryRepository) GetBySlugs(ctx context.Context, slugs []string) ([]*domain.Category, error) {
+	if len(slugs) == 0 {
+		return []*domain.Category{}, nil
+	}
+
+	var gormCategories []gormmodel.GormCategory
+	if err := r.DB.WithContext(ctx).Where("slug IN ?", slugs).Find(&gormCategories).Error; err != nil {
+		return nil, &domain.InternalError{Msg: "failed to get categories by slug", Err: err}
+	}
+
+	result := make([]*domain.Category, 0, len(gormCategories))
+	for _, gormCategory := range gormCategories {
+		result = append(result, gormCategory.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *GormCategoryRepository) Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.Category, error) {
+	result := r.DB.WithContext(ctx).Model(&gormmodel.GormCategory{}).Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			name, ok := updates["name"].(string)
+			if !ok {
+				name, _ = updates["slug"].(string)
+			}
+			return nil, &domain.UniqueNameError{Msg: name}
+		}
+		return nil, &domain.InternalError{Msg: "could not update the category", Err: result.Error}
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &domain.NotFoundError{ID: id}
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// Delete removes the category; its links to events and packets are dropped by
+// the cascading foreign keys.
+func (r *GormCategoryRepository) Delete(ctx context.Context, id int) (*domain.Category, error) {
+	var ret gormmodel.GormCategory
+	result := r.DB.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).Delete(&ret)
+
+	if result.Error != nil {
+		return nil, &domain.InternalError{Msg: "could not delete the category", Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return nil, &domain.NotFoundError{ID: id}
+	}
+
+	return ret.ToDomain(), nil
+}
+
+func (r *GormCategoryRepository) SetEventTags(ctx context.Context, eventID int, categoryIDs []int, tags []string) error {
+	return r.setTags(ctx, eventTaxonomy, eventID, categoryIDs, tags)
+}
+
+func (r *GormCategoryRepository) SetEventPacketTags(ctx context.Context, packetID int, categoryIDs []int, tags []string) error {
+	return r.setTags(ctx, eventPacketTaxonomy, packetID, categoryIDs, tags)
+}
+
+func (r *GormCategoryRepository) setTags(ctx context.Context, tables taxonomyTables, id int, categoryIDs []int, tags []string) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceTaxonomy(tx, tables, id, categoryIDs, tags)
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return &domain.ForeignKeyError{}
+		}
+		return &domain.InternalError{Msg: "failed to attach categories and tags", Err: err}
+	}
+	return nil
+}
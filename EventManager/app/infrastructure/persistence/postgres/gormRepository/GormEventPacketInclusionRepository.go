@@ -15,14 +15,22 @@ type GormEventPacketInclusionRepository struct {
 
 func (r *GormEventPacketInclusionRepository) Create(ctx context.Context, event *domain.EventPacketInclusion) (*domain.EventPacketInclusion, error) {
 	gormModel := gormmodel.FromEventPacketInclusion(event)
-	if err := r.DB.WithContext(ctx).Create(gormModel).Error; err != nil {
-		if errors.Is(err, gorm.ErrForeignKeyViolated) {
-			return nil, &domain.ForeignKeyError{}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gormModel).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return &domain.ForeignKeyError{}
+			}
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return &domain.AlreadyExistsError{Name: "event-packet inclusion"}
+			}
+			return &domain.InternalError{Msg: "failed to create event-packet inclusion", Err: err}
 		}
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return nil, &domain.AlreadyExistsError{Name: "event-packet inclusion"}
-		}
-		return nil, &domain.InternalError{Msg: "failed to create event-packet inclusion", Err: err}
+
+		return appendOutbox(tx, domain.AggregateEventPacket, aggregateID(gormModel.PacketID), domain.EventPacketInclusionCreated,
+			&domain.EventPacketInclusionPayload{EventID: gormModel.EventID, PacketID: gormModel.PacketID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if err := r.DB.WithContext(ctx).Preload("Event").Preload("Packet").
@@ -68,16 +76,24 @@ func (r *GormEventPacketInclusionRepository) GetEventPacketsByEventID(ctx contex
 }
 
 func (r *GormEventPacketInclusionRepository) Update(ctx context.Context, eventID, packetID int, updates map[string]interface{}) (*domain.EventPacketInclusion, error) {
-	result := r.DB.WithContext(ctx).Model(&gormmodel.GormEventPacketInclusion{}).
-		Where("event_id = ? AND packet_id = ?", eventID, packetID).
-		Updates(updates)
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&gormmodel.GormEventPacketInclusion{}).
+			Where("event_id = ? AND packet_id = ?", eventID, packetID).
+			Updates(updates)
 
-	if result.Error != nil {
-		return nil, &domain.InternalError{Msg: "failed to update event-packet inclusion", Err: result.Error}
-	}
+		if result.Error != nil {
+			return &domain.InternalError{Msg: "failed to update event-packet inclusion", Err: result.Error}
+		}
 
-	if result.RowsAffected == 0 {
-		return nil, &domain.NotFoundError{ID: eventID}
+		if result.RowsAffected == 0 {
+			return &domain.NotFoundError{ID: eventID}
+		}
+
+		return appendOutbox(tx, domain.AggregateEventPacket, aggregateID(packetID), domain.EventPacketInclusionUpdated,
+			&domain.EventPacketInclusionPayload{EventID: eventID, PacketID: packetID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var gormInclusion gormmodel.GormEventPacketInclusion
@@ -101,8 +117,16 @@ func (r *GormEventPacketInclusionRepository) Delete(ctx context.Context, eventID
 		return nil, &domain.InternalError{Msg: "failed to find event-packet inclusion", Err: err}
 	}
 
-	if err := r.DB.WithContext(ctx).Delete(&gormInclusion).Error; err != nil {
-		return nil, &domain.InternalError{Msg: "failed to delete event-packet inclusion", Err: err}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&gormInclusion).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to delete event-packet inclusion", Err: err}
+		}
+
+		return appendOutbox(tx, domain.AggregateEventPacket, aggregateID(packetID), domain.EventPacketInclusionDeleted,
+			&domain.EventPacketInclusionPayload{EventID: eventID, PacketID: packetID})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return gormInclusion.ToDomain(), nil
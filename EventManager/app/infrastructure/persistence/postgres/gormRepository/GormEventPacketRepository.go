@@ -19,14 +19,20 @@ func (r *GormEventPacketRepository) Create(ctx context.Context, event *domain.Ev
 
 	gormEvent := gormmodel.FromEventPacket(event)
 
-	if err := r.DB.WithContext(ctx).Create(gormEvent).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gormEvent).Error; err != nil {
 
-		if strings.Contains(err.Error(), "duplicate key") ||
-			strings.Contains(err.Error(), "23505") {
-			return nil, &domain.AlreadyExistsError{Name: gormEvent.Name}
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return &domain.AlreadyExistsError{Name: gormEvent.Name}
 
+			}
+			return &domain.InternalError{Msg: "failed to persist event", Err: err}
 		}
-		return nil, &domain.InternalError{Msg: "failed to persist event", Err: err}
+
+		return appendOutbox(tx, domain.AggregateEventPacket, aggregateID(gormEvent.ID), domain.EventPacketCreated, domain.NewEventPacketPayload(gormEvent.ToDomain()))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return gormEvent.ToDomain(), nil
@@ -43,27 +49,65 @@ func (r *GormEventPacketRepository) GetByID(ctx context.Context, id int) (*domai
 	}
 
 	retDomain := ret.ToDomain()
+	if err := attachEventPacketDetails(r.DB.WithContext(ctx), []*domain.EventPacket{retDomain}); err != nil {
+		return nil, err
+	}
 
 	return retDomain, nil
 }
 
+// GetByIDs returns the packets that exist among ids, in ascending id order.
+func (r *GormEventPacketRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.EventPacket, error) {
+	var gormPackets []gormmodel.GormEventPacket
+	if err := r.DB.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&gormPackets).Error; err != nil {
+		return nil, &domain.InternalError{Msg: "could not load the packets", Err: err}
+	}
+
+	packets := make([]*domain.EventPacket, 0, len(gormPackets))
+	for _, gormPacket := range gormPackets {
+		packets = append(packets, gormPacket.ToDomain())
+	}
+
+	if err := attachEventPacketDetails(r.DB.WithContext(ctx), packets); err != nil {
+		return nil, err
+	}
+
+	return packets, nil
+}
+
+// attachEventPacketDetails loads what is stored next to the packets
+// themselves: their taxonomy and the schedule of their events.
+func attachEventPacketDetails(db *gorm.DB, packets []*domain.EventPacket) error {
+	if err := attachEventPacketTaxonomy(db, packets); err != nil {
+		return err
+	}
+	return attachEventPacketSchedule(db, packets)
+}
+
 func (r *GormEventPacketRepository) Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.EventPacket, error) {
-	result := r.DB.WithContext(ctx).Model(&gormmodel.GormEventPacket{}).Clauses(clause.Returning{}).
-		Where("id = ?", id).
-		Updates(updates)
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var updated gormmodel.GormEventPacket
+		result := tx.Model(&updated).Clauses(clause.Returning{}).
+			Where("id = ?", id).
+			Updates(updates)
 
-	if result.Error != nil {
+		if result.Error != nil {
 
-		if strings.Contains(result.Error.Error(), "duplicate key") ||
-			strings.Contains(result.Error.Error(), "23505") {
+			if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
 
-			return nil, &domain.UniqueNameError{Msg: updates["name"].(string)}
+				return &domain.UniqueNameError{Msg: updates["name"].(string)}
+			}
+			return &domain.InternalError{Msg: "could not update the event", Err: result.Error}
 		}
-		return nil, &domain.InternalError{Msg: "could not update the event", Err: result.Error}
-	}
 
-	if result.RowsAffected == 0 {
-		return nil, &domain.NotFoundError{ID: id}
+		if result.RowsAffected == 0 {
+			return &domain.NotFoundError{ID: id}
+		}
+
+		return appendOutbox(tx, domain.AggregateEventPacket, aggregateID(id), domain.EventPacketUpdated, domain.NewEventPacketPayload(updated.ToDomain()))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r.GetByID(ctx, id)
@@ -106,7 +150,7 @@ func (r *GormEventPacketRepository) Delete(ctx context.Context, id int) (*domain
 		}
 
 		retDomain = ret.ToDomain()
-		return nil
+		return appendOutbox(tx, domain.AggregateEventPacket, aggregateID(id), domain.EventPacketDeleted, domain.NewEventPacketPayload(retDomain))
 	})
 
 	if err != nil {
@@ -126,93 +170,121 @@ func (r *GormEventPacketRepository) CountSoldTickets(ctx context.Context, packet
 	return int(count), nil
 }
 
-func (r *GormEventPacketRepository) FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, error) {
+func (r *GormEventPacketRepository) FilterEventPackets(ctx context.Context, filter *domain.EventPacketFilter) ([]*domain.EventPacket, *domain.PageInfo, error) {
 	if filter == nil {
-		return nil, &domain.ValidationError{Reason: "filter cannot be nil"}
+		return nil, nil, &domain.ValidationError{Reason: "filter cannot be nil"}
 	}
 	if filter.Page == nil {
-		return nil, &domain.ValidationError{Field: "page", Reason: "page cannot be nil"}
+		return nil, nil, &domain.ValidationError{Field: "page", Reason: "page cannot be nil"}
 	}
 	if filter.PerPage == nil {
-		return nil, &domain.ValidationError{Field: "per_page", Reason: "per_page cannot be nil"}
+		return nil, nil, &domain.ValidationError{Field: "per_page", Reason: "per_page cannot be nil"}
 	}
 
 	if err := filter.Validate(); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	var cursor *domain.PageCursor
+	if filter.Cursor != nil {
+		decoded, err := domain.DecodePageCursor(*filter.Cursor, filter.OrderBy)
+		if err != nil {
+			return nil, nil, err
+		}
+		cursor = decoded
 	}
 
 	var gormPackets []gormmodel.GormEventPacket
 	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEventPacket{})
 
-	if filter.Name != nil {
-		query = query.Where("name LIKE ?", "%"+*filter.Name+"%")
+	query = applyEventPacketFilter(query, filter)
+	if filter.Query != nil {
+		column, args := searchColumns(`"eventsPacket"`, *filter.Query)
+		query = selectColumns(query, `"eventsPacket"`, []string{column}, args)
 	}
 
-	if filter.Location != nil {
-		query = query.Where("location LIKE ?", "%"+*filter.Location+"%")
-	}
+	order := eventPacketKeysetOrder(filter)
+	limit := *filter.PerPage
+	query = applyKeyset(query, order, cursor, limit)
 
-	if filter.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*filter.Description+"%")
+	offset := 0
+	if cursor == nil {
+		offset = (*filter.Page - 1) * limit
+		query = query.Offset(offset)
 	}
 
-	if filter.MinSeats != nil {
-		query = query.Where("allocated_seats >= ?", *filter.MinSeats)
+	if err := query.Find(&gormPackets).Error; err != nil {
+		return nil, nil, &domain.InternalError{Msg: "failed to filter event packets", Err: err}
 	}
 
-	if filter.MaxSeats != nil {
-		query = query.Where("allocated_seats <= ?", *filter.MaxSeats)
-	}
+	gormPackets, pageInfo := keysetPage(gormPackets, order, cursor, cursor != nil || offset > 0, limit,
+		func(packet gormmodel.GormEventPacket) (interface{}, int) {
+			switch order.Name {
+			case "name_asc", "name_desc":
+				return packet.Name, packet.ID
+			case "seats_asc", "seats_desc":
+				if packet.AllocatedSeats == nil {
+					return 0, packet.ID
+				}
+				return *packet.AllocatedSeats, packet.ID
+			case "relevance":
+				if packet.SearchRank == nil {
+					return 0, packet.ID
+				}
+				return *packet.SearchRank, packet.ID
+			}
+			return nil, packet.ID
+		})
 
-	var sortTranslationMap = map[string]string{
-		"name_asc":   "name asc",
-		"name_desc":  "name desc",
-		"seats_asc":  "allocated_seats asc",
-		"seats_desc": "allocated_seats desc",
+	domainPackets := make([]*domain.EventPacket, 0, len(gormPackets))
+	for _, gormPacket := range gormPackets {
+		domainPackets = append(domainPackets, gormPacket.ToDomain())
 	}
 
-	if filter.OrderBy != nil {
-		if sqlSortString, ok := sortTranslationMap[*filter.OrderBy]; ok {
-			query = query.Order(sqlSortString)
-		}
-	} else {
-		query = query.Order("id asc")
+	if err := attachEventPacketDetails(r.DB.WithContext(ctx), domainPackets); err != nil {
+		return nil, nil, err
 	}
 
-	limit := *filter.PerPage
-	page := *filter.Page
-	offset := (page - 1) * limit
-	query = query.Limit(limit).Offset(offset)
+	return domainPackets, pageInfo, nil
+}
 
-	if err := query.Find(&gormPackets).Error; err != nil {
-		return nil, &domain.InternalError{Msg: "failed to filter event packets", Err: err}
-	}
+var eventPacketOrderings = map[string]keysetOrder{
+	"name_asc":   {Name: "name_asc", Expr: "name"},
+	"name_desc":  {Name: "name_desc", Expr: "name", Desc: true},
+	"seats_asc":  {Name: "seats_asc", Expr: "COALESCE(allocated_seats, 0)", IntKey: true},
+	"seats_desc": {Name: "seats_desc", Expr: "COALESCE(allocated_seats, 0)", Desc: true, IntKey: true},
+}
 
-	domainPackets := make([]*domain.EventPacket, 0, len(gormPackets))
-	for _, gormPacket := range gormPackets {
-		domainPackets = append(domainPackets, gormPacket.ToDomain())
+func eventPacketKeysetOrder(filter *domain.EventPacketFilter) keysetOrder {
+	if filter.OrderBy == nil {
+		return keysetOrder{}
 	}
-
-	return domainPackets, nil
+	if *filter.OrderBy == "relevance" {
+		return keysetOrder{
+			Name: "relevance",
+			Expr: searchRankExpr(`"eventsPacket"`),
+			Args: []interface{}{strings.TrimSpace(*filter.Query)},
+			Desc: true,
+		}
+	}
+	return eventPacketOrderings[*filter.OrderBy]
 }
 
-func (r *GormEventPacketRepository) CountEventPackets(ctx context.Context, filter *domain.EventPacketFilter) (int, error) {
-	if filter == nil {
-		return 0, &domain.ValidationError{Reason: "filter cannot be nil"}
+func applyEventPacketFilter(query *gorm.DB, filter *domain.EventPacketFilter) *gorm.DB {
+	if filter.Query != nil {
+		query = applySearchMatch(query, `"eventsPacket"`, *filter.Query)
 	}
 
-	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEventPacket{})
-
 	if filter.Name != nil {
-		query = query.Where("name LIKE ?", "%"+*filter.Name+"%")
+		query = query.Where("name ILIKE ?", "%"+*filter.Name+"%")
 	}
 
 	if filter.Location != nil {
-		query = query.Where("location LIKE ?", "%"+*filter.Location+"%")
+		query = query.Where("location ILIKE ?", "%"+*filter.Location+"%")
 	}
 
 	if filter.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*filter.Description+"%")
+		query = query.Where("description ILIKE ?", "%"+*filter.Description+"%")
 	}
 
 	if filter.MinSeats != nil {
@@ -223,6 +295,18 @@ func (r *GormEventPacketRepository) CountEventPackets(ctx context.Context, filte
 		query = query.Where("allocated_seats <= ?", *filter.MaxSeats)
 	}
 
+	return query
+}
+
+func (r *GormEventPacketRepository) CountEventPackets(ctx context.Context, filter *domain.EventPacketFilter) (int, error) {
+	if filter == nil {
+		return 0, &domain.ValidationError{Reason: "filter cannot be nil"}
+	}
+
+	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEventPacket{})
+
+	query = applyEventPacketFilter(query, filter)
+
 	var count int64
 	if err := query.Count(&count).Error; err != nil {
 		return 0, &domain.InternalError{Msg: "failed to count event packets", Err: err}
@@ -230,3 +314,39 @@ func (r *GormEventPacketRepository) CountEventPackets(ctx context.Context, filte
 
 	return int(count), nil
 }
+
+const eventPacketAvailabilityQuery = `
+SELECT p.id AS packet_id, p.id_owner, p.name, p.allocated_seats,
+	(SELECT COUNT(*) FROM tickets t WHERE t.packet_id = p.id) AS sold,
+	(SELECT COUNT(*) FROM events_packet_inclusion i WHERE i.packet_id = p.id) AS event_count
+FROM "eventsPacket" p
+`
+
+func (r *GormEventPacketRepository) GetAvailability(ctx context.Context, packetID int) (*domain.EventPacketAvailability, error) {
+	var rows []gormmodel.GormEventPacketAvailability
+	err := r.DB.WithContext(ctx).Raw(eventPacketAvailabilityQuery+"WHERE p.id = ?", packetID).Scan(&rows).Error
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to compute packet availability", Err: err}
+	}
+
+	if len(rows) == 0 {
+		return nil, &domain.NotFoundError{ID: packetID}
+	}
+
+	return rows[0].ToDomain(), nil
+}
+
+func (r *GormEventPacketRepository) GetAvailabilityByOwner(ctx context.Context, ownerID int) ([]*domain.EventPacketAvailability, error) {
+	var rows []gormmodel.GormEventPacketAvailability
+	err := r.DB.WithContext(ctx).Raw(eventPacketAvailabilityQuery+"WHERE p.id_owner = ? ORDER BY p.id", ownerID).Scan(&rows).Error
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to compute owner packet availability", Err: err}
+	}
+
+	result := make([]*domain.EventPacketAvailability, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, row.ToDomain())
+	}
+
+	return result, nil
+}
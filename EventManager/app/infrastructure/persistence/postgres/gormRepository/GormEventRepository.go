@@ -5,7 +5,9 @@ import (
 	"errors"
 	"eventManager/application/domain"
 	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+	"maps"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -18,14 +20,20 @@ type GormEventRepository struct {
 func (r *GormEventRepository) Create(ctx context.Context, event *domain.Event) (*domain.Event, error) {
 	gormEvent := gormmodel.FromEvent(event)
 
-	if err := r.DB.WithContext(ctx).Create(gormEvent).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gormEvent).Error; err != nil {
 
-		if strings.Contains(err.Error(), "duplicate key") ||
-			strings.Contains(err.Error(), "23505") {
-			return nil, &domain.AlreadyExistsError{Name: gormEvent.Name}
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return &domain.AlreadyExistsError{Name: gormEvent.Name}
 
+			}
+			return &domain.InternalError{Msg: "failed to persist event", Err: err}
 		}
-		return nil, &domain.InternalError{Msg: "failed to persist event", Err: err}
+
+		return appendOutbox(tx, domain.AggregateEvent, aggregateID(gormEvent.ID), domain.EventCreated, domain.NewEventPayload(gormEvent.ToDomain()))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return gormEvent.ToDomain(), nil
@@ -44,28 +52,88 @@ func (r *GormEventRepository) GetByID(ctx context.Context, id int) (*domain.Even
 	}
 
 	retDomain := ret.ToDomain()
+	if err := attachEventTaxonomy(r.DB.WithContext(ctx), []*domain.Event{retDomain}); err != nil {
+		return nil, err
+	}
 
 	return retDomain, nil
 }
 
+// GetByIDs returns the events that exist among ids, in ascending id order.
+func (r *GormEventRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Event, error) {
+	var gormEvents []gormmodel.GormEvent
+	if err := r.DB.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&gormEvents).Error; err != nil {
+		return nil, &domain.InternalError{Msg: "could not load the events", Err: err}
+	}
+
+	events := make([]*domain.Event, 0, len(gormEvents))
+	for _, gormEvent := range gormEvents {
+		events = append(events, gormEvent.ToDomain())
+	}
+
+	if err := attachEventTaxonomy(r.DB.WithContext(ctx), events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Update applies the changes and counts them as a new version of the event.
 func (r *GormEventRepository) Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.Event, error) {
+	changes := maps.Clone(updates)
+	changes["sequence"] = gorm.Expr("sequence + 1")
 
-	result := r.DB.WithContext(ctx).Model(&gormmodel.GormEvent{}).Clauses(clause.Returning{}).
-		Where("id = ?", id).
-		Updates(updates)
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var updated gormmodel.GormEvent
+		result := tx.Model(&updated).Clauses(clause.Returning{}).
+			Where("id = ?", id).
+			Updates(changes)
 
-	if result.Error != nil {
+		if result.Error != nil {
+
+			if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
 
-		if strings.Contains(result.Error.Error(), "duplicate key") ||
-			strings.Contains(result.Error.Error(), "23505") {
+				return &domain.UniqueNameError{Msg: updates["name"].(string)}
+			}
+			return &domain.InternalError{Msg: "could not update the event", Err: result.Error}
+		}
 
-			return nil, &domain.UniqueNameError{Msg: updates["name"].(string)}
+		if result.RowsAffected == 0 {
+			return &domain.NotFoundError{ID: id}
 		}
-		return nil, &domain.InternalError{Msg: "could not update the event", Err: result.Error}
+
+		return appendOutbox(tx, domain.AggregateEvent, aggregateID(id), domain.EventUpdated, domain.NewEventPayload(updated.ToDomain()))
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if result.RowsAffected == 0 {
-		return nil, &domain.NotFoundError{ID: id}
+	return r.GetByID(ctx, id)
+}
+
+// Cancel calls the event off at the given time. Cancelling an event that is
+// already cancelled changes nothing.
+func (r *GormEventRepository) Cancel(ctx context.Context, id int, at time.Time) (*domain.Event, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cancelled gormmodel.GormEvent
+		result := tx.Model(&cancelled).Clauses(clause.Returning{}).
+			Where("id = ? AND cancelled_at IS NULL", id).
+			Updates(map[string]interface{}{
+				"cancelled_at": at,
+				"sequence":     gorm.Expr("sequence + 1"),
+			})
+		if result.Error != nil {
+			return &domain.InternalError{Msg: "could not cancel the event", Err: result.Error}
+		}
+		// a missing event is reported by the lookup below
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return appendOutbox(tx, domain.AggregateEvent, aggregateID(id), domain.EventCancelled, domain.NewEventPayload(cancelled.ToDomain()))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r.GetByID(ctx, id)
@@ -108,7 +176,7 @@ func (r *GormEventRepository) Delete(ctx context.Context, id int) (*domain.Event
 		}
 
 		retDomain = ret.ToDomain()
-		return nil
+		return appendOutbox(tx, domain.AggregateEvent, aggregateID(id), domain.EventDeleted, domain.NewEventPayload(retDomain))
 	})
 
 	if err != nil {
@@ -118,94 +186,152 @@ func (r *GormEventRepository) Delete(ctx context.Context, id int) (*domain.Event
 	return retDomain, nil
 }
 
-func (r *GormEventRepository) FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, error) {
+func (r *GormEventRepository) FilterEvents(ctx context.Context, filter *domain.EventFilter) ([]*domain.Event, *domain.PageInfo, error) {
 	if filter == nil {
-		return nil, &domain.ValidationError{Reason: "filter cannot be nil"}
+		return nil, nil, &domain.ValidationError{Reason: "filter cannot be nil"}
 	}
 	if filter.Page == nil {
-		return nil, &domain.ValidationError{Field: "page", Reason: "page cannot be nil"}
+		return nil, nil, &domain.ValidationError{Field: "page", Reason: "page cannot be nil"}
 	}
 	if filter.PerPage == nil {
-		return nil, &domain.ValidationError{Field: "per_page", Reason: "per_page cannot be nil"}
+		return nil, nil, &domain.ValidationError{Field: "per_page", Reason: "per_page cannot be nil"}
 	}
 
 	if err := filter.Validate(); err != nil {
-		return nil, err
-	}
-
-	var gormEvents []gormmodel.GormEvent
-	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEvent{})
-
-	if filter.Name != nil {
-		query = query.Where("name LIKE ?", "%"+*filter.Name+"%")
+		return nil, nil, err
 	}
 
-	if filter.Location != nil {
-		query = query.Where("location LIKE ?", "%"+*filter.Location+"%")
-	}
-
-	if filter.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*filter.Description+"%")
+	var cursor *domain.PageCursor
+	if filter.Cursor != nil {
+		decoded, err := domain.DecodePageCursor(*filter.Cursor, filter.OrderBy)
+		if err != nil {
+			return nil, nil, err
+		}
+		cursor = decoded
 	}
 
-	if filter.MinSeats != nil {
-		query = query.Where("seats >= ?", *filter.MinSeats)
-	}
+	var gormEvents []gormmodel.GormEvent
+	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEvent{})
 
-	if filter.MaxSeats != nil {
-		query = query.Where("seats <= ?", *filter.MaxSeats)
-	}
+	query = applyEventFilter(query, filter)
 
-	var sortTranslationMap = map[string]string{
-		"name_asc":   "name asc",
-		"name_desc":  "name desc",
-		"seats_asc":  "seats asc",
-		"seats_desc": "seats desc",
+	var columns []string
+	var columnArgs []interface{}
+	if filter.Query != nil {
+		column, args := searchColumns("events", *filter.Query)
+		columns, columnArgs = append(columns, column), append(columnArgs, args...)
 	}
-
-	if filter.OrderBy != nil {
-		if sqlSortString, ok := sortTranslationMap[*filter.OrderBy]; ok {
-			query = query.Order(sqlSortString)
-		}
-	} else {
-		query = query.Order("id asc")
+	if point, _ := filter.NearPoint(); point != nil {
+		column, args := distanceColumn("events", point)
+		columns, columnArgs = append(columns, column), append(columnArgs, args...)
 	}
+	query = selectColumns(query, "events", columns, columnArgs)
 
+	order := eventKeysetOrder(filter)
 	limit := *filter.PerPage
-	page := *filter.Page
-	offset := (page - 1) * limit
-	query = query.Limit(limit).Offset(offset)
+	query = applyKeyset(query, order, cursor, limit)
+
+	// page is still honoured for old clients; the links handed out afterwards
+	// are keyset cursors
+	offset := 0
+	if cursor == nil {
+		offset = (*filter.Page - 1) * limit
+		query = query.Offset(offset)
+	}
 
 	if err := query.Find(&gormEvents).Error; err != nil {
-		return nil, &domain.InternalError{Msg: "failed to filter events", Err: err}
+		return nil, nil, &domain.InternalError{Msg: "failed to filter events", Err: err}
 	}
 
+	gormEvents, pageInfo := keysetPage(gormEvents, order, cursor, cursor != nil || offset > 0, limit,
+		func(event gormmodel.GormEvent) (interface{}, int) {
+			switch order.Name {
+			case "name_asc", "name_desc":
+				return event.Name, event.ID
+			case "seats_asc", "seats_desc":
+				if event.Seats == nil {
+					return 0, event.ID
+				}
+				return *event.Seats, event.ID
+			case "relevance":
+				if event.SearchRank == nil {
+					return 0, event.ID
+				}
+				return *event.SearchRank, event.ID
+			case "distance":
+				if event.DistanceKm == nil {
+					return 0, event.ID
+				}
+				return *event.DistanceKm, event.ID
+			}
+			return nil, event.ID
+		})
+
 	domainEvents := make([]*domain.Event, 0, len(gormEvents))
 	for _, gormEvent := range gormEvents {
 		domainEvents = append(domainEvents, gormEvent.ToDomain())
 	}
 
-	return domainEvents, nil
+	if err := attachEventTaxonomy(r.DB.WithContext(ctx), domainEvents); err != nil {
+		return nil, nil, err
+	}
+
+	return domainEvents, pageInfo, nil
 
 }
 
-func (r *GormEventRepository) CountEvents(ctx context.Context, filter *domain.EventFilter) (int, error) {
-	if filter == nil {
-		return 0, &domain.ValidationError{Reason: "filter cannot be nil"}
+var eventOrderings = map[string]keysetOrder{
+	"name_asc":   {Name: "name_asc", Expr: "name"},
+	"name_desc":  {Name: "name_desc", Expr: "name", Desc: true},
+	"seats_asc":  {Name: "seats_asc", Expr: "COALESCE(seats, 0)", IntKey: true},
+	"seats_desc": {Name: "seats_desc", Expr: "COALESCE(seats, 0)", Desc: true, IntKey: true},
+}
+
+func eventKeysetOrder(filter *domain.EventFilter) keysetOrder {
+	if filter.OrderBy == nil {
+		return keysetOrder{}
 	}
+	if *filter.OrderBy == "relevance" {
+		return keysetOrder{
+			Name: "relevance",
+			Expr: searchRankExpr("events"),
+			Args: []interface{}{strings.TrimSpace(*filter.Query)},
+			Desc: true,
+		}
+	}
+	if *filter.OrderBy == "distance" {
+		point, _ := filter.NearPoint()
+		if point != nil {
+			return keysetOrder{
+				Name: "distance",
+				Expr: haversineExpr("events"),
+				Args: haversineArgs(point),
+			}
+		}
+	}
+	return eventOrderings[*filter.OrderBy]
+}
 
-	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEvent{})
+func applyEventFilter(query *gorm.DB, filter *domain.EventFilter) *gorm.DB {
+	if filter.Query != nil {
+		query = applySearchMatch(query, "events", *filter.Query)
+	}
+
+	// the filter is validated before counting and listing
+	if point, _ := filter.NearPoint(); point != nil && filter.RadiusKm != nil {
+		query = applyNear(query, "events", point, *filter.RadiusKm)
+	}
 
 	if filter.Name != nil {
-		query = query.Where("name LIKE ?", "%"+*filter.Name+"%")
+		query = query.Where("name ILIKE ?", "%"+*filter.Name+"%")
 	}
 
 	if filter.Location != nil {
-		query = query.Where("location LIKE ?", "%"+*filter.Location+"%")
+		query = query.Where("location ILIKE ?", "%"+*filter.Location+"%")
 	}
 
 	if filter.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*filter.Description+"%")
+		query = query.Where("description ILIKE ?", "%"+*filter.Description+"%")
 	}
 
 	if filter.MinSeats != nil {
@@ -216,6 +342,48 @@ func (r *GormEventRepository) CountEvents(ctx context.Context, filter *domain.Ev
 		query = query.Where("seats <= ?", *filter.MaxSeats)
 	}
 
+	if filter.Category != nil {
+		query = query.Where(`events.id IN (SELECT ec.event_id FROM event_categories ec
+			JOIN categories c ON c.id = ec.category_id WHERE c.slug = ?)`, *filter.Category)
+	}
+
+	// every requested tag must be present
+	if len(filter.Tags) > 0 {
+		query = query.Where(`events.id IN (SELECT et.event_id FROM event_tags et
+			JOIN tags t ON t.id = et.tag_id WHERE t.name IN ?
+			GROUP BY et.event_id HAVING COUNT(*) = ?)`, filter.Tags, len(filter.Tags))
+	}
+
+	return query
+}
+
+// GetFacets counts the events matching filter per category and per tag. The
+// category counts ignore the category filter itself so the other categories
+// stay selectable.
+func (r *GormEventRepository) GetFacets(ctx context.Context, filter *domain.EventFilter) (*domain.EventFacets, error) {
+	if filter == nil {
+		return nil, &domain.ValidationError{Reason: "filter cannot be nil"}
+	}
+
+	withoutCategory := *filter
+	withoutCategory.Category = nil
+
+	db := r.DB.WithContext(ctx)
+	categoryMatching := applyEventFilter(db.Model(&gormmodel.GormEvent{}).Select("events.id"), &withoutCategory)
+	tagMatching := applyEventFilter(db.Model(&gormmodel.GormEvent{}).Select("events.id"), filter)
+
+	return facetCounts(db, eventTaxonomy, categoryMatching, tagMatching)
+}
+
+func (r *GormEventRepository) CountEvents(ctx context.Context, filter *domain.EventFilter) (int, error) {
+	if filter == nil {
+		return 0, &domain.ValidationError{Reason: "filter cannot be nil"}
+	}
+
+	query := r.DB.WithContext(ctx).Model(&gormmodel.GormEvent{})
+
+	query = applyEventFilter(query, filter)
+
 	var count int64
 	if err := query.Count(&count).Error; err != nil {
 		return 0, &domain.InternalError{Msg: "failed to count events", Err: err}
@@ -232,3 +400,44 @@ func (r *GormEventRepository) CountSoldTickets(ctx context.Context, eventID int)
 	}
 	return int(count), nil
 }
+
+const eventAvailabilityQuery = `
+SELECT e.id AS event_id, e.id_owner, e.name, e.seats AS total_seats,
+	(SELECT COUNT(*) FROM tickets t WHERE t.event_id = e.id) AS direct_sold,
+	(SELECT COALESCE(SUM(p.allocated_seats), 0)
+		FROM events_packet_inclusion i JOIN "eventsPacket" p ON p.id = i.packet_id
+		WHERE i.event_id = e.id) AS packet_reserved,
+	(SELECT COUNT(*)
+		FROM tickets t JOIN events_packet_inclusion i ON i.packet_id = t.packet_id
+		WHERE i.event_id = e.id) AS packet_sold
+FROM events e
+`
+
+func (r *GormEventRepository) GetAvailability(ctx context.Context, eventID int) (*domain.EventAvailability, error) {
+	var rows []gormmodel.GormEventAvailability
+	err := r.DB.WithContext(ctx).Raw(eventAvailabilityQuery+"WHERE e.id = ?", eventID).Scan(&rows).Error
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to compute event availability", Err: err}
+	}
+
+	if len(rows) == 0 {
+		return nil, &domain.NotFoundError{ID: eventID}
+	}
+
+	return rows[0].ToDomain(), nil
+}
+
+func (r *GormEventRepository) GetAvailabilityByOwner(ctx context.Context, ownerID int) ([]*domain.EventAvailability, error) {
+	var rows []gormmodel.GormEventAvailability
+	err := r.DB.WithContext(ctx).Raw(eventAvailabilityQuery+"WHERE e.id_owner = ? ORDER BY e.id", ownerID).Scan(&rows).Error
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to compute owner availability", Err: err}
+	}
+
+	result := make([]*domain.EventAvailability, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, row.ToDomain())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package gormrepository
+
+import (
+	"context"
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"shared/idempotency"
+)
+
+type GormIdempotencyRepository struct {
+	DB *gorm.DB
+}
+
+// Reserve first drops the key if it expired or its request was abandoned, so
+// a stale row never blocks a retry.
+func (r *GormIdempotencyRepository) Reserve(ctx context.Context, record *idempotency.Record) (*idempotency.Record, bool, error) {
+	var existing *idempotency.Record
+	reserved := false
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Where("scope = ? AND idempotency_key = ?", record.Scope, record.Key).
+			Where("expires_at <= ? OR (status_code = 0 AND created_at <= ?)", now, now.Add(-idempotency.LockTimeout)).
+			Delete(&gormmodel.GormIdempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(gormmodel.FromIdempotencyRecord(record))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			reserved = true
+			return nil
+		}
+
+		var gormKey gormmodel.GormIdempotencyKey
+		if err := tx.Where("scope = ? AND idempotency_key = ?", record.Scope, record.Key).Take(&gormKey).Error; err != nil {
+			return err
+		}
+		existing = gormKey.ToDomain()
+		return nil
+	})
+	if err != nil {
+		return nil, false, &domain.InternalError{Msg: "failed to reserve idempotency key", Err: err}
+	}
+
+	return existing, reserved, nil
+}
+
+func (r *GormIdempotencyRepository) Complete(ctx context.Context, record *idempotency.Record) error {
+	err := r.DB.WithContext(ctx).
+		Model(&gormmodel.GormIdempotencyKey{}).
+		Where("scope = ? AND idempotency_key = ?", record.Scope, record.Key).
+		Updates(map[string]interface{}{
+			"status_code":   record.StatusCode,
+			"content_type":  record.ContentType,
+			"response_body": record.ResponseBody,
+			"expires_at":    record.ExpiresAt,
+		}).Error
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to store idempotent response", Err: err}
+	}
+	return nil
+}
+
+func (r *GormIdempotencyRepository) Release(ctx context.Context, scope string, key string) error {
+	err := r.DB.WithContext(ctx).
+		Where("scope = ? AND idempotency_key = ?", scope, key).
+		Delete(&gormmodel.GormIdempotencyKey{}).Error
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to release idempotency key", Err: err}
+	}
+	return nil
+}
+
+func (r *GormIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).Where("expires_at <= ?", now).Delete(&gormmodel.GormIdempotencyKey{})
+	if result.Error != nil {
+		return 0, &domain.InternalError{Msg: "failed to delete expired idempotency keys", Err: result.Error}
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,119 @@
+package gormrepository
+
+import (
+	"context"
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// pendingOutboxQuery selects the oldest unpublished message of every
+// aggregate that is due. Rows locked by another relay are skipped, and since
+// their successors are not first in line either, a second relay can never
+// overtake a message of the same aggregate.
+const pendingOutboxQuery = `
+SELECT m.* FROM outbox_messages m
+WHERE m.published_at IS NULL
+  AND m.next_attempt_at <= ?
+  AND NOT EXISTS (
+    SELECT 1 FROM outbox_messages p
+    WHERE p.aggregate_type = m.aggregate_type
+      AND p.aggregate_id = m.aggregate_id
+      AND p.published_at IS NULL
+      AND p.id < m.id
+  )
+ORDER BY m.id
+LIMIT ?
+FOR UPDATE SKIP LOCKED`
+
+type GormOutboxRepository struct {
+	DB *gorm.DB
+}
+
+func (r *GormOutboxRepository) ProcessPending(ctx context.Context, limit int, publish func(ctx context.Context, message *domain.OutboxMessage) error) (int, error) {
+	messages, err := r.claimPending(ctx, limit)
+	if err != nil || len(messages) == 0 {
+		return 0, err
+	}
+
+	// the broker is called without any row locks held, so a slow broker
+	// never keeps a transaction open
+	published := 0
+	updates := make([]map[string]interface{}, len(messages))
+	for i := range messages {
+		message := &messages[i]
+		now := time.Now().UTC()
+
+		updates[i] = map[string]interface{}{"attempts": message.Attempts + 1}
+		if err := publish(ctx, message.ToDomain()); err != nil {
+			updates[i]["last_error"] = err.Error()
+			updates[i]["next_attempt_at"] = now.Add(domain.OutboxRetryDelay(message.Attempts + 1))
+		} else {
+			updates[i]["last_error"] = nil
+			updates[i]["published_at"] = now
+			published++
+		}
+	}
+
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range messages {
+			if err := tx.Model(&gormmodel.GormOutboxMessage{}).
+				Where("id = ? AND published_at IS NULL", messages[i].ID).
+				Updates(updates[i]).Error; err != nil {
+				return &domain.InternalError{Msg: "failed to update domain event", Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+// claimPending locks the due messages only long enough to push their
+// next_attempt_at past OutboxClaimTimeout, which hides them from other relays
+// while they are published. Their successors stay out of line because the
+// claimed messages are still unpublished.
+func (r *GormOutboxRepository) claimPending(ctx context.Context, limit int) ([]gormmodel.GormOutboxMessage, error) {
+	var messages []gormmodel.GormOutboxMessage
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		if err := tx.Raw(pendingOutboxQuery, now, limit).Scan(&messages).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to load pending domain events", Err: err}
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(messages))
+		for i := range messages {
+			ids[i] = messages[i].ID
+		}
+		if err := tx.Model(&gormmodel.GormOutboxMessage{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(domain.OutboxClaimTimeout)).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to claim pending domain events", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *GormOutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).
+		Where("published_at IS NOT NULL AND published_at < ?", before).
+		Delete(&gormmodel.GormOutboxMessage{})
+	if result.Error != nil {
+		return 0, &domain.InternalError{Msg: "failed to purge published domain events", Err: result.Error}
+	}
+	return result.RowsAffected, nil
+}
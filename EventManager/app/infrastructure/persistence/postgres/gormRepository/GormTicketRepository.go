@@ -17,12 +17,19 @@ type GormTicketRepository struct {
 func (r *GormTicketRepository) CreateTicket(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
 	gormTicket := gormmodel.FromTicket(ticket)
 
-	if err := r.DB.WithContext(ctx).Create(gormTicket).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gormTicket).Error; err != nil {
 
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return nil, &domain.AlreadyExistsError{Name: gormTicket.Code}
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return &domain.AlreadyExistsError{Name: gormTicket.Code}
+			}
+			return &domain.InternalError{Msg: "failed to create ticket", Err: err}
 		}
-		return nil, &domain.InternalError{Msg: "failed to create ticket", Err: err}
+
+		return appendTicketOutbox(tx, gormTicket, domain.TicketSold)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return gormTicket.ToDomain(), nil
@@ -42,17 +49,25 @@ func (r *GormTicketRepository) GetTicketByCode(ctx context.Context, code string)
 }
 
 func (r *GormTicketRepository) UpdateTicket(ctx context.Context, code string, updates map[string]interface{}) (*domain.Ticket, error) {
-	result := r.DB.WithContext(ctx).Model(&gormmodel.GormTicket{}).
-		Clauses(clause.Returning{}).
-		Where("code = ?", code).
-		Updates(updates)
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var updated gormmodel.GormTicket
+		result := tx.Model(&updated).
+			Clauses(clause.Returning{}).
+			Where("code = ?", code).
+			Updates(updates)
+
+		if result.Error != nil {
+			return &domain.InternalError{Msg: "could not update the ticket", Err: result.Error}
+		}
 
-	if result.Error != nil {
-		return nil, &domain.InternalError{Msg: "could not update the ticket", Err: result.Error}
-	}
+		if result.RowsAffected == 0 {
+			return &domain.NotFoundError{ID: 0}
+		}
 
-	if result.RowsAffected == 0 {
-		return nil, &domain.NotFoundError{ID: 0}
+		return appendTicketOutbox(tx, &updated, domain.TicketUpdated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r.GetTicketByCode(ctx, code)
@@ -61,13 +76,68 @@ func (r *GormTicketRepository) UpdateTicket(ctx context.Context, code string, up
 func (r *GormTicketRepository) ReplaceTicket(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
 	gormTicket := gormmodel.FromTicket(ticket)
 
-	if err := r.DB.WithContext(ctx).Save(gormTicket).Error; err != nil {
-		return nil, &domain.InternalError{Msg: "failed to replace ticket", Err: err}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("id").Save(gormTicket).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to replace ticket", Err: err}
+		}
+		// the domain ticket carries no ID, so read it back for the outbox key
+		if err := tx.Model(&gormmodel.GormTicket{}).Where("code = ?", gormTicket.Code).Select("id").Scan(&gormTicket.ID).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to replace ticket", Err: err}
+		}
+
+		return appendTicketOutbox(tx, gormTicket, domain.TicketUpdated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return gormTicket.ToDomain(), nil
 }
 
+// RotateCode gives the ticket a new code; the old one stops resolving. A
+// retried rotation whose first attempt already committed returns the ticket
+// under its new code.
+func (r *GormTicketRepository) RotateCode(ctx context.Context, code string, newCode string) (*domain.Ticket, error) {
+	var ret gormmodel.GormTicket
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&ret)
+
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			rotated := tx.Where("code = ?", newCode).First(&ret)
+			if errors.Is(rotated.Error, gorm.ErrRecordNotFound) {
+				return &domain.NotFoundError{ID: 0}
+			} else if rotated.Error != nil {
+				return &domain.InternalError{Msg: "could not find the ticket", Err: rotated.Error}
+			}
+			return nil
+		} else if result.Error != nil {
+			return &domain.InternalError{Msg: "could not find the ticket", Err: result.Error}
+		}
+
+		update := tx.Model(&gormmodel.GormTicket{}).Where("code = ?", code).Update("code", newCode)
+		if update.Error != nil {
+			if errors.Is(update.Error, gorm.ErrDuplicatedKey) {
+				return &domain.AlreadyExistsError{Name: newCode}
+			}
+			return &domain.InternalError{Msg: "could not rotate the ticket code", Err: update.Error}
+		}
+		ret.Code = newCode
+
+		payload, err := ticketPayload(tx, &ret)
+		if err != nil {
+			return err
+		}
+		payload.PreviousCode = &code
+		return appendOutbox(tx, domain.AggregateTicket, aggregateID(ret.ID), domain.TicketUpdated, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret.ToDomain(), nil
+}
+
 func (r *GormTicketRepository) DeleteEvent(ctx context.Context, code string) (*domain.Ticket, error) {
 	var ret gormmodel.GormTicket
 	var retDomain *domain.Ticket
@@ -88,7 +158,7 @@ func (r *GormTicketRepository) DeleteEvent(ctx context.Context, code string) (*d
 		}
 
 		retDomain = ret.ToDomain()
-		return nil
+		return appendTicketOutbox(tx, &ret, domain.TicketCancelled)
 	})
 
 	if err != nil {
@@ -0,0 +1,282 @@
+package gormrepository
+
+import (
+	"context"
+	"errors"
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const dueWebhookDeliveriesQuery = `
+SELECT d.* FROM webhook_deliveries d
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.status = ?
+  AND d.next_attempt_at <= ?
+  AND s.active
+ORDER BY d.id
+LIMIT ?
+FOR UPDATE OF d SKIP LOCKED`
+
+type GormWebhookRepository struct {
+	DB *gorm.DB
+}
+
+func (r *GormWebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	gormSubscription := gormmodel.FromWebhookSubscription(subscription)
+
+	if err := r.DB.WithContext(ctx).Create(gormSubscription).Error; err != nil {
+		return nil, &domain.InternalError{Msg: "failed to create webhook subscription", Err: err}
+	}
+
+	return gormSubscription.ToDomain(), nil
+}
+
+func (r *GormWebhookRepository) GetSubscriptionByID(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	var ret gormmodel.GormWebhookSubscription
+	result := r.DB.WithContext(ctx).Where("id = ?", id).First(&ret)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, &domain.NotFoundError{ID: id}
+	} else if result.Error != nil {
+		return nil, &domain.InternalError{Msg: "could not find the webhook subscription", Err: result.Error}
+	}
+
+	return ret.ToDomain(), nil
+}
+
+func (r *GormWebhookRepository) GetSubscriptionsByOwner(ctx context.Context, ownerID int) ([]*domain.WebhookSubscription, error) {
+	return r.findSubscriptions(r.DB.WithContext(ctx).Where("id_owner = ?", ownerID))
+}
+
+func (r *GormWebhookRepository) GetActiveSubscriptionsByOwner(ctx context.Context, ownerID int) ([]*domain.WebhookSubscription, error) {
+	return r.findSubscriptions(r.DB.WithContext(ctx).Where("id_owner = ? AND active", ownerID))
+}
+
+func (r *GormWebhookRepository) findSubscriptions(query *gorm.DB) ([]*domain.WebhookSubscription, error) {
+	var gormSubscriptions []gormmodel.GormWebhookSubscription
+	if err := query.Order("id").Find(&gormSubscriptions).Error; err != nil {
+		return nil, &domain.InternalError{Msg: "failed to get webhook subscriptions", Err: err}
+	}
+
+	subscriptions := make([]*domain.WebhookSubscription, 0, len(gormSubscriptions))
+	for i := range gormSubscriptions {
+		subscriptions = append(subscriptions, gormSubscriptions[i].ToDomain())
+	}
+	return subscriptions, nil
+}
+
+func (r *GormWebhookRepository) SaveSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	gormSubscription := gormmodel.FromWebhookSubscription(subscription)
+
+	result := r.DB.WithContext(ctx).Model(gormSubscription).
+		Select("url", "secret", "event_types", "event_ids", "packet_ids", "active", "updated_at").
+		Updates(gormSubscription)
+	if result.Error != nil {
+		return nil, &domain.InternalError{Msg: "could not update the webhook subscription", Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return nil, &domain.NotFoundError{ID: subscription.ID}
+	}
+
+	return r.GetSubscriptionByID(ctx, subscription.ID)
+}
+
+func (r *GormWebhookRepository) DeleteSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	var ret gormmodel.GormWebhookSubscription
+	result := r.DB.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).Delete(&ret)
+
+	if result.Error != nil {
+		return nil, &domain.InternalError{Msg: "could not delete the webhook subscription", Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return nil, &domain.NotFoundError{ID: id}
+	}
+
+	return ret.ToDomain(), nil
+}
+
+func (r *GormWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	gormDeliveries := make([]*gormmodel.GormWebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		gormDeliveries = append(gormDeliveries, gormmodel.FromWebhookDelivery(delivery))
+	}
+
+	err := r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "message_id"}},
+			DoNothing: true,
+		}).
+		Create(&gormDeliveries).Error
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to enqueue webhook deliveries", Err: err}
+	}
+	return nil
+}
+
+func (r *GormWebhookRepository) GetDeliveries(ctx context.Context, subscriptionID int, filter *domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int, error) {
+	query := r.DB.WithContext(ctx).Model(&gormmodel.GormWebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, &domain.InternalError{Msg: "failed to count webhook deliveries", Err: err}
+	}
+
+	var gormDeliveries []gormmodel.GormWebhookDelivery
+	err := query.Order("id DESC").
+		Offset((*filter.Page - 1) * *filter.PerPage).
+		Limit(*filter.PerPage).
+		Find(&gormDeliveries).Error
+	if err != nil {
+		return nil, 0, &domain.InternalError{Msg: "failed to get webhook deliveries", Err: err}
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(gormDeliveries))
+	for i := range gormDeliveries {
+		deliveries = append(deliveries, gormDeliveries[i].ToDomain())
+	}
+	return deliveries, int(total), nil
+}
+
+func (r *GormWebhookRepository) GetDelivery(ctx context.Context, subscriptionID int, deliveryID int64) (*domain.WebhookDelivery, error) {
+	var ret gormmodel.GormWebhookDelivery
+	result := r.DB.WithContext(ctx).Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).First(&ret)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, &domain.NotFoundError{ID: int(deliveryID)}
+	} else if result.Error != nil {
+		return nil, &domain.InternalError{Msg: "could not find the webhook delivery", Err: result.Error}
+	}
+
+	return ret.ToDomain(), nil
+}
+
+func (r *GormWebhookRepository) ResetDelivery(ctx context.Context, subscriptionID int, deliveryID int64) (*domain.WebhookDelivery, error) {
+	var ret gormmodel.GormWebhookDelivery
+	result := r.DB.WithContext(ctx).Model(&ret).Clauses(clause.Returning{}).
+		Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).
+		Updates(map[string]interface{}{
+			"status":          domain.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now().UTC(),
+		})
+
+	if result.Error != nil {
+		return nil, &domain.InternalError{Msg: "could not reset the webhook delivery", Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return nil, &domain.NotFoundError{ID: int(deliveryID)}
+	}
+
+	return ret.ToDomain(), nil
+}
+
+func (r *GormWebhookRepository) ProcessDue(ctx context.Context, limit int, send func(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error)) (int, error) {
+	deliveries, subscriptions, claimedUntil, err := r.claimDue(ctx, limit)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	// receivers are called without any row locks held, so slow endpoints
+	// never keep a transaction open
+	updates := make([]map[string]interface{}, len(deliveries))
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		attempts := delivery.Attempts + 1
+
+		statusCode, sendErr := send(ctx, subscriptions[delivery.SubscriptionID], delivery.ToDomain())
+		now := time.Now().UTC()
+
+		updates[i] = map[string]interface{}{"attempts": attempts, "last_status_code": nil}
+		if statusCode != 0 {
+			updates[i]["last_status_code"] = statusCode
+		}
+
+		switch {
+		case sendErr == nil:
+			updates[i]["status"] = domain.WebhookDeliveryDelivered
+			updates[i]["delivered_at"] = now
+			updates[i]["last_error"] = nil
+		case attempts >= domain.MaxWebhookAttempts:
+			updates[i]["status"] = domain.WebhookDeliveryDeadLetter
+			updates[i]["last_error"] = sendErr.Error()
+		default:
+			updates[i]["next_attempt_at"] = now.Add(domain.WebhookRetryDelay(attempts))
+			updates[i]["last_error"] = sendErr.Error()
+		}
+	}
+
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range deliveries {
+			// a delivery redelivered by hand or taken over by another
+			// dispatcher in the meantime no longer carries the claim
+			if err := tx.Model(&gormmodel.GormWebhookDelivery{}).
+				Where("id = ? AND status = ? AND next_attempt_at = ?", deliveries[i].ID, domain.WebhookDeliveryPending, claimedUntil).
+				Updates(updates[i]).Error; err != nil {
+				return &domain.InternalError{Msg: "failed to update webhook delivery", Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(deliveries), nil
+}
+
+// claimDue locks the due deliveries only long enough to push their
+// next_attempt_at to the returned claim deadline, which hides them from other
+// dispatchers while they are sent, and loads their subscriptions.
+func (r *GormWebhookRepository) claimDue(ctx context.Context, limit int) ([]gormmodel.GormWebhookDelivery, map[int]*domain.WebhookSubscription, time.Time, error) {
+	var deliveries []gormmodel.GormWebhookDelivery
+	subscriptions := make(map[int]*domain.WebhookSubscription)
+	now := time.Now().UTC()
+	// Postgres keeps microseconds, and the deadline is compared again later
+	claimedUntil := now.Add(domain.WebhookClaimTimeout).Truncate(time.Microsecond)
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(dueWebhookDeliveriesQuery, domain.WebhookDeliveryPending, now, limit).Scan(&deliveries).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to load due webhook deliveries", Err: err}
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(deliveries))
+		subscriptionIDs := make([]int, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+			subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID)
+		}
+		if err := tx.Model(&gormmodel.GormWebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", claimedUntil).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to claim due webhook deliveries", Err: err}
+		}
+
+		var gormSubscriptions []gormmodel.GormWebhookSubscription
+		if err := tx.Where("id IN ?", subscriptionIDs).Find(&gormSubscriptions).Error; err != nil {
+			return &domain.InternalError{Msg: "failed to load webhook subscriptions", Err: err}
+		}
+		for i := range gormSubscriptions {
+			subscriptions[gormSubscriptions[i].ID] = gormSubscriptions[i].ToDomain()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	return deliveries, subscriptions, claimedUntil, nil
+}
@@ -0,0 +1,44 @@
+package gormrepository
+
+import (
+	"eventManager/application/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// haversineExpr is the great-circle distance in km between the row and a
+// point bound as (lat, lat, lng).
+func haversineExpr(table string) string {
+	return fmt.Sprintf(`(%[2]g * 2 * ASIN(LEAST(1, SQRT(
+		POWER(SIN(RADIANS(%[1]s.latitude - ?) / 2), 2) +
+		COS(RADIANS(?)) * COS(RADIANS(%[1]s.latitude)) *
+		POWER(SIN(RADIANS(%[1]s.longitude - ?) / 2), 2)))))`, table, domain.EarthRadiusKm)
+}
+
+func haversineArgs(point *domain.GeoPoint) []interface{} {
+	return []interface{}{point.Latitude, point.Latitude, point.Longitude}
+}
+
+// applyNear keeps the rows within radiusKm of point. The bounding box lets
+// the lat/lng index discard most rows before the haversine is evaluated.
+func applyNear(query *gorm.DB, table string, point *domain.GeoPoint, radiusKm float64) *gorm.DB {
+	box := point.BoundingBox(radiusKm)
+
+	query = query.Where(fmt.Sprintf("%[1]s.latitude BETWEEN ? AND ? AND %[1]s.longitude IS NOT NULL", table), box.MinLat, box.MaxLat)
+
+	switch {
+	case box.AllLongitudes:
+	case box.WrapsLongitude:
+		query = query.Where(fmt.Sprintf("(%[1]s.longitude >= ? OR %[1]s.longitude <= ?)", table), box.MinLng, box.MaxLng)
+	default:
+		query = query.Where(fmt.Sprintf("%s.longitude BETWEEN ? AND ?", table), box.MinLng, box.MaxLng)
+	}
+
+	args := append(haversineArgs(point), radiusKm)
+	return query.Where(haversineExpr(table)+" <= ?", args...)
+}
+
+func distanceColumn(table string, point *domain.GeoPoint) (string, []interface{}) {
+	return haversineExpr(table) + " AS distance_km", haversineArgs(point)
+}
@@ -0,0 +1,66 @@
+package gormrepository
+
+import (
+	"encoding/json"
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// appendOutbox records a domain event in tx, so it is only published if the
+// write it describes commits.
+func appendOutbox(tx *gorm.DB, aggregateType string, aggregateID string, eventType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to encode domain event", Err: err}
+	}
+
+	now := time.Now().UTC()
+	message := &gormmodel.GormOutboxMessage{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       body,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+	if err := tx.Create(message).Error; err != nil {
+		return &domain.InternalError{Msg: "failed to record domain event", Err: err}
+	}
+	return nil
+}
+
+// ticketPayload resolves the owner of whatever the ticket was sold for.
+func ticketPayload(tx *gorm.DB, ticket *gormmodel.GormTicket) (*domain.TicketPayload, error) {
+	payload := &domain.TicketPayload{
+		Code:     ticket.Code,
+		EventID:  ticket.EventID,
+		PacketID: ticket.PacketID,
+	}
+
+	var err error
+	if ticket.EventID != nil {
+		err = tx.Model(&gormmodel.GormEvent{}).Where("id = ?", *ticket.EventID).Select("id_owner").Scan(&payload.OwnerID).Error
+	} else if ticket.PacketID != nil {
+		err = tx.Model(&gormmodel.GormEventPacket{}).Where("id = ?", *ticket.PacketID).Select("id_owner").Scan(&payload.OwnerID).Error
+	}
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to resolve ticket owner", Err: err}
+	}
+	return payload, nil
+}
+
+func aggregateID(id int) string {
+	return strconv.Itoa(id)
+}
+
+func appendTicketOutbox(tx *gorm.DB, ticket *gormmodel.GormTicket, eventType string) error {
+	payload, err := ticketPayload(tx, ticket)
+	if err != nil {
+		return err
+	}
+	return appendOutbox(tx, domain.AggregateTicket, aggregateID(ticket.ID), eventType, payload)
+}
@@ -0,0 +1,105 @@
+package gormrepository
+
+import (
+	"eventManager/application/domain"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// keysetOrder is the sort key of a listing. Ties are broken by id in the same
+// direction, so (key, id) is unique and can be compared as a row value.
+type keysetOrder struct {
+	Name string
+	Expr string
+	Args []interface{}
+	Desc bool
+
+	// cursor keys come back from JSON as float64
+	IntKey bool
+}
+
+// applyKeyset positions query after the cursor and fetches one extra row to
+// detect whether another page exists.
+func applyKeyset(query *gorm.DB, order keysetOrder, cursor *domain.PageCursor, limit int) *gorm.DB {
+	desc := order.Desc
+	if cursor != nil && cursor.Backward {
+		desc = !desc
+	}
+
+	op, dir := ">", "asc"
+	if desc {
+		op, dir = "<", "desc"
+	}
+
+	if cursor != nil {
+		if order.Expr == "" {
+			query = query.Where(fmt.Sprintf("id %s ?", op), cursor.ID)
+		} else {
+			key := cursor.Key
+			if f, ok := key.(float64); ok && order.IntKey {
+				key = int(f)
+			}
+			args := append(append([]interface{}{}, order.Args...), key, cursor.ID)
+			query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", order.Expr, op), args...)
+		}
+	}
+
+	if order.Expr == "" {
+		query = query.Order("id " + dir)
+	} else {
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                fmt.Sprintf("%s %s, id %s", order.Expr, dir, dir),
+			Vars:               order.Args,
+			WithoutParentheses: true,
+		}})
+	}
+
+	return query.Limit(limit + 1)
+}
+
+// keysetPage trims the extra row fetched by applyKeyset, restores the display
+// order of backward pages and builds the cursors of the neighbouring pages.
+func keysetPage[T any](rows []T, order keysetOrder, cursor *domain.PageCursor, hasPrevious bool, limit int, keyOf func(T) (interface{}, int)) ([]T, *domain.PageInfo) {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	info := &domain.PageInfo{}
+	if len(rows) == 0 {
+		return rows, info
+	}
+
+	cursorAt := func(row T, backward bool) *string {
+		key, id := keyOf(row)
+		if order.Expr == "" {
+			key = nil
+		}
+		encoded := (&domain.PageCursor{OrderBy: order.Name, Key: key, ID: id, Backward: backward}).Encode()
+		return &encoded
+	}
+
+	if backward {
+		if hasMore {
+			info.PrevCursor = cursorAt(rows[0], true)
+		}
+		info.NextCursor = cursorAt(rows[len(rows)-1], false)
+	} else {
+		if hasMore {
+			info.NextCursor = cursorAt(rows[len(rows)-1], false)
+		}
+		if hasPrevious {
+			info.PrevCursor = cursorAt(rows[0], true)
+		}
+	}
+
+	return rows, info
+}
@@ -0,0 +1,50 @@
+package gormrepository
+
+import (
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+
+	"gorm.io/gorm"
+)
+
+// attachEventPacketSchedule sets the span of each packet from its included
+// events: the earliest start and the latest end, an event without an end
+// counting as ending when it starts. A packet blocks transfers as soon as one
+// of its events does, and allows resale only when all of them do, under the
+// strictest markup cap among them.
+func attachEventPacketSchedule(db *gorm.DB, packets []*domain.EventPacket) error {
+	ids := make([]int, 0, len(packets))
+	for _, packet := range packets {
+		ids = append(ids, packet.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var rows []gormmodel.GormEventPacketSchedule
+	err := db.Raw(`
+SELECT i.packet_id, MIN(e.starts_at) AS starts_at, MAX(COALESCE(e.ends_at, e.starts_at)) AS ends_at,
+       BOOL_OR(e.transfers_blocked) AS transfers_blocked,
+       BOOL_AND(e.resale_allowed) AS resale_allowed, MIN(e.resale_max_markup_percent) AS resale_max_markup_percent
+FROM events_packet_inclusion i JOIN events e ON e.id = i.event_id
+WHERE i.packet_id IN ?
+GROUP BY i.packet_id`, ids).Scan(&rows).Error
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to load packet schedules", Err: err}
+	}
+
+	schedules := make(map[int]*gormmodel.GormEventPacketSchedule, len(rows))
+	for i := range rows {
+		schedules[rows[i].PacketID] = &rows[i]
+	}
+	for _, packet := range packets {
+		if schedule, ok := schedules[packet.ID]; ok {
+			packet.StartsAt = schedule.StartsAt
+			packet.EndsAt = schedule.EndsAt
+			packet.TransfersBlocked = schedule.TransfersBlocked
+			packet.ResaleAllowed = schedule.ResaleAllowed
+			packet.ResaleMaxMarkupPercent = schedule.ResaleMaxMarkupPercent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package gormrepository
+
+import (
+	"eventManager/infrastructure/persistence/postgres"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const highlightOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2"
+
+var tsQuery = fmt.Sprintf("websearch_to_tsquery('%s', ?)", postgres.SearchConfig)
+
+// applySearchMatch restricts query to rows matching the search terms.
+func applySearchMatch(query *gorm.DB, table string, search string) *gorm.DB {
+	return query.Where(fmt.Sprintf("%s.search_vector @@ %s", table, tsQuery), strings.TrimSpace(search))
+}
+
+func searchRankExpr(table string) string {
+	return fmt.Sprintf("ts_rank_cd(%s.search_vector, %s)", table, tsQuery)
+}
+
+// htmlEscapeExpr escapes the HTML special characters of a text column, so
+// the only markup in a headline is the <mark> tags ts_headline adds.
+func htmlEscapeExpr(column string) string {
+	return fmt.Sprintf(`replace(replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`, column)
+}
+
+// searchColumns returns the rank and the highlighted snippets read by the
+// gorm models, to be selected on top of the table columns. The snippets are
+// safe HTML: the owner's text is escaped before the matches are marked.
+func searchColumns(table string, search string) (string, []interface{}) {
+	search = strings.TrimSpace(search)
+
+	return fmt.Sprintf(`%[4]s AS search_rank,
+		ts_headline('%[2]s', %[6]s, %[5]s, 'HighlightAll=TRUE, StartSel=<mark>, StopSel=</mark>') AS name_highlight,
+		CASE WHEN %[1]s.description IS NULL THEN NULL
+			ELSE ts_headline('%[2]s', %[7]s, %[5]s, '%[3]s') END AS description_highlight`,
+		table, postgres.SearchConfig, highlightOptions, searchRankExpr(table), tsQuery,
+		htmlEscapeExpr(table+".name"), htmlEscapeExpr(table+".description"),
+	), []interface{}{search, search, search}
+}
+
+// selectColumns selects the table columns plus the computed ones.
+func selectColumns(query *gorm.DB, table string, columns []string, args []interface{}) *gorm.DB {
+	if len(columns) == 0 {
+		return query
+	}
+	return query.Select(table+".*, "+strings.Join(columns, ", "), args...)
+}
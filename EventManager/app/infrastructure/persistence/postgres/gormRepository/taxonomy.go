@@ -0,0 +1,174 @@
+package gormrepository
+
+import (
+	"eventManager/application/domain"
+	gormmodel "eventManager/infrastructure/persistence/postgres/gormModel"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// taxonomyTables names the join tables linking events or packets to
+// categories and tags.
+type taxonomyTables struct {
+	Key        string
+	Categories string
+	Tags       string
+}
+
+var eventTaxonomy = taxonomyTables{Key: "event_id", Categories: "event_categories", Tags: "event_tags"}
+
+var eventPacketTaxonomy = taxonomyTables{Key: "packet_id", Categories: "events_packet_categories", Tags: "events_packet_tags"}
+
+// loadTaxonomy fetches the categories and tags of several events or packets in
+// a single query, keyed by their id.
+func loadTaxonomy(db *gorm.DB, tables taxonomyTables, ids []int) (map[int][]*domain.Category, map[int][]string, error) {
+	categories := make(map[int][]*domain.Category)
+	tags := make(map[int][]string)
+	if len(ids) == 0 {
+		return categories, tags, nil
+	}
+
+	query := fmt.Sprintf(`
+SELECT j.%[1]s AS owner_id, c.id AS category_id, c.name, c.slug, c.description
+FROM %[2]s j JOIN categories c ON c.id = j.category_id
+WHERE j.%[1]s IN ?
+UNION ALL
+SELECT j.%[1]s, NULL, t.name, NULL, NULL
+FROM %[3]s j JOIN tags t ON t.id = j.tag_id
+WHERE j.%[1]s IN ?
+ORDER BY owner_id, name`, tables.Key, tables.Categories, tables.Tags)
+
+	var rows []gormmodel.GormTaxonomyRow
+	if err := db.Raw(query, ids, ids).Scan(&rows).Error; err != nil {
+		return nil, nil, &domain.InternalError{Msg: "failed to load categories and tags", Err: err}
+	}
+
+	for _, row := range rows {
+		if row.CategoryID == nil {
+			tags[row.OwnerID] = append(tags[row.OwnerID], row.Name)
+			continue
+		}
+		category := &domain.Category{ID: *row.CategoryID, Name: row.Name, Description: row.Description}
+		if row.Slug != nil {
+			category.Slug = *row.Slug
+		}
+		categories[row.OwnerID] = append(categories[row.OwnerID], category)
+	}
+
+	return categories, tags, nil
+}
+
+func attachEventTaxonomy(db *gorm.DB, events []*domain.Event) error {
+	ids := make([]int, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	categories, tags, err := loadTaxonomy(db, eventTaxonomy, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		event.Categories = categories[event.ID]
+		event.Tags = tags[event.ID]
+	}
+	return nil
+}
+
+func attachEventPacketTaxonomy(db *gorm.DB, packets []*domain.EventPacket) error {
+	ids := make([]int, 0, len(packets))
+	for _, packet := range packets {
+		ids = append(ids, packet.ID)
+	}
+
+	categories, tags, err := loadTaxonomy(db, eventPacketTaxonomy, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, packet := range packets {
+		packet.Categories = categories[packet.ID]
+		packet.Tags = tags[packet.ID]
+	}
+	return nil
+}
+
+// replaceTaxonomy swaps the categories and tags of one event or packet. Tags
+// that do not exist yet are created; categories must already exist.
+func replaceTaxonomy(tx *gorm.DB, tables taxonomyTables, id int, categoryIDs []int, tags []string) error {
+	if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", tables.Categories, tables.Key), id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", tables.Tags, tables.Key), id).Error; err != nil {
+		return err
+	}
+
+	if len(categoryIDs) > 0 {
+		insert := fmt.Sprintf("INSERT INTO %s (%s, category_id) SELECT ?, id FROM categories WHERE id IN ?", tables.Categories, tables.Key)
+		if err := tx.Exec(insert, id, categoryIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(tags) > 0 {
+		gormTags := make([]gormmodel.GormTag, 0, len(tags))
+		for _, tag := range tags {
+			gormTags = append(gormTags, gormmodel.GormTag{Name: tag})
+		}
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&gormTags).Error; err != nil {
+			return err
+		}
+
+		insert := fmt.Sprintf("INSERT INTO %s (%s, tag_id) SELECT ?, id FROM tags WHERE name IN ?", tables.Tags, tables.Key)
+		if err := tx.Exec(insert, id, tags).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// facetCounts counts the ids selected by the matching subqueries per category
+// and per tag, most frequent first.
+func facetCounts(db *gorm.DB, tables taxonomyTables, categoryMatching *gorm.DB, tagMatching *gorm.DB) (*domain.EventFacets, error) {
+	var categoryRows []gormmodel.GormFacetCount
+	err := db.Table(tables.Categories+" j").
+		Select("c.slug AS value, c.name AS label, COUNT(*) AS count").
+		Joins("JOIN categories c ON c.id = j.category_id").
+		Where(fmt.Sprintf("j.%s IN (?)", tables.Key), categoryMatching).
+		Group("c.id, c.slug, c.name").
+		Order("count DESC, c.name").
+		Limit(domain.MaxFacetValues).
+		Scan(&categoryRows).Error
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to count category facets", Err: err}
+	}
+
+	var tagRows []gormmodel.GormFacetCount
+	err = db.Table(tables.Tags+" j").
+		Select("t.name AS value, t.name AS label, COUNT(*) AS count").
+		Joins("JOIN tags t ON t.id = j.tag_id").
+		Where(fmt.Sprintf("j.%s IN (?)", tables.Key), tagMatching).
+		Group("t.id, t.name").
+		Order("count DESC, t.name").
+		Limit(domain.MaxFacetValues).
+		Scan(&tagRows).Error
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to count tag facets", Err: err}
+	}
+
+	facets := &domain.EventFacets{
+		Categories: make([]*domain.FacetCount, 0, len(categoryRows)),
+		Tags:       make([]*domain.FacetCount, 0, len(tagRows)),
+	}
+	for _, row := range categoryRows {
+		facets.Categories = append(facets.Categories, row.ToDomain())
+	}
+	for _, row := range tagRows {
+		facets.Tags = append(facets.Tags, row.ToDomain())
+	}
+	return facets, nil
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SearchConfig is the text search configuration used for the search_vector
+// columns. It lowercases and strips diacritics so "Brasov" matches "Brașov".
+const SearchConfig = "ro_unaccent"
+
+var searchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = '` + SearchConfig + `') THEN
+			CREATE TEXT SEARCH CONFIGURATION ` + SearchConfig + ` (COPY = simple);
+			ALTER TEXT SEARCH CONFIGURATION ` + SearchConfig + `
+				ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+		END IF;
+	END
+	$$`,
+	searchVectorColumn("events"),
+	`CREATE INDEX IF NOT EXISTS idx_events_search_vector ON events USING GIN (search_vector)`,
+	searchVectorColumn(`"eventsPacket"`),
+	`CREATE INDEX IF NOT EXISTS idx_events_packet_search_vector ON "eventsPacket" USING GIN (search_vector)`,
+}
+
+// name weighs more than location, which weighs more than description
+func searchVectorColumn(table string) string {
+	return fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('%[2]s', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('%[2]s', coalesce(location, '')), 'B') ||
+			setweight(to_tsvector('%[2]s', coalesce(description, '')), 'C')
+		) STORED`, table, SearchConfig)
+}
+
+func migrateSearch(db *gorm.DB) error {
+	for _, statement := range searchMigrations {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -56,9 +56,9 @@ func (s *DummyAuthorizationService) CanUserEditTicket(ctx context.Context, user
 	return false, nil
 }
 
-// nu se poate sterge
+// biletele se anuleaza doar prin serviciul clienti, cand o rambursare e aprobata
 func (s *DummyAuthorizationService) CanUserDeleteTicket(ctx context.Context, user service.UserIdentity, ticket *domain.Ticket) (bool, error) {
-	return false, nil
+	return user.Role == service.RoleServiceClient, nil
 }
 
 func (s *DummyAuthorizationService) CanUserBuyTicket(ctx context.Context, user service.UserIdentity, event *domain.Event) (bool, error) {
@@ -69,6 +69,11 @@ func (s *DummyAuthorizationService) CanUserCreateTicket(ctx context.Context, use
 	return user.Role == service.RoleServiceClient, nil
 }
 
+// biletele isi schimba detinatorul doar prin serviciul clienti
+func (s *DummyAuthorizationService) CanUserRotateTicket(ctx context.Context, user service.UserIdentity, ticket *domain.Ticket) (bool, error) {
+	return user.Role == service.RoleServiceClient, nil
+}
+
 func (s *DummyAuthorizationService) CanUserCreateEventPacket(ctx context.Context, user service.UserIdentity) (bool, error) {
 	return user.Role == service.RoleOwnerEvent, nil
 }
@@ -108,3 +113,26 @@ func (s *DummyAuthorizationService) CanUserUpdateEventPacketInclusion(ctx contex
 func (s *DummyAuthorizationService) CanUserDeleteEventPacketInclusion(ctx context.Context, user service.UserIdentity, eventID int, packetID int) (bool, error) {
 	return user.Role == service.RoleOwnerEvent, nil
 }
+
+// statisticile de vanzari sunt vizibile ownerului si serviciului clienti,
+// care urmareste locurile eliberate pentru lista de asteptare
+func (s *DummyAuthorizationService) CanUserViewEventAvailability(ctx context.Context, user service.UserIdentity, event *domain.Event) (bool, error) {
+	return user.UserID == uint(event.OwnerID) || user.Role == service.RoleServiceClient, nil
+}
+
+func (s *DummyAuthorizationService) CanUserViewEventPacketAvailability(ctx context.Context, user service.UserIdentity, packet *domain.EventPacket) (bool, error) {
+	return user.UserID == uint(packet.OwnerID) || user.Role == service.RoleServiceClient, nil
+}
+
+func (s *DummyAuthorizationService) CanUserViewOwnerDashboard(ctx context.Context, user service.UserIdentity, ownerID int) (bool, error) {
+	return user.Role == service.RoleOwnerEvent && user.UserID == uint(ownerID), nil
+}
+
+// categoriile sunt gestionate doar de admin
+func (s *DummyAuthorizationService) CanUserManageCategories(ctx context.Context, user service.UserIdentity) (bool, error) {
+	return user.Role == service.RoleAdmin, nil
+}
+
+func (s *DummyAuthorizationService) CanUserManageWebhooks(ctx context.Context, user service.UserIdentity, ownerID int) (bool, error) {
+	return user.Role == service.RoleOwnerEvent && user.UserID == uint(ownerID), nil
+}
@@ -11,8 +11,11 @@ import (
 
 	pb "idmService/proto"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type RealAuthenticationService struct {
@@ -56,9 +59,7 @@ func (s *RealAuthenticationService) WhoIsUser(ctx context.Context, token string)
 	req := &pb.VerifyTokenRequest{Token: token}
 	resp, err := s.client.VerifyToken(ctxWithTimeout, req)
 	if err != nil {
-		return nil, &domain.UnauthorizedError{
-			Reason: "failed to verify token with IDM service",
-		}
+		return nil, unauthorizedFromStatus(err)
 	}
 
 	if !resp.Valid {
@@ -99,6 +100,38 @@ func (s *RealAuthenticationService) WhoIsUser(ctx context.Context, token string)
 	}, nil
 }
 
+// unauthorizedFromStatus explains a rejected token using the ErrorInfo reason
+// IDM attaches to its Unauthenticated status.
+func unauthorizedFromStatus(err error) *domain.UnauthorizedError {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unauthenticated {
+		return &domain.UnauthorizedError{
+			Reason: "failed to verify token with IDM service",
+		}
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		switch info.Reason {
+		case "TOKEN_EXPIRED":
+			return &domain.UnauthorizedError{
+				Reason: "token has expired",
+			}
+		case "TOKEN_REVOKED":
+			return &domain.UnauthorizedError{
+				Reason: "token has been revoked",
+			}
+		}
+	}
+
+	return &domain.UnauthorizedError{
+		Reason: fmt.Sprintf("token is invalid: %s", st.Message()),
+	}
+}
+
 func (s *RealAuthenticationService) Close() error {
 	if s.conn != nil {
 		return s.conn.Close()
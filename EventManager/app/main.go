@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
+	"eventManager/application/repository"
 	"eventManager/application/service"
 	"eventManager/application/usecase"
 	"eventManager/infrastructure/http/config"
 	"eventManager/infrastructure/http/gin/handler"
+	"eventManager/infrastructure/http/gin/middleware"
 	"eventManager/infrastructure/http/gin/router"
+	"eventManager/infrastructure/messaging"
 	"eventManager/infrastructure/persistence/postgres"
 	gormrepository "eventManager/infrastructure/persistence/postgres/gormRepository"
 	infrastructureservice "eventManager/infrastructure/service"
 	"fmt"
 	"os"
+	"time"
 
 	_ "eventManager/docs"
 
@@ -18,6 +23,7 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"shared/debugvars"
 )
 
 // @title EventManager Service API
@@ -36,10 +42,18 @@ func main() {
 	eventPacketRepo := &gormrepository.GormEventPacketRepository{DB: db}
 	eventPacketInclusionRepo := &gormrepository.GormEventPacketInclusionRepository{DB: db}
 	ticketRepo := &gormrepository.GormTicketRepository{DB: db}
+	categoryRepo := &gormrepository.GormCategoryRepository{DB: db}
+	idempotencyRepo := &gormrepository.GormIdempotencyRepository{DB: db}
+	outboxRepo := &gormrepository.GormOutboxRepository{DB: db}
+	webhookRepo := &gormrepository.GormWebhookRepository{DB: db}
+	catalogImportRepo := &gormrepository.GormCatalogImportRepository{DB: db}
 
 	eventService := service.NewEventService(eventRepo, eventPacketInclusionRepo)
 	eventPacketService := service.NewEventPacketService(eventPacketRepo, eventRepo, eventPacketInclusionRepo)
 	ticketService := service.NewTicketService(ticketRepo, eventRepo, eventPacketRepo, eventPacketInclusionRepo)
+	categoryService := service.NewCategoryService(categoryRepo)
+	webhookService := service.NewWebhookService(webhookRepo, eventRepo, eventPacketRepo)
+	importService := service.NewImportService(catalogImportRepo, eventService, eventPacketService)
 
 	idmHost := os.Getenv("IDM_HOST")
 	idmPort := os.Getenv("IDM_PORT")
@@ -61,6 +75,10 @@ func main() {
 	eventPacketUseCase := usecase.NewEventPacketUseCase(eventPacketRepo, eventPacketService, authenService, authzService)
 	eventPacketInclusionUseCase := usecase.NewEventPacketInclusionUseCase(eventPacketInclusionRepo, eventRepo, eventPacketRepo, authenService, authzService)
 	ticketUseCase := usecase.NewTicketUseCase(ticketRepo, ticketService, authenService, authzService)
+	availabilityUseCase := usecase.NewAvailabilityUseCase(eventRepo, eventPacketRepo, authenService, authzService)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryService, eventRepo, eventPacketRepo, authenService, authzService)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookService, authenService, authzService)
+	importUseCase := usecase.NewImportUseCase(importService, authenService, authzService)
 
 	serviceURLs := config.NewServiceURLs()
 
@@ -68,24 +86,49 @@ func main() {
 	eventPacketHandler := handler.NewGinEventPacketHandler(eventPacketUseCase, eventPacketRepo, serviceURLs)
 	eventPacketInclusionHandler := handler.NewGinEventPacketInclusionHandler(eventPacketInclusionUseCase, serviceURLs)
 	ticketHandler := handler.NewGinTicketHandler(ticketUseCase, serviceURLs)
+	availabilityHandler := handler.NewGinAvailabilityHandler(availabilityUseCase, serviceURLs)
+	categoryHandler := handler.NewGinCategoryHandler(categoryUseCase, serviceURLs)
+	webhookHandler := handler.NewGinWebhookHandler(webhookUseCase, serviceURLs)
+	importHandler := handler.NewGinImportHandler(importUseCase, serviceURLs)
+
+	// call, retry and circuit breaker counters of the outgoing HTTP clients,
+	// kept off the public router
+	debugvars.Start(os.Getenv("EVENT_MANAGER_DEBUG_PORT"))
 
 	r := gin.Default()
 
+	r.Use(middleware.RequestID())
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:5173"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "User-Agent", "Cache-Control", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "User-Agent", "Cache-Control", "X-Requested-With", "X-Request-ID", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "X-Request-ID", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * 3600,
 	}))
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
 	eventAPI := r.Group("/api/event-manager")
 	router.RegisterEventRoutes(eventAPI, eventHandler)
 	router.RegisterEventPacketRoutes(eventAPI, eventPacketHandler)
 	router.RegisterEventPacketInclusionRoutes(eventAPI, eventPacketInclusionHandler)
-	router.RegisterTicketRoutes(eventAPI, ticketHandler)
+	router.RegisterTicketRoutes(eventAPI, ticketHandler, middleware.Idempotency(idempotencyRepo, authenService))
+	router.RegisterAvailabilityRoutes(eventAPI, availabilityHandler)
+	router.RegisterCategoryRoutes(eventAPI, categoryHandler)
+	router.RegisterWebhookRoutes(eventAPI, webhookHandler)
+	router.RegisterImportRoutes(eventAPI, importHandler)
+
+	go purgeExpiredIdempotencyKeys(idempotencyRepo, time.Hour)
+
+	broker, err := messaging.NewPublisherFromEnv()
+	if err != nil {
+		fmt.Printf("Failed to initialize event publisher: %v\n", err)
+		os.Exit(1)
+	}
+	publisher := messaging.FanOut{broker, service.EventPublisherFunc(webhookService.Enqueue)}
+	go service.NewOutboxRelay(outboxRepo, publisher).Run(context.Background(), time.Second)
+	go service.NewWebhookDispatcher(webhookRepo, messaging.NewHMACWebhookSender()).Run(context.Background(), 5*time.Second)
 
 	port := os.Getenv("EVENT_MANAGER_PORT")
 
@@ -93,3 +136,16 @@ func main() {
 		fmt.Println(err.Error())
 	}
 }
+
+// purgeExpiredIdempotencyKeys drops stored responses once their retention
+// window is over.
+func purgeExpiredIdempotencyKeys(repo repository.IdempotencyRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := repo.DeleteExpired(context.Background(), time.Now()); err != nil {
+			fmt.Printf("Failed to purge idempotency keys: %v\n", err)
+		}
+	}
+}
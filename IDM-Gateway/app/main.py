@@ -1,9 +1,13 @@
-from fastapi import FastAPI
+from fastapi import FastAPI, Request
+from fastapi.exceptions import RequestValidationError
 from fastapi.middleware.cors import CORSMiddleware
 from contextlib import asynccontextmanager
 from app.config import get_settings
 from app.grpc_client import grpc_client
 from app.routers import auth_router
+from app.utils.error_handler import (
+    ProblemException, handle_request_validation_error, problem_response
+)
 
 @asynccontextmanager
 async def lifespan(app: FastAPI):
@@ -29,6 +33,14 @@ app.add_middleware(
 
 app.include_router(auth_router.router)
 
+@app.exception_handler(ProblemException)
+async def problem_exception_handler(request: Request, exc: ProblemException):
+    return problem_response(request, exc)
+
+@app.exception_handler(RequestValidationError)
+async def validation_exception_handler(request: Request, exc: RequestValidationError):
+    return problem_response(request, handle_request_validation_error(exc))
+
 @app.get("/health")
 async def health_check():
     return {"status": "healthy", "service": "idm-gateway"}
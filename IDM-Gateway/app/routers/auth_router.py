@@ -1,4 +1,4 @@
-from fastapi import APIRouter, HTTPException
+from fastapi import APIRouter
 from app.models.auth_models import (
     RegisterRequest, RegisterResponse,
     LoginRequest, LoginResponse,
@@ -23,9 +23,6 @@ async def register(request: RegisterRequest):
         )
         response = await stub.Register(grpc_request)
 
-        if not response.success:
-            raise HTTPException(status_code=400, detail=response.message)
-
         return RegisterResponse(
             success=response.success,
             message=response.message,
@@ -1,16 +1,102 @@
-from fastapi import HTTPException
+from fastapi import Request
+from fastapi.exceptions import RequestValidationError
+from fastapi.responses import JSONResponse
+from google.rpc import error_details_pb2, status_pb2
 import grpc
 
-def handle_grpc_error(error: grpc.RpcError) -> HTTPException:
-    if error.code() == grpc.StatusCode.UNAVAILABLE:
-        return HTTPException(status_code=503, detail="IDM service unavailable")
-    elif error.code() == grpc.StatusCode.INVALID_ARGUMENT:
-        return HTTPException(status_code=400, detail="Invalid request")
-    elif error.code() == grpc.StatusCode.NOT_FOUND:
-        return HTTPException(status_code=404, detail="Resource not found")
-    elif error.code() == grpc.StatusCode.PERMISSION_DENIED:
-        return HTTPException(status_code=403, detail="Permission denied")
-    elif error.code() == grpc.StatusCode.UNAUTHENTICATED:
-        return HTTPException(status_code=401, detail="Unauthenticated")
+PROBLEM_CONTENT_TYPE = "application/problem+json"
+
+_GRPC_TO_HTTP = {
+    grpc.StatusCode.INVALID_ARGUMENT: (422, "validation-error"),
+    grpc.StatusCode.ALREADY_EXISTS: (409, "conflict"),
+    grpc.StatusCode.UNAUTHENTICATED: (401, "unauthorized"),
+    grpc.StatusCode.PERMISSION_DENIED: (403, "forbidden"),
+    grpc.StatusCode.NOT_FOUND: (404, "not-found"),
+    grpc.StatusCode.UNAVAILABLE: (503, "service-unavailable"),
+    grpc.StatusCode.DEADLINE_EXCEEDED: (504, "service-unavailable"),
+}
+
+_TITLES = {
+    401: "Unauthorized",
+    403: "Forbidden",
+    404: "Not Found",
+    409: "Conflict",
+    422: "Unprocessable Entity",
+    500: "Internal Server Error",
+    503: "Service Unavailable",
+    504: "Gateway Timeout",
+}
+
+
+class ProblemException(Exception):
+    def __init__(self, status: int, problem_type: str, detail: str, errors=None, reason=None):
+        self.status = status
+        self.problem_type = problem_type
+        self.detail = detail
+        self.errors = errors or []
+        self.reason = reason
+
+
+def problem_response(request: Request, problem: ProblemException) -> JSONResponse:
+    body = {
+        "type": f"/problems/{problem.problem_type}",
+        "title": _TITLES.get(problem.status, "Error"),
+        "status": problem.status,
+        "detail": problem.detail,
+        "instance": request.url.path,
+        "error": problem.detail,
+    }
+    if problem.errors:
+        body["errors"] = problem.errors
+    if problem.reason:
+        body["reason"] = problem.reason
+    trace_id = request.headers.get("x-request-id")
+    if trace_id:
+        body["trace_id"] = trace_id
+    return JSONResponse(status_code=problem.status, content=body, media_type=PROBLEM_CONTENT_TYPE)
+
+
+def _status_details(error: grpc.RpcError):
+    """Decodes the google.rpc.Status sent by IDM in the grpc-status-details-bin trailer."""
+    trailers = error.trailing_metadata() or ()
+    for key, value in trailers:
+        if key == "grpc-status-details-bin":
+            status = status_pb2.Status()
+            status.ParseFromString(value)
+            return status.details
+    return []
+
+
+def handle_grpc_error(error: grpc.RpcError) -> ProblemException:
+    status, problem_type = _GRPC_TO_HTTP.get(error.code(), (500, "internal-error"))
+    if status == 500:
+        detail = "Internal server error"
+    elif status in (503, 504):
+        detail = "IDM service unavailable"
     else:
-        return HTTPException(status_code=500, detail="Internal server error")
+        detail = error.details() or _TITLES[status]
+
+    errors = []
+    reason = None
+    for any_detail in _status_details(error):
+        info = error_details_pb2.ErrorInfo()
+        bad_request = error_details_pb2.BadRequest()
+        if any_detail.Is(info.DESCRIPTOR):
+            any_detail.Unpack(info)
+            reason = info.reason
+        elif any_detail.Is(bad_request.DESCRIPTOR):
+            any_detail.Unpack(bad_request)
+            errors.extend(
+                {"field": violation.field, "reason": violation.description}
+                for violation in bad_request.field_violations
+            )
+
+    return ProblemException(status, problem_type, detail, errors, reason)
+
+
+def handle_request_validation_error(exc: RequestValidationError) -> ProblemException:
+    errors = [
+        {"field": ".".join(str(part) for part in err["loc"] if part != "body"), "reason": err["msg"]}
+        for err in exc.errors()
+    ]
+    return ProblemException(422, "validation-error", "the request contains invalid fields", errors)
@@ -25,6 +25,18 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("%s not found", e.Resource)
 }
 
+type AlreadyExistsError struct {
+	Resource string
+	Field    string
+}
+
+func (e *AlreadyExistsError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%s with this %s already exists", e.Resource, e.Field)
+	}
+	return fmt.Sprintf("%s already exists", e.Resource)
+}
+
 type TokenError struct {
 	Reason      string
 	Expired     bool
@@ -42,9 +42,9 @@ func (uc *registerUseCase) Execute(ctx context.Context, email, password, role st
 	}
 
 	if existing != nil {
-		return nil, &domain.ValidationError{
-			Field:  "email",
-			Reason: "User with this email already exists",
+		return nil, &domain.AlreadyExistsError{
+			Resource: "User",
+			Field:    "email",
 		}
 	}
 
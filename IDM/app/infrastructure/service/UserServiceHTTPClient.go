@@ -1,7 +1,6 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,6 +8,7 @@ import (
 	"idmService/application/service"
 	"net/http"
 	"os"
+	"shared/httpclient"
 	"time"
 )
 
@@ -31,7 +31,7 @@ type HttpResponseUser struct {
 
 type UserServiceHTTPClient struct {
 	baseURL    string
-	httpClient *http.Client
+	httpClient *httpclient.Client
 }
 
 func NewUserServiceHTTPClient() *UserServiceHTTPClient {
@@ -42,9 +42,10 @@ func NewUserServiceHTTPClient() *UserServiceHTTPClient {
 
 	return &UserServiceHTTPClient{
 		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		httpClient: httpclient.New(httpclient.Config{
+			Name:           "user-service",
+			AttemptTimeout: 5 * time.Second,
+		}),
 	}
 }
 
@@ -61,23 +62,21 @@ func (c *UserServiceHTTPClient) CreateUser(ctx context.Context, req *service.Cre
 		return nil, &domain.InternalError{Operation: "create user profile", Err: fmt.Errorf("failed to marshal request: %w", err)}
 	}
 
-	url := fmt.Sprintf("%s/users", c.baseURL)
-	httpRequest, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, &domain.InternalError{Operation: "create user profile", Err: fmt.Errorf("failed to create HTTP request: %w", err)}
-	}
-
-	httpRequest.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(httpRequest)
+	// creating a profile is not idempotent, so it is sent once; the breaker
+	// still fails registrations fast while the User service is down
+	resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s/users", c.baseURL),
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   jsonData,
+	})
 	if err != nil {
 		return nil, &domain.InternalError{Operation: "create user profile", Err: fmt.Errorf("failed to call User service: %w", err)}
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errorResp map[string]string
-		json.NewDecoder(resp.Body).Decode(&errorResp)
+		var errorResp map[string]interface{}
+		json.Unmarshal(resp.Body, &errorResp)
 		return nil, &domain.InternalError{
 			Operation: "create user profile",
 			Err:       fmt.Errorf("User service returned status %d: %v", resp.StatusCode, errorResp),
@@ -85,7 +84,7 @@ func (c *UserServiceHTTPClient) CreateUser(ctx context.Context, req *service.Cre
 	}
 
 	var userResp HttpResponseUser
-	if err := json.NewDecoder(resp.Body).Decode(&userResp); err != nil {
+	if err := json.Unmarshal(resp.Body, &userResp); err != nil {
 		return nil, &domain.InternalError{Operation: "create user profile", Err: fmt.Errorf("failed to decode response: %w", err)}
 	}
 
@@ -14,6 +14,7 @@ import (
 	"idmService/infrastructure/service"
 	pb "idmService/proto"
 	"idmService/server"
+	"shared/debugvars"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -80,6 +81,9 @@ func main() {
 	userServiceClient := service.NewUserServiceHTTPClient()
 	fmt.Println("User service client initialized")
 
+	// call, retry and circuit breaker counters of the outgoing HTTP client
+	debugvars.Start(os.Getenv("IDM_DEBUG_PORT"))
+
 	registerUseCase := usecase.NewRegisterUseCase(userRepo, userServiceClient, passwordHasher)
 	loginUseCase := usecase.NewLoginUseCase(userRepo, tokenService, passwordHasher)
 	verifyTokenUseCase := usecase.NewVerifyTokenUseCase(userRepo, tokenService, tokenBlacklist)
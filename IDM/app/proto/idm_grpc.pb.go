@@ -28,6 +28,10 @@ const (
 // IdentityServiceClient is the client API for IdentityService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Failures are returned as gRPC status errors carrying google.rpc.ErrorInfo
+// (domain "idm") and, where relevant, BadRequest or ResourceInfo details; the
+// success/valid flags below are only set on successful calls.
 type IdentityServiceClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
@@ -86,6 +90,10 @@ func (c *identityServiceClient) RevokeToken(ctx context.Context, in *RevokeToken
 // IdentityServiceServer is the server API for IdentityService service.
 // All implementations must embed UnimplementedIdentityServiceServer
 // for forward compatibility.
+//
+// Failures are returned as gRPC status errors carrying google.rpc.ErrorInfo
+// (domain "idm") and, where relevant, BadRequest or ResourceInfo details; the
+// success/valid flags below are only set on successful calls.
 type IdentityServiceServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
@@ -2,79 +2,112 @@ package server
 
 import (
 	"errors"
+	"strconv"
+
 	"idmService/application/domain"
-	pb "idmService/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain identifies IDM in the ErrorInfo details of returned statuses.
+const ErrorDomain = "idm"
+
+// ErrorInfo reasons clients can switch on instead of parsing messages.
+const (
+	ReasonValidationFailed     = "VALIDATION_FAILED"
+	ReasonAlreadyExists        = "ALREADY_EXISTS"
+	ReasonAuthenticationFailed = "AUTHENTICATION_FAILED"
+	ReasonTokenExpired         = "TOKEN_EXPIRED"
+	ReasonTokenRevoked         = "TOKEN_REVOKED"
+	ReasonTokenMalformed       = "TOKEN_MALFORMED"
+	ReasonTokenInvalid         = "TOKEN_INVALID"
+	ReasonNotFound             = "NOT_FOUND"
+	ReasonConfiguration        = "CONFIGURATION_ERROR"
+	ReasonInternal             = "INTERNAL"
 )
 
-func mapRegisterError(err error) *pb.RegisterResponse {
+// toStatusError maps a use case error to a gRPC status carrying an ErrorInfo
+// and, where it applies, BadRequest or ResourceInfo details.
+func toStatusError(err error) error {
 	var validationErr *domain.ValidationError
 	if errors.As(err, &validationErr) {
-		return &pb.RegisterResponse{
-			Success: false,
-			Message: validationErr.Error(),
-		}
+		return withDetails(status.New(codes.InvalidArgument, validationErr.Error()),
+			errorInfo(ReasonValidationFailed, nil),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       validationErr.Field,
+				Description: validationErr.Reason,
+			}}},
+		)
 	}
 
-	var internalErr *domain.InternalError
-	if errors.As(err, &internalErr) {
-		return &pb.RegisterResponse{
-			Success: false,
-			Message: "Internal error during registration",
-		}
+	var existsErr *domain.AlreadyExistsError
+	if errors.As(err, &existsErr) {
+		return withDetails(status.New(codes.AlreadyExists, existsErr.Error()),
+			errorInfo(ReasonAlreadyExists, map[string]string{"field": existsErr.Field}),
+			&errdetails.ResourceInfo{ResourceType: existsErr.Resource, Description: existsErr.Error()},
+		)
 	}
 
-	return &pb.RegisterResponse{
-		Success: false,
-		Message: "Registration failed",
-	}
-}
-
-func mapLoginError(err error) *pb.LoginResponse {
 	var authErr *domain.AuthenticationError
 	if errors.As(err, &authErr) {
-		return &pb.LoginResponse{
-			Success: false,
-			Token:   "",
-			Message: authErr.Error(),
-		}
+		return withDetails(status.New(codes.Unauthenticated, authErr.Error()),
+			errorInfo(ReasonAuthenticationFailed, nil),
+		)
+	}
+
+	var tokenErr *domain.TokenError
+	if errors.As(err, &tokenErr) {
+		return withDetails(status.New(codes.Unauthenticated, tokenErr.Error()),
+			errorInfo(tokenReason(tokenErr), map[string]string{
+				"expired":     strconv.FormatBool(tokenErr.Expired),
+				"blacklisted": strconv.FormatBool(tokenErr.Blacklisted),
+			}),
+		)
+	}
+
+	var notFoundErr *domain.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return withDetails(status.New(codes.NotFound, notFoundErr.Error()),
+			errorInfo(ReasonNotFound, nil),
+			&errdetails.ResourceInfo{ResourceType: notFoundErr.Resource, ResourceName: notFoundErr.ID},
+		)
 	}
 
 	var configErr *domain.ConfigurationError
 	if errors.As(err, &configErr) {
-		return &pb.LoginResponse{
-			Success: false,
-			Token:   "",
-			Message: "Service configuration error",
-		}
+		return withDetails(status.New(codes.Internal, "Service configuration error"),
+			errorInfo(ReasonConfiguration, nil),
+		)
 	}
 
-	return &pb.LoginResponse{
-		Success: false,
-		Token:   "",
-		Message: "Internal error",
-	}
+	return withDetails(status.New(codes.Internal, "Internal error"),
+		errorInfo(ReasonInternal, nil),
+	)
 }
 
-func mapVerifyTokenError(err error) *pb.VerifyTokenResponse {
-	var tokenErr *domain.TokenError
-	if errors.As(err, &tokenErr) {
-		return &pb.VerifyTokenResponse{
-			Valid:       false,
-			Message:     tokenErr.Error(),
-			Expired:     tokenErr.Expired,
-			Blacklisted: tokenErr.Blacklisted,
-		}
+func tokenReason(err *domain.TokenError) string {
+	switch {
+	case err.Expired:
+		return ReasonTokenExpired
+	case err.Blacklisted:
+		return ReasonTokenRevoked
+	case err.Corrupted:
+		return ReasonTokenMalformed
 	}
+	return ReasonTokenInvalid
+}
 
-	return &pb.VerifyTokenResponse{
-		Valid:   false,
-		Message: "Internal error",
-	}
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain, Metadata: metadata}
 }
 
-func mapRevokeTokenError(err error) *pb.RevokeTokenResponse {
-	return &pb.RevokeTokenResponse{
-		Success: false,
-		Message: "Internal error",
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
 	}
+	return detailed.Err()
 }
@@ -32,7 +32,7 @@ func NewIdentityServer(
 func (s *IdentityServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	result, err := s.registerUseCase.Execute(ctx, req.Email, req.Password, req.Role)
 	if err != nil {
-		return mapRegisterError(err), nil
+		return nil, toStatusError(err)
 	}
 
 	return &pb.RegisterResponse{
@@ -45,7 +45,7 @@ func (s *IdentityServer) Register(ctx context.Context, req *pb.RegisterRequest)
 func (s *IdentityServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	result, err := s.loginUseCase.Execute(ctx, req.Email, req.Password)
 	if err != nil {
-		return mapLoginError(err), nil
+		return nil, toStatusError(err)
 	}
 
 	return &pb.LoginResponse{
@@ -61,7 +61,7 @@ func (s *IdentityServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 func (s *IdentityServer) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
 	result, err := s.verifyTokenUseCase.Execute(ctx, req.Token)
 	if err != nil {
-		return mapVerifyTokenError(err), nil
+		return nil, toStatusError(err)
 	}
 
 	return &pb.VerifyTokenResponse{
@@ -78,7 +78,7 @@ func (s *IdentityServer) VerifyToken(ctx context.Context, req *pb.VerifyTokenReq
 func (s *IdentityServer) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
 	result, err := s.revokeTokenUseCase.Execute(ctx, req.Token)
 	if err != nil {
-		return mapRevokeTokenError(err), nil
+		return nil, toStatusError(err)
 	}
 
 	return &pb.RevokeTokenResponse{
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// CalendarFeed is the subscribable calendar of a user's tickets. Calendar
+// apps poll the feed URL without a bearer token, so the secret Token in the
+// URL stands in for it; rotating the token cuts off every URL handed out
+// before.
+type CalendarFeed struct {
+	UserID    int
+	Token     string
+	CreatedAt time.Time
+}
+
+// CalendarEntry is an event the user holds tickets for, directly or through
+// packets including it.
+type CalendarEntry struct {
+	Event       *EventSummary
+	TicketCount int
+	// Packets names the packets whose tickets admit the user to the event
+	Packets []string
+}
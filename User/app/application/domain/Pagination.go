@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const MaxPerPage = 100
+
+// PageCursor is the keyset position a listing continues from. It is handed
+// out to clients as an opaque base64 string.
+type PageCursor struct {
+	OrderBy  string      `json:"o,omitempty"`
+	Key      interface{} `json:"k,omitempty"`
+	ID       int         `json:"id"`
+	Backward bool        `json:"b,omitempty"`
+}
+
+type PageInfo struct {
+	NextCursor *string
+	PrevCursor *string
+}
+
+func (cursor *PageCursor) Encode() string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodePageCursor(encoded string, orderBy *string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, &ValidationError{Field: "cursor", Reason: "invalid cursor"}
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID < 1 {
+		return nil, &ValidationError{Field: "cursor", Reason: "invalid cursor"}
+	}
+
+	expected := ""
+	if orderBy != nil {
+		expected = *orderBy
+	}
+	if cursor.OrderBy != expected {
+		return nil, &ValidationError{Field: "cursor", Reason: "cursor was issued for a different order_by"}
+	}
+
+	return &cursor, nil
+}
+
+// Customer orderings; without one customers are listed by user id.
+const (
+	CustomerOrderNameAsc         = "name_asc"
+	CustomerOrderNameDesc        = "name_desc"
+	CustomerOrderEmailAsc        = "email_asc"
+	CustomerOrderEmailDesc       = "email_desc"
+	CustomerOrderPurchasedAtAsc  = "purchased_at_asc"
+	CustomerOrderPurchasedAtDesc = "purchased_at_desc"
+)
+
+type CustomerFilter struct {
+	PerPage *int
+	Cursor  *string
+	OrderBy *string
+}
+
+func (filter *CustomerFilter) Default() {
+	if filter.PerPage == nil {
+		filter.PerPage = new(int)
+		*filter.PerPage = 20
+	}
+	if *filter.PerPage > MaxPerPage {
+		*filter.PerPage = MaxPerPage
+	}
+}
+
+func (filter *CustomerFilter) Validate() error {
+	if filter.PerPage != nil && *filter.PerPage < 1 {
+		return &ValidationError{Field: "per_page", Reason: "per_page must be at least 1"}
+	}
+	if err := ValidateCustomerOrder(filter.OrderBy); err != nil {
+		return err
+	}
+	if filter.Cursor != nil {
+		if _, err := DecodePageCursor(*filter.Cursor, filter.OrderBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PageCursor returns the decoded cursor, or nil on the first page.
+func (filter *CustomerFilter) PageCursor() (*PageCursor, error) {
+	if filter.Cursor == nil {
+		return nil, nil
+	}
+	return DecodePageCursor(*filter.Cursor, filter.OrderBy)
+}
+
+func ValidateCustomerOrder(orderBy *string) error {
+	if orderBy == nil {
+		return nil
+	}
+	switch *orderBy {
+	case CustomerOrderNameAsc, CustomerOrderNameDesc,
+		CustomerOrderEmailAsc, CustomerOrderEmailDesc,
+		CustomerOrderPurchasedAtAsc, CustomerOrderPurchasedAtDesc:
+		return nil
+	}
+	return &ValidationError{Field: "order_by", Reason: "invalid order by. valid options: name_asc/desc, email_asc/desc, purchased_at_asc/desc"}
+}
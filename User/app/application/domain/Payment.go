@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// PaymentCurrency is the currency every price is set in; amounts are in
+// bani.
+const PaymentCurrency = "RON"
+
+const (
+	// PaymentAuthorized holds the amount on the buyer's card while the
+	// tickets are issued; it is captured once they are, and voided if they
+	// cannot be.
+	PaymentAuthorized = "authorized"
+	PaymentCaptured   = "captured"
+	PaymentVoided     = "voided"
+	PaymentFailed     = "failed"
+)
+
+// PaymentRequest asks the gateway to hold Amount on the card Token stands
+// for. The gateway answers a repeated IdempotencyKey with the authorization
+// it already gave.
+type PaymentRequest struct {
+	Amount         int
+	Currency       string
+	Token          string
+	IdempotencyKey string
+	Description    string
+}
+
+// PaymentAuthorization is the gateway's answer to an authorized
+// PaymentRequest; Reference names the payment in later calls.
+type PaymentAuthorization struct {
+	Reference string
+	Amount    int
+}
+
+const (
+	PaymentEventCaptured = "payment.captured"
+	PaymentEventVoided   = "payment.voided"
+	PaymentEventRefunded = "payment.refunded"
+)
+
+// PaymentEvent is a change of a payment the gateway reports through its
+// webhook. Amount is the amount captured, or refunded so far, as the
+// gateway sees it.
+type PaymentEvent struct {
+	ID        string
+	Type      string
+	Reference string
+	Amount    int
+}
+
+// Payment records the money taken for one order. Refunded is what was paid
+// back of Amount so far, RefundIDs the refunds that did.
+type Payment struct {
+	ID            string
+	OrderID       string
+	UserID        int
+	Amount        int
+	Currency      string
+	Status        string
+	Reference     string
+	TicketCodes   []string
+	Refunded      int
+	RefundIDs     []string
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CapturedAt    *time.Time
+}
+
+// Refundable is what can still be paid back.
+func (p *Payment) Refundable() int {
+	if p.Status != PaymentCaptured {
+		return 0
+	}
+	return p.Amount - p.Refunded
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DiscountPercent takes a share of the order; DiscountFixed takes an
+	// amount in bani off the order, down to zero at most.
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// NormalizePromoCode makes codes case insensitive: they are kept and looked
+// up upper case.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// PromoCode is a discount an owner hands out for some of their events and
+// packets. Redemptions counts the purchases it was used for; MaxRedemptions
+// and MaxPerUser cap them overall and for each user when set.
+type PromoCode struct {
+	ID        string
+	Code      string
+	OwnerID   int
+	EventIDs  []int
+	PacketIDs []int
+
+	DiscountType  string
+	DiscountValue int
+
+	MaxRedemptions *int
+	MaxPerUser     *int
+	MinQuantity    int
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	Active         bool
+
+	Redemptions int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (p *PromoCode) Validate() error {
+	if !promoCodePattern.MatchString(p.Code) {
+		return &ValidationError{Field: "code", Reason: "code must be 3 to 32 letters, digits, '-' or '_'"}
+	}
+	if len(p.EventIDs) == 0 && len(p.PacketIDs) == 0 {
+		return &ValidationError{Field: "event_ids", Reason: "at least one event or packet is required"}
+	}
+	if slices.ContainsFunc(p.EventIDs, func(id int) bool { return id < 1 }) {
+		return &ValidationError{Field: "event_ids", Reason: "event ids must be positive"}
+	}
+	if slices.ContainsFunc(p.PacketIDs, func(id int) bool { return id < 1 }) {
+		return &ValidationError{Field: "packet_ids", Reason: "packet ids must be positive"}
+	}
+
+	switch p.DiscountType {
+	case DiscountPercent:
+		if p.DiscountValue < 1 || p.DiscountValue > 100 {
+			return &ValidationError{Field: "discount_value", Reason: "a percent discount must be between 1 and 100"}
+		}
+	case DiscountFixed:
+		if p.DiscountValue < 1 {
+			return &ValidationError{Field: "discount_value", Reason: "a fixed discount must be positive"}
+		}
+	default:
+		return &ValidationError{Field: "discount_type", Reason: "discount_type must be percent or fixed"}
+	}
+
+	if p.MaxRedemptions != nil && *p.MaxRedemptions < 1 {
+		return &ValidationError{Field: "max_redemptions", Reason: "max_redemptions must be positive"}
+	}
+	if p.MaxPerUser != nil && *p.MaxPerUser < 1 {
+		return &ValidationError{Field: "max_per_user", Reason: "max_per_user must be positive"}
+	}
+	if p.MinQuantity < 1 || p.MinQuantity > MaxTicketsPerPurchase {
+		return &ValidationError{Field: "min_quantity", Reason: fmt.Sprintf("min_quantity must be between 1 and %d", MaxTicketsPerPurchase)}
+	}
+	if p.ValidFrom != nil && p.ValidUntil != nil && !p.ValidUntil.After(*p.ValidFrom) {
+		return &ValidationError{Field: "valid_until", Reason: "valid_until must be after valid_from"}
+	}
+	return nil
+}
+
+// Covers reports whether the code may be used to buy target.
+func (p *PromoCode) Covers(target *WaitlistTarget) bool {
+	if target.EventID != nil {
+		return slices.Contains(p.EventIDs, *target.EventID)
+	}
+	return target.PacketID != nil && slices.Contains(p.PacketIDs, *target.PacketID)
+}
+
+// CheckApplicable tells why the code cannot be used to buy quantity tickets
+// of target at now; the usage limits are checked when it is redeemed.
+func (p *PromoCode) CheckApplicable(target *WaitlistTarget, quantity int, now time.Time) error {
+	switch {
+	case !p.Active:
+		return &PromoCodeNotApplicableError{Detail: fmt.Sprintf("promo code %s is no longer active", p.Code)}
+	case p.ValidFrom != nil && now.Before(*p.ValidFrom):
+		return &PromoCodeNotApplicableError{Detail: fmt.Sprintf("promo code %s is valid from %s", p.Code, p.ValidFrom.Format(time.RFC3339))}
+	case p.ValidUntil != nil && !now.Before(*p.ValidUntil):
+		return &PromoCodeNotApplicableError{Detail: fmt.Sprintf("promo code %s expired on %s", p.Code, p.ValidUntil.Format(time.RFC3339))}
+	case !p.Covers(target):
+		return &PromoCodeNotApplicableError{Detail: fmt.Sprintf("promo code %s does not apply to this event or packet", p.Code)}
+	case quantity < p.MinQuantity:
+		return &PromoCodeNotApplicableError{Detail: fmt.Sprintf("promo code %s needs at least %d tickets", p.Code, p.MinQuantity)}
+	}
+	return nil
+}
+
+// Discount is what the code takes off quantity tickets of unitPrice.
+func (p *PromoCode) Discount(unitPrice int, quantity int) int {
+	subtotal := unitPrice * quantity
+	if p.DiscountType == DiscountPercent {
+		return subtotal * p.DiscountValue / 100
+	}
+	return min(p.DiscountValue, subtotal)
+}
+
+const (
+	// RedemptionReserved holds a use of the code while the tickets are
+	// being issued; the use is given back if the purchase fails.
+	RedemptionReserved  = "reserved"
+	RedemptionCompleted = "completed"
+	RedemptionReleased  = "released"
+)
+
+// PromoRedemption is one purchase a promo code was used for.
+type PromoRedemption struct {
+	ID          string
+	PromoCodeID string
+	Code        string
+	UserID      int
+	EventID     *int
+	PacketID    *int
+	Quantity    int
+	Discount    int
+	TicketCodes []string
+	Status      string
+	RedeemedAt  time.Time
+	CompletedAt *time.Time
+}
+
+// PromoUserUsage sums up what one user bought with a promo code.
+type PromoUserUsage struct {
+	UserID      int
+	Redemptions int
+	Tickets     int
+	Discount    int
+	LastUsedAt  time.Time
+}
+
+// PromoCodeUsage reports the completed purchases a promo code was used for.
+type PromoCodeUsage struct {
+	PromoCode   *PromoCode
+	Redemptions int
+	Tickets     int
+	Discount    int
+	Users       []*PromoUserUsage
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxTicketsPerPurchase caps how many tickets one purchase may buy.
+const MaxTicketsPerPurchase = 10
+
+// PurchaseRequest is what a user asks to buy: Quantity tickets of an event
+// or a packet, optionally with a promo code.
+type PurchaseRequest struct {
+	EventID   *int
+	PacketID  *int
+	Quantity  int
+	PromoCode *string
+	// PaymentToken stands for the buyer's card at the payment gateway.
+	PaymentToken string
+}
+
+func (r *PurchaseRequest) Target() *WaitlistTarget {
+	return &WaitlistTarget{EventID: r.EventID, PacketID: r.PacketID}
+}
+
+func (r *PurchaseRequest) Validate() error {
+	if err := r.Target().Validate(); err != nil {
+		return err
+	}
+	if r.Quantity < 1 || r.Quantity > MaxTicketsPerPurchase {
+		return &ValidationError{Field: "quantity", Reason: fmt.Sprintf("quantity must be between 1 and %d", MaxTicketsPerPurchase)}
+	}
+	return nil
+}
+
+// Purchase is the outcome of buying Quantity tickets of an event or packet.
+// UnitPrice and Total are nil when no price is set; Payment is nil when
+// there was nothing to pay.
+type Purchase struct {
+	OrderID     string
+	TicketCodes []string
+	EventID     *int
+	PacketID    *int
+	Quantity    int
+	UnitPrice   *int
+	Discount    int
+	Total       *int
+	PromoCode   *string
+	Payment     *Payment
+	ReceiptID   *string
+}
+
+const (
+	OrderPending   = "pending"
+	OrderCompleted = "completed"
+	OrderFailed    = "failed"
+)
+
+// Order records one purchase from the moment it is priced: what was bought,
+// for how much and, once completed, the tickets issued for it. An order
+// that could not be paid for or issued is kept as failed.
+type Order struct {
+	ID            string
+	UserID        int
+	EventID       *int
+	PacketID      *int
+	Quantity      int
+	UnitPrice     *int
+	Discount      int
+	Total         *int
+	PromoCode     *string
+	TicketCodes   []string
+	Status        string
+	FailureReason string
+	CreatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// Due is what the buyer has to pay for the order, in bani.
+func (o *Order) Due() int {
+	if o.Total == nil {
+		return 0
+	}
+	return *o.Total
+}
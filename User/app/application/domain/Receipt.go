@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Receipt is the numbered proof of a completed order. Each organizer, the
+// owner of what was bought, has their own series, numbered from 1 without
+// gaps. Prices include VAT; TaxAmount is the VAT share of Total at TaxRate
+// percent.
+type Receipt struct {
+	ID          string
+	OrderID     string
+	OrganizerID int
+	Number      int
+	UserID      int
+	BuyerName   string
+	BuyerEmail  string
+	Item        ReceiptItem
+	Lines       []ReceiptLine
+	Subtotal    int
+	Discount    int
+	Total       int
+	TaxRate     int
+	TaxAmount   int
+	Currency    string
+	TicketCodes []string
+	PaymentID   *string
+	IssuedAt    time.Time
+}
+
+// Series names the numbering a receipt belongs to.
+func (r *Receipt) Series() string {
+	return fmt.Sprintf("ORG%d", r.OrganizerID)
+}
+
+// DisplayNumber is the number printed on the receipt, such as ORG7-000042.
+func (r *Receipt) DisplayNumber() string {
+	return fmt.Sprintf("%s-%06d", r.Series(), r.Number)
+}
+
+// ReceiptItem is the event or packet an order bought, as it was when the
+// receipt was issued.
+type ReceiptItem struct {
+	EventID  *int
+	PacketID *int
+	Name     string
+	Location *string
+	City     *string
+	Country  *string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// ReceiptLine is one priced line; a discount is a line with a negative
+// amount.
+type ReceiptLine struct {
+	Description string
+	Quantity    int
+	UnitPrice   int
+	Amount      int
+}
+
+// IncludedTax is the VAT share of a gross amount at rate percent, rounded
+// to the nearest ban.
+func IncludedTax(gross int, rate int) int {
+	if rate <= 0 {
+		return 0
+	}
+	return (2*gross*rate + 100 + rate) / (2 * (100 + rate))
+}
+
+// NewReceipt builds the receipt of a completed order bought from item,
+// leaving its number to be assigned when it is stored.
+func NewReceipt(order *Order, buyer *User, item *ReceiptItem, organizerID int, taxRate int, payment *Payment, issuedAt time.Time) *Receipt {
+	unitPrice := 0
+	if order.UnitPrice != nil {
+		unitPrice = *order.UnitPrice
+	}
+	kind := "Ticket"
+	if item.PacketID != nil {
+		kind = "Packet ticket"
+	}
+
+	lines := []ReceiptLine{{
+		Description: fmt.Sprintf("%s - %s", kind, item.Name),
+		Quantity:    order.Quantity,
+		UnitPrice:   unitPrice,
+		Amount:      unitPrice * order.Quantity,
+	}}
+	if order.Discount > 0 {
+		description := "Discount"
+		if order.PromoCode != nil {
+			description = fmt.Sprintf("Promo code %s", *order.PromoCode)
+		}
+		lines = append(lines, ReceiptLine{
+			Description: description,
+			Quantity:    1,
+			UnitPrice:   -order.Discount,
+			Amount:      -order.Discount,
+		})
+	}
+
+	receipt := &Receipt{
+		OrderID:     order.ID,
+		OrganizerID: organizerID,
+		UserID:      order.UserID,
+		BuyerName:   fmt.Sprintf("%s %s", buyer.FirstName, buyer.LastName),
+		BuyerEmail:  buyer.Email,
+		Item:        *item,
+		Lines:       lines,
+		Subtotal:    unitPrice * order.Quantity,
+		Discount:    order.Discount,
+		Total:       order.Due(),
+		TaxRate:     taxRate,
+		TaxAmount:   IncludedTax(order.Due(), taxRate),
+		Currency:    PaymentCurrency,
+		TicketCodes: order.TicketCodes,
+		IssuedAt:    issuedAt,
+	}
+	if payment != nil {
+		receipt.PaymentID = &payment.ID
+	}
+	return receipt
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	RefundRequested = "requested"
+	// RefundApproving marks an approved refund whose ticket has not been
+	// voided and removed yet; approving it again resumes from where it
+	// stopped.
+	RefundApproving = "approving"
+	RefundApproved  = "approved"
+	RefundDenied    = "denied"
+)
+
+// RefundRequest asks the owner of an event or packet to take a ticket back.
+// Amount, in bani, and the decision fields are set once it is decided.
+type RefundRequest struct {
+	ID           string
+	TicketCode   string
+	EventID      *int
+	PacketID     *int
+	UserID       int
+	Reason       string
+	Status       string
+	Amount       *int
+	DecisionNote string
+	DecidedBy    *int
+	RequestedAt  time.Time
+	DecidedAt    *time.Time
+}
+
+// Open reports whether the refund still holds the ticket.
+func (r *RefundRequest) Open() bool {
+	return r.Status == RefundRequested || r.Status == RefundApproving
+}
+
+// Target is the event or packet whose seat the refund frees.
+func (r *RefundRequest) Target() *WaitlistTarget {
+	return &WaitlistTarget{EventID: r.EventID, PacketID: r.PacketID}
+}
+
+// RefundDecision is what an owner or the client service records when
+// answering a refund.
+type RefundDecision struct {
+	DecidedBy int
+	Amount    *int
+	Note      string
+}
+
+// RefundTerms are the refund rules of the event or packet a ticket was
+// bought for, as its owner set them.
+type RefundTerms struct {
+	OwnerID     int
+	FaceValue   *int
+	StartsAt    *time.Time
+	WindowHours *int
+}
+
+// CheckWindow returns a RefundNotAllowedError when the owner does not refund
+// tickets or the window already closed. Without a schedule the window never
+// closes.
+func (t *RefundTerms) CheckWindow(now time.Time) error {
+	if t.WindowHours == nil {
+		return &RefundNotAllowedError{}
+	}
+	if t.StartsAt == nil {
+		return nil
+	}
+	deadline := t.StartsAt.Add(-time.Duration(*t.WindowHours) * time.Hour)
+	if !now.Before(deadline) {
+		return &RefundNotAllowedError{Detail: fmt.Sprintf("refunds closed at %s", deadline.UTC().Format(time.RFC3339))}
+	}
+	return nil
+}
+
+// Amount settles what is paid back: the face value unless the decider picks
+// less. It fails when neither is known.
+func (t *RefundTerms) Amount(requested *int) (*int, error) {
+	if requested == nil {
+		if t.FaceValue == nil {
+			return nil, &ValidationError{Field: "amount", Reason: "amount is required when the ticket has no price"}
+		}
+		amount := *t.FaceValue
+		return &amount, nil
+	}
+	if *requested < 0 {
+		return nil, &ValidationError{Field: "amount", Reason: "amount cannot be negative"}
+	}
+	if t.FaceValue != nil && *requested > *t.FaceValue {
+		return nil, &ValidationError{Field: "amount", Reason: fmt.Sprintf("amount cannot exceed the ticket price of %d", *t.FaceValue)}
+	}
+	return requested, nil
+}
+
+// RefundFilter picks the refunds of one event or packet for the ones
+// deciding them.
+type RefundFilter struct {
+	EventID  *int
+	PacketID *int
+	Status   *string
+}
+
+func (filter *RefundFilter) Validate() error {
+	if (filter.EventID == nil) == (filter.PacketID == nil) {
+		return &ValidationError{Field: "event_id", Reason: "exactly one of event_id and packet_id is required"}
+	}
+	if filter.EventID != nil && *filter.EventID < 1 {
+		return &ValidationError{Field: "event_id", Reason: "event_id must be positive"}
+	}
+	if filter.PacketID != nil && *filter.PacketID < 1 {
+		return &ValidationError{Field: "packet_id", Reason: "packet_id must be positive"}
+	}
+	return ValidateRefundStatus(filter.Status)
+}
+
+func ValidateRefundStatus(status *string) error {
+	if status == nil {
+		return nil
+	}
+	switch *status {
+	case RefundRequested, RefundApproving, RefundApproved, RefundDenied:
+		return nil
+	}
+	return &ValidationError{Field: "status", Reason: "must be requested, approving, approved or denied"}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+const (
+	ListingActive = "active"
+	// ListingSelling marks a listing a buyer is purchasing whose reissue or
+	// ownership move has not finished yet; the same buyer purchasing again
+	// resumes from where it stopped.
+	ListingSelling   = "selling"
+	ListingSold      = "sold"
+	ListingCancelled = "cancelled"
+)
+
+// ResaleListing offers a ticket its holder cannot use to other users. The
+// price is in bani. NewCode is picked when a buyer claims the listing, so
+// every retry of the purchase reissues the ticket to the same code.
+type ResaleListing struct {
+	ID         string
+	TicketCode string
+	NewCode    string
+	EventID    *int
+	PacketID   *int
+	SellerID   int
+	BuyerID    *int
+	Price      int
+	Status     string
+	CreatedAt  time.Time
+	SoldAt     *time.Time
+}
+
+// Open reports whether the listing still holds the ticket.
+func (l *ResaleListing) Open() bool {
+	return l.Status == ListingActive || l.Status == ListingSelling
+}
+
+// ResaleMaxPrice is the most a ticket with faceValue may be resold for under
+// a markup cap, or nil when there is no cap. It mirrors the check EventManager
+// makes when the ticket is reissued.
+func ResaleMaxPrice(faceValue *int, maxMarkupPercent *int) *int {
+	if maxMarkupPercent == nil {
+		return nil
+	}
+	value := 0
+	if faceValue != nil {
+		value = *faceValue
+	}
+	maxPrice := value * (100 + *maxMarkupPercent) / 100
+	return &maxPrice
+}
+
+// ResaleListingFilter picks the active listings of one event or packet.
+type ResaleListingFilter struct {
+	EventID  *int
+	PacketID *int
+}
+
+func (filter *ResaleListingFilter) Validate() error {
+	if (filter.EventID == nil) == (filter.PacketID == nil) {
+		return &ValidationError{Field: "event_id", Reason: "exactly one of event_id and packet_id is required"}
+	}
+	if filter.EventID != nil && *filter.EventID < 1 {
+		return &ValidationError{Field: "event_id", Reason: "event_id must be positive"}
+	}
+	if filter.PacketID != nil && *filter.PacketID < 1 {
+		return &ValidationError{Field: "packet_id", Reason: "packet_id must be positive"}
+	}
+	return nil
+}
+
+// ValidateListingStatus checks the status a seller filters their listings
+// by.
+func ValidateListingStatus(status *string) error {
+	if status == nil {
+		return nil
+	}
+	switch *status {
+	case ListingActive, ListingSelling, ListingSold, ListingCancelled:
+		return nil
+	}
+	return &ValidationError{Field: "status", Reason: "must be active, selling, sold or cancelled"}
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// EventSummary is what the User service shows of an EventManager event.
+type EventSummary struct {
+	ID       int
+	Name     string
+	Location *string
+	Address  *string
+	City     *string
+	Country  *string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	// CancelledAt is set once the owner calls the event off; Sequence
+	// counts the changes to the event, for calendars
+	CancelledAt *time.Time
+	Sequence    int
+	// TransfersBlocked is set by the owner to keep tickets with their buyers
+	TransfersBlocked bool
+
+	// Price is the face value of a ticket, in bani; the owner may allow
+	// resale up to a markup over it
+	Price                  *int
+	ResaleAllowed          bool
+	ResaleMaxMarkupPercent *int
+
+	// OwnerID decides refunds, which can be asked for until RefundWindowHours
+	// before the start; without a window the ticket is not refunded
+	OwnerID           int
+	RefundWindowHours *int
+
+	// MaxTicketsPerUser caps the tickets one user may hold, counting those
+	// of packets including the event
+	MaxTicketsPerUser *int
+}
+
+// PacketSummary is what the User service shows of an EventManager packet;
+// its schedule spans the events it includes, it blocks transfers when any of
+// them does and allows resale only when all of them do.
+type PacketSummary struct {
+	ID       int
+	Name     string
+	Location *string
+	City     *string
+	Country  *string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+
+	TransfersBlocked bool
+
+	Price                  *int
+	ResaleAllowed          bool
+	ResaleMaxMarkupPercent *int
+
+	OwnerID           int
+	RefundWindowHours *int
+
+	MaxTicketsPerUser *int
+}
+
+// OwnedTicket is a ticket of a user together with what it was bought for.
+// Event or Packet is nil when EventManager no longer knows it.
+type OwnedTicket struct {
+	Ticket
+	Event  *EventSummary
+	Packet *PacketSummary
+}
+
+// Past reports whether what the ticket was bought for is over. A ticket
+// without a schedule is never past.
+func (t *OwnedTicket) Past(now time.Time) bool {
+	var startsAt, endsAt *time.Time
+	switch {
+	case t.Event != nil:
+		startsAt, endsAt = t.Event.StartsAt, t.Event.EndsAt
+	case t.Packet != nil:
+		startsAt, endsAt = t.Packet.StartsAt, t.Packet.EndsAt
+	}
+	if endsAt == nil {
+		endsAt = startsAt
+	}
+	return endsAt != nil && endsAt.Before(now)
+}
+
+// ETicket is a ticket as printed for its holder.
+type ETicket struct {
+	OwnedTicket
+	HolderName string
+}
+
+const (
+	TicketsUpcoming = "upcoming"
+	TicketsPast     = "past"
+)
+
+type TicketFilter struct {
+	When *string
+}
+
+func (filter *TicketFilter) Validate() error {
+	if filter.When == nil {
+		return nil
+	}
+	switch *filter.When {
+	case TicketsUpcoming, TicketsPast:
+		return nil
+	}
+	return &ValidationError{Field: "when", Reason: "when must be one of upcoming, past"}
+}
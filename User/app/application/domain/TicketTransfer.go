@@ -0,0 +1,124 @@
+package domain
+
+import "time"
+
+const (
+	TransferPending = "pending"
+	// TransferAccepting marks a transfer the recipient accepted whose code
+	// rotation or ownership move has not finished yet; accepting it again
+	// resumes from where it stopped.
+	TransferAccepting = "accepting"
+	TransferAccepted  = "accepted"
+	TransferDeclined  = "declined"
+	TransferCancelled = "cancelled"
+	TransferExpired   = "expired"
+)
+
+// TransferOfferTTL is how long a recipient has to answer a transfer.
+const TransferOfferTTL = 7 * 24 * time.Hour
+
+// TicketTransfer hands a ticket from its holder to another registered user.
+// NewCode is picked when the transfer is made, so every retry of the
+// acceptance rotates the ticket to the same code.
+type TicketTransfer struct {
+	ID             string
+	TicketCode     string
+	NewCode        string
+	EventID        *int
+	PacketID       *int
+	FromUserID     int
+	ToUserID       int
+	RecipientEmail string
+	Status         string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	CompletedAt    *time.Time
+}
+
+// Open reports whether the transfer still holds the ticket.
+func (t *TicketTransfer) Open() bool {
+	return t.Status == TransferPending || t.Status == TransferAccepting
+}
+
+// Expired reports whether a pending transfer ran out of time. Transfers
+// being accepted never expire, so an acceptance that stopped half way can
+// still finish.
+func (t *TicketTransfer) Expired(now time.Time) bool {
+	return t.Status == TransferPending && !now.Before(t.ExpiresAt)
+}
+
+// Direction of a transfer as seen by a user listing theirs.
+const (
+	TransferOutgoing = "outgoing"
+	TransferIncoming = "incoming"
+)
+
+type TicketTransferFilter struct {
+	Direction *string
+	Status    *string
+}
+
+func (filter *TicketTransferFilter) Validate() error {
+	if filter.Direction != nil && *filter.Direction != TransferOutgoing && *filter.Direction != TransferIncoming {
+		return &ValidationError{Field: "direction", Reason: "must be outgoing or incoming"}
+	}
+	if filter.Status != nil {
+		switch *filter.Status {
+		case TransferPending, TransferAccepting, TransferAccepted, TransferDeclined, TransferCancelled, TransferExpired:
+		default:
+			return &ValidationError{Field: "status", Reason: "must be pending, accepting, accepted, declined, cancelled or expired"}
+		}
+	}
+	return nil
+}
+
+// Ticket audit actions, recorded once for each party of a transfer.
+const (
+	AuditTransferRequested = "transfer_requested"
+	AuditTransferAccepted  = "transfer_accepted"
+	AuditTransferDeclined  = "transfer_declined"
+	AuditTransferCancelled = "transfer_cancelled"
+)
+
+// TicketAuditEntry records something that happened to a ticket a user held
+// or was offered. TicketCode is the code as the user knows it: recipients
+// only learn the code once the ticket is theirs.
+type TicketAuditEntry struct {
+	ID             string
+	UserID         int
+	Action         string
+	Direction      string
+	TicketCode     string
+	EventID        *int
+	PacketID       *int
+	TransferID     string
+	CounterpartyID int
+	At             time.Time
+}
+
+// TransferAuditEntries returns the entries both parties get when transfer
+// goes through action.
+func TransferAuditEntries(transfer *TicketTransfer, action string, at time.Time) []*TicketAuditEntry {
+	outgoing := &TicketAuditEntry{
+		UserID:         transfer.FromUserID,
+		Action:         action,
+		Direction:      TransferOutgoing,
+		TicketCode:     transfer.TicketCode,
+		EventID:        transfer.EventID,
+		PacketID:       transfer.PacketID,
+		TransferID:     transfer.ID,
+		CounterpartyID: transfer.ToUserID,
+		At:             at,
+	}
+
+	incoming := *outgoing
+	incoming.UserID = transfer.ToUserID
+	incoming.Direction = TransferIncoming
+	incoming.CounterpartyID = transfer.FromUserID
+	incoming.TicketCode = ""
+	if action == AuditTransferAccepted {
+		incoming.TicketCode = transfer.NewCode
+	}
+
+	return []*TicketAuditEntry{outgoing, &incoming}
+}
@@ -3,12 +3,14 @@ package domain
 import (
 	"regexp"
 	"strings"
+	"time"
 )
 
 type Ticket struct {
-	PacketID *int
-	EventID  *int
-	Code     string
+	PacketID    *int
+	EventID     *int
+	Code        string
+	PurchasedAt time.Time
 }
 
 type User struct {
@@ -23,6 +25,60 @@ type User struct {
 	LastNamePrivate  bool
 }
 
+// Customer is a user who bought tickets for an event or packet, along with
+// how many they bought and when.
+type Customer struct {
+	User
+	TicketCount      int
+	FirstPurchasedAt time.Time
+	LastPurchasedAt  time.Time
+}
+
+// PrivateNamePlaceholder replaces the names a customer keeps private in
+// everything shown to event owners.
+const PrivateNamePlaceholder = "[Private]"
+
+// HolderName is the name printed on the user's tickets. Names the user
+// keeps private are left out, and the placeholder stands in when both are.
+func (u *User) HolderName() string {
+	var parts []string
+	if !u.FirstNamePrivate && strings.TrimSpace(u.FirstName) != "" {
+		parts = append(parts, strings.TrimSpace(u.FirstName))
+	}
+	if !u.LastNamePrivate && strings.TrimSpace(u.LastName) != "" {
+		parts = append(parts, strings.TrimSpace(u.LastName))
+	}
+	if len(parts) == 0 {
+		return PrivateNamePlaceholder
+	}
+	return strings.Join(parts, " ")
+}
+
+// Masked returns a copy of the customer as event owners may see it: private
+// names are replaced and the ticket list is left out.
+func (c *Customer) Masked() *Customer {
+	masked := &Customer{
+		User: User{
+			ID:        c.ID,
+			Email:     c.Email,
+			FirstName: c.FirstName,
+			LastName:  c.LastName,
+
+			SocialMediaLinks: c.SocialMediaLinks,
+		},
+		TicketCount:      c.TicketCount,
+		FirstPurchasedAt: c.FirstPurchasedAt,
+		LastPurchasedAt:  c.LastPurchasedAt,
+	}
+	if c.FirstNamePrivate {
+		masked.FirstName = PrivateNamePlaceholder
+	}
+	if c.LastNamePrivate {
+		masked.LastName = PrivateNamePlaceholder
+	}
+	return masked
+}
+
 func validateEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 
 type ValidationError struct {
@@ -13,6 +16,20 @@ func (e *ValidationError) Error() string {
 }
 
 
+// ValidationErrors collects every failing field of a request body.
+type ValidationErrors struct {
+	Errors []*ValidationError
+}
+
+func (e *ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+
 type NotFoundError struct {
 	ID int
 }
@@ -93,3 +110,339 @@ func (e *DatabaseError) Error() string {
 func (e *DatabaseError) Unwrap() error {
 	return e.Err
 }
+
+
+// Machine-readable codes EventManager attaches to seat-availability failures.
+const (
+	CodeEventSoldOut          = "EVENT_SOLD_OUT"
+	CodePacketSoldOut         = "PACKET_SOLD_OUT"
+	CodeCapacityNotConfigured = "CAPACITY_NOT_CONFIGURED"
+)
+
+
+// SoldOutError is returned when EventManager has no seat left for the
+// requested event or packet. Remaining is kept for the client.
+type SoldOutError struct {
+	Code      string
+	Resource  string
+	ID        int
+	Capacity  int
+	Sold      int
+	Reserved  int
+	Remaining int
+	Detail    string
+}
+
+func (e *SoldOutError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return fmt.Sprintf("%s %d is sold out", e.Resource, e.ID)
+}
+
+
+// CapacityNotConfiguredError is returned when the event or packet has no
+// seats defined, so no ticket can be sold for it.
+type CapacityNotConfiguredError struct {
+	Detail string
+}
+
+func (e *CapacityNotConfiguredError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return "no seats are configured for this event or packet"
+}
+
+
+// CodeEventCancelled is the code EventManager attaches to ticket sales for
+// an event its owner called off.
+const CodeEventCancelled = "EVENT_CANCELLED"
+
+
+// EventCancelledError is returned when the event, or an event the packet
+// includes, was cancelled, so no ticket can be sold for it.
+type EventCancelledError struct {
+	Detail string
+}
+
+func (e *EventCancelledError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return "the event was cancelled"
+}
+
+
+// ServiceUnavailableError is returned when a downstream service is failing
+// and calls to it are being short-circuited.
+type ServiceUnavailableError struct {
+	Service string
+}
+
+func (e *ServiceUnavailableError) Error() string {
+	return fmt.Sprintf("%s service is temporarily unavailable", e.Service)
+}
+
+
+// ResourceNotFoundError is returned for missing records that are not users,
+// such as tickets and transfers.
+type ResourceNotFoundError struct {
+	Resource string
+	ID       string
+}
+
+func (e *ResourceNotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.ID)
+}
+
+
+// CodeTransfersBlocked is the code EventManager attaches when the owner of
+// an event does not allow its tickets to change hands.
+const CodeTransfersBlocked = "TRANSFERS_BLOCKED"
+
+
+// TransfersBlockedError is returned when a ticket is transferred for an
+// event, or a packet including an event, that blocks transfers.
+type TransfersBlockedError struct {
+	Detail string
+}
+
+func (e *TransfersBlockedError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return "the event owner does not allow ticket transfers"
+}
+
+
+// TransferStateError is returned when a transfer is answered or cancelled
+// after it already left the state the action needs.
+type TransferStateError struct {
+	ID     string
+	Status string
+}
+
+func (e *TransferStateError) Error() string {
+	return fmt.Sprintf("transfer %s is %s", e.ID, e.Status)
+}
+
+
+// CodeResaleNotAllowed and CodeResalePriceAboveCap are the codes EventManager
+// attaches when the owner's resale policy refuses a resale.
+const (
+	CodeResaleNotAllowed    = "RESALE_NOT_ALLOWED"
+	CodeResalePriceAboveCap = "RESALE_PRICE_ABOVE_CAP"
+)
+
+
+// ResaleNotAllowedError is returned when a ticket is resold for an event, or
+// a packet including an event, whose owner does not allow resale.
+type ResaleNotAllowedError struct {
+	Detail string
+}
+
+func (e *ResaleNotAllowedError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return "the event owner does not allow ticket resale"
+}
+
+
+// ResalePriceAboveCapError is returned when a ticket is resold for more than
+// the markup the owner allows over its face value.
+type ResalePriceAboveCapError struct {
+	MaxPrice *int
+	Detail   string
+}
+
+func (e *ResalePriceAboveCapError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	if e.MaxPrice != nil {
+		return fmt.Sprintf("this ticket cannot be resold for more than %d", *e.MaxPrice)
+	}
+	return "the price is above the markup the event owner allows"
+}
+
+
+// ListingStateError is returned when a resale listing is bought or cancelled
+// after it already left the state the action needs.
+type ListingStateError struct {
+	ID     string
+	Status string
+}
+
+func (e *ListingStateError) Error() string {
+	return fmt.Sprintf("listing %s is %s", e.ID, e.Status)
+}
+
+
+// CodeRefundNotAllowed is attached when the owner does not refund a ticket,
+// or no longer does because its refund window closed.
+const CodeRefundNotAllowed = "REFUND_NOT_ALLOWED"
+
+
+// RefundNotAllowedError is returned when a refund is requested outside the
+// window the owner of the event or packet allows.
+type RefundNotAllowedError struct {
+	Detail string
+}
+
+func (e *RefundNotAllowedError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return "the event owner does not refund this ticket"
+}
+
+
+// RefundStateError is returned when a refund is decided after it already
+// left the state the action needs, or a ticket with an open refund is
+// refunded, transferred or listed again.
+type RefundStateError struct {
+	ID     string
+	Status string
+}
+
+func (e *RefundStateError) Error() string {
+	return fmt.Sprintf("refund %s is %s", e.ID, e.Status)
+}
+
+
+// WaitlistStateError is returned when a user joins a waitlist they are
+// already in, or leaves an entry that is no longer open.
+type WaitlistStateError struct {
+	ID     string
+	Status string
+}
+
+func (e *WaitlistStateError) Error() string {
+	return fmt.Sprintf("waitlist entry %s is %s", e.ID, e.Status)
+}
+
+
+// CodePromoCodeNotApplicable and CodePromoCodeExhausted tell a promo code
+// that cannot be used for the purchase from one that ran out of uses.
+const (
+	CodePromoCodeNotApplicable = "PROMO_CODE_NOT_APPLICABLE"
+	CodePromoCodeExhausted     = "PROMO_CODE_EXHAUSTED"
+)
+
+
+// PromoCodeNotApplicableError is returned when a purchase names a promo
+// code that is unknown, inactive, outside its validity window, meant for
+// other events or packets, or needs more tickets.
+type PromoCodeNotApplicableError struct {
+	Detail string
+}
+
+func (e *PromoCodeNotApplicableError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return "the promo code does not apply to this purchase"
+}
+
+
+// PromoCodeExhaustedError is returned when a promo code was already used as
+// many times as it may be, overall or by the buyer.
+type PromoCodeExhaustedError struct {
+	Code    string
+	PerUser bool
+}
+
+func (e *PromoCodeExhaustedError) Error() string {
+	if e.PerUser {
+		return fmt.Sprintf("promo code %s was already used as many times as one user may", e.Code)
+	}
+	return fmt.Sprintf("promo code %s has no uses left", e.Code)
+}
+
+
+// PromoCodeTakenError is returned when a promo code is created with a code
+// that already exists; codes are typed in at purchase, so they are unique
+// across owners.
+type PromoCodeTakenError struct {
+	Code string
+}
+
+func (e *PromoCodeTakenError) Error() string {
+	return fmt.Sprintf("promo code %s already exists", e.Code)
+}
+
+
+// PromoCodeInUseError is returned when a promo code that was already
+// redeemed is deleted; it can be deactivated instead.
+type PromoCodeInUseError struct {
+	Code string
+}
+
+func (e *PromoCodeInUseError) Error() string {
+	return fmt.Sprintf("promo code %s was already redeemed; deactivate it instead", e.Code)
+}
+
+
+// CodePurchaseLimitReached is attached when a purchase would leave the buyer
+// with more tickets than the owner allows one user to hold.
+const CodePurchaseLimitReached = "PURCHASE_LIMIT_REACHED"
+
+
+// PurchaseLimitError is returned when buying would take the tickets userID
+// holds for an event or packet over its per-user limit. Held counts the
+// tickets of the event bought directly and through packets including it.
+type PurchaseLimitError struct {
+	Resource string
+	ID       int
+	Limit    int
+	Held     int
+}
+
+func (e *PurchaseLimitError) Error() string {
+	return fmt.Sprintf("%s %d allows at most %d tickets per user and %d are already held", e.Resource, e.ID, e.Limit, e.Held)
+}
+
+
+// CodePaymentDeclined is attached when the payment gateway refuses to
+// authorize the amount of a purchase.
+const CodePaymentDeclined = "PAYMENT_DECLINED"
+
+
+// PaymentDeclinedError is returned when the buyer's card was refused; no
+// tickets are issued.
+type PaymentDeclinedError struct {
+	Reason string
+}
+
+func (e *PaymentDeclinedError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("payment declined: %s", e.Reason)
+	}
+	return "payment declined"
+}
+
+
+// PaymentGatewayError is returned when the payment gateway could not carry
+// out Operation, such as a capture or a refund.
+type PaymentGatewayError struct {
+	Operation string
+	Detail    string
+}
+
+func (e *PaymentGatewayError) Error() string {
+	return fmt.Sprintf("payment gateway failed to %s: %s", e.Operation, e.Detail)
+}
+
+
+// PaymentStateError is returned when a payment is captured, voided or
+// refunded after it already left the state the action needs.
+type PaymentStateError struct {
+	ID     string
+	Status string
+}
+
+func (e *PaymentStateError) Error() string {
+	return fmt.Sprintf("payment %s is %s", e.ID, e.Status)
+}
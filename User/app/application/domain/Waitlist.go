@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+const (
+	WaitlistWaiting = "waiting"
+	// WaitlistOffered marks an entry whose user may buy one of the freed
+	// seats until the offer expires.
+	WaitlistOffered  = "offered"
+	WaitlistAccepted = "accepted"
+	WaitlistExpired  = "expired"
+	WaitlistLeft     = "left"
+)
+
+// WaitlistOfferTTL is how long an offered user has to buy the seat before
+// it goes to the next one in line.
+const WaitlistOfferTTL = time.Hour
+
+// WaitlistTarget is the sold out event or packet a waitlist is kept for.
+type WaitlistTarget struct {
+	EventID  *int
+	PacketID *int
+}
+
+func (target *WaitlistTarget) Validate() error {
+	if (target.EventID == nil) == (target.PacketID == nil) {
+		return &ValidationError{Field: "event_id", Reason: "exactly one of event_id and packet_id is required"}
+	}
+	if target.EventID != nil && *target.EventID < 1 {
+		return &ValidationError{Field: "event_id", Reason: "event_id must be positive"}
+	}
+	if target.PacketID != nil && *target.PacketID < 1 {
+		return &ValidationError{Field: "packet_id", Reason: "packet_id must be positive"}
+	}
+	return nil
+}
+
+// WaitlistEntry is a user waiting for a seat of an event or packet. Users
+// are offered seats in the order they joined.
+type WaitlistEntry struct {
+	ID             string
+	EventID        *int
+	PacketID       *int
+	UserID         int
+	Status         string
+	JoinedAt       time.Time
+	OfferedAt      *time.Time
+	OfferExpiresAt *time.Time
+	ClosedAt       *time.Time
+	// Position counts the users still waiting ahead, only while waiting
+	Position *int
+}
+
+func (e *WaitlistEntry) Target() *WaitlistTarget {
+	return &WaitlistTarget{EventID: e.EventID, PacketID: e.PacketID}
+}
+
+// Open reports whether the user is still in line or holds an offer.
+func (e *WaitlistEntry) Open() bool {
+	return e.Status == WaitlistWaiting || e.Status == WaitlistOffered
+}
+
+// ValidateWaitlistStatus checks the status a user filters their entries by.
+func ValidateWaitlistStatus(status *string) error {
+	if status == nil {
+		return nil
+	}
+	switch *status {
+	case WaitlistWaiting, WaitlistOffered, WaitlistAccepted, WaitlistExpired, WaitlistLeft:
+		return nil
+	}
+	return &ValidationError{Field: "status", Reason: "must be waiting, offered, accepted, expired or left"}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"userService/application/domain"
+)
+
+type CalendarFeedRepository interface {
+	// GetByUserID returns a ResourceNotFoundError when the user has no feed
+	// yet.
+	GetByUserID(ctx context.Context, userID int) (*domain.CalendarFeed, error)
+	// Create stores feed unless the user already has one, in which case the
+	// stored feed is returned instead.
+	Create(ctx context.Context, feed *domain.CalendarFeed) (*domain.CalendarFeed, error)
+	// Replace gives the user's feed a new token, creating the feed if needed.
+	Replace(ctx context.Context, feed *domain.CalendarFeed) (*domain.CalendarFeed, error)
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "shared/idempotency"
+
+type IdempotencyRepository interface {
+	idempotency.Store
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"userService/application/domain"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	GetByID(ctx context.Context, id string) (*domain.Order, error)
+	GetByUserID(ctx context.Context, userID int) ([]*domain.Order, error)
+
+	// Complete records the tickets issued for a pending order; Fail records
+	// why a pending order was given up.
+	Complete(ctx context.Context, id string, ticketCodes []string, at time.Time) (*domain.Order, error)
+	Fail(ctx context.Context, id string, reason string) (*domain.Order, error)
+}
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
+	GetByID(ctx context.Context, id string) (*domain.Payment, error)
+	// GetByOrderID and GetByReference return a ResourceNotFoundError when no
+	// payment matches; so does GetByTicketCode, which finds the captured
+	// payment a ticket was bought with.
+	GetByOrderID(ctx context.Context, orderID string) (*domain.Payment, error)
+	GetByReference(ctx context.Context, reference string) (*domain.Payment, error)
+	GetByTicketCode(ctx context.Context, code string) (*domain.Payment, error)
+
+	// UpdateStatus moves the payment to status if it is in one of from,
+	// recording ticketCodes and reason when given, and returns a
+	// PaymentStateError naming its current status otherwise.
+	UpdateStatus(ctx context.Context, id string, from []string, status string, ticketCodes []string, reason string, at time.Time) (*domain.Payment, error)
+	// AddRefund counts amount as paid back by refundID, once per refund.
+	AddRefund(ctx context.Context, id string, refundID string, amount int, at time.Time) (*domain.Payment, error)
+	// SyncRefunded raises the amount paid back to what the gateway reports.
+	SyncRefunded(ctx context.Context, id string, refunded int, at time.Time) (*domain.Payment, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"userService/application/domain"
+)
+
+type PromoCodeRepository interface {
+	// Create fails with a PromoCodeTakenError when the code is already
+	// used by any owner.
+	Create(ctx context.Context, promo *domain.PromoCode) (*domain.PromoCode, error)
+	GetByID(ctx context.Context, id string) (*domain.PromoCode, error)
+	GetByCode(ctx context.Context, code string) (*domain.PromoCode, error)
+	GetByOwnerID(ctx context.Context, ownerID int) ([]*domain.PromoCode, error)
+	Update(ctx context.Context, id string, updates map[string]interface{}, updatedAt time.Time) (*domain.PromoCode, error)
+	// Delete fails with a PromoCodeInUseError once the code was redeemed.
+	Delete(ctx context.Context, id string) error
+
+	// Redeem takes one use of the code for userID unless that would go over
+	// its overall or per-user limit, in which case it returns a
+	// PromoCodeExhaustedError. The check and the count are one write, so
+	// concurrent purchases cannot redeem more uses than allowed.
+	Redeem(ctx context.Context, id string, userID int) (*domain.PromoCode, error)
+	// Release gives back a use taken by Redeem.
+	Release(ctx context.Context, id string, userID int) error
+}
+
+type PromoRedemptionRepository interface {
+	Create(ctx context.Context, redemption *domain.PromoRedemption) (*domain.PromoRedemption, error)
+	// UpdateStatus moves a reserved redemption to status, recording the
+	// tickets bought when it completes.
+	UpdateStatus(ctx context.Context, id string, status string, ticketCodes []string, at time.Time) error
+	// GetUsage sums up the completed redemptions of a code, per user.
+	GetUsage(ctx context.Context, promoCodeID string) (*domain.PromoCodeUsage, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"userService/application/domain"
+)
+
+type ReceiptRepository interface {
+	// Issue stores receipt under the next number of its organizer's series
+	// and returns it numbered. An order has one receipt; issuing it again
+	// returns the one stored.
+	Issue(ctx context.Context, receipt *domain.Receipt) (*domain.Receipt, error)
+	GetByID(ctx context.Context, id string) (*domain.Receipt, error)
+	// GetByOrderID returns a ResourceNotFoundError when the order has no
+	// receipt yet.
+	GetByOrderID(ctx context.Context, orderID string) (*domain.Receipt, error)
+	GetByUserID(ctx context.Context, userID int) ([]*domain.Receipt, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"userService/application/domain"
+)
+
+type RefundRepository interface {
+	// Create fails with a RefundStateError when the ticket already has an
+	// open refund.
+	Create(ctx context.Context, refund *domain.RefundRequest) (*domain.RefundRequest, error)
+	GetByID(ctx context.Context, id string) (*domain.RefundRequest, error)
+	// GetOpenByTicketCode returns a ResourceNotFoundError when the ticket
+	// has no open refund.
+	GetOpenByTicketCode(ctx context.Context, code string) (*domain.RefundRequest, error)
+	GetByUserID(ctx context.Context, userID int, status *string) ([]*domain.RefundRequest, error)
+	GetByFilter(ctx context.Context, filter *domain.RefundFilter) ([]*domain.RefundRequest, error)
+
+	// UpdateStatus moves the refund to status if it is in one of from,
+	// recording decision and decidedAt when given, and returns a
+	// RefundStateError naming its current status otherwise.
+	UpdateStatus(ctx context.Context, id string, from []string, status string, decision *domain.RefundDecision, decidedAt *time.Time) (*domain.RefundRequest, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"userService/application/domain"
+)
+
+type ResaleListingRepository interface {
+	// Create fails with a ListingStateError when the ticket is already
+	// listed.
+	Create(ctx context.Context, listing *domain.ResaleListing) (*domain.ResaleListing, error)
+	GetByID(ctx context.Context, id string) (*domain.ResaleListing, error)
+	// GetOpenByTicketCode returns a ResourceNotFoundError when the ticket is
+	// not listed.
+	GetOpenByTicketCode(ctx context.Context, code string) (*domain.ResaleListing, error)
+	GetBySellerID(ctx context.Context, sellerID int, status *string) ([]*domain.ResaleListing, error)
+	// GetActive lists what is on sale, cheapest first.
+	GetActive(ctx context.Context, filter *domain.ResaleListingFilter) ([]*domain.ResaleListing, error)
+
+	// Claim reserves an active listing for buyerID under newCode. Claiming
+	// a listing the same buyer already claimed returns it unchanged, with
+	// the code picked the first time.
+	Claim(ctx context.Context, id string, buyerID int, newCode string) (*domain.ResaleListing, error)
+	// Release puts a claimed listing back on sale.
+	Release(ctx context.Context, id string) (*domain.ResaleListing, error)
+	// UpdateStatus moves the listing to status if it is in one of from, and
+	// returns a ListingStateError naming its current status otherwise.
+	UpdateStatus(ctx context.Context, id string, from []string, status string, soldAt *time.Time) (*domain.ResaleListing, error)
+}
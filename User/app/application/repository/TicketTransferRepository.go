@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"userService/application/domain"
+)
+
+type TicketTransferRepository interface {
+	// Create fails with a TransferStateError when the ticket already has an
+	// open transfer.
+	Create(ctx context.Context, transfer *domain.TicketTransfer) (*domain.TicketTransfer, error)
+	GetByID(ctx context.Context, id string) (*domain.TicketTransfer, error)
+	// GetOpenByTicketCode returns a ResourceNotFoundError when the ticket is
+	// in no open transfer.
+	GetOpenByTicketCode(ctx context.Context, code string) (*domain.TicketTransfer, error)
+	GetByUserID(ctx context.Context, userID int, filter *domain.TicketTransferFilter) ([]*domain.TicketTransfer, error)
+
+	// UpdateStatus moves the transfer to status if it is in one of from, and
+	// returns a TransferStateError naming its current status otherwise.
+	UpdateStatus(ctx context.Context, id string, from []string, status string, completedAt *time.Time) (*domain.TicketTransfer, error)
+	// ExpirePending marks every pending transfer whose offer ran out by now
+	// as expired.
+	ExpirePending(ctx context.Context, now time.Time) error
+}
+
+// TicketAuditRepository keeps the ticket history of each user. Adding an
+// entry that was already recorded is a no-op, so retried steps do not write
+// it twice.
+type TicketAuditRepository interface {
+	Add(ctx context.Context, entries []*domain.TicketAuditEntry) error
+	GetByUserID(ctx context.Context, userID int) ([]*domain.TicketAuditEntry, error)
+}
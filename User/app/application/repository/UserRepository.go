@@ -8,9 +8,16 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) (*domain.User, error)
 	GetByID(ctx context.Context, id int) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.User, error)
 	Delete(ctx context.Context, id int) (*domain.User, error)
 
-	GetUsersByEventID(ctx context.Context, eventID int) ([]*domain.User, error)
-	GetUsersByPacketID(ctx context.Context, packetID int) ([]*domain.User, error)
+	GetUsersByEventID(ctx context.Context, eventID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error)
+	GetUsersByPacketID(ctx context.Context, packetID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error)
+
+	// EachUserByEventID and EachUserByPacketID walk every customer in order
+	// without holding them all in memory; they stop at the first error fn
+	// returns.
+	EachUserByEventID(ctx context.Context, eventID int, orderBy *string, fn func(*domain.Customer) error) error
+	EachUserByPacketID(ctx context.Context, packetID int, orderBy *string, fn func(*domain.Customer) error) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"userService/application/domain"
+)
+
+// UserTicketRepository stores which user owns which ticket, one record per
+// ticket.
+type UserTicketRepository interface {
+	Add(ctx context.Context, userID int, ticket *domain.Ticket) error
+	GetByUserID(ctx context.Context, userID int) ([]domain.Ticket, error)
+
+	// Move hands the ticket from one user to another under its new code in
+	// a single write. Moving a ticket that already moved is a no-op.
+	Move(ctx context.Context, code string, fromUserID int, newCode string, toUserID int) error
+	// Remove drops a voided ticket from its holder. Removing a ticket that is
+	// already gone is a no-op.
+	Remove(ctx context.Context, code string, userID int) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"userService/application/domain"
+)
+
+type WaitlistRepository interface {
+	// Create fails with a WaitlistStateError when the user already has an
+	// open entry for the same event or packet.
+	Create(ctx context.Context, entry *domain.WaitlistEntry) (*domain.WaitlistEntry, error)
+	GetByID(ctx context.Context, id string) (*domain.WaitlistEntry, error)
+	// GetOpen returns a ResourceNotFoundError when the user is not waiting
+	// for target.
+	GetOpen(ctx context.Context, userID int, target *domain.WaitlistTarget) (*domain.WaitlistEntry, error)
+	GetByUserID(ctx context.Context, userID int, status *string) ([]*domain.WaitlistEntry, error)
+	// CountAhead counts the users waiting for the same target who joined
+	// before entry.
+	CountAhead(ctx context.Context, entry *domain.WaitlistEntry) (int64, error)
+	CountOffered(ctx context.Context, target *domain.WaitlistTarget) (int64, error)
+	// GetTargets lists the events and packets with open entries.
+	GetTargets(ctx context.Context) ([]*domain.WaitlistTarget, error)
+
+	// OfferNext offers up to n seats of target to the users waiting longest,
+	// until expiresAt, and returns the entries it offered.
+	OfferNext(ctx context.Context, target *domain.WaitlistTarget, n int, now time.Time, expiresAt time.Time) ([]*domain.WaitlistEntry, error)
+	// ExpireOffers closes every offer that ran out by now.
+	ExpireOffers(ctx context.Context, now time.Time) error
+	// UpdateStatus moves the entry to status if it is in one of from, and
+	// returns a WaitlistStateError naming its current status otherwise.
+	UpdateStatus(ctx context.Context, id string, from []string, status string, closedAt time.Time) (*domain.WaitlistEntry, error)
+}
@@ -16,4 +16,12 @@ type AuthorizationService interface {
 
 	CanUserViewEventCustomers(ctx context.Context, identity *UserIdentity, eventID int) (bool, error)
 	CanUserViewPacketCustomers(ctx context.Context, identity *UserIdentity, packetID int) (bool, error)
+
+	// CanUserDecideRefund reports whether identity may list and decide the
+	// refunds of an event or packet owned by ownerID.
+	CanUserDecideRefund(ctx context.Context, identity *UserIdentity, ownerID int) (bool, error)
+
+	// CanUserManagePromoCodes reports whether identity may create, change
+	// and read the usage of promo codes for what ownerID owns.
+	CanUserManagePromoCodes(ctx context.Context, identity *UserIdentity, ownerID int) (bool, error)
 }
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+)
+
+// CalendarCatalog looks up the events of tickets, including those of the
+// packets a user holds tickets for.
+type CalendarCatalog interface {
+	TicketCatalog
+	PacketContents
+}
+
+type CalendarService interface {
+	// GetFeed returns the user's calendar feed, creating it on first use.
+	GetFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error)
+	RotateFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error)
+	// GetCalendar returns the scheduled events the user holds tickets for,
+	// by start time, provided token is the one of the user's feed.
+	GetCalendar(ctx context.Context, userID int, token string, catalog CalendarCatalog) ([]*domain.CalendarEntry, error)
+}
+
+type calendarService struct {
+	feedRepo repository.CalendarFeedRepository
+	userRepo repository.UserRepository
+}
+
+func NewCalendarService(feedRepo repository.CalendarFeedRepository, userRepo repository.UserRepository) CalendarService {
+	return &calendarService{
+		feedRepo: feedRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *calendarService) GetFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error) {
+	var notFound *domain.ResourceNotFoundError
+	feed, err := s.feedRepo.GetByUserID(ctx, userID)
+	if err == nil {
+		return feed, nil
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	feed, err = newCalendarFeed(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.feedRepo.Create(ctx, feed)
+}
+
+func (s *calendarService) RotateFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error) {
+	feed, err := newCalendarFeed(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.feedRepo.Replace(ctx, feed)
+}
+
+func newCalendarFeed(userID int) (*domain.CalendarFeed, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to generate calendar token", Err: err}
+	}
+	return &domain.CalendarFeed{
+		UserID:    userID,
+		Token:     base64.RawURLEncoding.EncodeToString(buf),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// GetCalendar reports a wrong token the same as a missing feed, so feed
+// URLs cannot be probed. Events without a start time cannot be placed in a
+// calendar and are left out.
+func (s *calendarService) GetCalendar(ctx context.Context, userID int, token string, catalog CalendarCatalog) ([]*domain.CalendarEntry, error) {
+	var notFound *domain.ResourceNotFoundError
+	feed, err := s.feedRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, err
+	}
+	if err != nil || subtle.ConstantTimeCompare([]byte(feed.Token), []byte(token)) != 1 {
+		return nil, &domain.ResourceNotFoundError{Resource: "calendar", ID: strconv.Itoa(userID)}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := describeTickets(ctx, user.TicketList, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[int]*domain.CalendarEntry)
+	add := func(event *domain.EventSummary, packet *domain.PacketSummary) {
+		entry, ok := entries[event.ID]
+		if !ok {
+			entry = &domain.CalendarEntry{Event: event}
+			entries[event.ID] = entry
+		}
+		entry.TicketCount++
+		if packet != nil && !slices.Contains(entry.Packets, packet.Name) {
+			entry.Packets = append(entry.Packets, packet.Name)
+		}
+	}
+
+	packetEvents := make(map[int][]*domain.EventSummary)
+	for _, ticket := range owned {
+		switch {
+		case ticket.Event != nil:
+			add(ticket.Event, nil)
+		case ticket.Packet != nil:
+			events, ok := packetEvents[ticket.Packet.ID]
+			if !ok {
+				events, err = catalog.GetEventsOfPacket(ctx, ticket.Packet.ID)
+				if err != nil {
+					return nil, err
+				}
+				packetEvents[ticket.Packet.ID] = events
+			}
+			for _, event := range events {
+				add(event, ticket.Packet)
+			}
+		}
+	}
+
+	calendar := make([]*domain.CalendarEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Event.StartsAt != nil {
+			calendar = append(calendar, entry)
+		}
+	}
+	slices.SortFunc(calendar, func(a, b *domain.CalendarEntry) int {
+		if c := a.Event.StartsAt.Compare(*b.Event.StartsAt); c != 0 {
+			return c
+		}
+		return a.Event.ID - b.Event.ID
+	})
+	return calendar, nil
+}
@@ -1,9 +1,56 @@
 package service
 
-import "context"
+import (
+	"context"
+	"userService/application/domain"
+)
 
 type EventManagerService interface {
 	CreateTicket(ctx context.Context, code string, packetID *int, eventID *int) (*TicketResponse, error)
+	TicketCatalog
+	TicketTransferer
+	TicketReseller
+	TicketVoider
+	SeatCounter
+	PacketContents
+}
+
+// TicketCatalog looks up what tickets were bought for. Ids EventManager does
+// not know are left out of the result.
+type TicketCatalog interface {
+	GetEventsByIDs(ctx context.Context, ids []int) ([]*domain.EventSummary, error)
+	GetPacketsByIDs(ctx context.Context, ids []int) ([]*domain.PacketSummary, error)
+}
+
+// TicketTransferer rotates the code of a ticket changing hands, so the code
+// the previous holder knows stops being valid.
+type TicketTransferer interface {
+	RotateTicketCode(ctx context.Context, code string, newCode string) (*TicketResponse, error)
+}
+
+// TicketReseller reissues a ticket sold on the resale marketplace to the
+// buyer's code; EventManager refuses prices the owner's policy does not allow.
+type TicketReseller interface {
+	ResellTicket(ctx context.Context, code string, newCode string, price int) (*TicketResponse, error)
+}
+
+// TicketVoider voids a refunded ticket in EventManager, which gives its seat
+// back. Voiding a ticket that is already gone is not an error.
+type TicketVoider interface {
+	VoidTicket(ctx context.Context, code string) error
+}
+
+// SeatCounter tells how many seats of an event or packet are still for sale;
+// nil means no capacity is configured.
+type SeatCounter interface {
+	GetRemainingSeats(ctx context.Context, target *domain.WaitlistTarget) (*int, error)
+}
+
+// PacketContents tells which events a packet includes and which packets
+// include an event.
+type PacketContents interface {
+	GetEventsOfPacket(ctx context.Context, packetID int) ([]*domain.EventSummary, error)
+	GetPacketsIncludingEvent(ctx context.Context, eventID int) ([]*domain.PacketSummary, error)
 }
 
 type TicketResponse struct {
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+// PaymentGateway is the payment provider purchases are paid through.
+type PaymentGateway interface {
+	// Authorize holds request.Amount on the buyer's card. A refused card is
+	// a PaymentDeclinedError, a failing gateway a PaymentGatewayError.
+	Authorize(ctx context.Context, request *domain.PaymentRequest) (*domain.PaymentAuthorization, error)
+	// Capture takes amount of an authorization; Void releases it uncaptured.
+	Capture(ctx context.Context, reference string, amount int) error
+	Void(ctx context.Context, reference string) error
+	// Refund pays amount of a captured payment back. A repeated
+	// idempotencyKey does not pay it twice.
+	Refund(ctx context.Context, reference string, amount int, idempotencyKey string) error
+	// VerifyWebhook checks the signature of a webhook call and reads the
+	// event it carries; a bad signature is an UnauthorizedError.
+	VerifyWebhook(payload []byte, signature string) (*domain.PaymentEvent, error)
+}
+
+// PaymentRefunder pays back, through the gateway, what was paid for a
+// refunded ticket.
+type PaymentRefunder interface {
+	RefundTicket(ctx context.Context, userID int, ticketCode string, amount int, refundID string) error
+}
+
+type PaymentService interface {
+	PaymentRefunder
+
+	CreateOrder(ctx context.Context, userID int, request *domain.PurchaseRequest, unitPrice *int, redemption *domain.PromoRedemption) (*domain.Order, error)
+	CompleteOrder(ctx context.Context, order *domain.Order, ticketCodes []string) (*domain.Order, error)
+	FailOrder(ctx context.Context, order *domain.Order, reason string) error
+	GetOrder(ctx context.Context, id string) (*domain.Order, error)
+	GetUserOrders(ctx context.Context, userID int) ([]*domain.Order, error)
+	GetOrderPayment(ctx context.Context, orderID string) (*domain.Payment, error)
+
+	AuthorizePayment(ctx context.Context, order *domain.Order, token string) (*domain.Payment, error)
+	CapturePayment(ctx context.Context, payment *domain.Payment, ticketCodes []string) (*domain.Payment, error)
+	VoidPayment(ctx context.Context, payment *domain.Payment, reason string) error
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (*domain.Payment, error)
+}
+
+type paymentService struct {
+	gateway     PaymentGateway
+	orderRepo   repository.OrderRepository
+	paymentRepo repository.PaymentRepository
+}
+
+func NewPaymentService(gateway PaymentGateway, orderRepo repository.OrderRepository, paymentRepo repository.PaymentRepository) PaymentService {
+	return &paymentService{
+		gateway:     gateway,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+// CreateOrder records a priced purchase before anything is charged or
+// issued for it; the promo code discount comes off the total.
+func (s *paymentService) CreateOrder(ctx context.Context, userID int, request *domain.PurchaseRequest, unitPrice *int, redemption *domain.PromoRedemption) (*domain.Order, error) {
+	order := &domain.Order{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		EventID:   request.EventID,
+		PacketID:  request.PacketID,
+		Quantity:  request.Quantity,
+		UnitPrice: unitPrice,
+		Status:    domain.OrderPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if unitPrice != nil {
+		total := *unitPrice * request.Quantity
+		order.Total = &total
+	}
+	if redemption != nil {
+		*order.Total -= redemption.Discount
+		order.Discount = redemption.Discount
+		order.PromoCode = &redemption.Code
+	}
+	return s.orderRepo.Create(ctx, order)
+}
+
+func (s *paymentService) CompleteOrder(ctx context.Context, order *domain.Order, ticketCodes []string) (*domain.Order, error) {
+	return s.orderRepo.Complete(ctx, order.ID, ticketCodes, time.Now().UTC())
+}
+
+func (s *paymentService) FailOrder(ctx context.Context, order *domain.Order, reason string) error {
+	_, err := s.orderRepo.Fail(ctx, order.ID, reason)
+	return err
+}
+
+func (s *paymentService) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	return s.orderRepo.GetByID(ctx, id)
+}
+
+func (s *paymentService) GetUserOrders(ctx context.Context, userID int) ([]*domain.Order, error) {
+	return s.orderRepo.GetByUserID(ctx, userID)
+}
+
+func (s *paymentService) GetOrderPayment(ctx context.Context, orderID string) (*domain.Payment, error) {
+	return s.paymentRepo.GetByOrderID(ctx, orderID)
+}
+
+// AuthorizePayment holds what the order costs on the buyer's card. An order
+// with nothing to pay needs no payment and gets none. A declined card is
+// recorded as a failed payment.
+func (s *paymentService) AuthorizePayment(ctx context.Context, order *domain.Order, token string) (*domain.Payment, error) {
+	if order.Due() == 0 {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	payment := &domain.Payment{
+		ID:        uuid.New().String(),
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		Amount:    order.Due(),
+		Currency:  domain.PaymentCurrency,
+		Status:    domain.PaymentAuthorized,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	authorization, err := s.gateway.Authorize(ctx, &domain.PaymentRequest{
+		Amount:         payment.Amount,
+		Currency:       payment.Currency,
+		Token:          token,
+		IdempotencyKey: order.ID,
+		Description:    fmt.Sprintf("order %s", order.ID),
+	})
+	if err != nil {
+		var declined *domain.PaymentDeclinedError
+		if errors.As(err, &declined) {
+			payment.Status = domain.PaymentFailed
+			payment.FailureReason = declined.Error()
+			_, _ = s.paymentRepo.Create(ctx, payment)
+		}
+		return nil, err
+	}
+
+	payment.Reference = authorization.Reference
+	created, err := s.paymentRepo.Create(ctx, payment)
+	if err != nil {
+		// nothing was issued yet, so the hold is given back
+		_ = s.gateway.Void(ctx, authorization.Reference)
+		return nil, err
+	}
+	return created, nil
+}
+
+// CapturePayment takes the authorized amount once the tickets are issued,
+// and links them to the payment. A capture the gateway refused is a
+// PaymentGatewayError; any other error comes after the money was taken.
+func (s *paymentService) CapturePayment(ctx context.Context, payment *domain.Payment, ticketCodes []string) (*domain.Payment, error) {
+	if err := s.gateway.Capture(ctx, payment.Reference, payment.Amount); err != nil {
+		return nil, err
+	}
+	// the gateway's webhook may have reported the capture already
+	return s.paymentRepo.UpdateStatus(ctx, payment.ID,
+		[]string{domain.PaymentAuthorized, domain.PaymentCaptured}, domain.PaymentCaptured, ticketCodes, "", time.Now().UTC())
+}
+
+// VoidPayment releases the hold of a purchase that failed.
+func (s *paymentService) VoidPayment(ctx context.Context, payment *domain.Payment, reason string) error {
+	if err := s.gateway.Void(ctx, payment.Reference); err != nil {
+		return err
+	}
+	_, err := s.paymentRepo.UpdateStatus(ctx, payment.ID,
+		[]string{domain.PaymentAuthorized}, domain.PaymentVoided, nil, reason, time.Now().UTC())
+	return err
+}
+
+// RefundTicket pays amount back to the card the ticket was bought with, at
+// most what is left of its payment. Tickets bought without a payment, and
+// tickets that changed hands since, are not paid back through the gateway.
+// Repeating a refund does not pay it twice.
+func (s *paymentService) RefundTicket(ctx context.Context, userID int, ticketCode string, amount int, refundID string) error {
+	payment, err := s.paymentRepo.GetByTicketCode(ctx, ticketCode)
+	var notFound *domain.ResourceNotFoundError
+	if errors.As(err, &notFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if payment.UserID != userID || slices.Contains(payment.RefundIDs, refundID) {
+		return nil
+	}
+
+	amount = min(amount, payment.Refundable())
+	if amount <= 0 {
+		return nil
+	}
+	if err := s.gateway.Refund(ctx, payment.Reference, amount, refundID); err != nil {
+		return err
+	}
+	_, err = s.paymentRepo.AddRefund(ctx, payment.ID, refundID, amount, time.Now().UTC())
+	return err
+}
+
+// HandleWebhook applies a change the gateway reports on its own, such as an
+// authorization that ran out or a refund made from its dashboard. Events
+// that were already applied, or that this service does not track, change
+// nothing.
+func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (*domain.Payment, error) {
+	event, err := s.gateway.VerifyWebhook(payload, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRepo.GetByReference(ctx, event.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var updated *domain.Payment
+	switch event.Type {
+	case domain.PaymentEventCaptured:
+		updated, err = s.paymentRepo.UpdateStatus(ctx, payment.ID,
+			[]string{domain.PaymentAuthorized}, domain.PaymentCaptured, nil, "", now)
+	case domain.PaymentEventVoided:
+		updated, err = s.paymentRepo.UpdateStatus(ctx, payment.ID,
+			[]string{domain.PaymentAuthorized}, domain.PaymentVoided, nil, "voided by the payment gateway", now)
+	case domain.PaymentEventRefunded:
+		updated, err = s.paymentRepo.SyncRefunded(ctx, payment.ID, event.Amount, now)
+	default:
+		return payment, nil
+	}
+
+	var stateErr *domain.PaymentStateError
+	if errors.As(err, &stateErr) {
+		return payment, nil
+	}
+	return updated, err
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+type PromoCodeService interface {
+	CreatePromoCode(ctx context.Context, promo *domain.PromoCode) (*domain.PromoCode, error)
+	GetPromoCode(ctx context.Context, id string) (*domain.PromoCode, error)
+	GetPromoCodes(ctx context.Context, ownerID int) ([]*domain.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promo *domain.PromoCode, updates map[string]interface{}) (*domain.PromoCode, error)
+	DeletePromoCode(ctx context.Context, promo *domain.PromoCode) error
+	GetPromoCodeUsage(ctx context.Context, promo *domain.PromoCode) (*domain.PromoCodeUsage, error)
+	GetScopeOwner(ctx context.Context, eventIDs []int, packetIDs []int, catalog TicketCatalog) (int, error)
+
+	ReserveRedemption(ctx context.Context, code string, userID int, target *domain.WaitlistTarget, quantity int, unitPrice *int) (*domain.PromoRedemption, error)
+	CompleteRedemption(ctx context.Context, redemption *domain.PromoRedemption, ticketCodes []string) error
+	ReleaseRedemption(ctx context.Context, redemption *domain.PromoRedemption) error
+}
+
+type promoCodeService struct {
+	promoRepo      repository.PromoCodeRepository
+	redemptionRepo repository.PromoRedemptionRepository
+}
+
+func NewPromoCodeService(promoRepo repository.PromoCodeRepository, redemptionRepo repository.PromoRedemptionRepository) PromoCodeService {
+	return &promoCodeService{
+		promoRepo:      promoRepo,
+		redemptionRepo: redemptionRepo,
+	}
+}
+
+// CreatePromoCode stores a new code for promo.OwnerID; a code without a
+// minimum quantity applies from one ticket.
+func (s *promoCodeService) CreatePromoCode(ctx context.Context, promo *domain.PromoCode) (*domain.PromoCode, error) {
+	promo.Code = domain.NormalizePromoCode(promo.Code)
+	if promo.MinQuantity == 0 {
+		promo.MinQuantity = 1
+	}
+	if err := promo.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	promo.ID = uuid.New().String()
+	promo.Redemptions = 0
+	promo.CreatedAt = now
+	promo.UpdatedAt = now
+	return s.promoRepo.Create(ctx, promo)
+}
+
+func (s *promoCodeService) GetPromoCode(ctx context.Context, id string) (*domain.PromoCode, error) {
+	return s.promoRepo.GetByID(ctx, id)
+}
+
+func (s *promoCodeService) GetPromoCodes(ctx context.Context, ownerID int) ([]*domain.PromoCode, error) {
+	return s.promoRepo.GetByOwnerID(ctx, ownerID)
+}
+
+// UpdatePromoCode changes the terms of a code. Its code, scope and kind of
+// discount stay as they were, so the usage report keeps adding up.
+func (s *promoCodeService) UpdatePromoCode(ctx context.Context, promo *domain.PromoCode, updates map[string]interface{}) (*domain.PromoCode, error) {
+	if len(updates) == 0 {
+		return promo, nil
+	}
+
+	updated := *promo
+	for key, value := range updates {
+		switch key {
+		case "discount_value":
+			updated.DiscountValue = value.(int)
+		case "max_redemptions":
+			maxRedemptions := value.(int)
+			updated.MaxRedemptions = &maxRedemptions
+		case "max_per_user":
+			maxPerUser := value.(int)
+			updated.MaxPerUser = &maxPerUser
+		case "min_quantity":
+			updated.MinQuantity = value.(int)
+		case "valid_from":
+			validFrom := value.(time.Time)
+			updated.ValidFrom = &validFrom
+		case "valid_until":
+			validUntil := value.(time.Time)
+			updated.ValidUntil = &validUntil
+		case "active":
+			updated.Active = value.(bool)
+		default:
+			return nil, &domain.ValidationError{Field: key, Reason: "cannot be changed"}
+		}
+	}
+	if err := updated.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.promoRepo.Update(ctx, promo.ID, updates, time.Now().UTC())
+}
+
+func (s *promoCodeService) DeletePromoCode(ctx context.Context, promo *domain.PromoCode) error {
+	return s.promoRepo.Delete(ctx, promo.ID)
+}
+
+func (s *promoCodeService) GetPromoCodeUsage(ctx context.Context, promo *domain.PromoCode) (*domain.PromoCodeUsage, error) {
+	usage, err := s.redemptionRepo.GetUsage(ctx, promo.ID)
+	if err != nil {
+		return nil, err
+	}
+	usage.PromoCode = promo
+	return usage, nil
+}
+
+// GetScopeOwner looks up who owns the events and packets a code is meant
+// for. They must all exist and belong to the same owner.
+func (s *promoCodeService) GetScopeOwner(ctx context.Context, eventIDs []int, packetIDs []int, catalog TicketCatalog) (int, error) {
+	var owners []int
+
+	if len(eventIDs) > 0 {
+		events, err := catalog.GetEventsByIDs(ctx, eventIDs)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range eventIDs {
+			i := slices.IndexFunc(events, func(event *domain.EventSummary) bool { return event.ID == id })
+			if i < 0 {
+				return 0, &domain.ResourceNotFoundError{Resource: "event", ID: strconv.Itoa(id)}
+			}
+			owners = append(owners, events[i].OwnerID)
+		}
+	}
+
+	if len(packetIDs) > 0 {
+		packets, err := catalog.GetPacketsByIDs(ctx, packetIDs)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range packetIDs {
+			i := slices.IndexFunc(packets, func(packet *domain.PacketSummary) bool { return packet.ID == id })
+			if i < 0 {
+				return 0, &domain.ResourceNotFoundError{Resource: "packet", ID: strconv.Itoa(id)}
+			}
+			owners = append(owners, packets[i].OwnerID)
+		}
+	}
+
+	if len(owners) == 0 {
+		return 0, &domain.ValidationError{Field: "event_ids", Reason: "at least one event or packet is required"}
+	}
+	if slices.ContainsFunc(owners, func(owner int) bool { return owner != owners[0] }) {
+		return 0, &domain.ValidationError{Field: "event_ids", Reason: "all events and packets of a promo code must have the same owner"}
+	}
+	return owners[0], nil
+}
+
+// ReserveRedemption takes a use of code for quantity tickets of target
+// before they are issued. The use is counted right away, so buyers racing
+// for the last uses cannot all get one; CompleteRedemption or
+// ReleaseRedemption then settles it.
+func (s *promoCodeService) ReserveRedemption(ctx context.Context, code string, userID int, target *domain.WaitlistTarget, quantity int, unitPrice *int) (*domain.PromoRedemption, error) {
+	code = domain.NormalizePromoCode(code)
+	promo, err := s.promoRepo.GetByCode(ctx, code)
+	var notFound *domain.ResourceNotFoundError
+	if errors.As(err, &notFound) {
+		return nil, &domain.PromoCodeNotApplicableError{Detail: "unknown promo code " + code}
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := promo.CheckApplicable(target, quantity, now); err != nil {
+		return nil, err
+	}
+	if unitPrice == nil {
+		return nil, &domain.PromoCodeNotApplicableError{Detail: "no price is set for this event or packet, so there is nothing to discount"}
+	}
+
+	promo, err = s.promoRepo.Redeem(ctx, promo.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	redemption, err := s.redemptionRepo.Create(ctx, &domain.PromoRedemption{
+		ID:          uuid.New().String(),
+		PromoCodeID: promo.ID,
+		Code:        promo.Code,
+		UserID:      userID,
+		EventID:     target.EventID,
+		PacketID:    target.PacketID,
+		Quantity:    quantity,
+		Discount:    promo.Discount(*unitPrice, quantity),
+		Status:      domain.RedemptionReserved,
+		RedeemedAt:  now,
+	})
+	if err != nil {
+		_ = s.promoRepo.Release(ctx, promo.ID, userID)
+		return nil, err
+	}
+	return redemption, nil
+}
+
+func (s *promoCodeService) CompleteRedemption(ctx context.Context, redemption *domain.PromoRedemption, ticketCodes []string) error {
+	return s.redemptionRepo.UpdateStatus(ctx, redemption.ID, domain.RedemptionCompleted, ticketCodes, time.Now().UTC())
+}
+
+// ReleaseRedemption gives the use back after the purchase failed.
+func (s *promoCodeService) ReleaseRedemption(ctx context.Context, redemption *domain.PromoRedemption) error {
+	if err := s.redemptionRepo.UpdateStatus(ctx, redemption.ID, domain.RedemptionReleased, nil, time.Now().UTC()); err != nil {
+		return err
+	}
+	return s.promoRepo.Release(ctx, redemption.PromoCodeID, redemption.UserID)
+}
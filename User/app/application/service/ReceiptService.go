@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+type ReceiptService interface {
+	IssueReceipt(ctx context.Context, order *domain.Order, catalog TicketCatalog) (*domain.Receipt, error)
+	GetReceipt(ctx context.Context, id string) (*domain.Receipt, error)
+	GetUserReceipts(ctx context.Context, userID int) ([]*domain.Receipt, error)
+}
+
+type receiptService struct {
+	receiptRepo repository.ReceiptRepository
+	userRepo    repository.UserRepository
+	paymentRepo repository.PaymentRepository
+	taxRate     int
+}
+
+// NewReceiptService issues receipts with VAT included at taxRate percent.
+func NewReceiptService(
+	receiptRepo repository.ReceiptRepository,
+	userRepo repository.UserRepository,
+	paymentRepo repository.PaymentRepository,
+	taxRate int,
+) ReceiptService {
+	return &receiptService{
+		receiptRepo: receiptRepo,
+		userRepo:    userRepo,
+		paymentRepo: paymentRepo,
+		taxRate:     taxRate,
+	}
+}
+
+// IssueReceipt numbers and stores the receipt of a completed order, with
+// the buyer and the event or packet as they are now. An order keeps the
+// receipt it was issued first.
+func (s *receiptService) IssueReceipt(ctx context.Context, order *domain.Order, catalog TicketCatalog) (*domain.Receipt, error) {
+	if order.Status != domain.OrderCompleted {
+		return nil, &domain.ResourceNotFoundError{Resource: "receipt", ID: order.ID}
+	}
+
+	var notFound *domain.ResourceNotFoundError
+	existing, err := s.receiptRepo.GetByOrderID(ctx, order.ID)
+	if err == nil {
+		return existing, nil
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	buyer, err := s.userRepo.GetByID(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, organizerID, err := s.receiptItem(ctx, order, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRepo.GetByOrderID(ctx, order.ID)
+	if errors.As(err, &notFound) {
+		payment = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	receipt := domain.NewReceipt(order, buyer, item, organizerID, s.taxRate, payment, time.Now().UTC())
+	receipt.ID = uuid.New().String()
+	return s.receiptRepo.Issue(ctx, receipt)
+}
+
+// receiptItem looks up what the order bought and who organizes it.
+func (s *receiptService) receiptItem(ctx context.Context, order *domain.Order, catalog TicketCatalog) (*domain.ReceiptItem, int, error) {
+	switch {
+	case order.EventID != nil:
+		events, err := catalog.GetEventsByIDs(ctx, []int{*order.EventID})
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(events) == 0 {
+			return nil, 0, &domain.ResourceNotFoundError{Resource: "event", ID: strconv.Itoa(*order.EventID)}
+		}
+		event := events[0]
+		return &domain.ReceiptItem{
+			EventID:  order.EventID,
+			Name:     event.Name,
+			Location: event.Location,
+			City:     event.City,
+			Country:  event.Country,
+			StartsAt: event.StartsAt,
+			EndsAt:   event.EndsAt,
+		}, event.OwnerID, nil
+	case order.PacketID != nil:
+		packets, err := catalog.GetPacketsByIDs(ctx, []int{*order.PacketID})
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(packets) == 0 {
+			return nil, 0, &domain.ResourceNotFoundError{Resource: "packet", ID: strconv.Itoa(*order.PacketID)}
+		}
+		packet := packets[0]
+		return &domain.ReceiptItem{
+			PacketID: order.PacketID,
+			Name:     packet.Name,
+			Location: packet.Location,
+			City:     packet.City,
+			Country:  packet.Country,
+			StartsAt: packet.StartsAt,
+			EndsAt:   packet.EndsAt,
+		}, packet.OwnerID, nil
+	}
+	return nil, 0, &domain.ValidationError{Field: "order", Reason: "order has neither an event nor a packet"}
+}
+
+func (s *receiptService) GetReceipt(ctx context.Context, id string) (*domain.Receipt, error) {
+	return s.receiptRepo.GetByID(ctx, id)
+}
+
+func (s *receiptService) GetUserReceipts(ctx context.Context, userID int) ([]*domain.Receipt, error) {
+	return s.receiptRepo.GetByUserID(ctx, userID)
+}
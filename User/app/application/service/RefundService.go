@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+type RefundService interface {
+	RequestRefund(ctx context.Context, userID int, code string, reason string, catalog TicketCatalog) (*domain.RefundRequest, error)
+	GetRefund(ctx context.Context, id string) (*domain.RefundRequest, error)
+	GetUserRefunds(ctx context.Context, userID int, status *string) ([]*domain.RefundRequest, error)
+	GetRefunds(ctx context.Context, filter *domain.RefundFilter) ([]*domain.RefundRequest, error)
+	GetRefundTerms(ctx context.Context, eventID *int, packetID *int, catalog TicketCatalog) (*domain.RefundTerms, error)
+	ApproveRefund(ctx context.Context, refund *domain.RefundRequest, terms *domain.RefundTerms, decision *domain.RefundDecision, voider TicketVoider, refunder PaymentRefunder) (*domain.RefundRequest, error)
+	DenyRefund(ctx context.Context, refund *domain.RefundRequest, decision *domain.RefundDecision) (*domain.RefundRequest, error)
+}
+
+type refundService struct {
+	ticketRepo   repository.UserTicketRepository
+	refundRepo   repository.RefundRepository
+	transferRepo repository.TicketTransferRepository
+	listingRepo  repository.ResaleListingRepository
+}
+
+func NewRefundService(
+	ticketRepo repository.UserTicketRepository,
+	refundRepo repository.RefundRepository,
+	transferRepo repository.TicketTransferRepository,
+	listingRepo repository.ResaleListingRepository,
+) RefundService {
+	return &refundService{
+		ticketRepo:   ticketRepo,
+		refundRepo:   refundRepo,
+		transferRepo: transferRepo,
+		listingRepo:  listingRepo,
+	}
+}
+
+// RequestRefund asks for a ticket of userID to be taken back. The ticket
+// stays with its holder, and cannot be transferred or listed, until the
+// refund is decided.
+func (s *refundService) RequestRefund(ctx context.Context, userID int, code string, reason string, catalog TicketCatalog) (*domain.RefundRequest, error) {
+	code = strings.TrimSpace(code)
+	reason = strings.TrimSpace(reason)
+	if code == "" {
+		return nil, &domain.ValidationError{Field: "ticket_code", Reason: "ticket code is required"}
+	}
+
+	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var ticket *domain.Ticket
+	for i := range tickets {
+		if tickets[i].Code == code {
+			ticket = &tickets[i]
+			break
+		}
+	}
+	if ticket == nil {
+		return nil, &domain.ResourceNotFoundError{Resource: "ticket", ID: code}
+	}
+
+	terms, err := s.GetRefundTerms(ctx, ticket.EventID, ticket.PacketID, catalog)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if err := terms.CheckWindow(now); err != nil {
+		return nil, err
+	}
+
+	if err := s.transferRepo.ExpirePending(ctx, now); err != nil {
+		return nil, err
+	}
+	var notFound *domain.ResourceNotFoundError
+	transfer, err := s.transferRepo.GetOpenByTicketCode(ctx, ticket.Code)
+	if err == nil {
+		return nil, &domain.TransferStateError{ID: transfer.ID, Status: transfer.Status}
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+	listing, err := s.listingRepo.GetOpenByTicketCode(ctx, ticket.Code)
+	if err == nil {
+		return nil, &domain.ListingStateError{ID: listing.ID, Status: listing.Status}
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	return s.refundRepo.Create(ctx, &domain.RefundRequest{
+		ID:          uuid.New().String(),
+		TicketCode:  ticket.Code,
+		EventID:     ticket.EventID,
+		PacketID:    ticket.PacketID,
+		UserID:      userID,
+		Reason:      reason,
+		Status:      domain.RefundRequested,
+		RequestedAt: now,
+	})
+}
+
+// GetRefundTerms reads the refund rules of an event or packet as they are
+// now, so a change of owner or window applies to refunds already asked for.
+func (s *refundService) GetRefundTerms(ctx context.Context, eventID *int, packetID *int, catalog TicketCatalog) (*domain.RefundTerms, error) {
+	switch {
+	case eventID != nil:
+		events, err := catalog.GetEventsByIDs(ctx, []int{*eventID})
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			return nil, &domain.ResourceNotFoundError{Resource: "event", ID: strconv.Itoa(*eventID)}
+		}
+		event := events[0]
+		return &domain.RefundTerms{OwnerID: event.OwnerID, FaceValue: event.Price, StartsAt: event.StartsAt, WindowHours: event.RefundWindowHours}, nil
+	case packetID != nil:
+		packets, err := catalog.GetPacketsByIDs(ctx, []int{*packetID})
+		if err != nil {
+			return nil, err
+		}
+		if len(packets) == 0 {
+			return nil, &domain.ResourceNotFoundError{Resource: "packet", ID: strconv.Itoa(*packetID)}
+		}
+		packet := packets[0]
+		return &domain.RefundTerms{OwnerID: packet.OwnerID, FaceValue: packet.Price, StartsAt: packet.StartsAt, WindowHours: packet.RefundWindowHours}, nil
+	}
+	return nil, &domain.ValidationError{Field: "ticket", Reason: "ticket has neither an event nor a packet"}
+}
+
+func (s *refundService) GetRefund(ctx context.Context, id string) (*domain.RefundRequest, error) {
+	return s.refundRepo.GetByID(ctx, id)
+}
+
+func (s *refundService) GetUserRefunds(ctx context.Context, userID int, status *string) ([]*domain.RefundRequest, error) {
+	if err := domain.ValidateRefundStatus(status); err != nil {
+		return nil, err
+	}
+	return s.refundRepo.GetByUserID(ctx, userID, status)
+}
+
+func (s *refundService) GetRefunds(ctx context.Context, filter *domain.RefundFilter) ([]*domain.RefundRequest, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.refundRepo.GetByFilter(ctx, filter)
+}
+
+// ApproveRefund claims the refund with the decision, pays the amount back,
+// voids the ticket in EventManager, which releases its seat, and then drops
+// it from the holder.
+// Every step can be repeated, so an approval that failed half way is
+// finished by approving again; the decision recorded the first time stands.
+func (s *refundService) ApproveRefund(ctx context.Context, refund *domain.RefundRequest, terms *domain.RefundTerms, decision *domain.RefundDecision, voider TicketVoider, refunder PaymentRefunder) (*domain.RefundRequest, error) {
+	claimed := refund
+	switch refund.Status {
+	case domain.RefundRequested:
+		amount, err := terms.Amount(decision.Amount)
+		if err != nil {
+			return nil, err
+		}
+		decision.Amount = amount
+		decision.Note = strings.TrimSpace(decision.Note)
+
+		claimed, err = s.refundRepo.UpdateStatus(ctx, refund.ID,
+			[]string{domain.RefundRequested}, domain.RefundApproving, decision, nil)
+		if err != nil {
+			return nil, err
+		}
+	case domain.RefundApproving:
+	default:
+		return nil, &domain.RefundStateError{ID: refund.ID, Status: refund.Status}
+	}
+
+	if claimed.Amount != nil {
+		if err := refunder.RefundTicket(ctx, claimed.UserID, claimed.TicketCode, *claimed.Amount, claimed.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := voider.VoidTicket(ctx, claimed.TicketCode); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketRepo.Remove(ctx, claimed.TicketCode, claimed.UserID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return s.refundRepo.UpdateStatus(ctx, claimed.ID, []string{domain.RefundApproving}, domain.RefundApproved, nil, &now)
+}
+
+func (s *refundService) DenyRefund(ctx context.Context, refund *domain.RefundRequest, decision *domain.RefundDecision) (*domain.RefundRequest, error) {
+	decision.Amount = nil
+	decision.Note = strings.TrimSpace(decision.Note)
+
+	now := time.Now().UTC()
+	return s.refundRepo.UpdateStatus(ctx, refund.ID, []string{domain.RefundRequested}, domain.RefundDenied, decision, &now)
+}
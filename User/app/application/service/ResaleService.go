@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+type ResaleService interface {
+	CreateListing(ctx context.Context, sellerID int, code string, price int, catalog TicketCatalog) (*domain.ResaleListing, error)
+	GetListing(ctx context.Context, id string) (*domain.ResaleListing, error)
+	GetListings(ctx context.Context, filter *domain.ResaleListingFilter) ([]*domain.ResaleListing, error)
+	GetSellerListings(ctx context.Context, sellerID int, status *string) ([]*domain.ResaleListing, error)
+	CancelListing(ctx context.Context, listing *domain.ResaleListing) (*domain.ResaleListing, error)
+	PurchaseListing(ctx context.Context, listing *domain.ResaleListing, buyerID int, reseller TicketReseller) (*domain.ResaleListing, error)
+	ReverseSale(ctx context.Context, listing *domain.ResaleListing, transferer TicketTransferer) error
+}
+
+type resaleService struct {
+	ticketRepo   repository.UserTicketRepository
+	listingRepo  repository.ResaleListingRepository
+	transferRepo repository.TicketTransferRepository
+	refundRepo   repository.RefundRepository
+}
+
+func NewResaleService(
+	ticketRepo repository.UserTicketRepository,
+	listingRepo repository.ResaleListingRepository,
+	transferRepo repository.TicketTransferRepository,
+	refundRepo repository.RefundRepository,
+) ResaleService {
+	return &resaleService{
+		ticketRepo:   ticketRepo,
+		listingRepo:  listingRepo,
+		transferRepo: transferRepo,
+		refundRepo:   refundRepo,
+	}
+}
+
+// CreateListing puts a ticket of sellerID on sale for price. The ticket stays
+// with its holder until someone buys it.
+func (s *resaleService) CreateListing(ctx context.Context, sellerID int, code string, price int, catalog TicketCatalog) (*domain.ResaleListing, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, &domain.ValidationError{Field: "ticket_code", Reason: "ticket code is required"}
+	}
+	if price < 0 {
+		return nil, &domain.ValidationError{Field: "price", Reason: "price cannot be negative"}
+	}
+
+	tickets, err := s.ticketRepo.GetByUserID(ctx, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	var ticket *domain.Ticket
+	for i := range tickets {
+		if tickets[i].Code == code {
+			ticket = &tickets[i]
+			break
+		}
+	}
+	if ticket == nil {
+		return nil, &domain.ResourceNotFoundError{Resource: "ticket", ID: code}
+	}
+
+	// EventManager checks again when the ticket is reissued, so a policy
+	// the owner tightens later still holds
+	if err := checkResaleAllowed(ctx, ticket, price, catalog); err != nil {
+		return nil, err
+	}
+
+	if err := s.transferRepo.ExpirePending(ctx, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	transfer, err := s.transferRepo.GetOpenByTicketCode(ctx, ticket.Code)
+	var notFound *domain.ResourceNotFoundError
+	if err == nil {
+		return nil, &domain.TransferStateError{ID: transfer.ID, Status: transfer.Status}
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+	refund, err := s.refundRepo.GetOpenByTicketCode(ctx, ticket.Code)
+	if err == nil {
+		return nil, &domain.RefundStateError{ID: refund.ID, Status: refund.Status}
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	return s.listingRepo.Create(ctx, &domain.ResaleListing{
+		ID:         uuid.New().String(),
+		TicketCode: ticket.Code,
+		EventID:    ticket.EventID,
+		PacketID:   ticket.PacketID,
+		SellerID:   sellerID,
+		Price:      price,
+		Status:     domain.ListingActive,
+		CreatedAt:  time.Now().UTC(),
+	})
+}
+
+func checkResaleAllowed(ctx context.Context, ticket *domain.Ticket, price int, catalog TicketCatalog) error {
+	var allowed bool
+	var faceValue, maxMarkupPercent *int
+
+	switch {
+	case ticket.EventID != nil:
+		events, err := catalog.GetEventsByIDs(ctx, []int{*ticket.EventID})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return &domain.ResourceNotFoundError{Resource: "event", ID: strconv.Itoa(*ticket.EventID)}
+		}
+		allowed, faceValue, maxMarkupPercent = events[0].ResaleAllowed, events[0].Price, events[0].ResaleMaxMarkupPercent
+	case ticket.PacketID != nil:
+		packets, err := catalog.GetPacketsByIDs(ctx, []int{*ticket.PacketID})
+		if err != nil {
+			return err
+		}
+		if len(packets) == 0 {
+			return &domain.ResourceNotFoundError{Resource: "packet", ID: strconv.Itoa(*ticket.PacketID)}
+		}
+		allowed, faceValue, maxMarkupPercent = packets[0].ResaleAllowed, packets[0].Price, packets[0].ResaleMaxMarkupPercent
+	}
+
+	if !allowed {
+		return &domain.ResaleNotAllowedError{}
+	}
+	if maxPrice := domain.ResaleMaxPrice(faceValue, maxMarkupPercent); maxPrice != nil && price > *maxPrice {
+		return &domain.ResalePriceAboveCapError{MaxPrice: maxPrice}
+	}
+	return nil
+}
+
+func (s *resaleService) GetListing(ctx context.Context, id string) (*domain.ResaleListing, error) {
+	return s.listingRepo.GetByID(ctx, id)
+}
+
+func (s *resaleService) GetListings(ctx context.Context, filter *domain.ResaleListingFilter) ([]*domain.ResaleListing, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.listingRepo.GetActive(ctx, filter)
+}
+
+func (s *resaleService) GetSellerListings(ctx context.Context, sellerID int, status *string) ([]*domain.ResaleListing, error) {
+	if err := domain.ValidateListingStatus(status); err != nil {
+		return nil, err
+	}
+	return s.listingRepo.GetBySellerID(ctx, sellerID, status)
+}
+
+func (s *resaleService) CancelListing(ctx context.Context, listing *domain.ResaleListing) (*domain.ResaleListing, error) {
+	return s.listingRepo.UpdateStatus(ctx, listing.ID, []string{domain.ListingActive}, domain.ListingCancelled, nil)
+}
+
+// PurchaseListing claims the listing for buyerID, has EventManager reissue
+// the ticket under a new code and then moves it to the buyer. Like accepting
+// a transfer, every step can be repeated, so a purchase that failed half way
+// is finished by purchasing again. The claim is only given back when
+// EventManager refuses the reissue.
+func (s *resaleService) PurchaseListing(ctx context.Context, listing *domain.ResaleListing, buyerID int, reseller TicketReseller) (*domain.ResaleListing, error) {
+	if listing.SellerID == buyerID {
+		return nil, &domain.ValidationError{Field: "listing_id", Reason: "cannot buy your own listing"}
+	}
+
+	claimed, err := s.listingRepo.Claim(ctx, listing.ID, buyerID, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := reseller.ResellTicket(ctx, claimed.TicketCode, claimed.NewCode, claimed.Price); err != nil {
+		var notFound *domain.ResourceNotFoundError
+		switch {
+		case errors.As(err, &notFound):
+			// the ticket left the seller some other way; nothing is left to sell
+			if _, cancelErr := s.listingRepo.UpdateStatus(ctx, claimed.ID,
+				[]string{domain.ListingSelling}, domain.ListingCancelled, nil); cancelErr != nil {
+				return nil, cancelErr
+			}
+		case resaleRefused(err):
+			if _, releaseErr := s.listingRepo.Release(ctx, claimed.ID); releaseErr != nil {
+				return nil, releaseErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.ticketRepo.Move(ctx, claimed.TicketCode, claimed.SellerID, claimed.NewCode, buyerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return s.listingRepo.UpdateStatus(ctx, claimed.ID, []string{domain.ListingSelling}, domain.ListingSold, &now)
+}
+
+// ReverseSale hands a sold ticket back to its seller when the buyer's
+// payment could not be taken. The ticket moves to a fresh code, so the one
+// the buyer got stops working, and the listing is cancelled; the seller can
+// list the ticket again.
+func (s *resaleService) ReverseSale(ctx context.Context, listing *domain.ResaleListing, transferer TicketTransferer) error {
+	code := uuid.New().String()
+	if _, err := transferer.RotateTicketCode(ctx, listing.NewCode, code); err != nil {
+		return err
+	}
+	if err := s.ticketRepo.Move(ctx, listing.NewCode, *listing.BuyerID, code, listing.SellerID); err != nil {
+		return err
+	}
+	_, err := s.listingRepo.UpdateStatus(ctx, listing.ID, []string{domain.ListingSold}, domain.ListingCancelled, nil)
+	return err
+}
+
+// resaleRefused reports whether EventManager turned the reissue down because
+// of the owner's resale policy or the request itself.
+func resaleRefused(err error) bool {
+	var notAllowed *domain.ResaleNotAllowedError
+	var aboveCap *domain.ResalePriceAboveCapError
+	var validation *domain.ValidationError
+	return errors.As(err, &notAllowed) || errors.As(err, &aboveCap) || errors.As(err, &validation)
+}
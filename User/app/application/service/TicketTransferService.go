@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+type TicketTransferService interface {
+	InitiateTransfer(ctx context.Context, fromUserID int, code string, recipientEmail string, catalog TicketCatalog) (*domain.TicketTransfer, error)
+	GetTransfer(ctx context.Context, id string) (*domain.TicketTransfer, error)
+	GetTransfers(ctx context.Context, userID int, filter *domain.TicketTransferFilter) ([]*domain.TicketTransfer, error)
+	AcceptTransfer(ctx context.Context, transfer *domain.TicketTransfer, transferer TicketTransferer) (*domain.TicketTransfer, error)
+	DeclineTransfer(ctx context.Context, transfer *domain.TicketTransfer) (*domain.TicketTransfer, error)
+	CancelTransfer(ctx context.Context, transfer *domain.TicketTransfer) (*domain.TicketTransfer, error)
+	GetTicketAudit(ctx context.Context, userID int) ([]*domain.TicketAuditEntry, error)
+}
+
+type ticketTransferService struct {
+	userRepo     repository.UserRepository
+	ticketRepo   repository.UserTicketRepository
+	transferRepo repository.TicketTransferRepository
+	auditRepo    repository.TicketAuditRepository
+	listingRepo  repository.ResaleListingRepository
+	refundRepo   repository.RefundRepository
+}
+
+func NewTicketTransferService(
+	userRepo repository.UserRepository,
+	ticketRepo repository.UserTicketRepository,
+	transferRepo repository.TicketTransferRepository,
+	auditRepo repository.TicketAuditRepository,
+	listingRepo repository.ResaleListingRepository,
+	refundRepo repository.RefundRepository,
+) TicketTransferService {
+	return &ticketTransferService{
+		userRepo:     userRepo,
+		ticketRepo:   ticketRepo,
+		transferRepo: transferRepo,
+		auditRepo:    auditRepo,
+		listingRepo:  listingRepo,
+		refundRepo:   refundRepo,
+	}
+}
+
+// InitiateTransfer offers a ticket of fromUserID to the user registered
+// with recipientEmail. The ticket stays with its holder until the recipient
+// accepts.
+func (s *ticketTransferService) InitiateTransfer(ctx context.Context, fromUserID int, code string, recipientEmail string, catalog TicketCatalog) (*domain.TicketTransfer, error) {
+	code = strings.TrimSpace(code)
+	recipientEmail = strings.TrimSpace(recipientEmail)
+	if code == "" {
+		return nil, &domain.ValidationError{Field: "ticket_code", Reason: "ticket code is required"}
+	}
+	if !validateEmail(recipientEmail) {
+		return nil, &domain.ValidationError{Field: "recipient_email", Reason: "Invalid email format"}
+	}
+
+	tickets, err := s.ticketRepo.GetByUserID(ctx, fromUserID)
+	if err != nil {
+		return nil, err
+	}
+	var ticket *domain.Ticket
+	for i := range tickets {
+		if tickets[i].Code == code {
+			ticket = &tickets[i]
+			break
+		}
+	}
+	if ticket == nil {
+		return nil, &domain.ResourceNotFoundError{Resource: "ticket", ID: code}
+	}
+
+	recipient, err := s.userRepo.GetByEmail(ctx, recipientEmail)
+	var notFound *domain.ResourceNotFoundError
+	if errors.As(err, &notFound) {
+		return nil, &domain.ValidationError{Field: "recipient_email", Reason: "no user is registered with this email"}
+	} else if err != nil {
+		return nil, err
+	}
+	if recipient.ID == fromUserID {
+		return nil, &domain.ValidationError{Field: "recipient_email", Reason: "cannot transfer a ticket to yourself"}
+	}
+
+	// EventManager checks again when the code is rotated; this only spares
+	// the recipient an offer that cannot be accepted
+	if err := checkTransfersAllowed(ctx, ticket, catalog); err != nil {
+		return nil, err
+	}
+
+	listing, err := s.listingRepo.GetOpenByTicketCode(ctx, ticket.Code)
+	if err == nil {
+		return nil, &domain.ListingStateError{ID: listing.ID, Status: listing.Status}
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+	refund, err := s.refundRepo.GetOpenByTicketCode(ctx, ticket.Code)
+	if err == nil {
+		return nil, &domain.RefundStateError{ID: refund.ID, Status: refund.Status}
+	} else if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.transferRepo.ExpirePending(ctx, now); err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.transferRepo.Create(ctx, &domain.TicketTransfer{
+		ID:             uuid.New().String(),
+		TicketCode:     ticket.Code,
+		NewCode:        uuid.New().String(),
+		EventID:        ticket.EventID,
+		PacketID:       ticket.PacketID,
+		FromUserID:     fromUserID,
+		ToUserID:       recipient.ID,
+		RecipientEmail: recipient.Email,
+		Status:         domain.TransferPending,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(domain.TransferOfferTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditRepo.Add(ctx, domain.TransferAuditEntries(transfer, domain.AuditTransferRequested, now)); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func checkTransfersAllowed(ctx context.Context, ticket *domain.Ticket, catalog TicketCatalog) error {
+	if ticket.EventID != nil {
+		events, err := catalog.GetEventsByIDs(ctx, []int{*ticket.EventID})
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 && events[0].TransfersBlocked {
+			return &domain.TransfersBlockedError{}
+		}
+	}
+
+	if ticket.PacketID != nil {
+		packets, err := catalog.GetPacketsByIDs(ctx, []int{*ticket.PacketID})
+		if err != nil {
+			return err
+		}
+		if len(packets) > 0 && packets[0].TransfersBlocked {
+			return &domain.TransfersBlockedError{}
+		}
+	}
+
+	return nil
+}
+
+// GetTransfer shows pending transfers whose offer ran out as expired even
+// before the sweep marks them.
+func (s *ticketTransferService) GetTransfer(ctx context.Context, id string) (*domain.TicketTransfer, error) {
+	transfer, err := s.transferRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Expired(time.Now()) {
+		transfer.Status = domain.TransferExpired
+	}
+	return transfer, nil
+}
+
+func (s *ticketTransferService) GetTransfers(ctx context.Context, userID int, filter *domain.TicketTransferFilter) ([]*domain.TicketTransfer, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.transferRepo.ExpirePending(ctx, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return s.transferRepo.GetByUserID(ctx, userID, filter)
+}
+
+// AcceptTransfer claims the transfer, rotates the ticket code in
+// EventManager and then moves the ticket to the recipient. Every step can be
+// repeated, so an acceptance that failed half way is finished by accepting
+// again. The claim is only given back when EventManager refuses the
+// rotation; after any other failure the code may already have changed.
+func (s *ticketTransferService) AcceptTransfer(ctx context.Context, transfer *domain.TicketTransfer, transferer TicketTransferer) (*domain.TicketTransfer, error) {
+	now := time.Now().UTC()
+	if transfer.Expired(now) {
+		if err := s.transferRepo.ExpirePending(ctx, now); err != nil {
+			return nil, err
+		}
+		return nil, &domain.TransferStateError{ID: transfer.ID, Status: domain.TransferExpired}
+	}
+
+	claimed, err := s.transferRepo.UpdateStatus(ctx, transfer.ID,
+		[]string{domain.TransferPending, domain.TransferAccepting}, domain.TransferAccepting, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := transferer.RotateTicketCode(ctx, claimed.TicketCode, claimed.NewCode); err != nil {
+		if rotationRefused(err) {
+			if _, revertErr := s.transferRepo.UpdateStatus(ctx, claimed.ID,
+				[]string{domain.TransferAccepting}, domain.TransferPending, nil); revertErr != nil {
+				return nil, revertErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.ticketRepo.Move(ctx, claimed.TicketCode, claimed.FromUserID, claimed.NewCode, claimed.ToUserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditRepo.Add(ctx, domain.TransferAuditEntries(claimed, domain.AuditTransferAccepted, now)); err != nil {
+		return nil, err
+	}
+
+	return s.transferRepo.UpdateStatus(ctx, claimed.ID, []string{domain.TransferAccepting}, domain.TransferAccepted, &now)
+}
+
+// rotationRefused reports whether EventManager answered that the rotation
+// cannot happen, as opposed to failing in a way that leaves it unknown.
+func rotationRefused(err error) bool {
+	var blocked *domain.TransfersBlockedError
+	var notFound *domain.ResourceNotFoundError
+	var validation *domain.ValidationError
+	return errors.As(err, &blocked) || errors.As(err, &notFound) || errors.As(err, &validation)
+}
+
+func (s *ticketTransferService) DeclineTransfer(ctx context.Context, transfer *domain.TicketTransfer) (*domain.TicketTransfer, error) {
+	return s.closeTransfer(ctx, transfer, domain.TransferDeclined, domain.AuditTransferDeclined)
+}
+
+func (s *ticketTransferService) CancelTransfer(ctx context.Context, transfer *domain.TicketTransfer) (*domain.TicketTransfer, error) {
+	return s.closeTransfer(ctx, transfer, domain.TransferCancelled, domain.AuditTransferCancelled)
+}
+
+// closeTransfer ends a pending transfer without moving the ticket.
+func (s *ticketTransferService) closeTransfer(ctx context.Context, transfer *domain.TicketTransfer, status string, action string) (*domain.TicketTransfer, error) {
+	now := time.Now().UTC()
+	if err := s.transferRepo.ExpirePending(ctx, now); err != nil {
+		return nil, err
+	}
+
+	closed, err := s.transferRepo.UpdateStatus(ctx, transfer.ID, []string{domain.TransferPending}, status, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditRepo.Add(ctx, domain.TransferAuditEntries(closed, action, now)); err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+func (s *ticketTransferService) GetTicketAudit(ctx context.Context, userID int) ([]*domain.TicketAuditEntry, error) {
+	return s.auditRepo.GetByUserID(ctx, userID)
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"userService/application/domain"
 	"userService/application/repository"
 
@@ -15,26 +19,53 @@ type UserService interface {
 	GetUserByID(ctx context.Context, id int) (*domain.User, error)
 	UpdateUser(ctx context.Context, id int, updates map[string]interface{}) (*domain.User, error)
 	DeleteUser(ctx context.Context, id int) (*domain.User, error)
-	CreateTicketForUser(ctx context.Context, userID int, packetID *int, eventID *int, ticketCreator TicketCreator) (string, error)
-	GetCustomersByEventID(ctx context.Context, eventID int) ([]*domain.User, error)
-	GetCustomersByPacketID(ctx context.Context, packetID int) ([]*domain.User, error)
+	CreateTicketForUser(ctx context.Context, userID int, packetID *int, eventID *int, quantity int, ticketIssuer TicketIssuer) ([]string, error)
+	GetTicketPrice(ctx context.Context, target *domain.WaitlistTarget, catalog TicketCatalog) (*int, error)
+	CheckPurchaseLimit(ctx context.Context, userID int, target *domain.WaitlistTarget, quantity int, catalog PurchaseCatalog) (bool, error)
+	TakeBackTickets(ctx context.Context, userID int, codes []string, voider TicketVoider)
+	GetUserTickets(ctx context.Context, userID int, filter *domain.TicketFilter, catalog TicketCatalog) ([]*domain.OwnedTicket, error)
+	GetETickets(ctx context.Context, userID int, codes []string, catalog TicketCatalog) ([]*domain.ETicket, error)
+	GetCustomersByEventID(ctx context.Context, eventID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error)
+	GetCustomersByPacketID(ctx context.Context, packetID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error)
+	ExportCustomersByEventID(ctx context.Context, eventID int, orderBy *string, fn func(*domain.Customer) error) error
+	ExportCustomersByPacketID(ctx context.Context, packetID int, orderBy *string, fn func(*domain.Customer) error) error
 }
 
 type TicketCreator interface {
 	CreateTicket(ctx context.Context, code string, packetID *int, eventID *int) (*TicketResponse, error)
 }
 
+// PurchaseCatalog looks up the per-user limits of what is bought and of the
+// events a packet includes.
+type PurchaseCatalog interface {
+	TicketCatalog
+	PacketContents
+}
+
+// TicketIssuer creates the tickets of a purchase and voids them again when
+// the purchase fails half way.
+type TicketIssuer interface {
+	TicketCreator
+	TicketVoider
+}
+
 type userService struct {
-	repo repository.UserRepository
+	repo       repository.UserRepository
+	ticketRepo repository.UserTicketRepository
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
+func NewUserService(repo repository.UserRepository, ticketRepo repository.UserTicketRepository) UserService {
 	return &userService{
-		repo: repo,
+		repo:       repo,
+		ticketRepo: ticketRepo,
 	}
 }
 
 func (s *userService) validateEmail(email string) bool {
+	return validateEmail(email)
+}
+
+func validateEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
 }
@@ -128,76 +159,322 @@ func (s *userService) DeleteUser(ctx context.Context, id int) (*domain.User, err
 	return s.repo.Delete(ctx, id)
 }
 
-func (s *userService) CreateTicketForUser(ctx context.Context, userID int, packetID *int, eventID *int, ticketCreator TicketCreator) (string, error) {
-	user, err := s.repo.GetByID(ctx, userID)
-	if err != nil {
-		return "", err
+// CreateTicketForUser buys quantity tickets, one by one. A purchase is all
+// or nothing: when a ticket cannot be issued, the ones issued before it are
+// voided and taken back from the user.
+func (s *userService) CreateTicketForUser(ctx context.Context, userID int, packetID *int, eventID *int, quantity int, ticketIssuer TicketIssuer) ([]string, error) {
+	if quantity < 1 || quantity > domain.MaxTicketsPerPurchase {
+		return nil, &domain.ValidationError{Field: "quantity", Reason: fmt.Sprintf("quantity must be between 1 and %d", domain.MaxTicketsPerPurchase)}
+	}
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return nil, err
 	}
 
+	codes := make([]string, 0, quantity)
+	for range quantity {
+		code, err := s.issueTicket(ctx, userID, packetID, eventID, ticketIssuer)
+		if err != nil {
+			s.TakeBackTickets(ctx, userID, codes, ticketIssuer)
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+func (s *userService) issueTicket(ctx context.Context, userID int, packetID *int, eventID *int, ticketIssuer TicketIssuer) (string, error) {
 	ticketCode := uuid.New().String()
 
-	ticketResp, err := ticketCreator.CreateTicket(ctx, ticketCode, packetID, eventID)
+	ticketResp, err := ticketIssuer.CreateTicket(ctx, ticketCode, packetID, eventID)
 	if err != nil {
 		return "", err
 	}
+	_ = ticketResp
 
-	newTicket := domain.Ticket{
-		PacketID: packetID,
-		EventID:  eventID,
-		Code:     ticketCode,
+	newTicket := &domain.Ticket{
+		PacketID:    packetID,
+		EventID:     eventID,
+		Code:        ticketCode,
+		PurchasedAt: time.Now().UTC(),
 	}
-	_ = ticketResp
 
-	updatedTicketList := append(user.TicketList, newTicket)
+	if err := s.ticketRepo.Add(ctx, userID, newTicket); err != nil {
+		_ = ticketIssuer.VoidTicket(ctx, ticketCode)
+		return "", &domain.InternalError{Msg: "failed to record user ticket", Err: err}
+	}
+
+	return ticketCode, nil
+}
 
-	updates := map[string]interface{}{
-		"ticket_list": updatedTicketList,
+// TakeBackTickets undoes the tickets of a failed purchase as far as it can;
+// the error that failed the purchase is the one reported.
+func (s *userService) TakeBackTickets(ctx context.Context, userID int, codes []string, voider TicketVoider) {
+	for _, code := range codes {
+		if err := voider.VoidTicket(ctx, code); err != nil {
+			continue
+		}
+		_ = s.ticketRepo.Remove(ctx, code, userID)
 	}
+}
 
-	_, err = s.repo.Update(ctx, userID, updates)
-	if err != nil {
-		return "", &domain.InternalError{Msg: "failed to update user ticket list", Err: err}
+// GetTicketPrice reads the face value of a ticket of target; nil means the
+// owner set no price.
+func (s *userService) GetTicketPrice(ctx context.Context, target *domain.WaitlistTarget, catalog TicketCatalog) (*int, error) {
+	if target.EventID != nil {
+		events, err := catalog.GetEventsByIDs(ctx, []int{*target.EventID})
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			return nil, &domain.ResourceNotFoundError{Resource: "event", ID: strconv.Itoa(*target.EventID)}
+		}
+		return events[0].Price, nil
 	}
 
-	return ticketCode, nil
+	packets, err := catalog.GetPacketsByIDs(ctx, []int{*target.PacketID})
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) == 0 {
+		return nil, &domain.ResourceNotFoundError{Resource: "packet", ID: strconv.Itoa(*target.PacketID)}
+	}
+	return packets[0].Price, nil
 }
 
-func (s *userService) filterPrivateFields(users []*domain.User) []*domain.User {
-	filteredUsers := make([]*domain.User, 0, len(users))
-	for _, u := range users {
-		filtered := &domain.User{
-			ID:               u.ID,
-			Email:            u.Email,
-			FirstName:        u.FirstName,
-			LastName:         u.LastName,
-			SocialMediaLinks: u.SocialMediaLinks,
-			TicketList:       nil,
+// CheckPurchaseLimit refuses quantity more tickets of target when they would
+// take what userID holds over a per-user limit: the packet's own limit, and
+// the limit of every event it includes, or of the event bought. Tickets of an
+// event count whether bought directly or through a packet including it. It
+// reports whether any limit applies, so a purchase without limits need not
+// be checked again.
+func (s *userService) CheckPurchaseLimit(ctx context.Context, userID int, target *domain.WaitlistTarget, quantity int, catalog PurchaseCatalog) (bool, error) {
+	var events []*domain.EventSummary
+	limited := false
+
+	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if target.PacketID != nil {
+		packetID := *target.PacketID
+		packets, err := catalog.GetPacketsByIDs(ctx, []int{packetID})
+		if err != nil {
+			return false, err
+		}
+		if len(packets) == 0 {
+			return false, &domain.ResourceNotFoundError{Resource: "packet", ID: strconv.Itoa(packetID)}
+		}
+
+		if limit := packets[0].MaxTicketsPerUser; limit != nil {
+			limited = true
+			held := countTickets(tickets, nil, []int{packetID})
+			if held+quantity > *limit {
+				return true, &domain.PurchaseLimitError{Resource: "packet", ID: packetID, Limit: *limit, Held: held}
+			}
 		}
 
-		if u.FirstNamePrivate {
-			filtered.FirstName = "[Private]"
+		if events, err = catalog.GetEventsOfPacket(ctx, packetID); err != nil {
+			return false, err
 		}
-		if u.LastNamePrivate {
-			filtered.LastName = "[Private]"
+	} else {
+		if events, err = catalog.GetEventsByIDs(ctx, []int{*target.EventID}); err != nil {
+			return false, err
 		}
+		if len(events) == 0 {
+			return false, &domain.ResourceNotFoundError{Resource: "event", ID: strconv.Itoa(*target.EventID)}
+		}
+	}
 
-		filteredUsers = append(filteredUsers, filtered)
+	for _, event := range events {
+		if event.MaxTicketsPerUser == nil {
+			continue
+		}
+		limited = true
+
+		packets, err := catalog.GetPacketsIncludingEvent(ctx, event.ID)
+		if err != nil {
+			return false, err
+		}
+		packetIDs := make([]int, 0, len(packets))
+		for _, packet := range packets {
+			packetIDs = append(packetIDs, packet.ID)
+		}
+
+		held := countTickets(tickets, []int{event.ID}, packetIDs)
+		if held+quantity > *event.MaxTicketsPerUser {
+			return true, &domain.PurchaseLimitError{Resource: "event", ID: event.ID, Limit: *event.MaxTicketsPerUser, Held: held}
+		}
 	}
-	return filteredUsers
+	return limited, nil
 }
 
-func (s *userService) GetCustomersByEventID(ctx context.Context, eventID int) ([]*domain.User, error) {
-	users, err := s.repo.GetUsersByEventID(ctx, eventID)
+// countTickets counts the tickets bought for any of eventIDs or packetIDs.
+func countTickets(tickets []domain.Ticket, eventIDs []int, packetIDs []int) int {
+	count := 0
+	for _, ticket := range tickets {
+		if (ticket.EventID != nil && slices.Contains(eventIDs, *ticket.EventID)) ||
+			(ticket.PacketID != nil && slices.Contains(packetIDs, *ticket.PacketID)) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetUserTickets resolves what each ticket of the user was bought for with
+// one lookup per kind, then keeps the upcoming or past ones if asked to.
+func (s *userService) GetUserTickets(ctx context.Context, userID int, filter *domain.TicketFilter, catalog TicketCatalog) ([]*domain.OwnedTicket, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := describeTickets(ctx, user.TicketList, catalog)
 	if err != nil {
 		return nil, err
 	}
-	return s.filterPrivateFields(users), nil
+
+	now := time.Now()
+	tickets := make([]*domain.OwnedTicket, 0, len(owned))
+	for _, ticket := range owned {
+		if filter.When != nil && ticket.Past(now) != (*filter.When == domain.TicketsPast) {
+			continue
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
 }
 
-func (s *userService) GetCustomersByPacketID(ctx context.Context, packetID int) ([]*domain.User, error) {
-	users, err := s.repo.GetUsersByPacketID(ctx, packetID)
+// GetETickets returns the tickets among codes the user still holds, in the
+// order of codes, with the holder name to print on them.
+func (s *userService) GetETickets(ctx context.Context, userID int, codes []string, catalog TicketCatalog) ([]*domain.ETicket, error) {
+	user, err := s.repo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	return s.filterPrivateFields(users), nil
+
+	held := make(map[string]domain.Ticket, len(user.TicketList))
+	for _, ticket := range user.TicketList {
+		held[ticket.Code] = ticket
+	}
+	var selected []domain.Ticket
+	for _, code := range codes {
+		if ticket, ok := held[code]; ok {
+			selected = append(selected, ticket)
+			delete(held, code)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	owned, err := describeTickets(ctx, selected, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	holderName := user.HolderName()
+	tickets := make([]*domain.ETicket, 0, len(owned))
+	for _, ticket := range owned {
+		tickets = append(tickets, &domain.ETicket{OwnedTicket: *ticket, HolderName: holderName})
+	}
+	return tickets, nil
+}
+
+// describeTickets looks up what each ticket was bought for, asking the
+// catalog once for all events and once for all packets.
+func describeTickets(ctx context.Context, tickets []domain.Ticket, catalog TicketCatalog) ([]*domain.OwnedTicket, error) {
+	var eventIDs, packetIDs []int
+	for _, ticket := range tickets {
+		if ticket.EventID != nil && !slices.Contains(eventIDs, *ticket.EventID) {
+			eventIDs = append(eventIDs, *ticket.EventID)
+		}
+		if ticket.PacketID != nil && !slices.Contains(packetIDs, *ticket.PacketID) {
+			packetIDs = append(packetIDs, *ticket.PacketID)
+		}
+	}
+
+	events := make(map[int]*domain.EventSummary, len(eventIDs))
+	if len(eventIDs) > 0 {
+		found, err := catalog.GetEventsByIDs(ctx, eventIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range found {
+			events[event.ID] = event
+		}
+	}
+
+	packets := make(map[int]*domain.PacketSummary, len(packetIDs))
+	if len(packetIDs) > 0 {
+		found, err := catalog.GetPacketsByIDs(ctx, packetIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, packet := range found {
+			packets[packet.ID] = packet
+		}
+	}
+
+	owned := make([]*domain.OwnedTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		described := &domain.OwnedTicket{Ticket: ticket}
+		if ticket.EventID != nil {
+			described.Event = events[*ticket.EventID]
+		}
+		if ticket.PacketID != nil {
+			described.Packet = packets[*ticket.PacketID]
+		}
+		owned = append(owned, described)
+	}
+	return owned, nil
+}
+
+func (s *userService) filterPrivateFields(customers []*domain.Customer) []*domain.Customer {
+	filtered := make([]*domain.Customer, 0, len(customers))
+	for _, customer := range customers {
+		filtered = append(filtered, customer.Masked())
+	}
+	return filtered
+}
+
+func (s *userService) GetCustomersByEventID(ctx context.Context, eventID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error) {
+	customers, pageInfo, err := s.repo.GetUsersByEventID(ctx, eventID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.filterPrivateFields(customers), pageInfo, nil
+}
+
+func (s *userService) GetCustomersByPacketID(ctx context.Context, packetID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error) {
+	customers, pageInfo, err := s.repo.GetUsersByPacketID(ctx, packetID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.filterPrivateFields(customers), pageInfo, nil
+}
+
+// ExportCustomersByEventID hands every customer of the event to fn, masked
+// the same way as the paged listing.
+func (s *userService) ExportCustomersByEventID(ctx context.Context, eventID int, orderBy *string, fn func(*domain.Customer) error) error {
+	if err := domain.ValidateCustomerOrder(orderBy); err != nil {
+		return err
+	}
+	return s.repo.EachUserByEventID(ctx, eventID, orderBy, func(customer *domain.Customer) error {
+		return fn(customer.Masked())
+	})
+}
+
+func (s *userService) ExportCustomersByPacketID(ctx context.Context, packetID int, orderBy *string, fn func(*domain.Customer) error) error {
+	if err := domain.ValidateCustomerOrder(orderBy); err != nil {
+		return err
+	}
+	return s.repo.EachUserByPacketID(ctx, packetID, orderBy, func(customer *domain.Customer) error {
+		return fn(customer.Masked())
+	})
 }
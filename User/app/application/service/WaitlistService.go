@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"userService/application/domain"
+	"userService/application/repository"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistService interface {
+	Join(ctx context.Context, userID int, target *domain.WaitlistTarget, seats SeatCounter) (*domain.WaitlistEntry, error)
+	GetEntry(ctx context.Context, id string) (*domain.WaitlistEntry, error)
+	GetUserEntries(ctx context.Context, userID int, status *string) ([]*domain.WaitlistEntry, error)
+	Leave(ctx context.Context, entry *domain.WaitlistEntry, seats SeatCounter) (*domain.WaitlistEntry, error)
+
+	// Advance offers the seats of target nobody holds an offer for to the
+	// next users in line.
+	Advance(ctx context.Context, target *domain.WaitlistTarget, seats SeatCounter) error
+	// Sweep expires the offers that ran out and advances every waitlist, so
+	// seats freed by cancellations or a capacity increase reach the line.
+	Sweep(ctx context.Context, seats SeatCounter) error
+	// Run sweeps every interval until ctx is done.
+	Run(ctx context.Context, interval time.Duration, seats SeatCounter)
+
+	// CheckPurchase refuses a purchase of quantity seats by userID when the
+	// seats left that are not offered to someone else are too few.
+	CheckPurchase(ctx context.Context, userID int, target *domain.WaitlistTarget, quantity int, seats SeatCounter) error
+	// CompletePurchase closes the entry of a user who bought a ticket for
+	// target, if they were in line.
+	CompletePurchase(ctx context.Context, userID int, target *domain.WaitlistTarget) error
+}
+
+type waitlistService struct {
+	waitlistRepo repository.WaitlistRepository
+}
+
+func NewWaitlistService(waitlistRepo repository.WaitlistRepository) WaitlistService {
+	return &waitlistService{
+		waitlistRepo: waitlistRepo,
+	}
+}
+
+// Join puts userID at the end of the line for target. When seats are free
+// already, the user is offered one right away.
+func (s *waitlistService) Join(ctx context.Context, userID int, target *domain.WaitlistTarget, seats SeatCounter) (*domain.WaitlistEntry, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+
+	remaining, err := seats.GetRemainingSeats(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if remaining == nil {
+		return nil, &domain.CapacityNotConfiguredError{}
+	}
+
+	entry, err := s.waitlistRepo.Create(ctx, &domain.WaitlistEntry{
+		ID:       uuid.New().String(),
+		EventID:  target.EventID,
+		PacketID: target.PacketID,
+		UserID:   userID,
+		Status:   domain.WaitlistWaiting,
+		JoinedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.waitlistRepo.ExpireOffers(ctx, now); err != nil {
+		return nil, err
+	}
+	if err := s.offer(ctx, target, *remaining, now); err != nil {
+		return nil, err
+	}
+	return s.GetEntry(ctx, entry.ID)
+}
+
+// GetEntry shows offers that ran out as expired even before the sweep marks
+// them.
+func (s *waitlistService) GetEntry(ctx context.Context, id string) (*domain.WaitlistEntry, error) {
+	entry, err := s.waitlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.describe(ctx, entry, time.Now()); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *waitlistService) GetUserEntries(ctx context.Context, userID int, status *string) ([]*domain.WaitlistEntry, error) {
+	if err := domain.ValidateWaitlistStatus(status); err != nil {
+		return nil, err
+	}
+	if err := s.waitlistRepo.ExpireOffers(ctx, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.waitlistRepo.GetByUserID(ctx, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if err := s.describe(ctx, entry, now); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (s *waitlistService) describe(ctx context.Context, entry *domain.WaitlistEntry, now time.Time) error {
+	switch entry.Status {
+	case domain.WaitlistOffered:
+		if entry.OfferExpiresAt != nil && !now.Before(*entry.OfferExpiresAt) {
+			entry.Status = domain.WaitlistExpired
+		}
+	case domain.WaitlistWaiting:
+		ahead, err := s.waitlistRepo.CountAhead(ctx, entry)
+		if err != nil {
+			return err
+		}
+		position := int(ahead) + 1
+		entry.Position = &position
+	}
+	return nil
+}
+
+// Leave takes the user out of line. An offer given back goes to the next
+// user; if EventManager cannot be asked for the seats now, the next sweep
+// makes that offer.
+func (s *waitlistService) Leave(ctx context.Context, entry *domain.WaitlistEntry, seats SeatCounter) (*domain.WaitlistEntry, error) {
+	left, err := s.waitlistRepo.UpdateStatus(ctx, entry.ID,
+		[]string{domain.WaitlistWaiting, domain.WaitlistOffered}, domain.WaitlistLeft, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Status == domain.WaitlistOffered {
+		_ = s.Advance(ctx, left.Target(), seats)
+	}
+	return left, nil
+}
+
+func (s *waitlistService) Advance(ctx context.Context, target *domain.WaitlistTarget, seats SeatCounter) error {
+	now := time.Now().UTC()
+	if err := s.waitlistRepo.ExpireOffers(ctx, now); err != nil {
+		return err
+	}
+	return s.advance(ctx, target, seats, now)
+}
+
+func (s *waitlistService) advance(ctx context.Context, target *domain.WaitlistTarget, seats SeatCounter, now time.Time) error {
+	remaining, err := seats.GetRemainingSeats(ctx, target)
+	if err != nil {
+		return err
+	}
+	if remaining == nil {
+		return nil
+	}
+	return s.offer(ctx, target, *remaining, now)
+}
+
+// offer hands out the remaining seats not yet offered, first come first
+// served.
+func (s *waitlistService) offer(ctx context.Context, target *domain.WaitlistTarget, remaining int, now time.Time) error {
+	offered, err := s.waitlistRepo.CountOffered(ctx, target)
+	if err != nil {
+		return err
+	}
+	if free := remaining - int(offered); free > 0 {
+		if _, err := s.waitlistRepo.OfferNext(ctx, target, free, now, now.Add(domain.WaitlistOfferTTL)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sweep keeps going past a waitlist it cannot advance and returns the first
+// error it met.
+func (s *waitlistService) Sweep(ctx context.Context, seats SeatCounter) error {
+	now := time.Now().UTC()
+	if err := s.waitlistRepo.ExpireOffers(ctx, now); err != nil {
+		return err
+	}
+
+	targets, err := s.waitlistRepo.GetTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, target := range targets {
+		var notFound *domain.ResourceNotFoundError
+		if err := s.advance(ctx, target, seats, now); err != nil && !errors.As(err, &notFound) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *waitlistService) Run(ctx context.Context, interval time.Duration, seats SeatCounter) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sweep(ctx, seats); err != nil && ctx.Err() == nil {
+			fmt.Printf("Failed to advance waitlists: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckPurchase only asks EventManager for the seats when offers are out,
+// so purchases for events nobody waits for cost nothing extra.
+func (s *waitlistService) CheckPurchase(ctx context.Context, userID int, target *domain.WaitlistTarget, quantity int, seats SeatCounter) error {
+	if err := target.Validate(); err != nil {
+		return err
+	}
+	if err := s.waitlistRepo.ExpireOffers(ctx, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	offered, err := s.waitlistRepo.CountOffered(ctx, target)
+	if err != nil || offered == 0 {
+		return err
+	}
+
+	entry, err := s.waitlistRepo.GetOpen(ctx, userID, target)
+	var notFound *domain.ResourceNotFoundError
+	if err == nil && entry.Status == domain.WaitlistOffered {
+		offered--
+	} else if err != nil && !errors.As(err, &notFound) {
+		return err
+	}
+	if offered == 0 {
+		return nil
+	}
+
+	remaining, err := seats.GetRemainingSeats(ctx, target)
+	if err != nil {
+		return err
+	}
+	if remaining == nil || *remaining-int(offered) >= quantity {
+		return nil
+	}
+
+	soldOut := &domain.SoldOutError{
+		Code:      domain.CodeEventSoldOut,
+		Resource:  "event",
+		Remaining: *remaining,
+		Detail:    "the remaining seats are offered to users on the waitlist",
+	}
+	if target.PacketID != nil {
+		soldOut.Code, soldOut.Resource, soldOut.ID = domain.CodePacketSoldOut, "packet", *target.PacketID
+	} else {
+		soldOut.ID = *target.EventID
+	}
+	return soldOut
+}
+
+func (s *waitlistService) CompletePurchase(ctx context.Context, userID int, target *domain.WaitlistTarget) error {
+	entry, err := s.waitlistRepo.GetOpen(ctx, userID, target)
+	var notFound *domain.ResourceNotFoundError
+	if errors.As(err, &notFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err = s.waitlistRepo.UpdateStatus(ctx, entry.ID,
+		[]string{domain.WaitlistWaiting, domain.WaitlistOffered}, domain.WaitlistAccepted, time.Now().UTC())
+	return err
+}
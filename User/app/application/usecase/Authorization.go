@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// authorizeUser checks that the token belongs to the user, the same way
+// ticket purchases do.
+func authorizeUser(ctx context.Context, authNService service.AuthenticationService, userService service.UserService, token string, userID int) error {
+	identity, err := authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
+	}
+
+	user, err := userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Email != identity.Email {
+		return &domain.ForbiddenError{Reason: "token email does not match user email"}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+type CalendarUsecase interface {
+	GetCalendarFeed(ctx context.Context, token string, userID int) (*domain.CalendarFeed, error)
+	RotateCalendarFeed(ctx context.Context, token string, userID int) (*domain.CalendarFeed, error)
+	// GetCalendar is authorized by the feed token alone, which calendar apps
+	// send as part of the feed URL.
+	GetCalendar(ctx context.Context, userID int, feedToken string) ([]*domain.CalendarEntry, error)
+}
+
+type calendarUsecase struct {
+	calendarService     service.CalendarService
+	userService         service.UserService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+}
+
+func NewCalendarUsecase(
+	calendarService service.CalendarService,
+	userService service.UserService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+) CalendarUsecase {
+	return &calendarUsecase{
+		calendarService:     calendarService,
+		userService:         userService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+	}
+}
+
+func (uc *calendarUsecase) GetCalendarFeed(ctx context.Context, token string, userID int) (*domain.CalendarFeed, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.calendarService.GetFeed(ctx, userID)
+}
+
+func (uc *calendarUsecase) RotateCalendarFeed(ctx context.Context, token string, userID int) (*domain.CalendarFeed, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.calendarService.RotateFeed(ctx, userID)
+}
+
+func (uc *calendarUsecase) GetCalendar(ctx context.Context, userID int, feedToken string) ([]*domain.CalendarEntry, error) {
+	return uc.calendarService.GetCalendar(ctx, userID, feedToken, uc.eventManagerService)
+}
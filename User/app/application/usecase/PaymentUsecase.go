@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+type PaymentUsecase interface {
+	GetUserOrders(ctx context.Context, token string, userID int) ([]*domain.Order, error)
+	GetUserOrder(ctx context.Context, token string, userID int, orderID string) (*domain.Order, *domain.Payment, error)
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) (*domain.Payment, error)
+}
+
+type paymentUsecase struct {
+	paymentService service.PaymentService
+	userService    service.UserService
+	authNService   service.AuthenticationService
+}
+
+func NewPaymentUsecase(
+	paymentService service.PaymentService,
+	userService service.UserService,
+	authNService service.AuthenticationService,
+) PaymentUsecase {
+	return &paymentUsecase{
+		paymentService: paymentService,
+		userService:    userService,
+		authNService:   authNService,
+	}
+}
+
+func (uc *paymentUsecase) GetUserOrders(ctx context.Context, token string, userID int) ([]*domain.Order, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.paymentService.GetUserOrders(ctx, userID)
+}
+
+// GetUserOrder returns an order with its payment, nil when there was
+// nothing to pay. Orders of other users are reported as missing.
+func (uc *paymentUsecase) GetUserOrder(ctx context.Context, token string, userID int, orderID string) (*domain.Order, *domain.Payment, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, nil, err
+	}
+
+	order, err := uc.paymentService.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if order.UserID != userID {
+		return nil, nil, &domain.ResourceNotFoundError{Resource: "order", ID: orderID}
+	}
+
+	payment, err := uc.paymentService.GetOrderPayment(ctx, order.ID)
+	var notFound *domain.ResourceNotFoundError
+	if errors.As(err, &notFound) {
+		return order, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return order, payment, nil
+}
+
+// HandlePaymentWebhook needs no token; the gateway's signature stands for
+// it.
+func (uc *paymentUsecase) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) (*domain.Payment, error) {
+	return uc.paymentService.HandleWebhook(ctx, payload, signature)
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// PromoCodeUsecase lets owners manage the promo codes of their events and
+// packets and see how they were used. The client service may manage the
+// codes of any owner.
+type PromoCodeUsecase interface {
+	CreatePromoCode(ctx context.Context, token string, promo *domain.PromoCode) (*domain.PromoCode, error)
+	GetPromoCodes(ctx context.Context, token string, ownerID *int) ([]*domain.PromoCode, error)
+	GetPromoCode(ctx context.Context, token string, id string) (*domain.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, token string, id string, updates map[string]interface{}) (*domain.PromoCode, error)
+	DeletePromoCode(ctx context.Context, token string, id string) (*domain.PromoCode, error)
+	GetPromoCodeUsage(ctx context.Context, token string, id string) (*domain.PromoCodeUsage, error)
+}
+
+type promoCodeUsecase struct {
+	promoCodeService    service.PromoCodeService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+	authZService        service.AuthorizationService
+}
+
+func NewPromoCodeUsecase(
+	promoCodeService service.PromoCodeService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+	authZService service.AuthorizationService,
+) PromoCodeUsecase {
+	return &promoCodeUsecase{
+		promoCodeService:    promoCodeService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+		authZService:        authZService,
+	}
+}
+
+// authorizeManager checks that the token belongs to ownerID or to the client
+// service.
+func (uc *promoCodeUsecase) authorizeManager(ctx context.Context, identity *service.UserIdentity, ownerID int) error {
+	allowed, err := uc.authZService.CanUserManagePromoCodes(ctx, identity, ownerID)
+	if err != nil {
+		return &domain.ForbiddenError{Reason: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return &domain.ForbiddenError{Reason: "only the owner or the client service can manage promo codes"}
+	}
+	return nil
+}
+
+func (uc *promoCodeUsecase) authenticate(ctx context.Context, token string) (*service.UserIdentity, error) {
+	identity, err := uc.authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return nil, &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
+	}
+	return identity, nil
+}
+
+// authorizedPromoCode reports codes the caller may not manage as forbidden,
+// the same as refunds of other owners.
+func (uc *promoCodeUsecase) authorizedPromoCode(ctx context.Context, token string, id string) (*domain.PromoCode, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	promo, err := uc.promoCodeService.GetPromoCode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeManager(ctx, identity, promo.OwnerID); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+// CreatePromoCode gives the code to the owner of the events and packets it
+// is meant for, which are looked up in EventManager.
+func (uc *promoCodeUsecase) CreatePromoCode(ctx context.Context, token string, promo *domain.PromoCode) (*domain.PromoCode, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID, err := uc.promoCodeService.GetScopeOwner(ctx, promo.EventIDs, promo.PacketIDs, uc.eventManagerService)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeManager(ctx, identity, ownerID); err != nil {
+		return nil, err
+	}
+
+	promo.OwnerID = ownerID
+	return uc.promoCodeService.CreatePromoCode(ctx, promo)
+}
+
+// GetPromoCodes lists the codes of ownerID, or of the caller when not given.
+func (uc *promoCodeUsecase) GetPromoCodes(ctx context.Context, token string, ownerID *int) ([]*domain.PromoCode, error) {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := int(identity.UserID)
+	if ownerID != nil {
+		owner = *ownerID
+	}
+	if err := uc.authorizeManager(ctx, identity, owner); err != nil {
+		return nil, err
+	}
+	return uc.promoCodeService.GetPromoCodes(ctx, owner)
+}
+
+func (uc *promoCodeUsecase) GetPromoCode(ctx context.Context, token string, id string) (*domain.PromoCode, error) {
+	return uc.authorizedPromoCode(ctx, token, id)
+}
+
+func (uc *promoCodeUsecase) UpdatePromoCode(ctx context.Context, token string, id string, updates map[string]interface{}) (*domain.PromoCode, error) {
+	promo, err := uc.authorizedPromoCode(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.promoCodeService.UpdatePromoCode(ctx, promo, updates)
+}
+
+func (uc *promoCodeUsecase) DeletePromoCode(ctx context.Context, token string, id string) (*domain.PromoCode, error) {
+	promo, err := uc.authorizedPromoCode(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.promoCodeService.DeletePromoCode(ctx, promo); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+func (uc *promoCodeUsecase) GetPromoCodeUsage(ctx context.Context, token string, id string) (*domain.PromoCodeUsage, error) {
+	promo, err := uc.authorizedPromoCode(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.promoCodeService.GetPromoCodeUsage(ctx, promo)
+}
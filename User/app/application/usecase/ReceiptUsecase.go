@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+type ReceiptUsecase interface {
+	GetUserReceipts(ctx context.Context, token string, userID int) ([]*domain.Receipt, error)
+	GetUserReceipt(ctx context.Context, token string, userID int, receiptID string) (*domain.Receipt, error)
+	GetOrderReceipt(ctx context.Context, token string, userID int, orderID string) (*domain.Receipt, error)
+}
+
+type receiptUsecase struct {
+	receiptService      service.ReceiptService
+	paymentService      service.PaymentService
+	userService         service.UserService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+}
+
+func NewReceiptUsecase(
+	receiptService service.ReceiptService,
+	paymentService service.PaymentService,
+	userService service.UserService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+) ReceiptUsecase {
+	return &receiptUsecase{
+		receiptService:      receiptService,
+		paymentService:      paymentService,
+		userService:         userService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+	}
+}
+
+func (uc *receiptUsecase) GetUserReceipts(ctx context.Context, token string, userID int) ([]*domain.Receipt, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.receiptService.GetUserReceipts(ctx, userID)
+}
+
+// GetUserReceipt reports receipts of other users as missing.
+func (uc *receiptUsecase) GetUserReceipt(ctx context.Context, token string, userID int, receiptID string) (*domain.Receipt, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	receipt, err := uc.receiptService.GetReceipt(ctx, receiptID)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.UserID != userID {
+		return nil, &domain.ResourceNotFoundError{Resource: "receipt", ID: receiptID}
+	}
+	return receipt, nil
+}
+
+// GetOrderReceipt returns the receipt of a completed order, issuing it now
+// if the purchase could not.
+func (uc *receiptUsecase) GetOrderReceipt(ctx context.Context, token string, userID int, orderID string) (*domain.Receipt, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	order, err := uc.paymentService.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, &domain.ResourceNotFoundError{Resource: "order", ID: orderID}
+	}
+	return uc.receiptService.IssueReceipt(ctx, order, uc.eventManagerService)
+}
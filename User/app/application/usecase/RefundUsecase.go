@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// RefundUsecase lets users ask for their tickets to be taken back and lets
+// the owner of the event or packet, or the client service, decide. Calls on
+// behalf of a holder act for the user in the path, who must be the one the
+// token belongs to.
+type RefundUsecase interface {
+	RequestRefund(ctx context.Context, token string, userID int, code string, reason string) (*domain.RefundRequest, error)
+	GetUserRefunds(ctx context.Context, token string, userID int, status *string) ([]*domain.RefundRequest, error)
+	GetUserRefund(ctx context.Context, token string, userID int, refundID string) (*domain.RefundRequest, error)
+
+	GetRefunds(ctx context.Context, token string, filter *domain.RefundFilter) ([]*domain.RefundRequest, error)
+	GetRefund(ctx context.Context, token string, refundID string) (*domain.RefundRequest, error)
+	ApproveRefund(ctx context.Context, token string, refundID string, amount *int, note string) (*domain.RefundRequest, error)
+	DenyRefund(ctx context.Context, token string, refundID string, note string) (*domain.RefundRequest, error)
+}
+
+type refundUsecase struct {
+	refundService       service.RefundService
+	paymentService      service.PaymentService
+	waitlistService     service.WaitlistService
+	userService         service.UserService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+	authZService        service.AuthorizationService
+}
+
+func NewRefundUsecase(
+	refundService service.RefundService,
+	paymentService service.PaymentService,
+	waitlistService service.WaitlistService,
+	userService service.UserService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+	authZService service.AuthorizationService,
+) RefundUsecase {
+	return &refundUsecase{
+		refundService:       refundService,
+		paymentService:      paymentService,
+		waitlistService:     waitlistService,
+		userService:         userService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+		authZService:        authZService,
+	}
+}
+
+// authorizeDecider checks that the token belongs to the owner of the event
+// or packet, or to the client service, and returns the refund terms it
+// looked up on the way.
+func (uc *refundUsecase) authorizeDecider(ctx context.Context, token string, eventID *int, packetID *int) (*service.UserIdentity, *domain.RefundTerms, error) {
+	identity, err := uc.authNService.WhoIsUser(ctx, token)
+	if err != nil {
+		return nil, nil, &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
+	}
+
+	terms, err := uc.refundService.GetRefundTerms(ctx, eventID, packetID, uc.eventManagerService)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allowed, err := uc.authZService.CanUserDecideRefund(ctx, identity, terms.OwnerID)
+	if err != nil {
+		return nil, nil, &domain.ForbiddenError{Reason: fmt.Sprintf("authorization check failed: %v", err)}
+	}
+	if !allowed {
+		return nil, nil, &domain.ForbiddenError{Reason: "only the owner or the client service can decide refunds"}
+	}
+	return identity, terms, nil
+}
+
+func (uc *refundUsecase) RequestRefund(ctx context.Context, token string, userID int, code string, reason string) (*domain.RefundRequest, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.refundService.RequestRefund(ctx, userID, code, reason, uc.eventManagerService)
+}
+
+func (uc *refundUsecase) GetUserRefunds(ctx context.Context, token string, userID int, status *string) ([]*domain.RefundRequest, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.refundService.GetUserRefunds(ctx, userID, status)
+}
+
+// GetUserRefund reports refunds of other users as missing.
+func (uc *refundUsecase) GetUserRefund(ctx context.Context, token string, userID int, refundID string) (*domain.RefundRequest, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	refund, err := uc.refundService.GetRefund(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+	if refund.UserID != userID {
+		return nil, &domain.ResourceNotFoundError{Resource: "refund", ID: refundID}
+	}
+	return refund, nil
+}
+
+func (uc *refundUsecase) GetRefunds(ctx context.Context, token string, filter *domain.RefundFilter) ([]*domain.RefundRequest, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if _, _, err := uc.authorizeDecider(ctx, token, filter.EventID, filter.PacketID); err != nil {
+		return nil, err
+	}
+	return uc.refundService.GetRefunds(ctx, filter)
+}
+
+func (uc *refundUsecase) GetRefund(ctx context.Context, token string, refundID string) (*domain.RefundRequest, error) {
+	refund, err := uc.refundService.GetRefund(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := uc.authorizeDecider(ctx, token, refund.EventID, refund.PacketID); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func (uc *refundUsecase) ApproveRefund(ctx context.Context, token string, refundID string, amount *int, note string) (*domain.RefundRequest, error) {
+	refund, err := uc.refundService.GetRefund(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, terms, err := uc.authorizeDecider(ctx, token, refund.EventID, refund.PacketID)
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := uc.refundService.ApproveRefund(ctx, refund, terms, &domain.RefundDecision{
+		DecidedBy: int(identity.UserID),
+		Amount:    amount,
+		Note:      note,
+	}, uc.eventManagerService, uc.paymentService)
+	if err != nil {
+		return nil, err
+	}
+
+	// the voided ticket freed a seat; if it cannot be offered now, the next
+	// waitlist sweep offers it
+	_ = uc.waitlistService.Advance(ctx, approved.Target(), uc.eventManagerService)
+	return approved, nil
+}
+
+func (uc *refundUsecase) DenyRefund(ctx context.Context, token string, refundID string, note string) (*domain.RefundRequest, error) {
+	refund, err := uc.refundService.GetRefund(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, _, err := uc.authorizeDecider(ctx, token, refund.EventID, refund.PacketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.refundService.DenyRefund(ctx, refund, &domain.RefundDecision{
+		DecidedBy: int(identity.UserID),
+		Note:      note,
+	})
+}
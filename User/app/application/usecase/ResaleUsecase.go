@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// ResaleUsecase runs the resale marketplace. Calls on behalf of a seller or
+// a buyer act for the user in the path, who must be the one the token
+// belongs to; browsing only needs a valid token.
+type ResaleUsecase interface {
+	CreateListing(ctx context.Context, token string, userID int, code string, price int) (*domain.ResaleListing, error)
+	GetUserListings(ctx context.Context, token string, userID int, status *string) ([]*domain.ResaleListing, error)
+	CancelListing(ctx context.Context, token string, userID int, listingID string) (*domain.ResaleListing, error)
+	PurchaseListing(ctx context.Context, token string, userID int, listingID string, paymentToken string) (*domain.ResaleListing, error)
+
+	GetListings(ctx context.Context, token string, filter *domain.ResaleListingFilter) ([]*domain.ResaleListing, error)
+	GetListing(ctx context.Context, token string, listingID string) (*domain.ResaleListing, error)
+}
+
+type resaleUsecase struct {
+	resaleService       service.ResaleService
+	paymentService      service.PaymentService
+	userService         service.UserService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+}
+
+func NewResaleUsecase(
+	resaleService service.ResaleService,
+	paymentService service.PaymentService,
+	userService service.UserService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+) ResaleUsecase {
+	return &resaleUsecase{
+		resaleService:       resaleService,
+		paymentService:      paymentService,
+		userService:         userService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+	}
+}
+
+func (uc *resaleUsecase) CreateListing(ctx context.Context, token string, userID int, code string, price int) (*domain.ResaleListing, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.resaleService.CreateListing(ctx, userID, code, price, uc.eventManagerService)
+}
+
+func (uc *resaleUsecase) GetUserListings(ctx context.Context, token string, userID int, status *string) ([]*domain.ResaleListing, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.resaleService.GetSellerListings(ctx, userID, status)
+}
+
+func (uc *resaleUsecase) CancelListing(ctx context.Context, token string, userID int, listingID string) (*domain.ResaleListing, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	listing, err := uc.resaleService.GetListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerID != userID {
+		return nil, &domain.ForbiddenError{Reason: "only the seller can cancel a listing"}
+	}
+
+	return uc.resaleService.CancelListing(ctx, listing)
+}
+
+// PurchaseListing is paid for like any other purchase: the listing price is
+// recorded as an order and authorized on the buyer's card, the ticket is
+// reissued to the buyer and the payment captured. A purchase that fails
+// voids the authorization; one whose capture the gateway refuses also hands
+// the ticket back to the seller.
+func (uc *resaleUsecase) PurchaseListing(ctx context.Context, token string, userID int, listingID string, paymentToken string) (*domain.ResaleListing, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	listing, err := uc.resaleService.GetListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	// checked before anything is authorized; the claim checks it again
+	if !listing.Open() {
+		return nil, &domain.ListingStateError{ID: listing.ID, Status: listing.Status}
+	}
+	if listing.SellerID == userID {
+		return nil, &domain.ValidationError{Field: "listing_id", Reason: "cannot buy your own listing"}
+	}
+
+	order, err := uc.paymentService.CreateOrder(ctx, userID, &domain.PurchaseRequest{
+		EventID:      listing.EventID,
+		PacketID:     listing.PacketID,
+		Quantity:     1,
+		PaymentToken: paymentToken,
+	}, &listing.Price, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := uc.paymentService.AuthorizePayment(ctx, order, paymentToken)
+	if err != nil {
+		_ = uc.paymentService.FailOrder(ctx, order, err.Error())
+		return nil, err
+	}
+
+	sold, err := uc.resaleService.PurchaseListing(ctx, listing, userID, uc.eventManagerService)
+	if err != nil {
+		// buying again starts a new order, so this authorization is never
+		// used
+		if payment != nil {
+			_ = uc.paymentService.VoidPayment(ctx, payment, err.Error())
+		}
+		_ = uc.paymentService.FailOrder(ctx, order, err.Error())
+		return nil, err
+	}
+
+	codes := []string{sold.NewCode}
+	if payment != nil {
+		_, err := uc.paymentService.CapturePayment(ctx, payment, codes)
+		var gatewayErr *domain.PaymentGatewayError
+		if errors.As(err, &gatewayErr) {
+			// a ticket that cannot be handed back stays with the buyer and
+			// the authorization is kept, to be settled by hand
+			if reverseErr := uc.resaleService.ReverseSale(ctx, sold, uc.eventManagerService); reverseErr != nil {
+				return nil, reverseErr
+			}
+			_ = uc.paymentService.VoidPayment(ctx, payment, err.Error())
+			_ = uc.paymentService.FailOrder(ctx, order, err.Error())
+			return nil, err
+		}
+		// otherwise the money was taken and the sale stands; the gateway's
+		// webhook brings the payment record up to date
+	}
+
+	_, _ = uc.paymentService.CompleteOrder(ctx, order, codes)
+	return sold, nil
+}
+
+func (uc *resaleUsecase) GetListings(ctx context.Context, token string, filter *domain.ResaleListingFilter) ([]*domain.ResaleListing, error) {
+	if _, err := uc.authNService.WhoIsUser(ctx, token); err != nil {
+		return nil, &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
+	}
+	return uc.resaleService.GetListings(ctx, filter)
+}
+
+func (uc *resaleUsecase) GetListing(ctx context.Context, token string, listingID string) (*domain.ResaleListing, error) {
+	if _, err := uc.authNService.WhoIsUser(ctx, token); err != nil {
+		return nil, &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
+	}
+	return uc.resaleService.GetListing(ctx, listingID)
+}
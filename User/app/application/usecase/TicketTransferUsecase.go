@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// TicketTransferUsecase lets users hand their tickets to each other. Every
+// call acts for the user in the path, who must be the one the token belongs
+// to.
+type TicketTransferUsecase interface {
+	InitiateTransfer(ctx context.Context, token string, userID int, code string, recipientEmail string) (*domain.TicketTransfer, error)
+	GetTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error)
+	GetTransfers(ctx context.Context, token string, userID int, filter *domain.TicketTransferFilter) ([]*domain.TicketTransfer, error)
+	AcceptTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error)
+	DeclineTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error)
+	CancelTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error)
+	GetTicketAudit(ctx context.Context, token string, userID int) ([]*domain.TicketAuditEntry, error)
+}
+
+type ticketTransferUsecase struct {
+	transferService     service.TicketTransferService
+	userService         service.UserService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+}
+
+func NewTicketTransferUsecase(
+	transferService service.TicketTransferService,
+	userService service.UserService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+) TicketTransferUsecase {
+	return &ticketTransferUsecase{
+		transferService:     transferService,
+		userService:         userService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+	}
+}
+
+// transferOf loads a transfer the user takes part in; others' transfers are
+// reported as missing.
+func (uc *ticketTransferUsecase) transferOf(ctx context.Context, userID int, transferID string) (*domain.TicketTransfer, error) {
+	transfer, err := uc.transferService.GetTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserID != userID && transfer.ToUserID != userID {
+		return nil, &domain.ResourceNotFoundError{Resource: "transfer", ID: transferID}
+	}
+	return transfer, nil
+}
+
+func (uc *ticketTransferUsecase) InitiateTransfer(ctx context.Context, token string, userID int, code string, recipientEmail string) (*domain.TicketTransfer, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.transferService.InitiateTransfer(ctx, userID, code, recipientEmail, uc.eventManagerService)
+}
+
+func (uc *ticketTransferUsecase) GetTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.transferOf(ctx, userID, transferID)
+}
+
+func (uc *ticketTransferUsecase) GetTransfers(ctx context.Context, token string, userID int, filter *domain.TicketTransferFilter) ([]*domain.TicketTransfer, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.transferService.GetTransfers(ctx, userID, filter)
+}
+
+func (uc *ticketTransferUsecase) AcceptTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := uc.transferOf(ctx, userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != userID {
+		return nil, &domain.ForbiddenError{Reason: "only the recipient can accept a transfer"}
+	}
+
+	return uc.transferService.AcceptTransfer(ctx, transfer, uc.eventManagerService)
+}
+
+func (uc *ticketTransferUsecase) DeclineTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := uc.transferOf(ctx, userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != userID {
+		return nil, &domain.ForbiddenError{Reason: "only the recipient can decline a transfer"}
+	}
+
+	return uc.transferService.DeclineTransfer(ctx, transfer)
+}
+
+func (uc *ticketTransferUsecase) CancelTransfer(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := uc.transferOf(ctx, userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserID != userID {
+		return nil, &domain.ForbiddenError{Reason: "only the holder can cancel a transfer"}
+	}
+
+	return uc.transferService.CancelTransfer(ctx, transfer)
+}
+
+func (uc *ticketTransferUsecase) GetTicketAudit(ctx context.Context, token string, userID int) ([]*domain.TicketAuditEntry, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.transferService.GetTicketAudit(ctx, userID)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"userService/application/domain"
 	"userService/application/service"
@@ -12,14 +13,23 @@ type UserUsecase interface {
 	GetUserByID(ctx context.Context, token string, id int) (*domain.User, error)
 	UpdateUser(ctx context.Context, token string, id int, updates map[string]interface{}) (*domain.User, error)
 	DeleteUser(ctx context.Context, token string, id int) (*domain.User, error)
-	CreateTicketForUser(ctx context.Context, userID int, token string, packetID *int, eventID *int) (string, error)
+	CreateTicketForUser(ctx context.Context, userID int, token string, request *domain.PurchaseRequest) (*domain.Purchase, error)
+	GetUserTickets(ctx context.Context, token string, userID int, filter *domain.TicketFilter) ([]*domain.OwnedTicket, error)
+	GetUserETicket(ctx context.Context, token string, userID int, code string) (*domain.ETicket, error)
+	GetOrderETickets(ctx context.Context, token string, userID int, orderID string) ([]*domain.ETicket, error)
 
-	GetCustomersByEventID(ctx context.Context, token string, eventID int) ([]*domain.User, error)
-	GetCustomersByPacketID(ctx context.Context, token string, packetID int) ([]*domain.User, error)
+	GetCustomersByEventID(ctx context.Context, token string, eventID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error)
+	GetCustomersByPacketID(ctx context.Context, token string, packetID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error)
+	ExportCustomersByEventID(ctx context.Context, token string, eventID int, orderBy *string, fn func(*domain.Customer) error) error
+	ExportCustomersByPacketID(ctx context.Context, token string, packetID int, orderBy *string, fn func(*domain.Customer) error) error
 }
 
 type userUsecase struct {
 	userService         service.UserService
+	waitlistService     service.WaitlistService
+	promoCodeService    service.PromoCodeService
+	paymentService      service.PaymentService
+	receiptService      service.ReceiptService
 	eventManagerService service.EventManagerService
 	authNService        service.AuthenticationService
 	authZService        service.AuthorizationService
@@ -27,12 +37,20 @@ type userUsecase struct {
 
 func NewUserUsecase(
 	userService service.UserService,
+	waitlistService service.WaitlistService,
+	promoCodeService service.PromoCodeService,
+	paymentService service.PaymentService,
+	receiptService service.ReceiptService,
 	eventManagerService service.EventManagerService,
 	authNService service.AuthenticationService,
 	authZService service.AuthorizationService,
 ) UserUsecase {
 	return &userUsecase{
 		userService:         userService,
+		waitlistService:     waitlistService,
+		promoCodeService:    promoCodeService,
+		paymentService:      paymentService,
+		receiptService:      receiptService,
 		eventManagerService: eventManagerService,
 		authNService:        authNService,
 		authZService:        authZService,
@@ -117,54 +135,249 @@ func (uc *userUsecase) DeleteUser(ctx context.Context, token string, id int) (*d
 	return uc.userService.DeleteUser(ctx, id)
 }
 
-func (uc *userUsecase) CreateTicketForUser(ctx context.Context, userID int, token string, packetID *int, eventID *int) (string, error) {
+// CreateTicketForUser checks the per-user limits, prices the purchase and
+// records it as an order, takes a use of its promo code, authorizes the
+// payment, issues the tickets, captures the payment and issues the receipt.
+// A purchase that fails gives the use back, voids the authorization and
+// takes back any tickets it issued.
+func (uc *userUsecase) CreateTicketForUser(ctx context.Context, userID int, token string, request *domain.PurchaseRequest) (*domain.Purchase, error) {
 	identity, err := uc.authNService.WhoIsUser(ctx, token)
 	if err != nil {
-		return "", &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
+		return nil, &domain.ValidationError{Field: "token", Reason: "invalid or expired token"}
 	}
 
 	user, err := uc.userService.GetUserByID(ctx, userID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if user.Email != identity.Email {
-		return "", &domain.ForbiddenError{Reason: "token email does not match user email"}
+		return nil, &domain.ForbiddenError{Reason: "token email does not match user email"}
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	target := request.Target()
+	limited, err := uc.userService.CheckPurchaseLimit(ctx, userID, target, request.Quantity, uc.eventManagerService)
+	if err != nil {
+		return nil, err
+	}
+
+	// seats offered to the waitlist are kept for the users they were offered to
+	if err := uc.waitlistService.CheckPurchase(ctx, userID, target, request.Quantity, uc.eventManagerService); err != nil {
+		return nil, err
+	}
+
+	unitPrice, err := uc.userService.GetTicketPrice(ctx, target, uc.eventManagerService)
+	if err != nil {
+		return nil, err
+	}
+
+	var redemption *domain.PromoRedemption
+	if request.PromoCode != nil {
+		redemption, err = uc.promoCodeService.ReserveRedemption(ctx, *request.PromoCode, userID, target, request.Quantity, unitPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := uc.paymentService.CreateOrder(ctx, userID, request, unitPrice, redemption)
+	if err != nil {
+		uc.abandonPurchase(ctx, nil, redemption, err)
+		return nil, err
 	}
 
-	return uc.userService.CreateTicketForUser(ctx, userID, packetID, eventID, uc.eventManagerService)
+	payment, err := uc.paymentService.AuthorizePayment(ctx, order, request.PaymentToken)
+	if err != nil {
+		uc.abandonPurchase(ctx, order, redemption, err)
+		return nil, err
+	}
+
+	codes, err := uc.userService.CreateTicketForUser(ctx, userID, request.PacketID, request.EventID, request.Quantity, uc.eventManagerService)
+	if err == nil && limited {
+		// a purchase by the same user running alongside may have passed the
+		// check too; counting again with the tickets recorded keeps the
+		// limit, at worst refusing both
+		if _, err = uc.userService.CheckPurchaseLimit(ctx, userID, target, 0, uc.eventManagerService); err != nil {
+			uc.userService.TakeBackTickets(ctx, userID, codes, uc.eventManagerService)
+		}
+	}
+	if err != nil {
+		if payment != nil {
+			_ = uc.paymentService.VoidPayment(ctx, payment, err.Error())
+		}
+		uc.abandonPurchase(ctx, order, redemption, err)
+		return nil, err
+	}
+
+	if payment != nil {
+		captured, err := uc.paymentService.CapturePayment(ctx, payment, codes)
+		var gatewayErr *domain.PaymentGatewayError
+		if errors.As(err, &gatewayErr) {
+			uc.userService.TakeBackTickets(ctx, userID, codes, uc.eventManagerService)
+			_ = uc.paymentService.VoidPayment(ctx, payment, err.Error())
+			uc.abandonPurchase(ctx, order, redemption, err)
+			return nil, err
+		}
+		// otherwise the money was taken and the tickets stand; the
+		// gateway's webhook brings the payment record up to date
+		if err == nil {
+			payment = captured
+		}
+	}
+
+	if redemption != nil {
+		// the tickets are bought either way; the use stays counted
+		_ = uc.promoCodeService.CompleteRedemption(ctx, redemption, codes)
+	}
+	if completed, err := uc.paymentService.CompleteOrder(ctx, order, codes); err == nil {
+		order = completed
+	} else {
+		order.TicketCodes = codes
+	}
+
+	// a receipt that cannot be issued now is issued when it is first asked for
+	var receiptID *string
+	if receipt, err := uc.receiptService.IssueReceipt(ctx, order, uc.eventManagerService); err == nil {
+		receiptID = &receipt.ID
+	}
+
+	// the ticket is bought either way; an offer left open runs out on its own
+	_ = uc.waitlistService.CompletePurchase(ctx, userID, target)
+	return &domain.Purchase{
+		OrderID:     order.ID,
+		TicketCodes: order.TicketCodes,
+		EventID:     order.EventID,
+		PacketID:    order.PacketID,
+		Quantity:    order.Quantity,
+		UnitPrice:   order.UnitPrice,
+		Discount:    order.Discount,
+		Total:       order.Total,
+		PromoCode:   order.PromoCode,
+		Payment:     payment,
+		ReceiptID:   receiptID,
+	}, nil
 }
 
-func (uc *userUsecase) GetCustomersByEventID(ctx context.Context, token string, eventID int) ([]*domain.User, error) {
-	identity, err := uc.authenticate(ctx, token)
+// abandonPurchase gives back the promo code use a failed purchase took and
+// records why its order failed.
+func (uc *userUsecase) abandonPurchase(ctx context.Context, order *domain.Order, redemption *domain.PromoRedemption, cause error) {
+	if redemption != nil {
+		_ = uc.promoCodeService.ReleaseRedemption(ctx, redemption)
+	}
+	if order != nil {
+		_ = uc.paymentService.FailOrder(ctx, order, cause.Error())
+	}
+}
+
+// GetUserTickets is allowed to whoever may view the user.
+func (uc *userUsecase) GetUserTickets(ctx context.Context, token string, userID int, filter *domain.TicketFilter) ([]*domain.OwnedTicket, error) {
+	if _, err := uc.GetUserByID(ctx, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.userService.GetUserTickets(ctx, userID, filter, uc.eventManagerService)
+}
+
+// GetUserETicket is allowed to whoever may view the user, and only for a
+// ticket the user still holds.
+func (uc *userUsecase) GetUserETicket(ctx context.Context, token string, userID int, code string) (*domain.ETicket, error) {
+	if _, err := uc.GetUserByID(ctx, token, userID); err != nil {
+		return nil, err
+	}
+
+	tickets, err := uc.userService.GetETickets(ctx, userID, []string{code}, uc.eventManagerService)
+	if err != nil {
+		return nil, err
+	}
+	if len(tickets) == 0 {
+		return nil, &domain.ResourceNotFoundError{Resource: "ticket", ID: code}
+	}
+	return tickets[0], nil
+}
+
+// GetOrderETickets returns the tickets of a completed order the user still
+// holds; those transferred, resold or refunded since are left out.
+func (uc *userUsecase) GetOrderETickets(ctx context.Context, token string, userID int, orderID string) ([]*domain.ETicket, error) {
+	if _, err := uc.GetUserByID(ctx, token, userID); err != nil {
+		return nil, err
+	}
+
+	order, err := uc.paymentService.GetOrder(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
+	if order.UserID != userID || order.Status != domain.OrderCompleted {
+		return nil, &domain.ResourceNotFoundError{Resource: "order", ID: orderID}
+	}
+
+	tickets, err := uc.userService.GetETickets(ctx, userID, order.TicketCodes, uc.eventManagerService)
+	if err != nil {
+		return nil, err
+	}
+	if len(tickets) == 0 {
+		return nil, &domain.ResourceNotFoundError{Resource: "tickets of order", ID: orderID}
+	}
+	return tickets, nil
+}
+
+func (uc *userUsecase) GetCustomersByEventID(ctx context.Context, token string, eventID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error) {
+	if err := uc.authorizeEventCustomers(ctx, token, eventID); err != nil {
+		return nil, nil, err
+	}
+	return uc.userService.GetCustomersByEventID(ctx, eventID, filter)
+}
+
+func (uc *userUsecase) GetCustomersByPacketID(ctx context.Context, token string, packetID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error) {
+	if err := uc.authorizePacketCustomers(ctx, token, packetID); err != nil {
+		return nil, nil, err
+	}
+	return uc.userService.GetCustomersByPacketID(ctx, packetID, filter)
+}
+
+func (uc *userUsecase) ExportCustomersByEventID(ctx context.Context, token string, eventID int, orderBy *string, fn func(*domain.Customer) error) error {
+	if err := uc.authorizeEventCustomers(ctx, token, eventID); err != nil {
+		return err
+	}
+	return uc.userService.ExportCustomersByEventID(ctx, eventID, orderBy, fn)
+}
+
+func (uc *userUsecase) ExportCustomersByPacketID(ctx context.Context, token string, packetID int, orderBy *string, fn func(*domain.Customer) error) error {
+	if err := uc.authorizePacketCustomers(ctx, token, packetID); err != nil {
+		return err
+	}
+	return uc.userService.ExportCustomersByPacketID(ctx, packetID, orderBy, fn)
+}
+
+func (uc *userUsecase) authorizeEventCustomers(ctx context.Context, token string, eventID int) error {
+	identity, err := uc.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
 
 	allowed, err := uc.authZService.CanUserViewEventCustomers(ctx, identity, eventID)
 	if err != nil {
-		return nil, &domain.ForbiddenError{Reason: fmt.Sprintf("authorization check failed: %v", err)}
+		return &domain.ForbiddenError{Reason: fmt.Sprintf("authorization check failed: %v", err)}
 	}
 	if !allowed {
-		return nil, &domain.ForbiddenError{Reason: "only event owners can view customers"}
+		return &domain.ForbiddenError{Reason: "only event owners can view customers"}
 	}
-
-	return uc.userService.GetCustomersByEventID(ctx, eventID)
+	return nil
 }
 
-func (uc *userUsecase) GetCustomersByPacketID(ctx context.Context, token string, packetID int) ([]*domain.User, error) {
+func (uc *userUsecase) authorizePacketCustomers(ctx context.Context, token string, packetID int) error {
 	identity, err := uc.authenticate(ctx, token)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	allowed, err := uc.authZService.CanUserViewPacketCustomers(ctx, identity, packetID)
 	if err != nil {
-		return nil, &domain.ForbiddenError{Reason: fmt.Sprintf("authorization check failed: %v", err)}
+		return &domain.ForbiddenError{Reason: fmt.Sprintf("authorization check failed: %v", err)}
 	}
 	if !allowed {
-		return nil, &domain.ForbiddenError{Reason: "only packet owners can view customers"}
+		return &domain.ForbiddenError{Reason: "only packet owners can view customers"}
 	}
-
-	return uc.userService.GetCustomersByPacketID(ctx, packetID)
+	return nil
 }
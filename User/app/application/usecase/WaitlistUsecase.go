@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// WaitlistUsecase lets users line up for sold out events and packets. Every
+// call acts for the user in the path, who must be the one the token belongs
+// to.
+type WaitlistUsecase interface {
+	JoinWaitlist(ctx context.Context, token string, userID int, target *domain.WaitlistTarget) (*domain.WaitlistEntry, error)
+	GetEntries(ctx context.Context, token string, userID int, status *string) ([]*domain.WaitlistEntry, error)
+	GetEntry(ctx context.Context, token string, userID int, entryID string) (*domain.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, token string, userID int, entryID string) (*domain.WaitlistEntry, error)
+}
+
+type waitlistUsecase struct {
+	waitlistService     service.WaitlistService
+	userService         service.UserService
+	eventManagerService service.EventManagerService
+	authNService        service.AuthenticationService
+}
+
+func NewWaitlistUsecase(
+	waitlistService service.WaitlistService,
+	userService service.UserService,
+	eventManagerService service.EventManagerService,
+	authNService service.AuthenticationService,
+) WaitlistUsecase {
+	return &waitlistUsecase{
+		waitlistService:     waitlistService,
+		userService:         userService,
+		eventManagerService: eventManagerService,
+		authNService:        authNService,
+	}
+}
+
+// entryOf loads an entry of the user; others' entries are reported as
+// missing.
+func (uc *waitlistUsecase) entryOf(ctx context.Context, userID int, entryID string) (*domain.WaitlistEntry, error) {
+	entry, err := uc.waitlistService.GetEntry(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userID {
+		return nil, &domain.ResourceNotFoundError{Resource: "waitlist entry", ID: entryID}
+	}
+	return entry, nil
+}
+
+func (uc *waitlistUsecase) JoinWaitlist(ctx context.Context, token string, userID int, target *domain.WaitlistTarget) (*domain.WaitlistEntry, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.waitlistService.Join(ctx, userID, target, uc.eventManagerService)
+}
+
+func (uc *waitlistUsecase) GetEntries(ctx context.Context, token string, userID int, status *string) ([]*domain.WaitlistEntry, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.waitlistService.GetUserEntries(ctx, userID, status)
+}
+
+func (uc *waitlistUsecase) GetEntry(ctx context.Context, token string, userID int, entryID string) (*domain.WaitlistEntry, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+	return uc.entryOf(ctx, userID, entryID)
+}
+
+func (uc *waitlistUsecase) LeaveWaitlist(ctx context.Context, token string, userID int, entryID string) (*domain.WaitlistEntry, error) {
+	if err := authorizeUser(ctx, uc.authNService, uc.userService, token, userID); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.entryOf(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.waitlistService.Leave(ctx, entry, uc.eventManagerService)
+}
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/clients/{user_id}/tickets": {
             "post": {
-                "description": "Purchase a ticket for a user by creating a ticket through the EventManager service",
+                "description": "Purchase up to 10 tickets of an event or packet for a user through the EventManager service, optionally with a promo code. A purchase with something to pay is authorized on the card payment_token stands for, and captured once the tickets are issued. The purchase is all or nothing: when a ticket cannot be issued or the payment cannot be captured, the tickets issued are voided and the authorization released.",
                 "consumes": [
                     "application/json"
                 ],
@@ -44,7 +44,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Ticket purchase details (packet_id and event_id)",
+                        "type": "string",
+                        "description": "Key making retries safe; the first response is replayed for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Ticket purchase details (packet_id or event_id, quantity, promo_code, payment_token)",
                         "name": "ticket",
                         "in": "body",
                         "required": true,
@@ -55,7 +61,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "201": {
-                        "description": "Ticket created successfully with ticket code",
+                        "description": "Tickets created successfully with their codes, price, order and payment",
                         "schema": {
                             "$ref": "#/definitions/httpdto.HttpCreateTicketResponse"
                         }
@@ -63,37 +69,55 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body or user ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment declined (code PAYMENT_DECLINED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "User, event, or packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Sold out, or the seats left are offered to the waitlist (code EVENT_SOLD_OUT/PACKET_SOLD_OUT), no capacity configured (code CAPACITY_NOT_CONFIGURED), per-user ticket limit reached (code PURCHASE_LIMIT_REACHED), promo code not applicable (code PROMO_CODE_NOT_APPLICABLE) or used up (code PROMO_CODE_EXHAUSTED), event cancelled (code EVENT_CANCELLED), or a request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reused with a different body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment gateway failed to authorize or capture the payment",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager is failing and calls to it are short-circuited",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -101,7 +125,7 @@ const docTemplate = `{
         },
         "/events/{event_id}/customers": {
             "get": {
-                "description": "Retrieve all customers who have purchased tickets for a specific event (owner only)",
+                "description": "Retrieve the customers who have purchased tickets for a specific event, with how many tickets each bought (owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -126,49 +150,148 @@ const docTemplate = `{
                         "name": "event_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Customers per page (default: 20, max: 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name_asc",
+                            "name_desc",
+                            "email_asc",
+                            "email_desc",
+                            "purchased_at_asc",
+                            "purchased_at_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor taken from the next/prev links",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "List of customers",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseUserList"
+                            "$ref": "#/definitions/httpdto.HttpResponseCustomerList"
                         }
                     },
                     "400": {
                         "description": "Invalid event ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - only event owners can view customers",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{event_id}/customers/export": {
+            "get": {
+                "description": "Download every customer of an event as CSV or XLSX. Private first and last names are masked as in the listing (owner only)",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Export the customers of an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name_asc",
+                            "name_desc",
+                            "email_asc",
+                            "email_desc",
+                            "purchased_at_asc",
+                            "purchased_at_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer export",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID, format or order",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden - only event owners can view customers",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -176,7 +299,7 @@ const docTemplate = `{
         },
         "/packets/{packet_id}/customers": {
             "get": {
-                "description": "Retrieve all customers who have purchased tickets for a specific packet (owner only)",
+                "description": "Retrieve the customers who have purchased tickets for a specific packet, with how many tickets each bought (owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -201,124 +324,212 @@ const docTemplate = `{
                         "name": "packet_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Customers per page (default: 20, max: 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name_asc",
+                            "name_desc",
+                            "email_asc",
+                            "email_desc",
+                            "purchased_at_asc",
+                            "purchased_at_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor taken from the next/prev links",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "List of customers",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseUserList"
+                            "$ref": "#/definitions/httpdto.HttpResponseCustomerList"
                         }
                     },
                     "400": {
                         "description": "Invalid packet ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden - only packet owners can view customers",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/users": {
-            "post": {
-                "description": "Create a new user account with the provided details",
-                "consumes": [
-                    "application/json"
-                ],
+        "/packets/{packet_id}/customers/export": {
+            "get": {
+                "description": "Download every customer of a packet as CSV or XLSX. Private first and last names are masked as in the listing (owner only)",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "users"
+                    "customers"
                 ],
-                "summary": "Create a new user",
+                "summary": "Export the customers of a packet",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer token (optional for user creation)",
+                        "description": "Bearer token",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "User details",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Packet ID",
+                        "name": "packet_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name_asc",
+                            "name_desc",
+                            "email_asc",
+                            "email_desc",
+                            "purchased_at_asc",
+                            "purchased_at_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer export",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpCreateUser"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid packet ID, format or order",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - only packet owners can view customers",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Called by the payment gateway when a payment changes on its side: payment.captured, payment.voided or payment.refunded. The body is signed with the shared webhook secret in X-Payment-Signature. Events already applied, or of a kind not tracked, are acknowledged without changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Receive a payment gateway webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sha256=\u003chex HMAC-SHA256 of the body\u003e",
+                        "name": "X-Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
+                    "200": {
+                        "description": "Payment after the event",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                            "$ref": "#/definitions/httpdto.HttpResponsePaymentWebhook"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Body is not a payment event",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "409": {
-                        "description": "User already exists",
+                    "401": {
+                        "description": "Missing or invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "No payment with this reference",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/promo-codes": {
             "get": {
-                "description": "Retrieve a specific user by their unique identifier",
+                "description": "List the promo codes of an owner, newest first. Owners see their own; the client service names the owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -326,9 +537,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "promo-codes"
                 ],
-                "summary": "Get user by ID",
+                "summary": "List promo codes",
                 "parameters": [
                     {
                         "type": "string",
@@ -339,68 +550,46 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Owner whose codes to list; defaults to the caller",
+                        "name": "owner_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details",
+                        "description": "Promo codes",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                            "$ref": "#/definitions/httpdto.HttpResponsePromoCodeList"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid filter",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - insufficient permissions",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Forbidden - not the owner or the client service",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a user account by its ID",
+            "post": {
+                "description": "Create a discount code for some events and packets of one owner. Percent discounts take discount_value percent off the order; fixed ones take discount_value bani off it. Codes are case insensitive and unique across owners.",
                 "consumes": [
                     "application/json"
                 ],
@@ -408,9 +597,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "promo-codes"
                 ],
-                "summary": "Delete a user",
+                "summary": "Create a promo code",
                 "parameters": [
                     {
                         "type": "string",
@@ -420,69 +609,70 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Promo code",
+                        "name": "promo_code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreatePromoCode"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User deleted successfully",
+                    "201": {
+                        "description": "Promo code created",
                         "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                            "$ref": "#/definitions/httpdto.HttpResponsePromoCode"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid request body, or events and packets of different owners",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - cannot delete other users",
+                        "description": "Forbidden - not the owner or the client service",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Event or packet not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager is failing and calls to it are short-circuited",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Partially update user details (PATCH - only provided fields are updated)",
+            }
+        },
+        "/promo-codes/{promo_id}": {
+            "get": {
+                "description": "Get a promo code with the number of purchases it was used for",
                 "consumes": [
                     "application/json"
                 ],
@@ -490,9 +680,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "promo-codes"
                 ],
-                "summary": "Update an existing user",
+                "summary": "Get a promo code",
                 "parameters": [
                     {
                         "type": "string",
@@ -502,170 +692,4576 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "promo_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpdto.HttpUpdateUser"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/httpdto.HttpResponseUser"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body or user ID",
+                        "description": "Promo code",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/httpdto.HttpResponsePromoCode"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - missing or invalid token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - cannot update other users",
+                        "description": "Forbidden - not the owner or the client service",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Promo code not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            }
-        }
-    },
+            },
+            "delete": {
+                "description": "Delete a promo code that was never redeemed. Redeemed codes keep their usage report and are deactivated instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promo-codes"
+                ],
+                "summary": "Delete a promo code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "promo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promo code deleted",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponsePromoCode"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Promo code not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo code already redeemed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the discount, limits, validity window or whether the code is active. The code, its events and packets and its kind of discount cannot change. Lowering a limit below the uses so far stops further uses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promo-codes"
+                ],
+                "summary": "Change a promo code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "promo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "promo_code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpUpdatePromoCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promo code changed",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponsePromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Promo code not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/promo-codes/{promo_id}/usage": {
+            "get": {
+                "description": "Sum up the completed purchases made with a promo code: uses, tickets and discount given in bani, overall and per user, most recent users first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promo-codes"
+                ],
+                "summary": "Get the usage of a promo code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "promo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usage report",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponsePromoCodeUsage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Promo code not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds": {
+            "get": {
+                "description": "List the refunds asked for tickets of an event or a packet, newest first. Only its owner and the client service can see them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "List the refunds of an event or packet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID; exactly one of event_id and packet_id is required",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Packet ID",
+                        "name": "packet_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "requested",
+                            "approving",
+                            "approved",
+                            "denied"
+                        ],
+                        "type": "string",
+                        "description": "Only refunds in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunds",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefundList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Event or packet not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds/{refund_id}": {
+            "get": {
+                "description": "Get a refund asked for a ticket of an event or packet. Only its owner and the client service can see it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Get a refund to decide",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "refund_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefund"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds/{refund_id}/approve": {
+            "post": {
+                "description": "Approve a refund for amount, in bani, or the face value of the ticket when left out. The ticket is voided in EventManager, which releases its seat, and removed from the holder. Approving again finishes an approval that failed half way.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Approve a refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "refund_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount and note; send {} to refund the face value",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpApproveRefund"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund approved",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefund"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or amount above the face value",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund already decided",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager is failing and calls to it are short-circuited",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds/{refund_id}/deny": {
+            "post": {
+                "description": "Turn a refund down; the ticket stays with its holder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Deny a refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "refund_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note; may be {}",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpDenyRefund"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund denied",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefund"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the owner or the client service",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund already decided",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/resale/listings": {
+            "get": {
+                "description": "List the tickets on sale for an event or a packet, cheapest first. Ticket codes are never shown.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Browse resold tickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID; exactly one of event_id and packet_id is required",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Packet ID",
+                        "name": "packet_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tickets on sale",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseResaleListingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/resale/listings/{listing_id}": {
+            "get": {
+                "description": "Get a listing of the resale marketplace. Ticket codes are never shown.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Get a resale listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Listing",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseResaleListing"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Create a new user account with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token (optional for user creation)",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Retrieve a specific user by their unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a user account by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - cannot delete other users",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update user details (PATCH - only provided fields are updated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update an existing user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpUpdateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - cannot update other users",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/calendar": {
+            "get": {
+                "description": "Get the URL of the user's calendar feed, which calendar apps can subscribe to without a bearer token. The feed is created on first use.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the calendar feed of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/calendar.ics": {
+            "get": {
+                "description": "Get an iCalendar (RFC 5545) feed with a VEVENT for every scheduled event the user holds tickets for, directly or through a packet. Cancelled events are kept with STATUS:CANCELLED. Authorized by the feed token instead of a bearer token.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the calendar of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token from GET /users/{id}/calendar",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "No calendar with this token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/calendar/token": {
+            "post": {
+                "description": "Give the user's calendar feed a new URL; subscriptions to the previous URL stop receiving updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Rotate the calendar feed token of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed with its new URL",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseCalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/orders": {
+            "get": {
+                "description": "List the purchases of the user, newest first, failed ones included",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "List the orders of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseOrderList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/orders/{order_id}": {
+            "get": {
+                "description": "Get a purchase of the user with its payment, left out when there was nothing to pay",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get an order of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order and payment",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or order not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/orders/{order_id}/receipt": {
+            "get": {
+                "description": "Get the receipt of a completed order, as JSON or PDF. A receipt the purchase could not issue is issued now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get the receipt of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Document format (default: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseReceipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or order not found, or the order was not completed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown format",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/orders/{order_id}/tickets/pdf": {
+            "get": {
+                "description": "Render the e-tickets of a completed order, one per page. Tickets the user no longer holds are left out.",
+                "produces": [
+                    "application/pdf",
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Download the tickets of an order as a PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "E-tickets of the order",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not allowed to view this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or completed order not found, or none of its tickets is still held",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/receipts": {
+            "get": {
+                "description": "List the receipts of the user's purchases, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "List the receipts of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipts of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseReceiptList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/receipts/{receipt_id}": {
+            "get": {
+                "description": "Get a numbered receipt with the buyer, the event or packet, the price lines and the VAT included, as JSON or PDF",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get a receipt of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receipt ID",
+                        "name": "receipt_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Document format (default: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseReceipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or receipt not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown format",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/refunds": {
+            "get": {
+                "description": "List the refunds the user asked for, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "List the refunds of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "requested",
+                            "approving",
+                            "approved",
+                            "denied"
+                        ],
+                        "type": "string",
+                        "description": "Only refunds in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunds of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefundList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ask the owner of the event or packet to take one of the user's tickets back. The owner must refund tickets and the request must come before their refund window closes. The ticket stays with the holder, and cannot be transferred or resold, until the refund is decided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Ask for a ticket to be refunded",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the holder",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ticket and reason",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateRefund"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Refund requested",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefund"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User, ticket, event or packet not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already in an open refund, transfer or listing, or refunds not allowed (code REFUND_NOT_ALLOWED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/refunds/{refund_id}": {
+            "get": {
+                "description": "Get a refund the user asked for",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Get a refund of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "refund_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseRefund"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/resale-listings": {
+            "get": {
+                "description": "List the tickets the user put up for resale, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "List the resale listings of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the seller",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "selling",
+                            "sold",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Only listings in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Listings of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseResaleListingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "List one of the user's tickets on the resale marketplace for price, in bani. The event owner must allow resale and the price must stay within their markup cap over the face value. The ticket stays with the seller until someone buys it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Put a ticket up for resale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the seller",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ticket and price",
+                        "name": "listing",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateResaleListing"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Ticket listed",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseResaleListing"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already listed or in an open transfer, or resale not allowed by the event owner (code RESALE_NOT_ALLOWED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Price above the owner's markup cap (code RESALE_PRICE_ABOVE_CAP)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/resale-listings/{listing_id}/cancel": {
+            "post": {
+                "description": "Withdraw a listing nobody is buying",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Take a ticket off resale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the seller",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Listing cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseResaleListing"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the seller of this listing",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Listing no longer active",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/resale-purchases": {
+            "post": {
+                "description": "Buy a ticket on the resale marketplace. The listing price is recorded as an order and authorized on the card payment_token stands for. EventManager then reissues the ticket under a new code, so the code the seller knew stops working, the ticket moves to the buyer and the payment is captured. A purchase that fails releases the authorization; buying again finishes a purchase that failed half way.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Buy a resold ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the buyer",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Listing to buy",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateResalePurchase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket bought; ticket_code is the buyer's new code",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseResaleListing"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or user ID, or the buyer's own listing",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment declined (code PAYMENT_DECLINED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User, listing or ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Listing no longer on sale or resale not allowed by the event owner (code RESALE_NOT_ALLOWED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Price above the owner's markup cap (code RESALE_PRICE_ABOVE_CAP)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment gateway failed to authorize or capture the payment",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager is failing and calls to it are short-circuited",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/ticket-audit": {
+            "get": {
+                "description": "List what happened to the tickets the user held or was offered, newest first: transfers requested, accepted, declined and cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get the ticket history of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket history",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketAudit"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tickets": {
+            "get": {
+                "description": "List the tickets a user owns with the name, location and schedule of the event or packet each was bought for",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the tickets of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "upcoming",
+                            "past"
+                        ],
+                        "type": "string",
+                        "description": "Only tickets for what has not ended yet (upcoming) or is over (past)",
+                        "name": "when",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tickets of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseUserTicketList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not allowed to view this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tickets/{code}/pdf": {
+            "get": {
+                "description": "Render an e-ticket with the event or packet name, place and schedule, the holder name without the names the holder keeps private, the ticket code and a QR code of it",
+                "produces": [
+                    "application/pdf",
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Download a ticket as a PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "E-ticket",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not allowed to view this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found, or the user does not hold the ticket",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/transfers": {
+            "get": {
+                "description": "List the transfers the user sent or received, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "List ticket transfers of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "outgoing",
+                            "incoming"
+                        ],
+                        "type": "string",
+                        "description": "Only transfers sent (outgoing) or received (incoming)",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "accepting",
+                            "accepted",
+                            "declined",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Only transfers in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfers of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketTransferList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start handing one of the user's tickets to the user registered with recipient_email. The ticket stays with the holder until the recipient accepts; the offer expires after 7 days.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Offer a ticket to another user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the holder",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ticket and recipient",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpCreateTicketTransfer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transfer offered",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already in an open transfer or transfers blocked by the event owner (code TRANSFERS_BLOCKED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "No user registered with the recipient email",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/transfers/{transfer_id}": {
+            "get": {
+                "description": "Get a transfer the user sent or received",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/transfers/{transfer_id}/accept": {
+            "post": {
+                "description": "Take over the offered ticket. Its code is rotated in EventManager, so the code the previous holder knew stops working, and the ticket moves to the recipient. Accepting again finishes an acceptance that failed half way.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Accept a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the recipient",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer accepted",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the recipient of this transfer",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User, transfer or ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer no longer pending or transfers blocked by the event owner (code TRANSFERS_BLOCKED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager is failing and calls to it are short-circuited",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/transfers/{transfer_id}/cancel": {
+            "post": {
+                "description": "Withdraw a transfer the recipient has not answered yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Cancel a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the holder",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the holder of this transfer",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/transfers/{transfer_id}/decline": {
+            "post": {
+                "description": "Turn down an offered ticket; it stays with its holder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Decline a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the recipient",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer declined",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseTicketTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the recipient of this transfer",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/waitlist": {
+            "get": {
+                "description": "List the events and packets the user waits for or was offered a seat of, newest first. Waiting entries carry their position in line.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "List the waitlist entries of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "waiting",
+                            "offered",
+                            "accepted",
+                            "expired",
+                            "left"
+                        ],
+                        "type": "string",
+                        "description": "Only entries in this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Waitlist entries of the user",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWaitlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or filter",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Line up for a sold out event or packet. When seats free up, through refunds, cancellations or a capacity increase, users are offered one in the order they joined and have an hour to buy it before it goes to the next one. When seats are free already, the offer comes right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "Join the waitlist of an event or packet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event or packet; exactly one of event_id and packet_id",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpJoinWaitlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Joined the waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User, event or packet not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already on this waitlist, or no capacity configured (code CAPACITY_NOT_CONFIGURED)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "EventManager is failing and calls to it are short-circuited",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/waitlist/{entry_id}": {
+            "get": {
+                "description": "Get an entry of the user, with their position in line while waiting and the offer expiry once offered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "Get a waitlist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Waitlist entry",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or waitlist entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/waitlist/{entry_id}/leave": {
+            "post": {
+                "description": "Step out of line, or give back an offered seat so it goes to the next user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "Leave a waitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Left the waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/httpdto.HttpResponseWaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - token does not belong to this user",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or waitlist entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Entry no longer waiting or offered",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
         "http.Link": {
             "type": "object",
             "properties": {
-                "href": {
+                "href": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "rel": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpApproveRefund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "httpdto.HttpCreatePromoCode": {
+            "type": "object",
+            "required": [
+                "code",
+                "discount_type",
+                "discount_value"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 3
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ]
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "event_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "packet_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpCreateRefund": {
+            "type": "object",
+            "required": [
+                "ticket_code"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "ticket_code": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "httpdto.HttpCreateResaleListing": {
+            "type": "object",
+            "required": [
+                "price",
+                "ticket_code"
+            ],
+            "properties": {
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "ticket_code": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "httpdto.HttpCreateResalePurchase": {
+            "type": "object",
+            "required": [
+                "listing_id"
+            ],
+            "properties": {
+                "listing_id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "payment_token": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "httpdto.HttpCreateTicketForUser": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "packet_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "payment_token": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "promo_code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                }
+            }
+        },
+        "httpdto.HttpCreateTicketResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment": {
+                    "$ref": "#/definitions/httpdto.HttpPayment"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "string"
+                },
+                "ticket_code": {
+                    "type": "string"
+                },
+                "ticket_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.HttpCreateTicketTransfer": {
+            "type": "object",
+            "required": [
+                "recipient_email",
+                "ticket_code"
+            ],
+            "properties": {
+                "recipient_email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "ticket_code": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "httpdto.HttpCreateUser": {
+            "type": "object",
+            "required": [
+                "email",
+                "id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "social_media_links": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "httpdto.HttpDenyRefund": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "httpdto.HttpJoinWaitlist": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "packet_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "httpdto.HttpPayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "captured_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "refunded": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpResponseCalendarFeed": {
+            "type": "object",
+            "properties": {
+                "calendar": {
+                    "$ref": "#/definitions/httpdto.httpResponseCalendarFeed"
+                }
+            }
+        },
+        "httpdto.HttpResponseCustomerList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "_metadata": {
+                    "$ref": "#/definitions/httpdto.PaginationMetadata"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseCustomer"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseOrder": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/httpdto.httpResponseOrder"
+                },
+                "payment": {
+                    "$ref": "#/definitions/httpdto.HttpPayment"
+                }
+            }
+        },
+        "httpdto.HttpResponseOrderList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseOrder"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponsePaymentWebhook": {
+            "type": "object",
+            "properties": {
+                "payment": {
+                    "$ref": "#/definitions/httpdto.HttpPayment"
+                }
+            }
+        },
+        "httpdto.HttpResponsePromoCode": {
+            "type": "object",
+            "properties": {
+                "promo_code": {
+                    "$ref": "#/definitions/httpdto.httpResponsePromoCode"
+                }
+            }
+        },
+        "httpdto.HttpResponsePromoCodeList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "promo_codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponsePromoCode"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponsePromoCodeUsage": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "promo_code": {
+                    "$ref": "#/definitions/httpdto.httpResponsePromoCode"
+                },
+                "redemptions": {
+                    "type": "integer"
+                },
+                "tickets": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponsePromoUserUsage"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseReceipt": {
+            "type": "object",
+            "properties": {
+                "receipt": {
+                    "$ref": "#/definitions/httpdto.httpResponseReceipt"
+                }
+            }
+        },
+        "httpdto.HttpResponseReceiptList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseReceipt"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseRefund": {
+            "type": "object",
+            "properties": {
+                "refund": {
+                    "$ref": "#/definitions/httpdto.httpResponseRefund"
+                }
+            }
+        },
+        "httpdto.HttpResponseRefundList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseRefund"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseResaleListing": {
+            "type": "object",
+            "properties": {
+                "listing": {
+                    "$ref": "#/definitions/httpdto.httpResponseResaleListing"
+                }
+            }
+        },
+        "httpdto.HttpResponseResaleListingList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "listings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseResaleListing"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseTicketAudit": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpTicketAuditEntry"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseTicketTransfer": {
+            "type": "object",
+            "properties": {
+                "transfer": {
+                    "$ref": "#/definitions/httpdto.httpResponseTicketTransfer"
+                }
+            }
+        },
+        "httpdto.HttpResponseTicketTransferList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseTicketTransfer"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseUser": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/httpdto.httpResponseUser"
+                }
+            }
+        },
+        "httpdto.HttpResponseUserTicketList": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseOwnedTicket"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseWaitlist": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpResponseWaitlistEntry"
+                    }
+                }
+            }
+        },
+        "httpdto.HttpResponseWaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "$ref": "#/definitions/httpdto.httpResponseWaitlistEntry"
+                }
+            }
+        },
+        "httpdto.HttpTicket": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "purchased_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpUpdatePromoCode": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "max_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.HttpUpdateUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "first_name_private": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "last_name_private": {
+                    "type": "boolean"
+                },
+                "social_media_links": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "httpdto.PaginationMetadata": {
+            "type": "object",
+            "properties": {
+                "per_page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpReceiptBuyer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpReceiptItem": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpReceiptLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpResponseCalendarFeed": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpResponseCustomer": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "first_name_private": {
+                    "type": "boolean"
+                },
+                "first_purchased_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "last_name_private": {
+                    "type": "boolean"
+                },
+                "last_purchased_at": {
+                    "type": "string"
+                },
+                "social_media_links": {
+                    "type": "string"
+                },
+                "ticket_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpResponseOrder": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "ticket_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpResponseOwnedTicket": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/httpdto.httpTicketEvent"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "packet": {
+                    "$ref": "#/definitions/httpdto.httpTicketEvent"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "purchased_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpResponsePromoCode": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "discount_value": {
+                    "type": "integer"
+                },
+                "event_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_per_user": {
+                    "type": "integer"
+                },
+                "max_redemptions": {
+                    "type": "integer"
+                },
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "packet_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "redemptions": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpdto.httpResponsePromoUserUsage": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "redemptions": {
+                    "type": "integer"
+                },
+                "tickets": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpResponseReceipt": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "buyer": {
+                    "$ref": "#/definitions/httpdto.httpReceiptBuyer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "item": {
+                    "$ref": "#/definitions/httpdto.httpReceiptItem"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.httpReceiptLine"
+                    }
+                },
+                "number": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "organizer_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "tax_amount": {
+                    "type": "integer"
+                },
+                "tax_rate": {
+                    "type": "integer"
+                },
+                "ticket_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpdto.httpResponseRefund": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "decided_at": {
                     "type": "string"
                 },
-                "method": {
+                "decided_by": {
+                    "type": "integer"
+                },
+                "decision_note": {
                     "type": "string"
                 },
-                "rel": {
+                "event_id": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "title": {
+                "packet_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_at": {
                     "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "ticket_code": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "httpdto.HttpCreateTicketForUser": {
+        "httpdto.httpResponseResaleListing": {
             "type": "object",
             "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "buyer_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "event_id": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "packet_id": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "sold_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "ticket_code": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.HttpCreateTicketResponse": {
+        "httpdto.httpResponseTicketTransfer": {
             "type": "object",
             "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "from_user_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "recipient_email": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "ticket_code": {
                     "type": "string"
+                },
+                "to_user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "httpdto.HttpCreateUser": {
+        "httpdto.httpResponseUser": {
             "type": "object",
-            "required": [
-                "email",
-                "id"
-            ],
             "properties": {
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
+                    }
+                },
                 "email": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "type": "string"
                 },
                 "first_name": {
-                    "type": "string",
-                    "maxLength": 100
+                    "type": "string"
+                },
+                "first_name_private": {
+                    "type": "boolean"
                 },
                 "id": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
                 },
                 "last_name": {
-                    "type": "string",
-                    "maxLength": 100
+                    "type": "string"
+                },
+                "last_name_private": {
+                    "type": "boolean"
                 },
                 "social_media_links": {
-                    "type": "string",
-                    "maxLength": 500
-                }
-            }
-        },
-        "httpdto.HttpResponseUser": {
-            "type": "object",
-            "properties": {
-                "user": {
-                    "$ref": "#/definitions/httpdto.httpResponseUser"
+                    "type": "string"
+                },
+                "ticket_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpdto.HttpTicket"
+                    }
                 }
             }
         },
-        "httpdto.HttpResponseUserList": {
+        "httpdto.httpResponseWaitlistEntry": {
             "type": "object",
             "properties": {
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/httpdto.httpResponseUser"
+                "_links": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.Link"
                     }
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "offer_expires_at": {
+                    "type": "string"
+                },
+                "offered_at": {
+                    "type": "string"
+                },
+                "packet_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.HttpTicket": {
+        "httpdto.httpTicketAuditEntry": {
             "type": "object",
             "properties": {
-                "code": {
+                "action": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "counterparty_id": {
+                    "type": "integer"
+                },
+                "direction": {
                     "type": "string"
                 },
                 "event_id": {
@@ -673,74 +5269,113 @@ const docTemplate = `{
                 },
                 "packet_id": {
                     "type": "integer"
+                },
+                "previous_code": {
+                    "type": "string"
+                },
+                "ticket_code": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.HttpUpdateUser": {
+        "httpdto.httpTicketEvent": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                "city": {
+                    "type": "string"
                 },
-                "first_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "country": {
+                    "type": "string"
                 },
-                "first_name_private": {
-                    "type": "boolean"
+                "ends_at": {
+                    "type": "string"
                 },
-                "last_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "id": {
+                    "type": "integer"
                 },
-                "last_name_private": {
-                    "type": "boolean"
+                "location": {
+                    "type": "string"
                 },
-                "social_media_links": {
-                    "type": "string",
-                    "maxLength": 500
+                "name": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
                 }
             }
         },
-        "httpdto.httpResponseUser": {
+        "problem.FieldError": {
             "type": "object",
             "properties": {
-                "_links": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/http.Link"
+                "field": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "problem.Problem": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "$ref": "#/definitions/problem.SeatAvailability"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/problem.FieldError"
                     }
                 },
-                "email": {
+                "instance": {
                     "type": "string"
                 },
-                "first_name": {
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 },
-                "first_name_private": {
-                    "type": "boolean"
+                "trace_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "problem.SeatAvailability": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "last_name": {
-                    "type": "string"
+                "remaining": {
+                    "type": "integer"
                 },
-                "last_name_private": {
-                    "type": "boolean"
+                "reserved": {
+                    "type": "integer"
                 },
-                "social_media_links": {
+                "resource": {
                     "type": "string"
                 },
-                "ticket_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/httpdto.HttpTicket"
-                    }
+                "sold": {
+                    "type": "integer"
                 }
             }
         }
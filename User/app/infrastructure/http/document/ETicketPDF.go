@@ -0,0 +1,109 @@
+package document
+
+import (
+	"fmt"
+	"io"
+	"userService/application/domain"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// qrWidth is the side of the printed QR code, including the blank margin of
+// four modules scanners need, which the encoder adds. qrSpacing keeps the
+// rest of the page clear of it.
+const (
+	qrWidth   = 170.0
+	qrSpacing = 30.0
+)
+
+// WriteETicketPDF renders each ticket on its own A4 page, with a QR code of
+// the ticket code for the entrance to scan.
+func WriteETicketPDF(w io.Writer, title string, tickets []*domain.ETicket) error {
+	doc := newPDFDocument(title)
+	for _, ticket := range tickets {
+		if err := writeETicketPage(doc, ticket); err != nil {
+			return err
+		}
+	}
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func writeETicketPage(doc *pdfDocument, ticket *domain.ETicket) error {
+	// level M recovers a damaged or dirty print of up to 15% of the code
+	code, err := qrcode.New(ticket.Code, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	kind, name, place, when := describeETicket(ticket)
+	doc.addPage()
+
+	y := 70.0
+	doc.text(marginLeft, y, 20, fontBold, "E-Ticket")
+	doc.textRight(marginRight, y, 9, fontBold, kind)
+	y += 14
+	doc.line(marginLeft, y, marginRight, y, 0.8)
+
+	y += 34
+	doc.text(marginLeft, y, 22, fontBold, doc.fitText(name, 22, fontBold, marginRight-marginLeft))
+	if place != "" {
+		y += 20
+		doc.text(marginLeft, y, 12, fontRegular, doc.fitText(place, 12, fontRegular, marginRight-marginLeft))
+	}
+	if when != "" {
+		y += 17
+		doc.text(marginLeft, y, 12, fontRegular, when)
+	}
+
+	y += 36
+	top := y
+	columnWidth := marginRight - qrWidth - qrSpacing - marginLeft
+	type field struct {
+		label string
+		value string
+		size  float64
+	}
+	fields := []field{
+		{"HOLDER", ticket.HolderName, 14},
+		{"TICKET CODE", ticket.Code, 11},
+	}
+	if !ticket.PurchasedAt.IsZero() {
+		fields = append(fields, field{"PURCHASED", formatDateTime(ticket.PurchasedAt), 11})
+	}
+	for _, field := range fields {
+		doc.text(marginLeft, y, 9, fontBold, field.label)
+		y += field.size + 4
+		doc.text(marginLeft, y, field.size, fontRegular, doc.fitText(field.value, field.size, fontRegular, columnWidth))
+		y += 28
+	}
+	doc.qrCode(marginRight-qrWidth, top, qrWidth, code.Bitmap())
+
+	y = max(y, top+qrWidth) + qrSpacing
+	doc.line(marginLeft, y, marginRight, y, 0.5)
+	y += 18
+	doc.text(marginLeft, y, 9, fontRegular, "Show this ticket at the entrance, printed or on screen. Each ticket code lets one person in, once.")
+	y += 13
+	doc.text(marginLeft, y, 9, fontRegular, "A ticket that was transferred, resold or refunded is no longer valid under this code.")
+	return nil
+}
+
+// describeETicket names what the ticket was bought for and where and when
+// it takes place, as far as EventManager still knows it.
+func describeETicket(ticket *domain.ETicket) (kind string, name string, place string, when string) {
+	switch {
+	case ticket.Event != nil:
+		return "EVENT", ticket.Event.Name,
+			formatPlace(ticket.Event.Location, ticket.Event.City, ticket.Event.Country),
+			formatSchedule(ticket.Event.StartsAt, ticket.Event.EndsAt)
+	case ticket.Packet != nil:
+		return "PACKET", ticket.Packet.Name,
+			formatPlace(ticket.Packet.Location, ticket.Packet.City, ticket.Packet.Country),
+			formatSchedule(ticket.Packet.StartsAt, ticket.Packet.EndsAt)
+	case ticket.EventID != nil:
+		return "EVENT", fmt.Sprintf("Event #%d", *ticket.EventID), "", ""
+	case ticket.PacketID != nil:
+		return "PACKET", fmt.Sprintf("Packet #%d", *ticket.PacketID), "", ""
+	}
+	return "", "Ticket", "", ""
+}
@@ -0,0 +1,90 @@
+package document
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"userService/application/domain"
+
+	"shared/ical"
+)
+
+// calendarRefreshInterval is how often calendar apps are asked to poll the
+// feed, which is how cancellations and schedule changes reach them.
+const calendarRefreshInterval = "PT1H"
+
+// WriteCalendar writes a calendar feed with a VEVENT for every entry.
+// Cancelled events stay in the feed with STATUS:CANCELLED and a higher
+// SEQUENCE, so subscribed calendars mark them instead of leaving them be.
+// eventManagerURL is the base URL the events are linked to.
+func WriteCalendar(w io.Writer, name string, entries []*domain.CalendarEntry, eventManagerURL string, now time.Time) error {
+	var out ical.Writer
+	out.Line("BEGIN", "VCALENDAR")
+	out.Line("VERSION", "2.0")
+	out.Line("PRODID", "-//POS//UserService//EN")
+	out.Line("CALSCALE", "GREGORIAN")
+	out.Line("METHOD", "PUBLISH")
+	out.Line("X-WR-CALNAME", ical.EscapeText(name))
+	out.Line("REFRESH-INTERVAL;VALUE=DURATION", calendarRefreshInterval)
+	out.Line("X-PUBLISHED-TTL", calendarRefreshInterval)
+
+	for _, entry := range entries {
+		event := entry.Event
+		if event.StartsAt == nil {
+			continue
+		}
+
+		out.Line("BEGIN", "VEVENT")
+		out.Line("UID", ical.EventUID(event.ID))
+		out.Line("DTSTAMP", ical.FormatTime(now))
+		out.Line("DTSTART", ical.FormatTime(*event.StartsAt))
+		if event.EndsAt != nil {
+			out.Line("DTEND", ical.FormatTime(*event.EndsAt))
+		}
+		out.Line("SUMMARY", ical.EscapeText(event.Name))
+		if location := calendarLocation(event); location != "" {
+			out.Line("LOCATION", ical.EscapeText(location))
+		}
+		out.Line("DESCRIPTION", ical.EscapeText(describeCalendarEntry(entry)))
+		out.Line("URL", fmt.Sprintf("%s/events/%d", eventManagerURL, event.ID))
+		if event.CancelledAt != nil {
+			out.Line("STATUS", "CANCELLED")
+		} else {
+			out.Line("STATUS", "CONFIRMED")
+		}
+		out.Line("SEQUENCE", fmt.Sprint(event.Sequence))
+		out.Line("END", "VEVENT")
+	}
+
+	out.Line("END", "VCALENDAR")
+
+	_, err := out.WriteTo(w)
+	return err
+}
+
+func calendarLocation(event *domain.EventSummary) string {
+	var parts []string
+	for _, part := range []*string{event.Location, event.Address, event.City, event.Country} {
+		if part != nil && strings.TrimSpace(*part) != "" {
+			parts = append(parts, strings.TrimSpace(*part))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeCalendarEntry(entry *domain.CalendarEntry) string {
+	var b strings.Builder
+	if entry.TicketCount == 1 {
+		b.WriteString("1 ticket")
+	} else {
+		fmt.Fprintf(&b, "%d tickets", entry.TicketCount)
+	}
+	if len(entry.Packets) > 0 {
+		fmt.Fprintf(&b, ", through packet %s", strings.Join(entry.Packets, ", "))
+	}
+	if entry.Event.CancelledAt != nil {
+		fmt.Fprintf(&b, "\nCancelled on %s", formatDateTime(*entry.Event.CancelledAt))
+	}
+	return b.String()
+}
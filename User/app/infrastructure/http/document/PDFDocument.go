@@ -0,0 +1,137 @@
+package document
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	FormatJSON = "json"
+	FormatPDF  = "pdf"
+
+	ContentTypePDF = "application/pdf"
+)
+
+// A4 in points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// pdfFont is the gofpdf style of the Helvetica face used.
+type pdfFont string
+
+const (
+	fontRegular pdfFont = ""
+	fontBold    pdfFont = "B"
+)
+
+// winAnsiFallbacks spells the letters WinAnsiEncoding lacks, Romanian ones
+// among them, without their diacritics.
+var winAnsiFallbacks = strings.NewReplacer(
+	"ă", "a", "Ă", "A", "ș", "s", "Ș", "S", "ş", "s", "Ş", "S", "ț", "t", "Ț", "T", "ţ", "t", "Ţ", "T",
+	"–", "-", "—", "-", "‘", "'", "’", "'", "“", `"`, "”", `"`,
+	"\r\n", " ", "\n", " ", "\r", " ", "\t", " ",
+)
+
+// pdfDocument lays out text, lines and boxes on A4 pages through gofpdf,
+// with the standard Helvetica fonts so no font has to be embedded.
+// Positions are in points from the top left corner of the page; text is
+// placed by its baseline.
+type pdfDocument struct {
+	pdf       *gofpdf.Fpdf
+	translate func(string) string
+}
+
+func newPDFDocument(title string) *pdfDocument {
+	pdf := gofpdf.New("P", "pt", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle(title, true)
+	pdf.SetProducer("userService", false)
+	pdf.SetFillColor(0, 0, 0)
+	return &pdfDocument{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor("cp1252"),
+	}
+}
+
+func (d *pdfDocument) addPage() {
+	d.pdf.AddPage()
+}
+
+// encode turns s into the WinAnsi bytes the standard fonts are set in.
+func (d *pdfDocument) encode(s string) string {
+	return d.translate(winAnsiFallbacks.Replace(s))
+}
+
+func (d *pdfDocument) text(x, y, size float64, font pdfFont, s string) {
+	d.pdf.SetFont("Helvetica", string(font), size)
+	d.pdf.Text(x, y, d.encode(s))
+}
+
+// textRight places s so that it ends at x.
+func (d *pdfDocument) textRight(x, y, size float64, font pdfFont, s string) {
+	d.text(x-d.textWidth(s, size, font), y, size, font, s)
+}
+
+func (d *pdfDocument) line(x1, y1, x2, y2, width float64) {
+	d.pdf.SetLineWidth(width)
+	d.pdf.Line(x1, y1, x2, y2)
+}
+
+// fillRect fills the box whose top left corner is at x, y.
+func (d *pdfDocument) fillRect(x, y, w, h float64) {
+	d.pdf.Rect(x, y, w, h, "F")
+}
+
+// qrCode draws the modules of a QR code as a square width points wide whose
+// top left corner is at x, y, filling each run of dark modules in a row at
+// once.
+func (d *pdfDocument) qrCode(x, y, width float64, modules [][]bool) {
+	size := len(modules)
+	module := width / float64(size)
+	for row := 0; row < size; row++ {
+		for col := 0; col < size; {
+			if !modules[row][col] {
+				col++
+				continue
+			}
+			start := col
+			for col < size && modules[row][col] {
+				col++
+			}
+			d.fillRect(x+float64(start)*module, y+float64(row)*module, float64(col-start)*module, module)
+		}
+	}
+}
+
+// textWidth is the width of s in points at size.
+func (d *pdfDocument) textWidth(s string, size float64, font pdfFont) float64 {
+	d.pdf.SetFont("Helvetica", string(font), size)
+	return d.pdf.GetStringWidth(d.encode(s))
+}
+
+// fitText shortens s with an ellipsis until it is at most width points wide.
+func (d *pdfDocument) fitText(s string, size float64, font pdfFont, width float64) string {
+	if d.textWidth(s, size, font) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && d.textWidth(string(runes)+"...", size, font) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "..."
+}
+
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	if err := d.pdf.Output(&out); err != nil {
+		return 0, err
+	}
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
@@ -0,0 +1,139 @@
+package document
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"userService/application/domain"
+)
+
+const (
+	marginLeft  = 50.0
+	marginRight = pageWidth - 50.0
+)
+
+// WriteReceiptPDF renders a receipt on a single A4 page.
+func WriteReceiptPDF(w io.Writer, receipt *domain.Receipt) error {
+	doc := newPDFDocument("Receipt " + receipt.DisplayNumber())
+	doc.addPage()
+
+	y := 70.0
+	doc.text(marginLeft, y, 20, fontBold, "Receipt")
+	doc.textRight(marginRight, y, 12, fontBold, receipt.DisplayNumber())
+	y += 18
+	doc.textRight(marginRight, y, 9, fontRegular, "Issued "+formatDateTime(receipt.IssuedAt))
+	y += 12
+	doc.textRight(marginRight, y, 9, fontRegular, "Order "+receipt.OrderID)
+	if receipt.PaymentID != nil {
+		y += 12
+		doc.textRight(marginRight, y, 9, fontRegular, "Payment "+*receipt.PaymentID)
+	}
+
+	y += 30
+	doc.text(marginLeft, y, 9, fontBold, "ORGANIZER")
+	doc.text(300, y, 9, fontBold, "BUYER")
+	y += 14
+	doc.text(marginLeft, y, 11, fontRegular, fmt.Sprintf("Organizer #%d", receipt.OrganizerID))
+	doc.text(300, y, 11, fontRegular, doc.fitText(receipt.BuyerName, 11, fontRegular, marginRight-300))
+	y += 14
+	doc.text(300, y, 10, fontRegular, doc.fitText(receipt.BuyerEmail, 10, fontRegular, marginRight-300))
+
+	y += 30
+	kind := "EVENT"
+	if receipt.Item.PacketID != nil {
+		kind = "PACKET"
+	}
+	doc.text(marginLeft, y, 9, fontBold, kind)
+	y += 16
+	doc.text(marginLeft, y, 14, fontBold, doc.fitText(receipt.Item.Name, 14, fontBold, marginRight-marginLeft))
+	if place := formatPlace(receipt.Item.Location, receipt.Item.City, receipt.Item.Country); place != "" {
+		y += 15
+		doc.text(marginLeft, y, 10, fontRegular, doc.fitText(place, 10, fontRegular, marginRight-marginLeft))
+	}
+	if when := formatSchedule(receipt.Item.StartsAt, receipt.Item.EndsAt); when != "" {
+		y += 14
+		doc.text(marginLeft, y, 10, fontRegular, when)
+	}
+
+	y += 34
+	doc.text(marginLeft, y, 9, fontBold, "DESCRIPTION")
+	doc.textRight(360, y, 9, fontBold, "QTY")
+	doc.textRight(450, y, 9, fontBold, "UNIT PRICE")
+	doc.textRight(marginRight, y, 9, fontBold, "AMOUNT")
+	y += 6
+	doc.line(marginLeft, y, marginRight, y, 0.8)
+	for _, line := range receipt.Lines {
+		y += 18
+		doc.text(marginLeft, y, 10, fontRegular, doc.fitText(line.Description, 10, fontRegular, 280))
+		doc.textRight(360, y, 10, fontRegular, strconv.Itoa(line.Quantity))
+		doc.textRight(450, y, 10, fontRegular, formatMoney(line.UnitPrice, receipt.Currency))
+		doc.textRight(marginRight, y, 10, fontRegular, formatMoney(line.Amount, receipt.Currency))
+	}
+	y += 10
+	doc.line(marginLeft, y, marginRight, y, 0.5)
+
+	totals := []struct {
+		label  string
+		amount int
+		font   pdfFont
+	}{
+		{"Subtotal", receipt.Subtotal, fontRegular},
+		{"Discount", -receipt.Discount, fontRegular},
+		{"Total", receipt.Total, fontBold},
+		{fmt.Sprintf("VAT included (%d%%)", receipt.TaxRate), receipt.TaxAmount, fontRegular},
+		{"Total without VAT", receipt.Total - receipt.TaxAmount, fontRegular},
+	}
+	for _, total := range totals {
+		y += 18
+		doc.textRight(450, y, 10, total.font, total.label)
+		doc.textRight(marginRight, y, 10, total.font, formatMoney(total.amount, receipt.Currency))
+	}
+
+	y += 36
+	doc.text(marginLeft, y, 9, fontBold, "TICKETS")
+	for _, code := range receipt.TicketCodes {
+		y += 14
+		doc.text(marginLeft, y, 10, fontRegular, code)
+	}
+
+	doc.text(marginLeft, pageHeight-40, 8, fontRegular, "Prices include VAT. This receipt was issued electronically and is valid without signature or stamp.")
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// formatMoney writes an amount in bani as lei, such as 1250 as 12.50 RON.
+func formatMoney(bani int, currency string) string {
+	sign := ""
+	if bani < 0 {
+		sign = "-"
+		bani = -bani
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, bani/100, bani%100, currency)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format("2 Jan 2006 15:04 UTC")
+}
+
+func formatPlace(location *string, city *string, country *string) string {
+	var parts []string
+	for _, part := range []*string{location, city, country} {
+		if part != nil && strings.TrimSpace(*part) != "" {
+			parts = append(parts, strings.TrimSpace(*part))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatSchedule(startsAt *time.Time, endsAt *time.Time) string {
+	switch {
+	case startsAt == nil:
+		return ""
+	case endsAt == nil:
+		return formatDateTime(*startsAt)
+	}
+	return fmt.Sprintf("%s - %s", formatDateTime(*startsAt), formatDateTime(*endsAt))
+}
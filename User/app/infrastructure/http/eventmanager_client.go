@@ -1,15 +1,20 @@
 package http
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 	"userService/application/domain"
+	"userService/infrastructure/http/problem"
+
+	"shared/httpclient"
 )
 
 type ServiceTokenProvider interface {
@@ -19,7 +24,7 @@ type ServiceTokenProvider interface {
 
 type EventManagerClient struct {
 	baseURL       string
-	httpClient    *http.Client
+	httpClient    *httpclient.Client
 	tokenProvider ServiceTokenProvider
 }
 
@@ -34,6 +39,35 @@ type TicketResponse struct {
 	EventID  *int   `json:"event_id"`
 }
 
+// EventResponse holds the fields the User service reads from an event or a
+// packet of EventManager's batch lookups.
+type EventResponse struct {
+	ID       int        `json:"id"`
+	Name     string     `json:"name"`
+	Location *string    `json:"location"`
+	Address  *string    `json:"address"`
+	City     *string    `json:"city"`
+	Country  *string    `json:"country"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+
+	CancelledAt *time.Time `json:"cancelled_at"`
+	Sequence    int        `json:"sequence"`
+
+	TransfersBlocked bool `json:"transfers_blocked"`
+
+	Price                  *int `json:"price"`
+	ResaleAllowed          bool `json:"resale_allowed"`
+	ResaleMaxMarkupPercent *int `json:"resale_max_markup_percent"`
+
+	OwnerID           int  `json:"id_owner"`
+	RefundWindowHours *int `json:"refund_window_hours"`
+	MaxTicketsPerUser *int `json:"max_tickets_per_user"`
+}
+
+// maxBatchIDs is the most ids EventManager resolves in one lookup.
+const maxBatchIDs = 100
+
 func resolveEventManagerURL() (string, error) {
 	if url := os.Getenv("EVENT_MANAGER_URL"); url != "" {
 		return url, nil
@@ -56,9 +90,10 @@ func NewEventManagerClient(tokenProvider ServiceTokenProvider) *EventManagerClie
 
 	return &EventManagerClient{
 		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		httpClient: httpclient.New(httpclient.Config{
+			Name:           "event-manager",
+			AttemptTimeout: 3 * time.Second,
+		}),
 		tokenProvider: tokenProvider,
 	}
 }
@@ -76,46 +111,58 @@ func (c *EventManagerClient) CreateTicket(ctx context.Context, code string, pack
 		return nil, &domain.InternalError{Msg: "failed to marshal request", Err: err}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, &domain.InternalError{Msg: "failed to create request", Err: err}
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
+	var serviceToken string
 	if c.tokenProvider != nil && c.tokenProvider.IsConfigured() {
-		serviceToken, err := c.tokenProvider.GetServiceToken(ctx)
+		serviceToken, err = c.tokenProvider.GetServiceToken(ctx)
 		if err != nil {
 			return nil, &domain.InternalError{Msg: "failed to get service token", Err: err}
 		}
-		req.Header.Set("Authorization", "Bearer "+serviceToken)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, &domain.InternalError{Msg: "event manager service unavailable", Err: err}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	// every attempt carries the same key, so EventManager replays the first
+	// outcome if an earlier attempt went through but its response was lost
+	header.Set("Idempotency-Key", "ticket-"+code)
+	if serviceToken != "" {
+		header.Set("Authorization", "Bearer "+serviceToken)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+		Method:     http.MethodPut,
+		URL:        url,
+		Header:     header,
+		Body:       jsonData,
+		Idempotent: true,
+	})
 	if err != nil {
-		return nil, &domain.InternalError{Msg: "failed to read response", Err: err}
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			return nil, &domain.ServiceUnavailableError{Service: "event manager"}
+		}
+		return nil, &domain.InternalError{Msg: "event manager service unavailable", Err: err}
 	}
+	body := resp.Body
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, &domain.UnauthorizedError{Reason: fmt.Sprintf("service authentication failed: %s", string(body))}
+		return nil, &domain.UnauthorizedError{Reason: fmt.Sprintf("service authentication failed: %s", problemDetail(body))}
 	}
 
 	if resp.StatusCode == http.StatusForbidden {
-		return nil, &domain.ForbiddenError{Reason: fmt.Sprintf("service not authorized: %s", string(body))}
+		return nil, &domain.ForbiddenError{Reason: fmt.Sprintf("service not authorized: %s", problemDetail(body))}
 	}
 
 	if resp.StatusCode >= 500 {
-		return nil, &domain.InternalError{Msg: "event manager service error", Err: fmt.Errorf("status %d: %s", resp.StatusCode, string(body))}
+		return nil, &domain.InternalError{Msg: "event manager service error", Err: fmt.Errorf("status %d: %s", resp.StatusCode, problemDetail(body))}
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		if err := seatError(body); err != nil {
+			return nil, err
+		}
 	}
 
 	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-		return nil, &domain.ValidationError{Field: "ticket", Reason: fmt.Sprintf("failed to create ticket: %s", string(body))}
+		return nil, &domain.ValidationError{Field: "ticket", Reason: fmt.Sprintf("failed to create ticket: %s", problemDetail(body))}
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
@@ -133,3 +180,375 @@ func (c *EventManagerClient) CreateTicket(ctx context.Context, code string, pack
 
 	return &ticketResp, nil
 }
+
+type RotateTicketRequest struct {
+	NewCode string `json:"new_code"`
+}
+
+// RotateTicketCode moves the ticket to newCode. The call is safe to retry:
+// EventManager answers a rotation that already went through with the
+// ticket under its new code.
+func (c *EventManagerClient) RotateTicketCode(ctx context.Context, code string, newCode string) (*TicketResponse, error) {
+	return c.changeTicketCode(ctx, code, "rotate", RotateTicketRequest{NewCode: newCode})
+}
+
+type ResellTicketRequest struct {
+	NewCode string `json:"new_code"`
+	Price   int    `json:"price"`
+}
+
+// ResellTicket reissues a resold ticket under newCode once EventManager
+// checks price against the owner's resale policy. Like RotateTicketCode it is
+// safe to retry.
+func (c *EventManagerClient) ResellTicket(ctx context.Context, code string, newCode string, price int) (*TicketResponse, error) {
+	return c.changeTicketCode(ctx, code, "resell", ResellTicketRequest{NewCode: newCode, Price: price})
+}
+
+// changeTicketCode posts reqBody to the action endpoint of the ticket and
+// returns the ticket under its new code.
+func (c *EventManagerClient) changeTicketCode(ctx context.Context, code string, action string, reqBody interface{}) (*TicketResponse, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to marshal request", Err: err}
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if c.tokenProvider != nil && c.tokenProvider.IsConfigured() {
+		serviceToken, err := c.tokenProvider.GetServiceToken(ctx)
+		if err != nil {
+			return nil, &domain.InternalError{Msg: "failed to get service token", Err: err}
+		}
+		header.Set("Authorization", "Bearer "+serviceToken)
+	}
+
+	resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+		Method:     http.MethodPost,
+		URL:        fmt.Sprintf("%s/api/event-manager/tickets/%s/%s", c.baseURL, url.PathEscape(code), action),
+		Header:     header,
+		Body:       jsonData,
+		Idempotent: true,
+	})
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			return nil, &domain.ServiceUnavailableError{Service: "event manager"}
+		}
+		return nil, &domain.InternalError{Msg: "event manager service unavailable", Err: err}
+	}
+	body := resp.Body
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, &domain.UnauthorizedError{Reason: fmt.Sprintf("service authentication failed: %s", problemDetail(body))}
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, &domain.ForbiddenError{Reason: fmt.Sprintf("service not authorized: %s", problemDetail(body))}
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &domain.ResourceNotFoundError{Resource: "ticket", ID: code}
+	case resp.StatusCode >= 500:
+		return nil, &domain.InternalError{Msg: "event manager service error", Err: fmt.Errorf("status %d: %s", resp.StatusCode, problemDetail(body))}
+	case resp.StatusCode >= 400:
+		switch problemCode(body) {
+		case domain.CodeTransfersBlocked:
+			return nil, &domain.TransfersBlockedError{Detail: problemDetail(body)}
+		case domain.CodeResaleNotAllowed:
+			return nil, &domain.ResaleNotAllowedError{Detail: problemDetail(body)}
+		case domain.CodeResalePriceAboveCap:
+			return nil, &domain.ResalePriceAboveCapError{Detail: problemDetail(body)}
+		}
+		return nil, &domain.ValidationError{Field: "ticket", Reason: fmt.Sprintf("failed to %s ticket: %s", action, problemDetail(body))}
+	case resp.StatusCode != http.StatusOK:
+		return nil, &domain.InternalError{Msg: "unexpected response from event manager", Err: fmt.Errorf("status %d", resp.StatusCode)}
+	}
+
+	var ticketResp TicketResponse
+	if err := json.Unmarshal(body, &ticketResp); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to parse response", Err: err}
+	}
+
+	return &ticketResp, nil
+}
+
+// VoidTicket deletes a refunded ticket, which gives its seat back. A ticket
+// EventManager no longer knows counts as voided, so the call is safe to
+// retry.
+func (c *EventManagerClient) VoidTicket(ctx context.Context, code string) error {
+	header := http.Header{}
+	if c.tokenProvider != nil && c.tokenProvider.IsConfigured() {
+		serviceToken, err := c.tokenProvider.GetServiceToken(ctx)
+		if err != nil {
+			return &domain.InternalError{Msg: "failed to get service token", Err: err}
+		}
+		header.Set("Authorization", "Bearer "+serviceToken)
+	}
+
+	resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+		Method:     http.MethodDelete,
+		URL:        fmt.Sprintf("%s/api/event-manager/tickets/%s", c.baseURL, url.PathEscape(code)),
+		Header:     header,
+		Idempotent: true,
+	})
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			return &domain.ServiceUnavailableError{Service: "event manager"}
+		}
+		return &domain.InternalError{Msg: "event manager service unavailable", Err: err}
+	}
+	body := resp.Body
+
+	switch {
+	case resp.StatusCode == http.StatusOK, resp.StatusCode == http.StatusNoContent, resp.StatusCode == http.StatusNotFound:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &domain.UnauthorizedError{Reason: fmt.Sprintf("service authentication failed: %s", problemDetail(body))}
+	case resp.StatusCode == http.StatusForbidden:
+		return &domain.ForbiddenError{Reason: fmt.Sprintf("service not authorized: %s", problemDetail(body))}
+	case resp.StatusCode >= 500:
+		return &domain.InternalError{Msg: "event manager service error", Err: fmt.Errorf("status %d: %s", resp.StatusCode, problemDetail(body))}
+	case resp.StatusCode >= 400:
+		return &domain.ValidationError{Field: "ticket", Reason: fmt.Sprintf("failed to void ticket: %s", problemDetail(body))}
+	}
+	return &domain.InternalError{Msg: "unexpected response from event manager", Err: fmt.Errorf("status %d", resp.StatusCode)}
+}
+
+// GetEventRemainingSeats returns how many seats of the event are still for
+// sale, or nil when it has no capacity.
+func (c *EventManagerClient) GetEventRemainingSeats(ctx context.Context, id int) (*int, error) {
+	return c.remainingSeats(ctx, fmt.Sprintf("/api/event-manager/events/%d/availability", id), "event", id)
+}
+
+func (c *EventManagerClient) GetPacketRemainingSeats(ctx context.Context, id int) (*int, error) {
+	return c.remainingSeats(ctx, fmt.Sprintf("/api/event-manager/event-packets/%d/availability", id), "packet", id)
+}
+
+func (c *EventManagerClient) remainingSeats(ctx context.Context, path string, resource string, id int) (*int, error) {
+	header := http.Header{}
+	if c.tokenProvider != nil && c.tokenProvider.IsConfigured() {
+		serviceToken, err := c.tokenProvider.GetServiceToken(ctx)
+		if err != nil {
+			return nil, &domain.InternalError{Msg: "failed to get service token", Err: err}
+		}
+		header.Set("Authorization", "Bearer "+serviceToken)
+	}
+
+	resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+		Method:     http.MethodGet,
+		URL:        c.baseURL + path,
+		Header:     header,
+		Idempotent: true,
+	})
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			return nil, &domain.ServiceUnavailableError{Service: "event manager"}
+		}
+		return nil, &domain.InternalError{Msg: "event manager service unavailable", Err: err}
+	}
+	body := resp.Body
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &domain.ResourceNotFoundError{Resource: resource, ID: strconv.Itoa(id)}
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, &domain.UnauthorizedError{Reason: fmt.Sprintf("service authentication failed: %s", problemDetail(body))}
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, &domain.ForbiddenError{Reason: fmt.Sprintf("service not authorized: %s", problemDetail(body))}
+	case resp.StatusCode != http.StatusOK:
+		return nil, &domain.InternalError{Msg: "event manager availability lookup failed", Err: fmt.Errorf("status %d: %s", resp.StatusCode, problemDetail(body))}
+	}
+
+	var availability struct {
+		Availability struct {
+			Remaining *int `json:"remaining"`
+		} `json:"availability"`
+	}
+	if err := json.Unmarshal(body, &availability); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to parse response", Err: err}
+	}
+	return availability.Availability.Remaining, nil
+}
+
+func (c *EventManagerClient) GetEventsByIDs(ctx context.Context, ids []int) ([]*EventResponse, error) {
+	var events []*EventResponse
+	err := c.lookupByIDs(ctx, "/api/event-manager/events", ids, func(body []byte) error {
+		var resp struct {
+			Events []*EventResponse `json:"events"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return err
+		}
+		events = append(events, resp.Events...)
+		return nil
+	})
+	return events, err
+}
+
+func (c *EventManagerClient) GetPacketsByIDs(ctx context.Context, ids []int) ([]*EventResponse, error) {
+	var packets []*EventResponse
+	err := c.lookupByIDs(ctx, "/api/event-manager/event-packets", ids, func(body []byte) error {
+		var resp struct {
+			EventPackets []*EventResponse `json:"event_packets"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return err
+		}
+		packets = append(packets, resp.EventPackets...)
+		return nil
+	})
+	return packets, err
+}
+
+// GetEventsOfPacket lists the events a packet includes.
+func (c *EventManagerClient) GetEventsOfPacket(ctx context.Context, packetID int) ([]*EventResponse, error) {
+	var resp struct {
+		Events []*EventResponse `json:"events"`
+	}
+	path := fmt.Sprintf("/api/event-manager/event-packet-inclusions/packet/%d", packetID)
+	if err := c.lookupInclusions(ctx, path, "packet", packetID, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Events, nil
+}
+
+// GetPacketsIncludingEvent lists the packets that include an event.
+func (c *EventManagerClient) GetPacketsIncludingEvent(ctx context.Context, eventID int) ([]*EventResponse, error) {
+	var resp struct {
+		EventPackets []*EventResponse `json:"event_packets"`
+	}
+	path := fmt.Sprintf("/api/event-manager/event-packet-inclusions/event/%d", eventID)
+	if err := c.lookupInclusions(ctx, path, "event", eventID, &resp); err != nil {
+		return nil, err
+	}
+	return resp.EventPackets, nil
+}
+
+func (c *EventManagerClient) lookupInclusions(ctx context.Context, path string, resource string, id int, out interface{}) error {
+	header := http.Header{}
+	if c.tokenProvider != nil && c.tokenProvider.IsConfigured() {
+		serviceToken, err := c.tokenProvider.GetServiceToken(ctx)
+		if err != nil {
+			return &domain.InternalError{Msg: "failed to get service token", Err: err}
+		}
+		header.Set("Authorization", "Bearer "+serviceToken)
+	}
+
+	resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+		Method:     http.MethodGet,
+		URL:        c.baseURL + path,
+		Header:     header,
+		Idempotent: true,
+	})
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			return &domain.ServiceUnavailableError{Service: "event manager"}
+		}
+		return &domain.InternalError{Msg: "event manager service unavailable", Err: err}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return &domain.ResourceNotFoundError{Resource: resource, ID: strconv.Itoa(id)}
+	case resp.StatusCode != http.StatusOK:
+		return &domain.InternalError{Msg: "event manager inclusion lookup failed", Err: fmt.Errorf("status %d: %s", resp.StatusCode, problemDetail(resp.Body))}
+	}
+
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return &domain.InternalError{Msg: "failed to parse response", Err: err}
+	}
+	return nil
+}
+
+// lookupByIDs asks path for ids in batches of maxBatchIDs and hands every
+// response body to decode.
+func (c *EventManagerClient) lookupByIDs(ctx context.Context, path string, ids []int, decode func(body []byte) error) error {
+	header := http.Header{}
+	if c.tokenProvider != nil && c.tokenProvider.IsConfigured() {
+		serviceToken, err := c.tokenProvider.GetServiceToken(ctx)
+		if err != nil {
+			return &domain.InternalError{Msg: "failed to get service token", Err: err}
+		}
+		header.Set("Authorization", "Bearer "+serviceToken)
+	}
+
+	for start := 0; start < len(ids); start += maxBatchIDs {
+		batch := ids[start:min(start+maxBatchIDs, len(ids))]
+		raw := make([]string, len(batch))
+		for i, id := range batch {
+			raw[i] = strconv.Itoa(id)
+		}
+
+		resp, err := c.httpClient.Do(ctx, &httpclient.Request{
+			Method:     http.MethodGet,
+			URL:        fmt.Sprintf("%s%s?ids=%s", c.baseURL, path, strings.Join(raw, ",")),
+			Header:     header,
+			Idempotent: true,
+		})
+		if err != nil {
+			if errors.Is(err, httpclient.ErrCircuitOpen) {
+				return &domain.ServiceUnavailableError{Service: "event manager"}
+			}
+			return &domain.InternalError{Msg: "event manager service unavailable", Err: err}
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return &domain.InternalError{Msg: "event manager lookup failed", Err: fmt.Errorf("status %d: %s", resp.StatusCode, problemDetail(resp.Body))}
+		}
+		if err := decode(resp.Body); err != nil {
+			return &domain.InternalError{Msg: "failed to parse response", Err: err}
+		}
+	}
+
+	return nil
+}
+
+// problemDetail pulls the detail out of a problem+json error body, falling
+// back to the raw body for older responses.
+func problemDetail(body []byte) string {
+	var p problem.Problem
+	if err := json.Unmarshal(body, &p); err == nil {
+		if p.Detail != "" {
+			return p.Detail
+		}
+		if p.Error != "" {
+			return p.Error
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// problemCode returns the machine-readable code of a problem+json body, if
+// it has one.
+func problemCode(body []byte) string {
+	var p problem.Problem
+	if err := json.Unmarshal(body, &p); err != nil {
+		return ""
+	}
+	return p.Code
+}
+
+// seatError turns EventManager's sold-out, missing-capacity and cancelled
+// event problems into their typed domain errors, so they reach the caller as
+// 409s with the same code instead of a generic validation failure.
+func seatError(body []byte) error {
+	var p problem.Problem
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil
+	}
+
+	switch p.Code {
+	case domain.CodeEventSoldOut, domain.CodePacketSoldOut:
+		soldOut := &domain.SoldOutError{Code: p.Code, Detail: p.Detail}
+		if p.Availability != nil {
+			soldOut.Resource = p.Availability.Resource
+			soldOut.ID = p.Availability.ID
+			soldOut.Capacity = p.Availability.Capacity
+			soldOut.Sold = p.Availability.Sold
+			soldOut.Reserved = p.Availability.Reserved
+			soldOut.Remaining = p.Availability.Remaining
+		}
+		return soldOut
+	case domain.CodeCapacityNotConfigured:
+		return &domain.CapacityNotConfiguredError{Detail: p.Detail}
+	case domain.CodeEventCancelled:
+		return &domain.EventCancelledError{Detail: p.Detail}
+	}
+	return nil
+}
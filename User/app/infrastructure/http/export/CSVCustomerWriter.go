@@ -0,0 +1,75 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"userService/application/domain"
+)
+
+// csvFlushEvery is how many rows are buffered before they are sent on.
+const csvFlushEvery = 100
+
+type csvCustomerWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+	buffered    int
+}
+
+func newCSVCustomerWriter(w io.Writer) *csvCustomerWriter {
+	return &csvCustomerWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvCustomerWriter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (cw *csvCustomerWriter) writeHeader() error {
+	if cw.wroteHeader {
+		return nil
+	}
+	cw.wroteHeader = true
+	return cw.w.Write(customerColumns)
+}
+
+func (cw *csvCustomerWriter) Write(customer *domain.Customer) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	row := customerRow(customer)
+	for i, cell := range row {
+		row[i] = escapeFormula(cell)
+	}
+	if err := cw.w.Write(row); err != nil {
+		return err
+	}
+
+	cw.buffered++
+	if cw.buffered >= csvFlushEvery {
+		cw.buffered = 0
+		cw.w.Flush()
+		return cw.w.Error()
+	}
+	return nil
+}
+
+func (cw *csvCustomerWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// escapeFormula keeps spreadsheet programs from evaluating names such as
+// "=HYPERLINK(...)" that customers picked for themselves.
+func escapeFormula(cell string) string {
+	if cell == "" {
+		return cell
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"userService/application/domain"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// CustomerWriter encodes a customer listing row by row as it is read, so an
+// export never holds more than one customer in memory. Close finishes the
+// file and has to be called once every customer was written.
+type CustomerWriter interface {
+	ContentType() string
+	Write(customer *domain.Customer) error
+	Close() error
+}
+
+var customerColumns = []string{
+	"id", "email", "first_name", "last_name", "ticket_count", "first_purchased_at", "last_purchased_at",
+}
+
+func NewCustomerWriter(format string, w io.Writer) (CustomerWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVCustomerWriter(w), nil
+	case FormatXLSX:
+		return newXLSXCustomerWriter(w), nil
+	}
+	return nil, &domain.ValidationError{Field: "format", Reason: fmt.Sprintf("format must be one of %s, %s", FormatCSV, FormatXLSX)}
+}
+
+// customerRow returns the exported cells of a customer in column order; the
+// names are expected to be masked already.
+func customerRow(customer *domain.Customer) []string {
+	return []string{
+		strconv.Itoa(customer.ID),
+		customer.Email,
+		customer.FirstName,
+		customer.LastName,
+		strconv.Itoa(customer.TicketCount),
+		formatPurchaseTime(customer.FirstPurchasedAt),
+		formatPurchaseTime(customer.LastPurchasedAt),
+	}
+}
+
+func formatPurchaseTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
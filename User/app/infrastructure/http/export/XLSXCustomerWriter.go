@@ -0,0 +1,123 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"userService/application/domain"
+)
+
+// The fixed parts of a workbook with a single "Customers" sheet. Cells are
+// written as inline strings, so no shared string table has to be built
+// before the sheet can be streamed.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Customers" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// numericColumns are written as numbers instead of text.
+var numericColumns = map[int]bool{0: true, 4: true}
+
+// xlsxCustomerWriter writes the sheet as the last entry of the archive, so
+// its rows go straight to the zip stream as they come in.
+type xlsxCustomerWriter struct {
+	zw      *zip.Writer
+	sheet   io.Writer
+	nextRow int
+}
+
+func newXLSXCustomerWriter(w io.Writer) *xlsxCustomerWriter {
+	return &xlsxCustomerWriter{zw: zip.NewWriter(w), nextRow: 1}
+}
+
+func (xw *xlsxCustomerWriter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (xw *xlsxCustomerWriter) start() error {
+	if xw.sheet != nil {
+		return nil
+	}
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		w, err := xw.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := xw.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+	xw.sheet = sheet
+
+	return xw.writeRow(customerColumns, nil)
+}
+
+func (xw *xlsxCustomerWriter) writeRow(cells []string, numeric map[int]bool) error {
+	var row strings.Builder
+	fmt.Fprintf(&row, `<row r="%d">`, xw.nextRow)
+	for i, cell := range cells {
+		ref := fmt.Sprintf("%c%d", 'A'+i, xw.nextRow)
+		if numeric[i] {
+			fmt.Fprintf(&row, `<c r="%s"><v>%s</v></c>`, ref, cell)
+			continue
+		}
+		fmt.Fprintf(&row, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(&row, []byte(cell)); err != nil {
+			return err
+		}
+		row.WriteString(`</t></is></c>`)
+	}
+	row.WriteString(`</row>`)
+
+	xw.nextRow++
+	_, err := io.WriteString(xw.sheet, row.String())
+	return err
+}
+
+func (xw *xlsxCustomerWriter) Write(customer *domain.Customer) error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+	return xw.writeRow(customerRow(customer), numericColumns)
+}
+
+func (xw *xlsxCustomerWriter) Close() error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(xw.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return xw.zw.Close()
+}
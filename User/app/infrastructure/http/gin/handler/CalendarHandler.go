@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+	"userService/application/domain"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/document"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+	"shared/ical"
+)
+
+type GinCalendarHandler struct {
+	usecase     usecase.CalendarUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinCalendarHandler(usecase usecase.CalendarUsecase, serviceURLs *config.ServiceURLs) *GinCalendarHandler {
+	return &GinCalendarHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// GetCalendarFeed godoc
+// @Summary Get the calendar feed of a user
+// @Description Get the URL of the user's calendar feed, which calendar apps can subscribe to without a bearer token. The feed is created on first use.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Success 200 {object} httpdto.HttpResponseCalendarFeed "Calendar feed"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/calendar [get]
+func (h *GinCalendarHandler) GetCalendarFeed(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	feed, err := h.usecase.GetCalendarFeed(c.Request.Context(), token, userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseCalendarFeed(feed, h.serviceURLs))
+}
+
+// RotateCalendarFeed godoc
+// @Summary Rotate the calendar feed token of a user
+// @Description Give the user's calendar feed a new URL; subscriptions to the previous URL stop receiving updates
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Success 200 {object} httpdto.HttpResponseCalendarFeed "Calendar feed with its new URL"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/calendar/token [post]
+func (h *GinCalendarHandler) RotateCalendarFeed(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	feed, err := h.usecase.RotateCalendarFeed(c.Request.Context(), token, userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseCalendarFeed(feed, h.serviceURLs))
+}
+
+// GetCalendar godoc
+// @Summary Get the calendar of a user
+// @Description Get an iCalendar (RFC 5545) feed with a VEVENT for every scheduled event the user holds tickets for, directly or through a packet. Cancelled events are kept with STATUS:CANCELLED. Authorized by the feed token instead of a bearer token.
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path int true "User ID"
+// @Param token query string true "Feed token from GET /users/{id}/calendar"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 404 {object} problem.Problem "No calendar with this token"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 503 {object} problem.Problem "EventManager unavailable"
+// @Router /users/{id}/calendar.ics [get]
+func (h *GinCalendarHandler) GetCalendar(c *gin.Context) {
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterCalendar
+	if err := middleware.StrictBindQuery(c, &query, []string{"token"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entries, err := h.usecase.GetCalendar(c.Request.Context(), userID, query.Token)
+	if handleError(c, err) {
+		return
+	}
+
+	var ics bytes.Buffer
+	if err := document.WriteCalendar(&ics, "Event tickets", entries, h.serviceURLs.EventManager, time.Now()); err != nil {
+		handleError(c, &domain.InternalError{Msg: "failed to render calendar", Err: err})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="calendar-%d.ics"`, userID))
+	c.Data(http.StatusOK, ical.ContentType, ics.Bytes())
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"userService/application/domain"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinPaymentHandler struct {
+	usecase     usecase.PaymentUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinPaymentHandler(usecase usecase.PaymentUsecase, serviceURLs *config.ServiceURLs) *GinPaymentHandler {
+	return &GinPaymentHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// GetUserOrders godoc
+// @Summary List the orders of a user
+// @Description List the purchases of the user, newest first, failed ones included
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Success 200 {object} httpdto.HttpResponseOrderList "Orders of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/orders [get]
+func (h *GinPaymentHandler) GetUserOrders(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	orders, err := h.usecase.GetUserOrders(c.Request.Context(), token, userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseOrderList(userID, orders, h.serviceURLs))
+}
+
+// GetUserOrder godoc
+// @Summary Get an order of a user
+// @Description Get a purchase of the user with its payment, left out when there was nothing to pay
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param order_id path string true "Order ID"
+// @Success 200 {object} httpdto.HttpResponseOrder "Order and payment"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or order not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/orders/{order_id} [get]
+func (h *GinPaymentHandler) GetUserOrder(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	order, payment, err := h.usecase.GetUserOrder(c.Request.Context(), token, userID, c.Param("order_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseOrder(order, payment, h.serviceURLs))
+}
+
+// PaymentWebhook godoc
+// @Summary Receive a payment gateway webhook
+// @Description Called by the payment gateway when a payment changes on its side: payment.captured, payment.voided or payment.refunded. The body is signed with the shared webhook secret in X-Payment-Signature. Events already applied, or of a kind not tracked, are acknowledged without changes.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "sha256=<hex HMAC-SHA256 of the body>"
+// @Success 200 {object} httpdto.HttpResponsePaymentWebhook "Payment after the event"
+// @Failure 400 {object} problem.Problem "Body is not a payment event"
+// @Failure 401 {object} problem.Problem "Missing or invalid signature"
+// @Failure 404 {object} problem.Problem "No payment with this reference"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /payments/webhook [post]
+func (h *GinPaymentHandler) PaymentWebhook(c *gin.Context) {
+	signature := c.GetHeader("X-Payment-Signature")
+	if signature == "" {
+		handleError(c, &domain.UnauthorizedError{Reason: "missing X-Payment-Signature header"})
+		return
+	}
+
+	payload, err := c.GetRawData()
+	if err != nil {
+		handleError(c, &domain.InvalidRequestError{Reason: "failed to read request body"})
+		return
+	}
+
+	payment, err := h.usecase.HandlePaymentWebhook(c.Request.Context(), payload, signature)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, &httpdto.HttpResponsePaymentWebhook{Payment: httpdto.ToHttpPayment(payment)})
+}
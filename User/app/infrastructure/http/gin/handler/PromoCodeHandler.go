@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"net/http"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinPromoCodeHandler struct {
+	usecase     usecase.PromoCodeUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinPromoCodeHandler(usecase usecase.PromoCodeUsecase, serviceURLs *config.ServiceURLs) *GinPromoCodeHandler {
+	return &GinPromoCodeHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// CreatePromoCode godoc
+// @Summary Create a promo code
+// @Description Create a discount code for some events and packets of one owner. Percent discounts take discount_value percent off the order; fixed ones take discount_value bani off it. Codes are case insensitive and unique across owners.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param promo_code body httpdto.HttpCreatePromoCode true "Promo code"
+// @Success 201 {object} httpdto.HttpResponsePromoCode "Promo code created"
+// @Failure 400 {object} problem.Problem "Invalid request body, or events and packets of different owners"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Event or packet not found"
+// @Failure 409 {object} problem.Problem "Code already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 503 {object} problem.Problem "EventManager is failing and calls to it are short-circuited"
+// @Router /promo-codes [post]
+func (h *GinPromoCodeHandler) CreatePromoCode(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.HttpCreatePromoCode
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	promo, err := h.usecase.CreatePromoCode(c.Request.Context(), token, req.ToPromoCode())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponsePromoCode(promo, h.serviceURLs))
+}
+
+// GetPromoCodes godoc
+// @Summary List promo codes
+// @Description List the promo codes of an owner, newest first. Owners see their own; the client service names the owner.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param owner_id query int false "Owner whose codes to list; defaults to the caller"
+// @Success 200 {object} httpdto.HttpResponsePromoCodeList "Promo codes"
+// @Failure 400 {object} problem.Problem "Invalid filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /promo-codes [get]
+func (h *GinPromoCodeHandler) GetPromoCodes(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var query httpdto.HttpFilterPromoCodes
+	if err := middleware.StrictBindQuery(c, &query, []string{"owner_id"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	promos, err := h.usecase.GetPromoCodes(c.Request.Context(), token, query.OwnerID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponsePromoCodeList(promos, query.OwnerID, h.serviceURLs))
+}
+
+// GetPromoCode godoc
+// @Summary Get a promo code
+// @Description Get a promo code with the number of purchases it was used for
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param promo_id path string true "Promo code ID"
+// @Success 200 {object} httpdto.HttpResponsePromoCode "Promo code"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Promo code not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /promo-codes/{promo_id} [get]
+func (h *GinPromoCodeHandler) GetPromoCode(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	promo, err := h.usecase.GetPromoCode(c.Request.Context(), token, c.Param("promo_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponsePromoCode(promo, h.serviceURLs))
+}
+
+// UpdatePromoCode godoc
+// @Summary Change a promo code
+// @Description Change the discount, limits, validity window or whether the code is active. The code, its events and packets and its kind of discount cannot change. Lowering a limit below the uses so far stops further uses.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param promo_id path string true "Promo code ID"
+// @Param promo_code body httpdto.HttpUpdatePromoCode true "Fields to change"
+// @Success 200 {object} httpdto.HttpResponsePromoCode "Promo code changed"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Promo code not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /promo-codes/{promo_id} [patch]
+func (h *GinPromoCodeHandler) UpdatePromoCode(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.HttpUpdatePromoCode
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	promo, err := h.usecase.UpdatePromoCode(c.Request.Context(), token, c.Param("promo_id"), req.ToUpdateMap())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponsePromoCode(promo, h.serviceURLs))
+}
+
+// DeletePromoCode godoc
+// @Summary Delete a promo code
+// @Description Delete a promo code that was never redeemed. Redeemed codes keep their usage report and are deactivated instead.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param promo_id path string true "Promo code ID"
+// @Success 200 {object} httpdto.HttpResponsePromoCode "Promo code deleted"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Promo code not found"
+// @Failure 409 {object} problem.Problem "Promo code already redeemed"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /promo-codes/{promo_id} [delete]
+func (h *GinPromoCodeHandler) DeletePromoCode(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	promo, err := h.usecase.DeletePromoCode(c.Request.Context(), token, c.Param("promo_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponsePromoCode(promo, h.serviceURLs))
+}
+
+// GetPromoCodeUsage godoc
+// @Summary Get the usage of a promo code
+// @Description Sum up the completed purchases made with a promo code: uses, tickets and discount given in bani, overall and per user, most recent users first
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param promo_id path string true "Promo code ID"
+// @Success 200 {object} httpdto.HttpResponsePromoCodeUsage "Usage report"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Promo code not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /promo-codes/{promo_id}/usage [get]
+func (h *GinPromoCodeHandler) GetPromoCodeUsage(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	usage, err := h.usecase.GetPromoCodeUsage(c.Request.Context(), token, c.Param("promo_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponsePromoCodeUsage(usage, h.serviceURLs))
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"userService/application/domain"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/document"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinReceiptHandler struct {
+	usecase     usecase.ReceiptUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinReceiptHandler(usecase usecase.ReceiptUsecase, serviceURLs *config.ServiceURLs) *GinReceiptHandler {
+	return &GinReceiptHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// GetUserReceipts godoc
+// @Summary List the receipts of a user
+// @Description List the receipts of the user's purchases, newest first
+// @Tags receipts
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Success 200 {object} httpdto.HttpResponseReceiptList "Receipts of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/receipts [get]
+func (h *GinReceiptHandler) GetUserReceipts(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	receipts, err := h.usecase.GetUserReceipts(c.Request.Context(), token, userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseReceiptList(userID, receipts, h.serviceURLs))
+}
+
+// GetUserReceipt godoc
+// @Summary Get a receipt of a user
+// @Description Get a numbered receipt with the buyer, the event or packet, the price lines and the VAT included, as JSON or PDF
+// @Tags receipts
+// @Accept json
+// @Produce json
+// @Produce application/pdf
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param receipt_id path string true "Receipt ID"
+// @Param format query string false "Document format (default: json)" Enums(json, pdf)
+// @Success 200 {object} httpdto.HttpResponseReceipt "Receipt"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or receipt not found"
+// @Failure 422 {object} problem.Problem "Unknown format"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/receipts/{receipt_id} [get]
+func (h *GinReceiptHandler) GetUserReceipt(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterReceipt
+	if err := middleware.StrictBindQuery(c, &query, []string{"format"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	receipt, err := h.usecase.GetUserReceipt(c.Request.Context(), token, userID, c.Param("receipt_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	h.writeReceipt(c, receipt, query.Format)
+}
+
+// GetOrderReceipt godoc
+// @Summary Get the receipt of an order
+// @Description Get the receipt of a completed order, as JSON or PDF. A receipt the purchase could not issue is issued now.
+// @Tags receipts
+// @Accept json
+// @Produce json
+// @Produce application/pdf
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param order_id path string true "Order ID"
+// @Param format query string false "Document format (default: json)" Enums(json, pdf)
+// @Success 200 {object} httpdto.HttpResponseReceipt "Receipt"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or order not found, or the order was not completed"
+// @Failure 422 {object} problem.Problem "Unknown format"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 503 {object} problem.Problem "EventManager unavailable"
+// @Router /users/{id}/orders/{order_id}/receipt [get]
+func (h *GinReceiptHandler) GetOrderReceipt(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterReceipt
+	if err := middleware.StrictBindQuery(c, &query, []string{"format"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	receipt, err := h.usecase.GetOrderReceipt(c.Request.Context(), token, userID, c.Param("order_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	h.writeReceipt(c, receipt, query.Format)
+}
+
+func (h *GinReceiptHandler) writeReceipt(c *gin.Context, receipt *domain.Receipt, format *string) {
+	if format == nil || *format == document.FormatJSON {
+		c.JSON(http.StatusOK, httpdto.ToHttpResponseReceipt(receipt, h.serviceURLs))
+		return
+	}
+	if *format != document.FormatPDF {
+		handleError(c, &domain.ValidationError{Field: "format", Reason: fmt.Sprintf("format must be one of %s, %s", document.FormatJSON, document.FormatPDF)})
+		return
+	}
+
+	var pdf bytes.Buffer
+	if err := document.WriteReceiptPDF(&pdf, receipt); err != nil {
+		handleError(c, &domain.InternalError{Msg: "failed to render receipt", Err: err})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="receipt-%s.pdf"`, receipt.DisplayNumber()))
+	c.Data(http.StatusOK, document.ContentTypePDF, pdf.Bytes())
+}
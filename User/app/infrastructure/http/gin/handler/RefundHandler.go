@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"net/http"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinRefundHandler struct {
+	usecase     usecase.RefundUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinRefundHandler(usecase usecase.RefundUsecase, serviceURLs *config.ServiceURLs) *GinRefundHandler {
+	return &GinRefundHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// RequestRefund godoc
+// @Summary Ask for a ticket to be refunded
+// @Description Ask the owner of the event or packet to take one of the user's tickets back. The owner must refund tickets and the request must come before their refund window closes. The ticket stays with the holder, and cannot be transferred or resold, until the refund is decided.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the holder"
+// @Param refund body httpdto.HttpCreateRefund true "Ticket and reason"
+// @Success 201 {object} httpdto.HttpResponseRefund "Refund requested"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User, ticket, event or packet not found"
+// @Failure 409 {object} problem.Problem "Ticket already in an open refund, transfer or listing, or refunds not allowed (code REFUND_NOT_ALLOWED)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/refunds [post]
+func (h *GinRefundHandler) RequestRefund(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpCreateRefund
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	refund, err := h.usecase.RequestRefund(c.Request.Context(), token, userID, req.TicketCode, req.Reason)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponseRefund(refund, false, h.serviceURLs))
+}
+
+// GetUserRefunds godoc
+// @Summary List the refunds of a user
+// @Description List the refunds the user asked for, newest first
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param status query string false "Only refunds in this status" Enums(requested, approving, approved, denied)
+// @Success 200 {object} httpdto.HttpResponseRefundList "Refunds of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID or filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/refunds [get]
+func (h *GinRefundHandler) GetUserRefunds(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterUserRefunds
+	if err := middleware.StrictBindQuery(c, &query, []string{"status"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	refunds, err := h.usecase.GetUserRefunds(c.Request.Context(), token, userID, query.Status)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseUserRefundList(userID, refunds, query.Status, h.serviceURLs))
+}
+
+// GetUserRefund godoc
+// @Summary Get a refund of a user
+// @Description Get a refund the user asked for
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param refund_id path string true "Refund ID"
+// @Success 200 {object} httpdto.HttpResponseRefund "Refund"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or refund not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/refunds/{refund_id} [get]
+func (h *GinRefundHandler) GetUserRefund(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	refund, err := h.usecase.GetUserRefund(c.Request.Context(), token, userID, c.Param("refund_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseRefund(refund, false, h.serviceURLs))
+}
+
+// GetRefunds godoc
+// @Summary List the refunds of an event or packet
+// @Description List the refunds asked for tickets of an event or a packet, newest first. Only its owner and the client service can see them.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param event_id query int false "Event ID; exactly one of event_id and packet_id is required"
+// @Param packet_id query int false "Packet ID"
+// @Param status query string false "Only refunds in this status" Enums(requested, approving, approved, denied)
+// @Success 200 {object} httpdto.HttpResponseRefundList "Refunds"
+// @Failure 400 {object} problem.Problem "Invalid filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Event or packet not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /refunds [get]
+func (h *GinRefundHandler) GetRefunds(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var query httpdto.HttpFilterRefunds
+	if err := middleware.StrictBindQuery(c, &query, []string{"event_id", "packet_id", "status"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := query.ToRefundFilter()
+	refunds, err := h.usecase.GetRefunds(c.Request.Context(), token, filter)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseRefundList(refunds, filter, h.serviceURLs))
+}
+
+// GetRefund godoc
+// @Summary Get a refund to decide
+// @Description Get a refund asked for a ticket of an event or packet. Only its owner and the client service can see it.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param refund_id path string true "Refund ID"
+// @Success 200 {object} httpdto.HttpResponseRefund "Refund"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Refund not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /refunds/{refund_id} [get]
+func (h *GinRefundHandler) GetRefund(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	refund, err := h.usecase.GetRefund(c.Request.Context(), token, c.Param("refund_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseRefund(refund, true, h.serviceURLs))
+}
+
+// ApproveRefund godoc
+// @Summary Approve a refund
+// @Description Approve a refund for amount, in bani, or the face value of the ticket when left out. The ticket is voided in EventManager, which releases its seat, and removed from the holder. Approving again finishes an approval that failed half way.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param refund_id path string true "Refund ID"
+// @Param decision body httpdto.HttpApproveRefund true "Amount and note; send {} to refund the face value"
+// @Success 200 {object} httpdto.HttpResponseRefund "Refund approved"
+// @Failure 400 {object} problem.Problem "Invalid request body or amount above the face value"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Refund not found"
+// @Failure 409 {object} problem.Problem "Refund already decided"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 503 {object} problem.Problem "EventManager is failing and calls to it are short-circuited"
+// @Router /refunds/{refund_id}/approve [post]
+func (h *GinRefundHandler) ApproveRefund(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.HttpApproveRefund
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	refund, err := h.usecase.ApproveRefund(c.Request.Context(), token, c.Param("refund_id"), req.Amount, req.Note)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseRefund(refund, true, h.serviceURLs))
+}
+
+// DenyRefund godoc
+// @Summary Deny a refund
+// @Description Turn a refund down; the ticket stays with its holder
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param refund_id path string true "Refund ID"
+// @Param decision body httpdto.HttpDenyRefund true "Note; may be {}"
+// @Success 200 {object} httpdto.HttpResponseRefund "Refund denied"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the owner or the client service"
+// @Failure 404 {object} problem.Problem "Refund not found"
+// @Failure 409 {object} problem.Problem "Refund already decided"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /refunds/{refund_id}/deny [post]
+func (h *GinRefundHandler) DenyRefund(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req httpdto.HttpDenyRefund
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	refund, err := h.usecase.DenyRefund(c.Request.Context(), token, c.Param("refund_id"), req.Note)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseRefund(refund, true, h.serviceURLs))
+}
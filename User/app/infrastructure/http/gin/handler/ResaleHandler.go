@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"net/http"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinResaleHandler struct {
+	usecase     usecase.ResaleUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinResaleHandler(usecase usecase.ResaleUsecase, serviceURLs *config.ServiceURLs) *GinResaleHandler {
+	return &GinResaleHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// CreateListing godoc
+// @Summary Put a ticket up for resale
+// @Description List one of the user's tickets on the resale marketplace for price, in bani. The event owner must allow resale and the price must stay within their markup cap over the face value. The ticket stays with the seller until someone buys it.
+// @Tags resale
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the seller"
+// @Param listing body httpdto.HttpCreateResaleListing true "Ticket and price"
+// @Success 201 {object} httpdto.HttpResponseResaleListing "Ticket listed"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or ticket not found"
+// @Failure 409 {object} problem.Problem "Ticket already listed or in an open transfer, or resale not allowed by the event owner (code RESALE_NOT_ALLOWED)"
+// @Failure 422 {object} problem.Problem "Price above the owner's markup cap (code RESALE_PRICE_ABOVE_CAP)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/resale-listings [post]
+func (h *GinResaleHandler) CreateListing(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpCreateResaleListing
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	listing, err := h.usecase.CreateListing(c.Request.Context(), token, userID, req.TicketCode, *req.Price)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponseResaleListing(userID, listing, h.serviceURLs))
+}
+
+// GetUserListings godoc
+// @Summary List the resale listings of a user
+// @Description List the tickets the user put up for resale, newest first
+// @Tags resale
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the seller"
+// @Param status query string false "Only listings in this status" Enums(active, selling, sold, cancelled)
+// @Success 200 {object} httpdto.HttpResponseResaleListingList "Listings of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID or filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/resale-listings [get]
+func (h *GinResaleHandler) GetUserListings(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterUserResaleListings
+	if err := middleware.StrictBindQuery(c, &query, []string{"status"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	listings, err := h.usecase.GetUserListings(c.Request.Context(), token, userID, query.Status)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseUserResaleListingList(userID, listings, query.Status, h.serviceURLs))
+}
+
+// CancelListing godoc
+// @Summary Take a ticket off resale
+// @Description Withdraw a listing nobody is buying
+// @Tags resale
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the seller"
+// @Param listing_id path string true "Listing ID"
+// @Success 200 {object} httpdto.HttpResponseResaleListing "Listing cancelled"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the seller of this listing"
+// @Failure 404 {object} problem.Problem "User or listing not found"
+// @Failure 409 {object} problem.Problem "Listing no longer active"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/resale-listings/{listing_id}/cancel [post]
+func (h *GinResaleHandler) CancelListing(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	listing, err := h.usecase.CancelListing(c.Request.Context(), token, userID, c.Param("listing_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseResaleListing(userID, listing, h.serviceURLs))
+}
+
+// PurchaseListing godoc
+// @Summary Buy a resold ticket
+// @Description Buy a ticket on the resale marketplace. The listing price is recorded as an order and authorized on the card payment_token stands for. EventManager then reissues the ticket under a new code, so the code the seller knew stops working, the ticket moves to the buyer and the payment is captured. A purchase that fails releases the authorization; buying again finishes a purchase that failed half way.
+// @Tags resale
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the buyer"
+// @Param purchase body httpdto.HttpCreateResalePurchase true "Listing to buy"
+// @Success 200 {object} httpdto.HttpResponseResaleListing "Ticket bought; ticket_code is the buyer's new code"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID, or the buyer's own listing"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 402 {object} problem.Problem "Payment declined (code PAYMENT_DECLINED)"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User, listing or ticket not found"
+// @Failure 409 {object} problem.Problem "Listing no longer on sale or resale not allowed by the event owner (code RESALE_NOT_ALLOWED)"
+// @Failure 422 {object} problem.Problem "Price above the owner's markup cap (code RESALE_PRICE_ABOVE_CAP)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 502 {object} problem.Problem "The payment gateway failed to authorize or capture the payment"
+// @Failure 503 {object} problem.Problem "EventManager is failing and calls to it are short-circuited"
+// @Router /users/{id}/resale-purchases [post]
+func (h *GinResaleHandler) PurchaseListing(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpCreateResalePurchase
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	listing, err := h.usecase.PurchaseListing(c.Request.Context(), token, userID, req.ListingID, req.PaymentToken)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseResaleListing(userID, listing, h.serviceURLs))
+}
+
+// GetListings godoc
+// @Summary Browse resold tickets
+// @Description List the tickets on sale for an event or a packet, cheapest first. Ticket codes are never shown.
+// @Tags resale
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param event_id query int false "Event ID; exactly one of event_id and packet_id is required"
+// @Param packet_id query int false "Packet ID"
+// @Success 200 {object} httpdto.HttpResponseResaleListingList "Tickets on sale"
+// @Failure 400 {object} problem.Problem "Invalid filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /resale/listings [get]
+func (h *GinResaleHandler) GetListings(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var query httpdto.HttpFilterResaleListings
+	if err := middleware.StrictBindQuery(c, &query, []string{"event_id", "packet_id"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := query.ToResaleListingFilter()
+	listings, err := h.usecase.GetListings(c.Request.Context(), token, filter)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseResaleListingList(listings, filter, h.serviceURLs))
+}
+
+// GetListing godoc
+// @Summary Get a resale listing
+// @Description Get a listing of the resale marketplace. Ticket codes are never shown.
+// @Tags resale
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param listing_id path string true "Listing ID"
+// @Success 200 {object} httpdto.HttpResponseResaleListing "Listing"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 404 {object} problem.Problem "Listing not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /resale/listings/{listing_id} [get]
+func (h *GinResaleHandler) GetListing(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	listing, err := h.usecase.GetListing(c.Request.Context(), token, c.Param("listing_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseResaleListing(0, listing, h.serviceURLs))
+}
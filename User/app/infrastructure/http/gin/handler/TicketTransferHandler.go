@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"userService/application/domain"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinTicketTransferHandler struct {
+	usecase     usecase.TicketTransferUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinTicketTransferHandler(usecase usecase.TicketTransferUsecase, serviceURLs *config.ServiceURLs) *GinTicketTransferHandler {
+	return &GinTicketTransferHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// InitiateTransfer godoc
+// @Summary Offer a ticket to another user
+// @Description Start handing one of the user's tickets to the user registered with recipient_email. The ticket stays with the holder until the recipient accepts; the offer expires after 7 days.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the holder"
+// @Param transfer body httpdto.HttpCreateTicketTransfer true "Ticket and recipient"
+// @Success 201 {object} httpdto.HttpResponseTicketTransfer "Transfer offered"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or ticket not found"
+// @Failure 409 {object} problem.Problem "Ticket already in an open transfer or transfers blocked by the event owner (code TRANSFERS_BLOCKED)"
+// @Failure 422 {object} problem.Problem "No user registered with the recipient email"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/transfers [post]
+func (h *GinTicketTransferHandler) InitiateTransfer(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpCreateTicketTransfer
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	transfer, err := h.usecase.InitiateTransfer(c.Request.Context(), token, userID, req.TicketCode, req.RecipientEmail)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponseTicketTransfer(userID, transfer, h.serviceURLs))
+}
+
+// GetTransfers godoc
+// @Summary List ticket transfers of a user
+// @Description List the transfers the user sent or received, newest first
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param direction query string false "Only transfers sent (outgoing) or received (incoming)" Enums(outgoing, incoming)
+// @Param status query string false "Only transfers in this status" Enums(pending, accepting, accepted, declined, cancelled, expired)
+// @Success 200 {object} httpdto.HttpResponseTicketTransferList "Transfers of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID or filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/transfers [get]
+func (h *GinTicketTransferHandler) GetTransfers(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterTicketTransfers
+	if err := middleware.StrictBindQuery(c, &query, []string{"direction", "status"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := query.ToTicketTransferFilter()
+	if err := filter.Validate(); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	transfers, err := h.usecase.GetTransfers(c.Request.Context(), token, userID, filter)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseTicketTransferList(userID, transfers, filter, h.serviceURLs))
+}
+
+// GetTransfer godoc
+// @Summary Get a ticket transfer
+// @Description Get a transfer the user sent or received
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param transfer_id path string true "Transfer ID"
+// @Success 200 {object} httpdto.HttpResponseTicketTransfer "Transfer"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or transfer not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/transfers/{transfer_id} [get]
+func (h *GinTicketTransferHandler) GetTransfer(c *gin.Context) {
+	h.answerTransfer(c, h.usecase.GetTransfer)
+}
+
+// AcceptTransfer godoc
+// @Summary Accept a ticket transfer
+// @Description Take over the offered ticket. Its code is rotated in EventManager, so the code the previous holder knew stops working, and the ticket moves to the recipient. Accepting again finishes an acceptance that failed half way.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the recipient"
+// @Param transfer_id path string true "Transfer ID"
+// @Success 200 {object} httpdto.HttpResponseTicketTransfer "Transfer accepted"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the recipient of this transfer"
+// @Failure 404 {object} problem.Problem "User, transfer or ticket not found"
+// @Failure 409 {object} problem.Problem "Transfer no longer pending or transfers blocked by the event owner (code TRANSFERS_BLOCKED)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 503 {object} problem.Problem "EventManager is failing and calls to it are short-circuited"
+// @Router /users/{id}/transfers/{transfer_id}/accept [post]
+func (h *GinTicketTransferHandler) AcceptTransfer(c *gin.Context) {
+	h.answerTransfer(c, h.usecase.AcceptTransfer)
+}
+
+// DeclineTransfer godoc
+// @Summary Decline a ticket transfer
+// @Description Turn down an offered ticket; it stays with its holder
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the recipient"
+// @Param transfer_id path string true "Transfer ID"
+// @Success 200 {object} httpdto.HttpResponseTicketTransfer "Transfer declined"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the recipient of this transfer"
+// @Failure 404 {object} problem.Problem "User or transfer not found"
+// @Failure 409 {object} problem.Problem "Transfer no longer pending"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/transfers/{transfer_id}/decline [post]
+func (h *GinTicketTransferHandler) DeclineTransfer(c *gin.Context) {
+	h.answerTransfer(c, h.usecase.DeclineTransfer)
+}
+
+// CancelTransfer godoc
+// @Summary Cancel a ticket transfer
+// @Description Withdraw a transfer the recipient has not answered yet
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID of the holder"
+// @Param transfer_id path string true "Transfer ID"
+// @Success 200 {object} httpdto.HttpResponseTicketTransfer "Transfer cancelled"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not the holder of this transfer"
+// @Failure 404 {object} problem.Problem "User or transfer not found"
+// @Failure 409 {object} problem.Problem "Transfer no longer pending"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/transfers/{transfer_id}/cancel [post]
+func (h *GinTicketTransferHandler) CancelTransfer(c *gin.Context) {
+	h.answerTransfer(c, h.usecase.CancelTransfer)
+}
+
+// answerTransfer runs one of the calls addressing a single transfer of the
+// user in the path.
+func (h *GinTicketTransferHandler) answerTransfer(c *gin.Context, run func(ctx context.Context, token string, userID int, transferID string) (*domain.TicketTransfer, error)) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	transfer, err := run(c.Request.Context(), token, userID, c.Param("transfer_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseTicketTransfer(userID, transfer, h.serviceURLs))
+}
+
+// GetTicketAudit godoc
+// @Summary Get the ticket history of a user
+// @Description List what happened to the tickets the user held or was offered, newest first: transfers requested, accepted, declined and cancelled
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Success 200 {object} httpdto.HttpResponseTicketAudit "Ticket history"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/ticket-audit [get]
+func (h *GinTicketTransferHandler) GetTicketAudit(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entries, err := h.usecase.GetTicketAudit(c.Request.Context(), token, userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseTicketAudit(userID, entries, h.serviceURLs))
+}
@@ -1,14 +1,19 @@
 package handler
 
 import (
-	"errors"
+	"bytes"
+	"fmt"
+	"log"
 	"net/http"
 	"strings"
 	"userService/application/domain"
 	"userService/application/usecase"
 	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/document"
+	"userService/infrastructure/http/export"
 	"userService/infrastructure/http/gin/middleware"
 	"userService/infrastructure/http/httpdto"
+	"userService/infrastructure/http/problem"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,49 +35,7 @@ func handleError(c *gin.Context, err error) bool {
 		return false
 	}
 
-	var validationErr *domain.ValidationError
-	if errors.As(err, &validationErr) {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
-		return true
-	}
-
-	var invalidReqErr *domain.InvalidRequestError
-	if errors.As(err, &invalidReqErr) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return true
-	}
-
-	var unauthorizedErr *domain.UnauthorizedError
-	if errors.As(err, &unauthorizedErr) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": unauthorizedErr.Error()})
-		return true
-	}
-
-	var forbiddenErr *domain.ForbiddenError
-	if errors.As(err, &forbiddenErr) {
-		c.JSON(http.StatusForbidden, gin.H{"error": forbiddenErr.Error()})
-		return true
-	}
-
-	var notFoundErr *domain.NotFoundError
-	if errors.As(err, &notFoundErr) {
-		c.JSON(http.StatusNotFound, gin.H{"error": notFoundErr.Error()})
-		return true
-	}
-
-	var existsErr *domain.AlreadyExistsError
-	if errors.As(err, &existsErr) {
-		c.JSON(http.StatusConflict, gin.H{"error": existsErr.Error()})
-		return true
-	}
-
-	var internalErr *domain.InternalError
-	if errors.As(err, &internalErr) {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
-		return true
-	}
-
-	c.JSON(http.StatusInternalServerError, gin.H{"error": "An unexpected error occurred"})
+	problem.WriteError(c, err)
 	return true
 }
 
@@ -93,7 +56,7 @@ func getTokenFromHeader(c *gin.Context) string {
 func requireAuth(c *gin.Context) (string, bool) {
 	token := getTokenFromHeader(c)
 	if token == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		problem.Write(c, problem.New(http.StatusUnauthorized, problem.TypeUnauthorized, "Authorization header required"))
 		return "", false
 	}
 	return token, true
@@ -108,9 +71,9 @@ func requireAuth(c *gin.Context) (string, bool) {
 // @Param Authorization header string false "Bearer token (optional for user creation)"
 // @Param user body httpdto.HttpCreateUser true "User details"
 // @Success 201 {object} httpdto.HttpResponseUser "User created successfully"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 409 {object} map[string]string "User already exists"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body"
+// @Failure 409 {object} problem.Problem "User already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /users [post]
 func (h *GinUserHandler) CreateUser(c *gin.Context) {
 	var req httpdto.HttpCreateUser
@@ -140,11 +103,11 @@ func (h *GinUserHandler) CreateUser(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path int true "User ID"
 // @Success 200 {object} httpdto.HttpResponseUser "User details"
-// @Failure 400 {object} map[string]string "Invalid user ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - insufficient permissions"
-// @Failure 404 {object} map[string]string "User not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - insufficient permissions"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /users/{id} [get]
 func (h *GinUserHandler) GetUserByID(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -177,11 +140,11 @@ func (h *GinUserHandler) GetUserByID(c *gin.Context) {
 // @Param id path int true "User ID"
 // @Param user body httpdto.HttpUpdateUser true "Fields to update"
 // @Success 200 {object} httpdto.HttpResponseUser "User updated successfully"
-// @Failure 400 {object} map[string]string "Invalid request body or user ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - cannot update other users"
-// @Failure 404 {object} map[string]string "User not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - cannot update other users"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /users/{id} [patch]
 func (h *GinUserHandler) UpdateUser(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -221,11 +184,11 @@ func (h *GinUserHandler) UpdateUser(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path int true "User ID"
 // @Success 200 {object} httpdto.HttpResponseUser "User deleted successfully"
-// @Failure 400 {object} map[string]string "Invalid user ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - cannot delete other users"
-// @Failure 404 {object} map[string]string "User not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - cannot delete other users"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /users/{id} [delete]
 func (h *GinUserHandler) DeleteUser(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -250,18 +213,24 @@ func (h *GinUserHandler) DeleteUser(c *gin.Context) {
 
 // CreateTicketForUser godoc
 // @Summary Create ticket for user
-// @Description Purchase a ticket for a user by creating a ticket through the EventManager service
+// @Description Purchase up to 10 tickets of an event or packet for a user through the EventManager service, optionally with a promo code. A purchase with something to pay is authorized on the card payment_token stands for, and captured once the tickets are issued. The purchase is all or nothing: when a ticket cannot be issued or the payment cannot be captured, the tickets issued are voided and the authorization released.
 // @Tags clients
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Param user_id path int true "User ID"
-// @Param ticket body httpdto.HttpCreateTicketForUser true "Ticket purchase details (packet_id and event_id)"
-// @Success 201 {object} httpdto.HttpCreateTicketResponse "Ticket created successfully with ticket code"
-// @Failure 400 {object} map[string]string "Invalid request body or user ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 404 {object} map[string]string "User, event, or packet not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param Idempotency-Key header string false "Key making retries safe; the first response is replayed for 24h"
+// @Param ticket body httpdto.HttpCreateTicketForUser true "Ticket purchase details (packet_id or event_id, quantity, promo_code, payment_token)"
+// @Success 201 {object} httpdto.HttpCreateTicketResponse "Tickets created successfully with their codes, price, order and payment"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 402 {object} problem.Problem "Payment declined (code PAYMENT_DECLINED)"
+// @Failure 404 {object} problem.Problem "User, event, or packet not found"
+// @Failure 409 {object} problem.Problem "Sold out, or the seats left are offered to the waitlist (code EVENT_SOLD_OUT/PACKET_SOLD_OUT), no capacity configured (code CAPACITY_NOT_CONFIGURED), per-user ticket limit reached (code PURCHASE_LIMIT_REACHED), promo code not applicable (code PROMO_CODE_NOT_APPLICABLE) or used up (code PROMO_CODE_EXHAUSTED), event cancelled (code EVENT_CANCELLED), or a request with the same Idempotency-Key is in progress"
+// @Failure 422 {object} problem.Problem "Idempotency-Key reused with a different body"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 502 {object} problem.Problem "The payment gateway failed to authorize or capture the payment"
+// @Failure 503 {object} problem.Problem "EventManager is failing and calls to it are short-circuited"
 // @Router /clients/{user_id}/tickets [post]
 func (h *GinUserHandler) CreateTicketForUser(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -281,30 +250,167 @@ func (h *GinUserHandler) CreateTicketForUser(c *gin.Context) {
 		return
 	}
 
-	ticketCode, err := h.usecase.CreateTicketForUser(c.Request.Context(), userID, token, req.PacketID, req.EventID)
+	purchase, err := h.usecase.CreateTicketForUser(c.Request.Context(), userID, token, req.ToPurchaseRequest())
 	if handleError(c, err) {
 		return
 	}
 
-	resp := &httpdto.HttpCreateTicketResponse{
-		TicketCode: ticketCode,
+	c.JSON(http.StatusCreated, httpdto.ToHttpCreateTicketResponse(purchase))
+}
+
+// GetUserTickets godoc
+// @Summary Get the tickets of a user
+// @Description List the tickets a user owns with the name, location and schedule of the event or packet each was bought for
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param when query string false "Only tickets for what has not ended yet (upcoming) or is over (past)" Enums(upcoming, past)
+// @Success 200 {object} httpdto.HttpResponseUserTicketList "Tickets of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID or filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not allowed to view this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 503 {object} problem.Problem "EventManager unavailable"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/tickets [get]
+func (h *GinUserHandler) GetUserTickets(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
 	}
-	c.JSON(http.StatusCreated, resp)
+
+	var query httpdto.HttpFilterUserTickets
+	if err := middleware.StrictBindQuery(c, &query, []string{"when"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := query.ToTicketFilter()
+	if err := filter.Validate(); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	tickets, err := h.usecase.GetUserTickets(c.Request.Context(), token, id, filter)
+	if handleError(c, err) {
+		return
+	}
+
+	resp := httpdto.ToHttpResponseUserTicketList(id, tickets, filter, h.serviceURLs)
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUserETicketPDF godoc
+// @Summary Download a ticket as a PDF
+// @Description Render an e-ticket with the event or packet name, place and schedule, the holder name without the names the holder keeps private, the ticket code and a QR code of it
+// @Tags users
+// @Produce application/pdf
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param code path string true "Ticket code"
+// @Success 200 {file} file "E-ticket"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not allowed to view this user"
+// @Failure 404 {object} problem.Problem "User not found, or the user does not hold the ticket"
+// @Failure 503 {object} problem.Problem "EventManager unavailable"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/tickets/{code}/pdf [get]
+func (h *GinUserHandler) GetUserETicketPDF(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	ticket, err := h.usecase.GetUserETicket(c.Request.Context(), token, id, c.Param("code"))
+	if handleError(c, err) {
+		return
+	}
+
+	writeETicketPDF(c, "ticket-"+ticket.Code, []*domain.ETicket{ticket})
+}
+
+// GetOrderETicketsPDF godoc
+// @Summary Download the tickets of an order as a PDF
+// @Description Render the e-tickets of a completed order, one per page. Tickets the user no longer holds are left out.
+// @Tags users
+// @Produce application/pdf
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param order_id path string true "Order ID"
+// @Success 200 {file} file "E-tickets of the order"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - not allowed to view this user"
+// @Failure 404 {object} problem.Problem "User or completed order not found, or none of its tickets is still held"
+// @Failure 503 {object} problem.Problem "EventManager unavailable"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/orders/{order_id}/tickets/pdf [get]
+func (h *GinUserHandler) GetOrderETicketsPDF(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	orderID := c.Param("order_id")
+	tickets, err := h.usecase.GetOrderETickets(c.Request.Context(), token, id, orderID)
+	if handleError(c, err) {
+		return
+	}
+
+	writeETicketPDF(c, fmt.Sprintf("order-%s-tickets", orderID), tickets)
+}
+
+// writeETicketPDF renders the whole document before answering, so a
+// failure can still be reported as a problem.
+func writeETicketPDF(c *gin.Context, filename string, tickets []*domain.ETicket) {
+	var pdf bytes.Buffer
+	if err := document.WriteETicketPDF(&pdf, filename, tickets); err != nil {
+		handleError(c, &domain.InternalError{Msg: "failed to render tickets", Err: err})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+	c.Data(http.StatusOK, document.ContentTypePDF, pdf.Bytes())
 }
 
 // GetCustomersByEventID godoc
 // @Summary Get customers who purchased tickets for an event
-// @Description Retrieve all customers who have purchased tickets for a specific event (owner only)
+// @Description Retrieve the customers who have purchased tickets for a specific event, with how many tickets each bought (owner only)
 // @Tags customers
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Param event_id path int true "Event ID"
-// @Success 200 {object} httpdto.HttpResponseUserList "List of customers"
-// @Failure 400 {object} map[string]string "Invalid event ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - only event owners can view customers"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param per_page query int false "Customers per page (default: 20, max: 100)"
+// @Param order_by query string false "Sort order" Enums(name_asc, name_desc, email_asc, email_desc, purchased_at_asc, purchased_at_desc)
+// @Param cursor query string false "Opaque cursor taken from the next/prev links"
+// @Success 200 {object} httpdto.HttpResponseCustomerList "List of customers"
+// @Failure 400 {object} problem.Problem "Invalid event ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - only event owners can view customers"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /events/{event_id}/customers [get]
 func (h *GinUserHandler) GetCustomersByEventID(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -318,28 +424,45 @@ func (h *GinUserHandler) GetCustomersByEventID(c *gin.Context) {
 		return
 	}
 
-	customers, err := h.usecase.GetCustomersByEventID(c.Request.Context(), token, eventID)
+	var query httpdto.HttpFilterCustomers
+	if err := middleware.StrictBindQuery(c, &query, []string{"per_page", "cursor", "order_by"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := query.ToCustomerFilter()
+	filter.Default()
+	if err := filter.Validate(); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	customers, pageInfo, err := h.usecase.GetCustomersByEventID(c.Request.Context(), token, eventID, filter)
 	if handleError(c, err) {
 		return
 	}
 
-	resp := httpdto.ToHttpResponseUserList(customers, h.serviceURLs)
+	selfPath := fmt.Sprintf("/events/%d/customers", eventID)
+	resp := httpdto.ToHttpResponseCustomerList(customers, selfPath, filter, pageInfo, h.serviceURLs)
 	c.JSON(http.StatusOK, resp)
 }
 
 // GetCustomersByPacketID godoc
 // @Summary Get customers who purchased tickets for a packet
-// @Description Retrieve all customers who have purchased tickets for a specific packet (owner only)
+// @Description Retrieve the customers who have purchased tickets for a specific packet, with how many tickets each bought (owner only)
 // @Tags customers
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Param packet_id path int true "Packet ID"
-// @Success 200 {object} httpdto.HttpResponseUserList "List of customers"
-// @Failure 400 {object} map[string]string "Invalid packet ID"
-// @Failure 401 {object} map[string]string "Unauthorized - missing or invalid token"
-// @Failure 403 {object} map[string]string "Forbidden - only packet owners can view customers"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param per_page query int false "Customers per page (default: 20, max: 100)"
+// @Param order_by query string false "Sort order" Enums(name_asc, name_desc, email_asc, email_desc, purchased_at_asc, purchased_at_desc)
+// @Param cursor query string false "Opaque cursor taken from the next/prev links"
+// @Success 200 {object} httpdto.HttpResponseCustomerList "List of customers"
+// @Failure 400 {object} problem.Problem "Invalid packet ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - only packet owners can view customers"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /packets/{packet_id}/customers [get]
 func (h *GinUserHandler) GetCustomersByPacketID(c *gin.Context) {
 	token, ok := requireAuth(c)
@@ -353,11 +476,138 @@ func (h *GinUserHandler) GetCustomersByPacketID(c *gin.Context) {
 		return
 	}
 
-	customers, err := h.usecase.GetCustomersByPacketID(c.Request.Context(), token, packetID)
+	var query httpdto.HttpFilterCustomers
+	if err := middleware.StrictBindQuery(c, &query, []string{"per_page", "cursor", "order_by"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := query.ToCustomerFilter()
+	filter.Default()
+	if err := filter.Validate(); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	customers, pageInfo, err := h.usecase.GetCustomersByPacketID(c.Request.Context(), token, packetID, filter)
 	if handleError(c, err) {
 		return
 	}
 
-	resp := httpdto.ToHttpResponseUserList(customers, h.serviceURLs)
+	selfPath := fmt.Sprintf("/packets/%d/customers", packetID)
+	resp := httpdto.ToHttpResponseCustomerList(customers, selfPath, filter, pageInfo, h.serviceURLs)
 	c.JSON(http.StatusOK, resp)
 }
+
+// ExportCustomersByEventID godoc
+// @Summary Export the customers of an event
+// @Description Download every customer of an event as CSV or XLSX. Private first and last names are masked as in the listing (owner only)
+// @Tags customers
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param Authorization header string true "Bearer token"
+// @Param event_id path int true "Event ID"
+// @Param format query string false "File format (default: csv)" Enums(csv, xlsx)
+// @Param order_by query string false "Sort order" Enums(name_asc, name_desc, email_asc, email_desc, purchased_at_asc, purchased_at_desc)
+// @Success 200 {file} file "Customer export"
+// @Failure 400 {object} problem.Problem "Invalid event ID, format or order"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - only event owners can view customers"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /events/{event_id}/customers/export [get]
+func (h *GinUserHandler) ExportCustomersByEventID(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	eventID, err := middleware.ParseIDParam(c, "event_id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpExportCustomers
+	if err := middleware.StrictBindQuery(c, &query, []string{"format", "order_by"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	exportCustomers(c, &query, fmt.Sprintf("event-%d-customers", eventID), func(fn func(*domain.Customer) error) error {
+		return h.usecase.ExportCustomersByEventID(c.Request.Context(), token, eventID, query.OrderBy, fn)
+	})
+}
+
+// ExportCustomersByPacketID godoc
+// @Summary Export the customers of a packet
+// @Description Download every customer of a packet as CSV or XLSX. Private first and last names are masked as in the listing (owner only)
+// @Tags customers
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param Authorization header string true "Bearer token"
+// @Param packet_id path int true "Packet ID"
+// @Param format query string false "File format (default: csv)" Enums(csv, xlsx)
+// @Param order_by query string false "Sort order" Enums(name_asc, name_desc, email_asc, email_desc, purchased_at_asc, purchased_at_desc)
+// @Success 200 {file} file "Customer export"
+// @Failure 400 {object} problem.Problem "Invalid packet ID, format or order"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - only packet owners can view customers"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /packets/{packet_id}/customers/export [get]
+func (h *GinUserHandler) ExportCustomersByPacketID(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	packetID, err := middleware.ParseIDParam(c, "packet_id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpExportCustomers
+	if err := middleware.StrictBindQuery(c, &query, []string{"format", "order_by"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	exportCustomers(c, &query, fmt.Sprintf("packet-%d-customers", packetID), func(fn func(*domain.Customer) error) error {
+		return h.usecase.ExportCustomersByPacketID(c.Request.Context(), token, packetID, query.OrderBy, fn)
+	})
+}
+
+// exportCustomers streams the customers run hands out into the response.
+// Errors are reported as a problem as long as nothing has been sent yet;
+// after that the download can only be cut short.
+func exportCustomers(c *gin.Context, query *httpdto.HttpExportCustomers, filename string, run func(fn func(*domain.Customer) error) error) {
+	format := export.FormatCSV
+	if query.Format != nil {
+		format = *query.Format
+	}
+
+	writer, err := export.NewCustomerWriter(format, c.Writer)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", writer.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+
+	err = run(writer.Write)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Disposition")
+		handleError(c, err)
+		return
+	}
+	log.Printf("customer export %s interrupted: %v", filename, err)
+	c.Abort()
+}
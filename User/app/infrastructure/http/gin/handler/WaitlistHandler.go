@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"userService/application/domain"
+	"userService/application/usecase"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/gin/middleware"
+	"userService/infrastructure/http/httpdto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GinWaitlistHandler struct {
+	usecase     usecase.WaitlistUsecase
+	serviceURLs *config.ServiceURLs
+}
+
+func NewGinWaitlistHandler(usecase usecase.WaitlistUsecase, serviceURLs *config.ServiceURLs) *GinWaitlistHandler {
+	return &GinWaitlistHandler{
+		usecase:     usecase,
+		serviceURLs: serviceURLs,
+	}
+}
+
+// JoinWaitlist godoc
+// @Summary Join the waitlist of an event or packet
+// @Description Line up for a sold out event or packet. When seats free up, through refunds, cancellations or a capacity increase, users are offered one in the order they joined and have an hour to buy it before it goes to the next one. When seats are free already, the offer comes right away.
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param entry body httpdto.HttpJoinWaitlist true "Event or packet; exactly one of event_id and packet_id"
+// @Success 201 {object} httpdto.HttpResponseWaitlistEntry "Joined the waitlist"
+// @Failure 400 {object} problem.Problem "Invalid request body or user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User, event or packet not found"
+// @Failure 409 {object} problem.Problem "Already on this waitlist, or no capacity configured (code CAPACITY_NOT_CONFIGURED)"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 503 {object} problem.Problem "EventManager is failing and calls to it are short-circuited"
+// @Router /users/{id}/waitlist [post]
+func (h *GinWaitlistHandler) JoinWaitlist(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var req httpdto.HttpJoinWaitlist
+	if err := middleware.StrictBindJSON(c, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entry, err := h.usecase.JoinWaitlist(c.Request.Context(), token, userID, req.ToWaitlistTarget())
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, httpdto.ToHttpResponseWaitlistEntry(entry, h.serviceURLs))
+}
+
+// GetEntries godoc
+// @Summary List the waitlist entries of a user
+// @Description List the events and packets the user waits for or was offered a seat of, newest first. Waiting entries carry their position in line.
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param status query string false "Only entries in this status" Enums(waiting, offered, accepted, expired, left)
+// @Success 200 {object} httpdto.HttpResponseWaitlist "Waitlist entries of the user"
+// @Failure 400 {object} problem.Problem "Invalid user ID or filter"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/waitlist [get]
+func (h *GinWaitlistHandler) GetEntries(c *gin.Context) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	var query httpdto.HttpFilterWaitlist
+	if err := middleware.StrictBindQuery(c, &query, []string{"status"}); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entries, err := h.usecase.GetEntries(c.Request.Context(), token, userID, query.Status)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWaitlist(userID, entries, query.Status, h.serviceURLs))
+}
+
+// GetEntry godoc
+// @Summary Get a waitlist entry
+// @Description Get an entry of the user, with their position in line while waiting and the offer expiry once offered
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param entry_id path string true "Waitlist entry ID"
+// @Success 200 {object} httpdto.HttpResponseWaitlistEntry "Waitlist entry"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or waitlist entry not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/waitlist/{entry_id} [get]
+func (h *GinWaitlistHandler) GetEntry(c *gin.Context) {
+	h.answerEntry(c, h.usecase.GetEntry)
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave a waitlist
+// @Description Step out of line, or give back an offered seat so it goes to the next user
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "User ID"
+// @Param entry_id path string true "Waitlist entry ID"
+// @Success 200 {object} httpdto.HttpResponseWaitlistEntry "Left the waitlist"
+// @Failure 400 {object} problem.Problem "Invalid user ID"
+// @Failure 401 {object} problem.Problem "Unauthorized - missing or invalid token"
+// @Failure 403 {object} problem.Problem "Forbidden - token does not belong to this user"
+// @Failure 404 {object} problem.Problem "User or waitlist entry not found"
+// @Failure 409 {object} problem.Problem "Entry no longer waiting or offered"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /users/{id}/waitlist/{entry_id}/leave [post]
+func (h *GinWaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	h.answerEntry(c, h.usecase.LeaveWaitlist)
+}
+
+// answerEntry runs one of the calls addressing a single entry of the user in
+// the path.
+func (h *GinWaitlistHandler) answerEntry(c *gin.Context, run func(ctx context.Context, token string, userID int, entryID string) (*domain.WaitlistEntry, error)) {
+	token, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	userID, err := middleware.ParseIDParam(c, "id")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entry, err := run(c.Request.Context(), token, userID, c.Param("entry_id"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, httpdto.ToHttpResponseWaitlistEntry(entry, h.serviceURLs))
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"userService/application/repository"
+	"userService/application/service"
+	"userService/infrastructure/http/problem"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"shared/idempotency"
+)
+
+// Idempotency stores and replays the responses of requests sent with an
+// Idempotency-Key header, per authenticated user. Requests without the
+// header, or with a token that does not authenticate, pass through
+// untouched.
+func Idempotency(repo repository.IdempotencyRepository, authNService service.AuthenticationService) gin.HandlerFunc {
+	return idempotency.Middleware(repo, func(c *gin.Context) (string, bool) {
+		token := ExtractToken(c)
+		if token == "" {
+			token = strings.TrimSpace(c.GetHeader("Authorization"))
+		}
+		identity, err := authNService.WhoIsUser(c.Request.Context(), token)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatUint(uint64(identity.UserID), 10), true
+	}, problem.WriteError)
+}
@@ -62,12 +62,14 @@ func StrictBindJSON(c *gin.Context, obj interface{}) error {
 			var validationErrs validator.ValidationErrors
 			if errors.As(err, &validationErrs) {
 
+				fieldErrors := make([]*domain.ValidationError, 0, len(validationErrs))
 				for _, fieldErr := range validationErrs {
-					return &domain.ValidationError{
+					fieldErrors = append(fieldErrors, &domain.ValidationError{
 						Field:  fieldErr.Field(),
 						Reason: fmt.Sprintf("validation failed on '%s' tag", fieldErr.Tag()),
-					}
+					})
 				}
+				return &domain.ValidationErrors{Errors: fieldErrors}
 			}
 			return &domain.ValidationError{Reason: err.Error()}
 		}
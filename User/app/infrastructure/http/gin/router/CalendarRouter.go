@@ -0,0 +1,13 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCalendarRoutes(router *gin.RouterGroup, handler *handler.GinCalendarHandler) {
+	router.GET("/users/:id/calendar", handler.GetCalendarFeed)
+	router.POST("/users/:id/calendar/token", handler.RotateCalendarFeed)
+	router.GET("/users/:id/calendar.ics", handler.GetCalendar)
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPaymentRoutes(router *gin.RouterGroup, handler *handler.GinPaymentHandler) {
+	router.GET("/users/:id/orders", handler.GetUserOrders)
+	router.GET("/users/:id/orders/:order_id", handler.GetUserOrder)
+
+	router.POST("/payments/webhook", handler.PaymentWebhook)
+}
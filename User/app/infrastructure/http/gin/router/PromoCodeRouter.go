@@ -0,0 +1,16 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPromoCodeRoutes(router *gin.RouterGroup, handler *handler.GinPromoCodeHandler) {
+	router.POST("/promo-codes", handler.CreatePromoCode)
+	router.GET("/promo-codes", handler.GetPromoCodes)
+	router.GET("/promo-codes/:promo_id", handler.GetPromoCode)
+	router.PATCH("/promo-codes/:promo_id", handler.UpdatePromoCode)
+	router.DELETE("/promo-codes/:promo_id", handler.DeletePromoCode)
+	router.GET("/promo-codes/:promo_id/usage", handler.GetPromoCodeUsage)
+}
@@ -0,0 +1,13 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterReceiptRoutes(router *gin.RouterGroup, handler *handler.GinReceiptHandler) {
+	router.GET("/users/:id/receipts", handler.GetUserReceipts)
+	router.GET("/users/:id/receipts/:receipt_id", handler.GetUserReceipt)
+	router.GET("/users/:id/orders/:order_id/receipt", handler.GetOrderReceipt)
+}
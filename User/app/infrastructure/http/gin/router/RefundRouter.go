@@ -0,0 +1,18 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRefundRoutes(router *gin.RouterGroup, handler *handler.GinRefundHandler) {
+	router.POST("/users/:id/refunds", handler.RequestRefund)
+	router.GET("/users/:id/refunds", handler.GetUserRefunds)
+	router.GET("/users/:id/refunds/:refund_id", handler.GetUserRefund)
+
+	router.GET("/refunds", handler.GetRefunds)
+	router.GET("/refunds/:refund_id", handler.GetRefund)
+	router.POST("/refunds/:refund_id/approve", handler.ApproveRefund)
+	router.POST("/refunds/:refund_id/deny", handler.DenyRefund)
+}
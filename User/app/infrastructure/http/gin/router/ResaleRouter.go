@@ -0,0 +1,17 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterResaleRoutes(router *gin.RouterGroup, handler *handler.GinResaleHandler) {
+	router.GET("/resale/listings", handler.GetListings)
+	router.GET("/resale/listings/:listing_id", handler.GetListing)
+
+	router.POST("/users/:id/resale-listings", handler.CreateListing)
+	router.GET("/users/:id/resale-listings", handler.GetUserListings)
+	router.POST("/users/:id/resale-listings/:listing_id/cancel", handler.CancelListing)
+	router.POST("/users/:id/resale-purchases", handler.PurchaseListing)
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTicketTransferRoutes(router *gin.RouterGroup, handler *handler.GinTicketTransferHandler) {
+	router.POST("/users/:id/transfers", handler.InitiateTransfer)
+	router.GET("/users/:id/transfers", handler.GetTransfers)
+	router.GET("/users/:id/transfers/:transfer_id", handler.GetTransfer)
+	router.POST("/users/:id/transfers/:transfer_id/accept", handler.AcceptTransfer)
+	router.POST("/users/:id/transfers/:transfer_id/decline", handler.DeclineTransfer)
+	router.POST("/users/:id/transfers/:transfer_id/cancel", handler.CancelTransfer)
+
+	router.GET("/users/:id/ticket-audit", handler.GetTicketAudit)
+}
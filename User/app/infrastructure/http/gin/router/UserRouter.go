@@ -6,11 +6,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterUserRoutes(router *gin.RouterGroup, handler *handler.GinUserHandler) {
+// idempotency guards ticket purchases, so retried requests carrying an
+// Idempotency-Key do not buy the same ticket twice.
+func RegisterUserRoutes(router *gin.RouterGroup, handler *handler.GinUserHandler, idempotency gin.HandlerFunc) {
 	router.POST("/users", handler.CreateUser)
 	router.POST("/users/", handler.CreateUser)
 
 	router.GET("/users/:id", handler.GetUserByID)
+	router.GET("/users/:id/tickets", handler.GetUserTickets)
+	router.GET("/users/:id/tickets/:code/pdf", handler.GetUserETicketPDF)
+	router.GET("/users/:id/orders/:order_id/tickets/pdf", handler.GetOrderETicketsPDF)
 
 	router.PATCH("/users/:id", handler.UpdateUser)
 
@@ -18,6 +23,8 @@ func RegisterUserRoutes(router *gin.RouterGroup, handler *handler.GinUserHandler
 
 	router.GET("/events/:event_id/customers", handler.GetCustomersByEventID)
 	router.GET("/packets/:packet_id/customers", handler.GetCustomersByPacketID)
+	router.GET("/events/:event_id/customers/export", handler.ExportCustomersByEventID)
+	router.GET("/packets/:packet_id/customers/export", handler.ExportCustomersByPacketID)
 
-	router.POST("/clients/:user_id/tickets", handler.CreateTicketForUser)
+	router.POST("/clients/:user_id/tickets", idempotency, handler.CreateTicketForUser)
 }
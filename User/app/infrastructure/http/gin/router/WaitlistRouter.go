@@ -0,0 +1,14 @@
+package router
+
+import (
+	"userService/infrastructure/http/gin/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWaitlistRoutes(router *gin.RouterGroup, handler *handler.GinWaitlistHandler) {
+	router.POST("/users/:id/waitlist", handler.JoinWaitlist)
+	router.GET("/users/:id/waitlist", handler.GetEntries)
+	router.GET("/users/:id/waitlist/:entry_id", handler.GetEntry)
+	router.POST("/users/:id/waitlist/:entry_id/leave", handler.LeaveWaitlist)
+}
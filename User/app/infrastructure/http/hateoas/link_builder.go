@@ -49,3 +49,16 @@ func BuildRelatedLink(url string, rel string, method string, title string) http.
 		Title:  title,
 	}
 }
+
+func BuildPaginationLink(baseURL string, resourcePath string, queryParams string, rel string, title string) http.Link {
+	href := fmt.Sprintf("%s%s", baseURL, resourcePath)
+	if queryParams != "" {
+		href = fmt.Sprintf("%s?%s", href, queryParams)
+	}
+	return http.Link{
+		Href:   href,
+		Rel:    rel,
+		Method: "GET",
+		Title:  title,
+	}
+}
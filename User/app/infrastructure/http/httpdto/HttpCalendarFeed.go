@@ -0,0 +1,57 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type HttpFilterCalendar struct {
+	Token string `json:"token" form:"token"`
+}
+
+// httpResponseCalendarFeed carries the feed URL to subscribe to; anyone
+// holding it can read the calendar until the token is rotated.
+type httpResponseCalendarFeed struct {
+	UserID    int                  `json:"user_id"`
+	URL       string               `json:"url"`
+	CreatedAt time.Time            `json:"created_at"`
+	Links     map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseCalendarFeed struct {
+	Calendar *httpResponseCalendarFeed `json:"calendar"`
+}
+
+func ToHttpResponseCalendarFeed(feed *domain.CalendarFeed, serviceURLs *config.ServiceURLs) *HttpResponseCalendarFeed {
+	selfPath := fmt.Sprintf("/users/%d/calendar", feed.UserID)
+	feedURL := fmt.Sprintf("%s/users/%d/calendar.ics?token=%s", serviceURLs.UserManager, feed.UserID, url.QueryEscape(feed.Token))
+
+	return &HttpResponseCalendarFeed{
+		Calendar: &httpResponseCalendarFeed{
+			UserID:    feed.UserID,
+			URL:       feedURL,
+			CreatedAt: feed.CreatedAt,
+			Links: map[string]http.Link{
+				"self": hateoas.BuildSelfLink(serviceURLs.UserManager, selfPath),
+				"feed": hateoas.BuildRelatedLink(feedURL, "feed", "GET", "Subscribe to this calendar"),
+				"rotate": hateoas.BuildRelatedLink(
+					fmt.Sprintf("%s%s/token", serviceURLs.UserManager, selfPath),
+					"rotate",
+					"POST",
+					"Replace the feed URL, cutting off the current one",
+				),
+				"user": hateoas.BuildRelatedLink(
+					fmt.Sprintf("%s/users/%d", serviceURLs.UserManager, feed.UserID),
+					"user",
+					"GET",
+					"Get the owner of this calendar",
+				),
+			},
+		},
+	}
+}
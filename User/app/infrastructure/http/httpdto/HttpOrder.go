@@ -0,0 +1,156 @@
+package httpdto
+
+import (
+	"fmt"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+// HttpPayment is the payment of an order; amounts are in bani.
+type HttpPayment struct {
+	ID            string     `json:"id"`
+	Status        string     `json:"status"`
+	Amount        int        `json:"amount"`
+	Currency      string     `json:"currency"`
+	Refunded      int        `json:"refunded"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CapturedAt    *time.Time `json:"captured_at,omitempty"`
+}
+
+func ToHttpPayment(payment *domain.Payment) *HttpPayment {
+	if payment == nil {
+		return nil
+	}
+	return &HttpPayment{
+		ID:            payment.ID,
+		Status:        payment.Status,
+		Amount:        payment.Amount,
+		Currency:      payment.Currency,
+		Refunded:      payment.Refunded,
+		FailureReason: payment.FailureReason,
+		CreatedAt:     payment.CreatedAt,
+		CapturedAt:    payment.CapturedAt,
+	}
+}
+
+type httpResponseOrder struct {
+	ID            string               `json:"id"`
+	EventID       *int                 `json:"event_id,omitempty"`
+	PacketID      *int                 `json:"packet_id,omitempty"`
+	Quantity      int                  `json:"quantity"`
+	UnitPrice     *int                 `json:"unit_price,omitempty"`
+	Discount      int                  `json:"discount"`
+	Total         *int                 `json:"total,omitempty"`
+	PromoCode     *string              `json:"promo_code,omitempty"`
+	TicketCodes   []string             `json:"ticket_codes"`
+	Status        string               `json:"status"`
+	FailureReason string               `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	CompletedAt   *time.Time           `json:"completed_at,omitempty"`
+	Links         map[string]http.Link `json:"_links"`
+}
+
+// HttpResponseOrder carries the payment of the order, left out when there
+// was nothing to pay.
+type HttpResponseOrder struct {
+	Order   *httpResponseOrder `json:"order"`
+	Payment *HttpPayment       `json:"payment,omitempty"`
+}
+
+type HttpResponseOrderList struct {
+	Orders []*httpResponseOrder `json:"orders"`
+	Links  map[string]http.Link `json:"_links"`
+}
+
+type HttpResponsePaymentWebhook struct {
+	Payment *HttpPayment `json:"payment"`
+}
+
+func toHttpOrder(order *domain.Order, serviceURLs *config.ServiceURLs) *httpResponseOrder {
+	links := map[string]http.Link{
+		"self": hateoas.BuildSelfLink(serviceURLs.UserManager, fmt.Sprintf("/users/%d/orders/%s", order.UserID, order.ID)),
+		"tickets": hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/users/%d/tickets", serviceURLs.UserManager, order.UserID),
+			"tickets",
+			"GET",
+			"Get the tickets of the buyer",
+		),
+	}
+	if order.Status == domain.OrderCompleted {
+		links["receipt"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/users/%d/orders/%s/receipt", serviceURLs.UserManager, order.UserID, order.ID),
+			"receipt",
+			"GET",
+			"Get the receipt of this order",
+		)
+		links["tickets-pdf"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/users/%d/orders/%s/tickets/pdf", serviceURLs.UserManager, order.UserID, order.ID),
+			"tickets-pdf",
+			"GET",
+			"Download the tickets of this order as a PDF",
+		)
+	}
+	if order.EventID != nil {
+		links["event"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/events/%d", serviceURLs.EventManager, *order.EventID),
+			"event",
+			"GET",
+			"Get the event of this order",
+		)
+	}
+	if order.PacketID != nil {
+		links["packet"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/event-packets/%d", serviceURLs.EventManager, *order.PacketID),
+			"packet",
+			"GET",
+			"Get the packet of this order",
+		)
+	}
+
+	ticketCodes := order.TicketCodes
+	if ticketCodes == nil {
+		ticketCodes = []string{}
+	}
+	return &httpResponseOrder{
+		ID:            order.ID,
+		EventID:       order.EventID,
+		PacketID:      order.PacketID,
+		Quantity:      order.Quantity,
+		UnitPrice:     order.UnitPrice,
+		Discount:      order.Discount,
+		Total:         order.Total,
+		PromoCode:     order.PromoCode,
+		TicketCodes:   ticketCodes,
+		Status:        order.Status,
+		FailureReason: order.FailureReason,
+		CreatedAt:     order.CreatedAt,
+		CompletedAt:   order.CompletedAt,
+		Links:         links,
+	}
+}
+
+func ToHttpResponseOrder(order *domain.Order, payment *domain.Payment, serviceURLs *config.ServiceURLs) *HttpResponseOrder {
+	return &HttpResponseOrder{
+		Order:   toHttpOrder(order, serviceURLs),
+		Payment: ToHttpPayment(payment),
+	}
+}
+
+// ToHttpResponseOrderList lists the orders of a user, failed ones included.
+func ToHttpResponseOrderList(userID int, orders []*domain.Order, serviceURLs *config.ServiceURLs) *HttpResponseOrderList {
+	httpOrders := make([]*httpResponseOrder, 0, len(orders))
+	for _, order := range orders {
+		httpOrders = append(httpOrders, toHttpOrder(order, serviceURLs))
+	}
+
+	return &HttpResponseOrderList{
+		Orders: httpOrders,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.UserManager, fmt.Sprintf("/users/%d/orders", userID)),
+		},
+	}
+}
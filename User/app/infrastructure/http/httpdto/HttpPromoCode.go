@@ -0,0 +1,249 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+// HttpCreatePromoCode takes discount_value as a percent for percent
+// discounts and in bani for fixed ones.
+type HttpCreatePromoCode struct {
+	Code           string     `json:"code" binding:"required,min=3,max=32"`
+	EventIDs       []int      `json:"event_ids" binding:"omitempty,max=100,dive,min=1"`
+	PacketIDs      []int      `json:"packet_ids" binding:"omitempty,max=100,dive,min=1"`
+	DiscountType   string     `json:"discount_type" binding:"required,oneof=percent fixed"`
+	DiscountValue  int        `json:"discount_value" binding:"required,min=1"`
+	MaxRedemptions *int       `json:"max_redemptions" binding:"omitempty,min=1"`
+	MaxPerUser     *int       `json:"max_per_user" binding:"omitempty,min=1"`
+	MinQuantity    *int       `json:"min_quantity" binding:"omitempty,min=1"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	Active         *bool      `json:"active"`
+}
+
+// ToPromoCode makes new codes active unless told otherwise.
+func (req *HttpCreatePromoCode) ToPromoCode() *domain.PromoCode {
+	promo := &domain.PromoCode{
+		Code:           req.Code,
+		EventIDs:       req.EventIDs,
+		PacketIDs:      req.PacketIDs,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValue,
+		MaxRedemptions: req.MaxRedemptions,
+		MaxPerUser:     req.MaxPerUser,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		Active:         req.Active == nil || *req.Active,
+	}
+	if req.MinQuantity != nil {
+		promo.MinQuantity = *req.MinQuantity
+	}
+	return promo
+}
+
+type HttpUpdatePromoCode struct {
+	DiscountValue  *int       `json:"discount_value" binding:"omitempty,min=1"`
+	MaxRedemptions *int       `json:"max_redemptions" binding:"omitempty,min=1"`
+	MaxPerUser     *int       `json:"max_per_user" binding:"omitempty,min=1"`
+	MinQuantity    *int       `json:"min_quantity" binding:"omitempty,min=1"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	Active         *bool      `json:"active"`
+}
+
+func (req *HttpUpdatePromoCode) ToUpdateMap() map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	if req.DiscountValue != nil {
+		updates["discount_value"] = *req.DiscountValue
+	}
+	if req.MaxRedemptions != nil {
+		updates["max_redemptions"] = *req.MaxRedemptions
+	}
+	if req.MaxPerUser != nil {
+		updates["max_per_user"] = *req.MaxPerUser
+	}
+	if req.MinQuantity != nil {
+		updates["min_quantity"] = *req.MinQuantity
+	}
+	if req.ValidFrom != nil {
+		updates["valid_from"] = req.ValidFrom.UTC()
+	}
+	if req.ValidUntil != nil {
+		updates["valid_until"] = req.ValidUntil.UTC()
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+
+	return updates
+}
+
+type HttpFilterPromoCodes struct {
+	OwnerID *int `json:"owner_id,omitempty" form:"owner_id"`
+}
+
+type httpResponsePromoCode struct {
+	ID             string               `json:"id"`
+	Code           string               `json:"code"`
+	OwnerID        int                  `json:"owner_id"`
+	EventIDs       []int                `json:"event_ids"`
+	PacketIDs      []int                `json:"packet_ids"`
+	DiscountType   string               `json:"discount_type"`
+	DiscountValue  int                  `json:"discount_value"`
+	MaxRedemptions *int                 `json:"max_redemptions,omitempty"`
+	MaxPerUser     *int                 `json:"max_per_user,omitempty"`
+	MinQuantity    int                  `json:"min_quantity"`
+	ValidFrom      *time.Time           `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time           `json:"valid_until,omitempty"`
+	Active         bool                 `json:"active"`
+	Redemptions    int                  `json:"redemptions"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	Links          map[string]http.Link `json:"_links"`
+}
+
+type HttpResponsePromoCode struct {
+	PromoCode *httpResponsePromoCode `json:"promo_code"`
+}
+
+type HttpResponsePromoCodeList struct {
+	PromoCodes []*httpResponsePromoCode `json:"promo_codes"`
+	Links      map[string]http.Link     `json:"_links"`
+}
+
+func toHttpPromoCode(promo *domain.PromoCode, serviceURLs *config.ServiceURLs) *httpResponsePromoCode {
+	resourcePath := "/promo-codes/" + promo.ID
+	eventIDs, packetIDs := promo.EventIDs, promo.PacketIDs
+	if eventIDs == nil {
+		eventIDs = []int{}
+	}
+	if packetIDs == nil {
+		packetIDs = []int{}
+	}
+
+	return &httpResponsePromoCode{
+		ID:             promo.ID,
+		Code:           promo.Code,
+		OwnerID:        promo.OwnerID,
+		EventIDs:       eventIDs,
+		PacketIDs:      packetIDs,
+		DiscountType:   promo.DiscountType,
+		DiscountValue:  promo.DiscountValue,
+		MaxRedemptions: promo.MaxRedemptions,
+		MaxPerUser:     promo.MaxPerUser,
+		MinQuantity:    promo.MinQuantity,
+		ValidFrom:      promo.ValidFrom,
+		ValidUntil:     promo.ValidUntil,
+		Active:         promo.Active,
+		Redemptions:    promo.Redemptions,
+		CreatedAt:      promo.CreatedAt,
+		UpdatedAt:      promo.UpdatedAt,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.UserManager, resourcePath),
+			"update": hateoas.BuildRelatedLink(
+				serviceURLs.UserManager+resourcePath,
+				"update",
+				"PATCH",
+				"Change the terms of this promo code",
+			),
+			"usage": hateoas.BuildRelatedLink(
+				serviceURLs.UserManager+resourcePath+"/usage",
+				"usage",
+				"GET",
+				"See how this promo code was used",
+			),
+		},
+	}
+}
+
+func ToHttpResponsePromoCode(promo *domain.PromoCode, serviceURLs *config.ServiceURLs) *HttpResponsePromoCode {
+	return &HttpResponsePromoCode{
+		PromoCode: toHttpPromoCode(promo, serviceURLs),
+	}
+}
+
+func ToHttpResponsePromoCodeList(promos []*domain.PromoCode, ownerID *int, serviceURLs *config.ServiceURLs) *HttpResponsePromoCodeList {
+	httpPromos := make([]*httpResponsePromoCode, 0, len(promos))
+	for _, promo := range promos {
+		httpPromos = append(httpPromos, toHttpPromoCode(promo, serviceURLs))
+	}
+
+	query := url.Values{}
+	if ownerID != nil {
+		query.Add("owner_id", strconv.Itoa(*ownerID))
+	}
+
+	return &HttpResponsePromoCodeList{
+		PromoCodes: httpPromos,
+		Links: map[string]http.Link{
+			"self":   hateoas.BuildPaginationLink(serviceURLs.UserManager, "/promo-codes", query.Encode(), "self", "Current listing"),
+			"create": hateoas.BuildCreateLink(serviceURLs.UserManager, "/promo-codes"),
+		},
+	}
+}
+
+type httpResponsePromoUserUsage struct {
+	UserID      int                  `json:"user_id"`
+	Redemptions int                  `json:"redemptions"`
+	Tickets     int                  `json:"tickets"`
+	Discount    int                  `json:"discount"`
+	LastUsedAt  time.Time            `json:"last_used_at"`
+	Links       map[string]http.Link `json:"_links"`
+}
+
+// HttpResponsePromoCodeUsage sums up the completed purchases made with a
+// code; discounts are in bani.
+type HttpResponsePromoCodeUsage struct {
+	PromoCode   *httpResponsePromoCode        `json:"promo_code"`
+	Redemptions int                           `json:"redemptions"`
+	Tickets     int                           `json:"tickets"`
+	Discount    int                           `json:"discount"`
+	Users       []*httpResponsePromoUserUsage `json:"users"`
+	Links       map[string]http.Link          `json:"_links"`
+}
+
+func ToHttpResponsePromoCodeUsage(usage *domain.PromoCodeUsage, serviceURLs *config.ServiceURLs) *HttpResponsePromoCodeUsage {
+	users := make([]*httpResponsePromoUserUsage, 0, len(usage.Users))
+	for _, user := range usage.Users {
+		users = append(users, &httpResponsePromoUserUsage{
+			UserID:      user.UserID,
+			Redemptions: user.Redemptions,
+			Tickets:     user.Tickets,
+			Discount:    user.Discount,
+			LastUsedAt:  user.LastUsedAt,
+			Links: map[string]http.Link{
+				"user": hateoas.BuildRelatedLink(
+					fmt.Sprintf("%s/users/%d", serviceURLs.UserManager, user.UserID),
+					"user",
+					"GET",
+					"Get the user",
+				),
+			},
+		})
+	}
+
+	resourcePath := "/promo-codes/" + usage.PromoCode.ID
+	return &HttpResponsePromoCodeUsage{
+		PromoCode:   toHttpPromoCode(usage.PromoCode, serviceURLs),
+		Redemptions: usage.Redemptions,
+		Tickets:     usage.Tickets,
+		Discount:    usage.Discount,
+		Users:       users,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.UserManager, resourcePath+"/usage"),
+			"promo_code": hateoas.BuildRelatedLink(
+				serviceURLs.UserManager+resourcePath,
+				"promo_code",
+				"GET",
+				"Get the promo code",
+			),
+		},
+	}
+}
@@ -0,0 +1,150 @@
+package httpdto
+
+import (
+	"fmt"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type HttpFilterReceipt struct {
+	Format *string `json:"format,omitempty" form:"format"`
+}
+
+type httpReceiptBuyer struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type httpReceiptItem struct {
+	EventID  *int       `json:"event_id,omitempty"`
+	PacketID *int       `json:"packet_id,omitempty"`
+	Name     string     `json:"name"`
+	Location *string    `json:"location,omitempty"`
+	City     *string    `json:"city,omitempty"`
+	Country  *string    `json:"country,omitempty"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+type httpReceiptLine struct {
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int    `json:"unit_price"`
+	Amount      int    `json:"amount"`
+}
+
+// httpResponseReceipt has its amounts in bani; tax_amount is the VAT
+// included in total.
+type httpResponseReceipt struct {
+	ID          string               `json:"id"`
+	Number      string               `json:"number"`
+	Series      string               `json:"series"`
+	Sequence    int                  `json:"sequence"`
+	OrganizerID int                  `json:"organizer_id"`
+	OrderID     string               `json:"order_id"`
+	PaymentID   *string              `json:"payment_id,omitempty"`
+	Buyer       httpReceiptBuyer     `json:"buyer"`
+	Item        httpReceiptItem      `json:"item"`
+	Lines       []httpReceiptLine    `json:"lines"`
+	Subtotal    int                  `json:"subtotal"`
+	Discount    int                  `json:"discount"`
+	Total       int                  `json:"total"`
+	TaxRate     int                  `json:"tax_rate"`
+	TaxAmount   int                  `json:"tax_amount"`
+	Currency    string               `json:"currency"`
+	TicketCodes []string             `json:"ticket_codes"`
+	IssuedAt    time.Time            `json:"issued_at"`
+	Links       map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseReceipt struct {
+	Receipt *httpResponseReceipt `json:"receipt"`
+}
+
+type HttpResponseReceiptList struct {
+	Receipts []*httpResponseReceipt `json:"receipts"`
+	Links    map[string]http.Link   `json:"_links"`
+}
+
+func toHttpReceipt(receipt *domain.Receipt, serviceURLs *config.ServiceURLs) *httpResponseReceipt {
+	selfPath := fmt.Sprintf("/users/%d/receipts/%s", receipt.UserID, receipt.ID)
+	links := map[string]http.Link{
+		"self": hateoas.BuildSelfLink(serviceURLs.UserManager, selfPath),
+		"pdf": hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s%s?format=pdf", serviceURLs.UserManager, selfPath),
+			"pdf",
+			"GET",
+			"Download this receipt as PDF",
+		),
+		"order": hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/users/%d/orders/%s", serviceURLs.UserManager, receipt.UserID, receipt.OrderID),
+			"order",
+			"GET",
+			"Get the order of this receipt",
+		),
+	}
+
+	lines := make([]httpReceiptLine, 0, len(receipt.Lines))
+	for _, line := range receipt.Lines {
+		lines = append(lines, httpReceiptLine{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      line.Amount,
+		})
+	}
+
+	return &httpResponseReceipt{
+		ID:          receipt.ID,
+		Number:      receipt.DisplayNumber(),
+		Series:      receipt.Series(),
+		Sequence:    receipt.Number,
+		OrganizerID: receipt.OrganizerID,
+		OrderID:     receipt.OrderID,
+		PaymentID:   receipt.PaymentID,
+		Buyer:       httpReceiptBuyer{Name: receipt.BuyerName, Email: receipt.BuyerEmail},
+		Item: httpReceiptItem{
+			EventID:  receipt.Item.EventID,
+			PacketID: receipt.Item.PacketID,
+			Name:     receipt.Item.Name,
+			Location: receipt.Item.Location,
+			City:     receipt.Item.City,
+			Country:  receipt.Item.Country,
+			StartsAt: receipt.Item.StartsAt,
+			EndsAt:   receipt.Item.EndsAt,
+		},
+		Lines:       lines,
+		Subtotal:    receipt.Subtotal,
+		Discount:    receipt.Discount,
+		Total:       receipt.Total,
+		TaxRate:     receipt.TaxRate,
+		TaxAmount:   receipt.TaxAmount,
+		Currency:    receipt.Currency,
+		TicketCodes: receipt.TicketCodes,
+		IssuedAt:    receipt.IssuedAt,
+		Links:       links,
+	}
+}
+
+func ToHttpResponseReceipt(receipt *domain.Receipt, serviceURLs *config.ServiceURLs) *HttpResponseReceipt {
+	return &HttpResponseReceipt{
+		Receipt: toHttpReceipt(receipt, serviceURLs),
+	}
+}
+
+func ToHttpResponseReceiptList(userID int, receipts []*domain.Receipt, serviceURLs *config.ServiceURLs) *HttpResponseReceiptList {
+	httpReceipts := make([]*httpResponseReceipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		httpReceipts = append(httpReceipts, toHttpReceipt(receipt, serviceURLs))
+	}
+
+	return &HttpResponseReceiptList{
+		Receipts: httpReceipts,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.UserManager, fmt.Sprintf("/users/%d/receipts", userID)),
+		},
+	}
+}
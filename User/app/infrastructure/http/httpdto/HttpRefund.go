@@ -0,0 +1,194 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type HttpCreateRefund struct {
+	TicketCode string `json:"ticket_code" binding:"required,max=255"`
+	Reason     string `json:"reason" binding:"max=1000"`
+}
+
+// HttpApproveRefund leaves amount out to refund the face value of the
+// ticket.
+type HttpApproveRefund struct {
+	Amount *int   `json:"amount" binding:"omitempty,min=0"`
+	Note   string `json:"note" binding:"max=1000"`
+}
+
+type HttpDenyRefund struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+type HttpFilterUserRefunds struct {
+	Status *string `json:"status,omitempty" form:"status"`
+}
+
+type HttpFilterRefunds struct {
+	EventID  *int    `json:"event_id,omitempty"  form:"event_id"`
+	PacketID *int    `json:"packet_id,omitempty" form:"packet_id"`
+	Status   *string `json:"status,omitempty"    form:"status"`
+}
+
+func (filter *HttpFilterRefunds) ToRefundFilter() *domain.RefundFilter {
+	return &domain.RefundFilter{
+		EventID:  filter.EventID,
+		PacketID: filter.PacketID,
+		Status:   filter.Status,
+	}
+}
+
+type httpResponseRefund struct {
+	ID           string               `json:"id"`
+	TicketCode   string               `json:"ticket_code"`
+	EventID      *int                 `json:"event_id,omitempty"`
+	PacketID     *int                 `json:"packet_id,omitempty"`
+	UserID       int                  `json:"user_id"`
+	Reason       string               `json:"reason,omitempty"`
+	Status       string               `json:"status"`
+	Amount       *int                 `json:"amount,omitempty"`
+	DecisionNote string               `json:"decision_note,omitempty"`
+	DecidedBy    *int                 `json:"decided_by,omitempty"`
+	RequestedAt  time.Time            `json:"requested_at"`
+	DecidedAt    *time.Time           `json:"decided_at,omitempty"`
+	Links        map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseRefund struct {
+	Refund *httpResponseRefund `json:"refund"`
+}
+
+type HttpResponseRefundList struct {
+	Refunds []*httpResponseRefund `json:"refunds"`
+	Links   map[string]http.Link  `json:"_links"`
+}
+
+// toHttpRefund builds the refund with the links of its holder, or of a
+// decider when decider is set.
+func toHttpRefund(refund *domain.RefundRequest, decider bool, serviceURLs *config.ServiceURLs) *httpResponseRefund {
+	selfPath := fmt.Sprintf("/users/%d/refunds/%s", refund.UserID, refund.ID)
+	if decider {
+		selfPath = fmt.Sprintf("/refunds/%s", refund.ID)
+	}
+	links := map[string]http.Link{
+		"self": hateoas.BuildSelfLink(serviceURLs.UserManager, selfPath),
+	}
+	if decider {
+		switch refund.Status {
+		case domain.RefundRequested:
+			links["approve"] = hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/refunds/%s/approve", serviceURLs.UserManager, refund.ID),
+				"approve",
+				"POST",
+				"Approve this refund and void the ticket",
+			)
+			links["deny"] = hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/refunds/%s/deny", serviceURLs.UserManager, refund.ID),
+				"deny",
+				"POST",
+				"Deny this refund",
+			)
+		case domain.RefundApproving:
+			links["approve"] = hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/refunds/%s/approve", serviceURLs.UserManager, refund.ID),
+				"approve",
+				"POST",
+				"Finish approving this refund",
+			)
+		}
+	}
+	if refund.EventID != nil {
+		links["event"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/events/%d", serviceURLs.EventManager, *refund.EventID),
+			"event",
+			"GET",
+			"Get the event of this ticket",
+		)
+	}
+	if refund.PacketID != nil {
+		links["packet"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/event-packets/%d", serviceURLs.EventManager, *refund.PacketID),
+			"packet",
+			"GET",
+			"Get the packet of this ticket",
+		)
+	}
+
+	return &httpResponseRefund{
+		ID:           refund.ID,
+		TicketCode:   refund.TicketCode,
+		EventID:      refund.EventID,
+		PacketID:     refund.PacketID,
+		UserID:       refund.UserID,
+		Reason:       refund.Reason,
+		Status:       refund.Status,
+		Amount:       refund.Amount,
+		DecisionNote: refund.DecisionNote,
+		DecidedBy:    refund.DecidedBy,
+		RequestedAt:  refund.RequestedAt,
+		DecidedAt:    refund.DecidedAt,
+		Links:        links,
+	}
+}
+
+func ToHttpResponseRefund(refund *domain.RefundRequest, decider bool, serviceURLs *config.ServiceURLs) *HttpResponseRefund {
+	return &HttpResponseRefund{
+		Refund: toHttpRefund(refund, decider, serviceURLs),
+	}
+}
+
+// ToHttpResponseUserRefundList lists the refunds a user asked for.
+func ToHttpResponseUserRefundList(userID int, refunds []*domain.RefundRequest, status *string, serviceURLs *config.ServiceURLs) *HttpResponseRefundList {
+	httpRefunds := make([]*httpResponseRefund, 0, len(refunds))
+	for _, refund := range refunds {
+		httpRefunds = append(httpRefunds, toHttpRefund(refund, false, serviceURLs))
+	}
+
+	selfPath := fmt.Sprintf("/users/%d/refunds", userID)
+	query := url.Values{}
+	if status != nil {
+		query.Add("status", *status)
+	}
+
+	return &HttpResponseRefundList{
+		Refunds: httpRefunds,
+		Links: map[string]http.Link{
+			"self":   hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, query.Encode(), "self", "Current listing"),
+			"create": hateoas.BuildCreateLink(serviceURLs.UserManager, selfPath),
+		},
+	}
+}
+
+// ToHttpResponseRefundList lists the refunds of an event or packet for the
+// ones deciding them.
+func ToHttpResponseRefundList(refunds []*domain.RefundRequest, filter *domain.RefundFilter, serviceURLs *config.ServiceURLs) *HttpResponseRefundList {
+	httpRefunds := make([]*httpResponseRefund, 0, len(refunds))
+	for _, refund := range refunds {
+		httpRefunds = append(httpRefunds, toHttpRefund(refund, true, serviceURLs))
+	}
+
+	query := url.Values{}
+	if filter.EventID != nil {
+		query.Add("event_id", strconv.Itoa(*filter.EventID))
+	}
+	if filter.PacketID != nil {
+		query.Add("packet_id", strconv.Itoa(*filter.PacketID))
+	}
+	if filter.Status != nil {
+		query.Add("status", *filter.Status)
+	}
+
+	return &HttpResponseRefundList{
+		Refunds: httpRefunds,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildPaginationLink(serviceURLs.UserManager, "/refunds", query.Encode(), "self", "Current listing"),
+		},
+	}
+}
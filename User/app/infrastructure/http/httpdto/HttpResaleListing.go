@@ -0,0 +1,175 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type HttpCreateResaleListing struct {
+	TicketCode string `json:"ticket_code" binding:"required,max=255"`
+	Price      *int   `json:"price" binding:"required,min=0"`
+}
+
+// HttpCreateResalePurchase names the listing to buy; payment_token stands
+// for the buyer's card at the payment gateway.
+type HttpCreateResalePurchase struct {
+	ListingID    string `json:"listing_id" binding:"required,max=255"`
+	PaymentToken string `json:"payment_token" binding:"max=255"`
+}
+
+type HttpFilterResaleListings struct {
+	EventID  *int `json:"event_id,omitempty"  form:"event_id"`
+	PacketID *int `json:"packet_id,omitempty" form:"packet_id"`
+}
+
+func (filter *HttpFilterResaleListings) ToResaleListingFilter() *domain.ResaleListingFilter {
+	return &domain.ResaleListingFilter{
+		EventID:  filter.EventID,
+		PacketID: filter.PacketID,
+	}
+}
+
+type HttpFilterUserResaleListings struct {
+	Status *string `json:"status,omitempty" form:"status"`
+}
+
+// httpResponseResaleListing shows the ticket code to the seller only; the
+// buyer sees the reissued code once the purchase went through, and everyone
+// else sees neither.
+type httpResponseResaleListing struct {
+	ID         string               `json:"id"`
+	TicketCode string               `json:"ticket_code,omitempty"`
+	EventID    *int                 `json:"event_id,omitempty"`
+	PacketID   *int                 `json:"packet_id,omitempty"`
+	SellerID   int                  `json:"seller_id"`
+	BuyerID    *int                 `json:"buyer_id,omitempty"`
+	Price      int                  `json:"price"`
+	Status     string               `json:"status"`
+	CreatedAt  time.Time            `json:"created_at"`
+	SoldAt     *time.Time           `json:"sold_at,omitempty"`
+	Links      map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseResaleListing struct {
+	Listing *httpResponseResaleListing `json:"listing"`
+}
+
+type HttpResponseResaleListingList struct {
+	Listings []*httpResponseResaleListing `json:"listings"`
+	Links    map[string]http.Link         `json:"_links"`
+}
+
+// toHttpResaleListing builds the listing as userID sees it; userID is 0 for
+// someone browsing what is on sale.
+func toHttpResaleListing(userID int, listing *domain.ResaleListing, serviceURLs *config.ServiceURLs) *httpResponseResaleListing {
+	publicPath := fmt.Sprintf("/resale/listings/%s", listing.ID)
+	buyer := listing.BuyerID != nil && *listing.BuyerID == userID
+
+	links := map[string]http.Link{
+		"self": hateoas.BuildSelfLink(serviceURLs.UserManager, publicPath),
+	}
+	if listing.SellerID == userID && listing.Status == domain.ListingActive {
+		links["cancel"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/users/%d/resale-listings/%s/cancel", serviceURLs.UserManager, userID, listing.ID),
+			"cancel",
+			"POST",
+			"Take this ticket off sale",
+		)
+	}
+	if listing.EventID != nil {
+		links["event"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/events/%d", serviceURLs.EventManager, *listing.EventID),
+			"event",
+			"GET",
+			"Get the event of this ticket",
+		)
+	}
+	if listing.PacketID != nil {
+		links["packet"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/event-packets/%d", serviceURLs.EventManager, *listing.PacketID),
+			"packet",
+			"GET",
+			"Get the packet of this ticket",
+		)
+	}
+
+	var code string
+	switch {
+	case listing.SellerID == userID:
+		code = listing.TicketCode
+	case buyer && listing.Status == domain.ListingSold:
+		code = listing.NewCode
+	}
+
+	return &httpResponseResaleListing{
+		ID:         listing.ID,
+		TicketCode: code,
+		EventID:    listing.EventID,
+		PacketID:   listing.PacketID,
+		SellerID:   listing.SellerID,
+		BuyerID:    listing.BuyerID,
+		Price:      listing.Price,
+		Status:     listing.Status,
+		CreatedAt:  listing.CreatedAt,
+		SoldAt:     listing.SoldAt,
+		Links:      links,
+	}
+}
+
+func ToHttpResponseResaleListing(userID int, listing *domain.ResaleListing, serviceURLs *config.ServiceURLs) *HttpResponseResaleListing {
+	return &HttpResponseResaleListing{
+		Listing: toHttpResaleListing(userID, listing, serviceURLs),
+	}
+}
+
+// ToHttpResponseUserResaleListingList lists the listings of a seller.
+func ToHttpResponseUserResaleListingList(userID int, listings []*domain.ResaleListing, status *string, serviceURLs *config.ServiceURLs) *HttpResponseResaleListingList {
+	httpListings := make([]*httpResponseResaleListing, 0, len(listings))
+	for _, listing := range listings {
+		httpListings = append(httpListings, toHttpResaleListing(userID, listing, serviceURLs))
+	}
+
+	selfPath := fmt.Sprintf("/users/%d/resale-listings", userID)
+	query := url.Values{}
+	if status != nil {
+		query.Add("status", *status)
+	}
+
+	return &HttpResponseResaleListingList{
+		Listings: httpListings,
+		Links: map[string]http.Link{
+			"self":   hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, query.Encode(), "self", "Current listing"),
+			"create": hateoas.BuildCreateLink(serviceURLs.UserManager, selfPath),
+		},
+	}
+}
+
+// ToHttpResponseResaleListingList lists what is on sale for an event or
+// packet.
+func ToHttpResponseResaleListingList(listings []*domain.ResaleListing, filter *domain.ResaleListingFilter, serviceURLs *config.ServiceURLs) *HttpResponseResaleListingList {
+	httpListings := make([]*httpResponseResaleListing, 0, len(listings))
+	for _, listing := range listings {
+		httpListings = append(httpListings, toHttpResaleListing(0, listing, serviceURLs))
+	}
+
+	query := url.Values{}
+	if filter.EventID != nil {
+		query.Add("event_id", strconv.Itoa(*filter.EventID))
+	}
+	if filter.PacketID != nil {
+		query.Add("packet_id", strconv.Itoa(*filter.PacketID))
+	}
+
+	return &HttpResponseResaleListingList{
+		Listings: httpListings,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildPaginationLink(serviceURLs.UserManager, "/resale/listings", query.Encode(), "self", "Current listing"),
+		},
+	}
+}
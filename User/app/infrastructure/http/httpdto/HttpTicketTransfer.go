@@ -0,0 +1,190 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type HttpCreateTicketTransfer struct {
+	TicketCode     string `json:"ticket_code" binding:"required,max=255"`
+	RecipientEmail string `json:"recipient_email" binding:"required,email,max=255"`
+}
+
+type HttpFilterTicketTransfers struct {
+	Direction *string `json:"direction,omitempty" form:"direction"`
+	Status    *string `json:"status,omitempty"    form:"status"`
+}
+
+func (filter *HttpFilterTicketTransfers) ToTicketTransferFilter() *domain.TicketTransferFilter {
+	return &domain.TicketTransferFilter{
+		Direction: filter.Direction,
+		Status:    filter.Status,
+	}
+}
+
+// httpResponseTicketTransfer shows the ticket code to its holder only; the
+// recipient sees the new code once the transfer is accepted, so an offer
+// never hands out a code that is still valid.
+type httpResponseTicketTransfer struct {
+	ID             string               `json:"id"`
+	TicketCode     string               `json:"ticket_code,omitempty"`
+	EventID        *int                 `json:"event_id,omitempty"`
+	PacketID       *int                 `json:"packet_id,omitempty"`
+	FromUserID     int                  `json:"from_user_id"`
+	ToUserID       int                  `json:"to_user_id"`
+	RecipientEmail string               `json:"recipient_email"`
+	Status         string               `json:"status"`
+	CreatedAt      time.Time            `json:"created_at"`
+	ExpiresAt      time.Time            `json:"expires_at"`
+	CompletedAt    *time.Time           `json:"completed_at,omitempty"`
+	Links          map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseTicketTransfer struct {
+	Transfer *httpResponseTicketTransfer `json:"transfer"`
+}
+
+type HttpResponseTicketTransferList struct {
+	Transfers []*httpResponseTicketTransfer `json:"transfers"`
+	Links     map[string]http.Link          `json:"_links"`
+}
+
+// toHttpTicketTransfer builds the transfer as userID sees it: the answers
+// the user may still give are offered as links.
+func toHttpTicketTransfer(userID int, transfer *domain.TicketTransfer, serviceURLs *config.ServiceURLs) *httpResponseTicketTransfer {
+	resourcePath := fmt.Sprintf("/users/%d/transfers/%s", userID, transfer.ID)
+
+	links := map[string]http.Link{
+		"self": hateoas.BuildSelfLink(serviceURLs.UserManager, resourcePath),
+	}
+
+	action := func(name string, title string) {
+		links[name] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s%s/%s", serviceURLs.UserManager, resourcePath, name),
+			name,
+			"POST",
+			title,
+		)
+	}
+	switch {
+	case transfer.Status == domain.TransferPending && transfer.ToUserID == userID:
+		action("accept", "Accept this ticket")
+		action("decline", "Decline this ticket")
+	case transfer.Status == domain.TransferAccepting && transfer.ToUserID == userID:
+		action("accept", "Finish accepting this ticket")
+	case transfer.Status == domain.TransferPending && transfer.FromUserID == userID:
+		action("cancel", "Cancel this transfer")
+	}
+
+	var code string
+	switch {
+	case transfer.FromUserID == userID:
+		code = transfer.TicketCode
+	case transfer.Status == domain.TransferAccepted:
+		code = transfer.NewCode
+	}
+
+	return &httpResponseTicketTransfer{
+		ID:             transfer.ID,
+		TicketCode:     code,
+		EventID:        transfer.EventID,
+		PacketID:       transfer.PacketID,
+		FromUserID:     transfer.FromUserID,
+		ToUserID:       transfer.ToUserID,
+		RecipientEmail: transfer.RecipientEmail,
+		Status:         transfer.Status,
+		CreatedAt:      transfer.CreatedAt,
+		ExpiresAt:      transfer.ExpiresAt,
+		CompletedAt:    transfer.CompletedAt,
+		Links:          links,
+	}
+}
+
+func ToHttpResponseTicketTransfer(userID int, transfer *domain.TicketTransfer, serviceURLs *config.ServiceURLs) *HttpResponseTicketTransfer {
+	return &HttpResponseTicketTransfer{
+		Transfer: toHttpTicketTransfer(userID, transfer, serviceURLs),
+	}
+}
+
+func ToHttpResponseTicketTransferList(userID int, transfers []*domain.TicketTransfer, filter *domain.TicketTransferFilter, serviceURLs *config.ServiceURLs) *HttpResponseTicketTransferList {
+	httpTransfers := make([]*httpResponseTicketTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		httpTransfers = append(httpTransfers, toHttpTicketTransfer(userID, transfer, serviceURLs))
+	}
+
+	selfPath := fmt.Sprintf("/users/%d/transfers", userID)
+	query := url.Values{}
+	if filter != nil && filter.Direction != nil {
+		query.Add("direction", *filter.Direction)
+	}
+	if filter != nil && filter.Status != nil {
+		query.Add("status", *filter.Status)
+	}
+
+	return &HttpResponseTicketTransferList{
+		Transfers: httpTransfers,
+		Links: map[string]http.Link{
+			"self":     hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, query.Encode(), "self", "Current listing"),
+			"create":   hateoas.BuildCreateLink(serviceURLs.UserManager, selfPath),
+			"incoming": hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, "direction="+domain.TransferIncoming, "incoming", "Transfers offered to this user"),
+			"outgoing": hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, "direction="+domain.TransferOutgoing, "outgoing", "Transfers sent by this user"),
+			"audit": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/ticket-audit", serviceURLs.UserManager, userID),
+				"audit",
+				"GET",
+				"Ticket history of this user",
+			),
+		},
+	}
+}
+
+type httpTicketAuditEntry struct {
+	Action         string    `json:"action"`
+	Direction      string    `json:"direction"`
+	TicketCode     string    `json:"ticket_code"`
+	PreviousCode   *string   `json:"previous_code,omitempty"`
+	EventID        *int      `json:"event_id,omitempty"`
+	PacketID       *int      `json:"packet_id,omitempty"`
+	TransferID     string    `json:"transfer_id"`
+	CounterpartyID int       `json:"counterparty_id"`
+	At             time.Time `json:"at"`
+}
+
+type HttpResponseTicketAudit struct {
+	Entries []*httpTicketAuditEntry `json:"entries"`
+	Links   map[string]http.Link    `json:"_links"`
+}
+
+func ToHttpResponseTicketAudit(userID int, entries []*domain.TicketAuditEntry, serviceURLs *config.ServiceURLs) *HttpResponseTicketAudit {
+	httpEntries := make([]*httpTicketAuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		httpEntries = append(httpEntries, &httpTicketAuditEntry{
+			Action:         entry.Action,
+			Direction:      entry.Direction,
+			TicketCode:     entry.TicketCode,
+			EventID:        entry.EventID,
+			PacketID:       entry.PacketID,
+			TransferID:     entry.TransferID,
+			CounterpartyID: entry.CounterpartyID,
+			At:             entry.At,
+		})
+	}
+
+	return &HttpResponseTicketAudit{
+		Entries: httpEntries,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildSelfLink(serviceURLs.UserManager, fmt.Sprintf("/users/%d/ticket-audit", userID)),
+			"transfers": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/transfers", serviceURLs.UserManager, userID),
+				"transfers",
+				"GET",
+				"Ticket transfers of this user",
+			),
+		},
+	}
+}
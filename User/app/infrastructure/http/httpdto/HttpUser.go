@@ -2,6 +2,10 @@ package httpdto
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 	"userService/application/domain"
 	"userService/infrastructure/http"
 	"userService/infrastructure/http/config"
@@ -9,9 +13,22 @@ import (
 )
 
 type HttpTicket struct {
-	PacketID *int   `json:"packet_id,omitempty"`
-	EventID  *int   `json:"event_id,omitempty"`
-	Code     string `json:"code"`
+	PacketID    *int       `json:"packet_id,omitempty"`
+	EventID     *int       `json:"event_id,omitempty"`
+	Code        string     `json:"code"`
+	PurchasedAt *time.Time `json:"purchased_at,omitempty"`
+}
+
+func toHttpTicket(ticket domain.Ticket) HttpTicket {
+	ret := HttpTicket{
+		PacketID: ticket.PacketID,
+		EventID:  ticket.EventID,
+		Code:     ticket.Code,
+	}
+	if !ticket.PurchasedAt.IsZero() {
+		ret.PurchasedAt = &ticket.PurchasedAt
+	}
+	return ret
 }
 
 type httpResponseUser struct {
@@ -39,11 +56,7 @@ func ToHttpResponseUser(user *domain.User, serviceURLs *config.ServiceURLs) *Htt
 
 	httpTickets := make([]HttpTicket, len(user.TicketList))
 	for i, ticket := range user.TicketList {
-		httpTickets[i] = HttpTicket{
-			PacketID: ticket.PacketID,
-			EventID:  ticket.EventID,
-			Code:     ticket.Code,
-		}
+		httpTickets[i] = toHttpTicket(ticket)
 	}
 
 	dto := &httpResponseUser{
@@ -78,11 +91,53 @@ func ToHttpResponseUser(user *domain.User, serviceURLs *config.ServiceURLs) *Htt
 				"Create a ticket for this user",
 			),
 			"tickets": hateoas.BuildRelatedLink(
-				fmt.Sprintf("%s/clients/%d/tickets", serviceURLs.UserManager, user.ID),
+				fmt.Sprintf("%s/users/%d/tickets", serviceURLs.UserManager, user.ID),
 				"tickets",
 				"GET",
 				"View purchased tickets",
 			),
+			"transfers": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/transfers", serviceURLs.UserManager, user.ID),
+				"transfers",
+				"GET",
+				"View ticket transfers sent and received",
+			),
+			"resale-listings": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/resale-listings", serviceURLs.UserManager, user.ID),
+				"resale-listings",
+				"GET",
+				"View tickets this user put up for resale",
+			),
+			"refunds": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/refunds", serviceURLs.UserManager, user.ID),
+				"refunds",
+				"GET",
+				"View refunds this user asked for",
+			),
+			"waitlist": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/waitlist", serviceURLs.UserManager, user.ID),
+				"waitlist",
+				"GET",
+				"View the waitlists this user is on",
+			),
+			"orders": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/orders", serviceURLs.UserManager, user.ID),
+				"orders",
+				"GET",
+				"View the orders this user placed",
+			),
+			"receipts": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/receipts", serviceURLs.UserManager, user.ID),
+				"receipts",
+				"GET",
+				"View the receipts of this user's purchases",
+			),
+			"calendar": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/calendar", serviceURLs.UserManager, user.ID),
+				"calendar",
+				"GET",
+				"Get the calendar feed of this user's events",
+			),
 		},
 	}
 
@@ -144,57 +199,187 @@ func (user *HttpUpdateUser) ToUpdateMap() map[string]interface{} {
 	return updates
 }
 
+// HttpCreateTicketForUser buys one ticket unless quantity says otherwise.
+// payment_token stands for the buyer's card at the payment gateway.
 type HttpCreateTicketForUser struct {
-	PacketID *int `json:"packet_id" binding:"omitempty,min=1"`
-	EventID  *int `json:"event_id" binding:"omitempty,min=1"`
+	PacketID     *int    `json:"packet_id" binding:"omitempty,min=1"`
+	EventID      *int    `json:"event_id" binding:"omitempty,min=1"`
+	Quantity     *int    `json:"quantity" binding:"omitempty,min=1,max=10"`
+	PromoCode    *string `json:"promo_code" binding:"omitempty,min=1,max=32"`
+	PaymentToken string  `json:"payment_token" binding:"max=255"`
+}
+
+func (req *HttpCreateTicketForUser) ToPurchaseRequest() *domain.PurchaseRequest {
+	request := &domain.PurchaseRequest{
+		EventID:      req.EventID,
+		PacketID:     req.PacketID,
+		Quantity:     1,
+		PromoCode:    req.PromoCode,
+		PaymentToken: req.PaymentToken,
+	}
+	if req.Quantity != nil {
+		request.Quantity = *req.Quantity
+	}
+	return request
 }
 
+// HttpCreateTicketResponse keeps ticket_code, the first ticket bought, for
+// clients that buy one at a time. Prices are in bani and left out when the
+// owner set none, as is the payment when there was nothing to pay.
 type HttpCreateTicketResponse struct {
-	TicketCode string `json:"ticket_code"`
+	OrderID     string       `json:"order_id"`
+	TicketCode  string       `json:"ticket_code"`
+	TicketCodes []string     `json:"ticket_codes"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   *int         `json:"unit_price,omitempty"`
+	Discount    int          `json:"discount"`
+	Total       *int         `json:"total,omitempty"`
+	PromoCode   *string      `json:"promo_code,omitempty"`
+	Payment     *HttpPayment `json:"payment,omitempty"`
+	ReceiptID   *string      `json:"receipt_id,omitempty"`
 }
 
-type HttpResponseUserList struct {
-	Users []*httpResponseUser `json:"users"`
+func ToHttpCreateTicketResponse(purchase *domain.Purchase) *HttpCreateTicketResponse {
+	return &HttpCreateTicketResponse{
+		OrderID:     purchase.OrderID,
+		TicketCode:  purchase.TicketCodes[0],
+		TicketCodes: purchase.TicketCodes,
+		Quantity:    purchase.Quantity,
+		UnitPrice:   purchase.UnitPrice,
+		Discount:    purchase.Discount,
+		Total:       purchase.Total,
+		PromoCode:   purchase.PromoCode,
+		Payment:     ToHttpPayment(purchase.Payment),
+		ReceiptID:   purchase.ReceiptID,
+	}
 }
 
-func ToHttpResponseUserList(users []*domain.User, serviceURLs *config.ServiceURLs) *HttpResponseUserList {
-	if users == nil {
-		users = []*domain.User{}
+type httpResponseCustomer struct {
+	ID               int                  `json:"id"`
+	Email            string               `json:"email"`
+	FirstName        string               `json:"first_name"`
+	LastName         string               `json:"last_name"`
+	SocialMediaLinks *string              `json:"social_media_links,omitempty"`
+	FirstNamePrivate bool                 `json:"first_name_private"`
+	LastNamePrivate  bool                 `json:"last_name_private"`
+	TicketCount      int                  `json:"ticket_count"`
+	FirstPurchasedAt *time.Time           `json:"first_purchased_at,omitempty"`
+	LastPurchasedAt  *time.Time           `json:"last_purchased_at,omitempty"`
+	Links            map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseCustomerList struct {
+	Users    []*httpResponseCustomer `json:"users"`
+	Links    map[string]http.Link    `json:"_links"`
+	Metadata *PaginationMetadata     `json:"_metadata,omitempty"`
+}
+
+type PaginationMetadata struct {
+	PerPage int `json:"per_page"`
+}
+
+type HttpFilterCustomers struct {
+	PerPage *int    `json:"per_page,omitempty" form:"per_page"`
+	Cursor  *string `json:"cursor,omitempty"   form:"cursor"`
+	OrderBy *string `json:"order_by,omitempty" form:"order_by"`
+}
+
+func (filter *HttpFilterCustomers) ToCustomerFilter() *domain.CustomerFilter {
+	return &domain.CustomerFilter{
+		PerPage: filter.PerPage,
+		Cursor:  filter.Cursor,
+		OrderBy: filter.OrderBy,
+	}
+}
+
+type HttpExportCustomers struct {
+	Format  *string `json:"format,omitempty"   form:"format"`
+	OrderBy *string `json:"order_by,omitempty" form:"order_by"`
+}
+
+func buildCustomerFilterQuery(filter *domain.CustomerFilter, cursor *string) string {
+	params := url.Values{}
+	if filter != nil && filter.PerPage != nil {
+		params.Add("per_page", strconv.Itoa(*filter.PerPage))
+	}
+	if filter != nil && filter.OrderBy != nil {
+		params.Add("order_by", *filter.OrderBy)
 	}
+	if cursor != nil {
+		params.Add("cursor", *cursor)
+	}
+	return params.Encode()
+}
 
-	httpUsers := make([]*httpResponseUser, 0, len(users))
+func purchaseTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
 
-	for _, user := range users {
-		resourcePath := fmt.Sprintf("/users/%d", user.ID)
+func ToHttpResponseCustomerList(customers []*domain.Customer, selfPath string, filter *domain.CustomerFilter, pageInfo *domain.PageInfo, serviceURLs *config.ServiceURLs) *HttpResponseCustomerList {
+	httpCustomers := make([]*httpResponseCustomer, 0, len(customers))
 
-		var httpTickets []HttpTicket
-		if user.TicketList != nil {
-			httpTickets = make([]HttpTicket, len(user.TicketList))
-			for i, ticket := range user.TicketList {
-				httpTickets[i] = HttpTicket{
-					PacketID: ticket.PacketID,
-					EventID:  ticket.EventID,
-					Code:     ticket.Code,
-				}
-			}
-		}
+	for _, customer := range customers {
+		resourcePath := fmt.Sprintf("/users/%d", customer.ID)
 
-		httpUsers = append(httpUsers, &httpResponseUser{
-			ID:               user.ID,
-			Email:            user.Email,
-			FirstName:        user.FirstName,
-			LastName:         user.LastName,
-			SocialMediaLinks: user.SocialMediaLinks,
-			TicketList:       httpTickets,
-			FirstNamePrivate: user.FirstNamePrivate,
-			LastNamePrivate:  user.LastNamePrivate,
+		httpCustomers = append(httpCustomers, &httpResponseCustomer{
+			ID:               customer.ID,
+			Email:            customer.Email,
+			FirstName:        customer.FirstName,
+			LastName:         customer.LastName,
+			SocialMediaLinks: customer.SocialMediaLinks,
+			FirstNamePrivate: customer.FirstNamePrivate,
+			LastNamePrivate:  customer.LastNamePrivate,
+			TicketCount:      customer.TicketCount,
+			FirstPurchasedAt: purchaseTime(customer.FirstPurchasedAt),
+			LastPurchasedAt:  purchaseTime(customer.LastPurchasedAt),
 			Links: map[string]http.Link{
 				"self": hateoas.BuildSelfLink(serviceURLs.UserManager, resourcePath),
 			},
 		})
 	}
 
-	return &HttpResponseUserList{
-		Users: httpUsers,
+	var cursor *string
+	if filter != nil {
+		cursor = filter.Cursor
+	}
+
+	exportQuery := url.Values{}
+	if filter != nil && filter.OrderBy != nil {
+		exportQuery.Add("order_by", *filter.OrderBy)
+	}
+
+	links := map[string]http.Link{
+		"self":  hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, buildCustomerFilterQuery(filter, cursor), "self", "Current page"),
+		"first": hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, buildCustomerFilterQuery(filter, nil), "first", "First page"),
+	}
+	for _, format := range []string{"csv", "xlsx"} {
+		exportQuery.Set("format", format)
+		rel := "export-" + format
+		links[rel] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s%s/export?%s", serviceURLs.UserManager, selfPath, exportQuery.Encode()),
+			rel,
+			"GET",
+			fmt.Sprintf("Download every customer as %s", strings.ToUpper(format)),
+		)
+	}
+	if pageInfo != nil && pageInfo.PrevCursor != nil {
+		links["prev"] = hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, buildCustomerFilterQuery(filter, pageInfo.PrevCursor), "prev", "Previous page")
+	}
+	if pageInfo != nil && pageInfo.NextCursor != nil {
+		links["next"] = hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, buildCustomerFilterQuery(filter, pageInfo.NextCursor), "next", "Next page")
+	}
+
+	var metadata *PaginationMetadata
+	if filter != nil && filter.PerPage != nil {
+		metadata = &PaginationMetadata{PerPage: *filter.PerPage}
+	}
+
+	return &HttpResponseCustomerList{
+		Users:    httpCustomers,
+		Links:    links,
+		Metadata: metadata,
 	}
 }
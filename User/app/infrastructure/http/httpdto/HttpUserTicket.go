@@ -0,0 +1,137 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type httpTicketEvent struct {
+	ID       int        `json:"id"`
+	Name     string     `json:"name"`
+	Location *string    `json:"location,omitempty"`
+	City     *string    `json:"city,omitempty"`
+	Country  *string    `json:"country,omitempty"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+type httpResponseOwnedTicket struct {
+	Code        string               `json:"code"`
+	EventID     *int                 `json:"event_id,omitempty"`
+	PacketID    *int                 `json:"packet_id,omitempty"`
+	PurchasedAt *time.Time           `json:"purchased_at,omitempty"`
+	Event       *httpTicketEvent     `json:"event,omitempty"`
+	Packet      *httpTicketEvent     `json:"packet,omitempty"`
+	Links       map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseUserTicketList struct {
+	Tickets []*httpResponseOwnedTicket `json:"tickets"`
+	Links   map[string]http.Link       `json:"_links"`
+}
+
+type HttpFilterUserTickets struct {
+	When *string `json:"when,omitempty" form:"when"`
+}
+
+func (filter *HttpFilterUserTickets) ToTicketFilter() *domain.TicketFilter {
+	return &domain.TicketFilter{When: filter.When}
+}
+
+func toHttpOwnedTicket(userID int, ticket *domain.OwnedTicket, serviceURLs *config.ServiceURLs) *httpResponseOwnedTicket {
+	dto := &httpResponseOwnedTicket{
+		Code:        ticket.Code,
+		EventID:     ticket.EventID,
+		PacketID:    ticket.PacketID,
+		PurchasedAt: purchaseTime(ticket.PurchasedAt),
+		Links: map[string]http.Link{
+			"ticket": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/tickets/%s", serviceURLs.EventManager, ticket.Code),
+				"ticket",
+				"GET",
+				"Get this ticket",
+			),
+			"pdf": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d/tickets/%s/pdf", serviceURLs.UserManager, userID, url.PathEscape(ticket.Code)),
+				"pdf",
+				"GET",
+				"Download this ticket as a PDF",
+			),
+		},
+	}
+
+	if ticket.Event != nil {
+		dto.Event = &httpTicketEvent{
+			ID:       ticket.Event.ID,
+			Name:     ticket.Event.Name,
+			Location: ticket.Event.Location,
+			City:     ticket.Event.City,
+			Country:  ticket.Event.Country,
+			StartsAt: ticket.Event.StartsAt,
+			EndsAt:   ticket.Event.EndsAt,
+		}
+	}
+	if ticket.EventID != nil {
+		dto.Links["event"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/events/%d", serviceURLs.EventManager, *ticket.EventID),
+			"event",
+			"GET",
+			"Get the event of this ticket",
+		)
+	}
+
+	if ticket.Packet != nil {
+		dto.Packet = &httpTicketEvent{
+			ID:       ticket.Packet.ID,
+			Name:     ticket.Packet.Name,
+			Location: ticket.Packet.Location,
+			City:     ticket.Packet.City,
+			Country:  ticket.Packet.Country,
+			StartsAt: ticket.Packet.StartsAt,
+			EndsAt:   ticket.Packet.EndsAt,
+		}
+	}
+	if ticket.PacketID != nil {
+		dto.Links["packet"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/event-packets/%d", serviceURLs.EventManager, *ticket.PacketID),
+			"packet",
+			"GET",
+			"Get the packet of this ticket",
+		)
+	}
+
+	return dto
+}
+
+func ToHttpResponseUserTicketList(userID int, tickets []*domain.OwnedTicket, filter *domain.TicketFilter, serviceURLs *config.ServiceURLs) *HttpResponseUserTicketList {
+	httpTickets := make([]*httpResponseOwnedTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		httpTickets = append(httpTickets, toHttpOwnedTicket(userID, ticket, serviceURLs))
+	}
+
+	selfPath := fmt.Sprintf("/users/%d/tickets", userID)
+	query := url.Values{}
+	if filter != nil && filter.When != nil {
+		query.Add("when", *filter.When)
+	}
+
+	return &HttpResponseUserTicketList{
+		Tickets: httpTickets,
+		Links: map[string]http.Link{
+			"self": hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, query.Encode(), "self", "Current listing"),
+			"user": hateoas.BuildRelatedLink(
+				fmt.Sprintf("%s/users/%d", serviceURLs.UserManager, userID),
+				"user",
+				"GET",
+				"Get the owner of these tickets",
+			),
+			"upcoming": hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, "when="+domain.TicketsUpcoming, "upcoming", "Tickets for what has not ended yet"),
+			"past":     hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, "when="+domain.TicketsPast, "past", "Tickets for what is over"),
+		},
+	}
+}
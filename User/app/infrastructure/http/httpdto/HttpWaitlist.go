@@ -0,0 +1,127 @@
+package httpdto
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/http"
+	"userService/infrastructure/http/config"
+	"userService/infrastructure/http/hateoas"
+)
+
+type HttpJoinWaitlist struct {
+	EventID  *int `json:"event_id" binding:"omitempty,min=1"`
+	PacketID *int `json:"packet_id" binding:"omitempty,min=1"`
+}
+
+func (req *HttpJoinWaitlist) ToWaitlistTarget() *domain.WaitlistTarget {
+	return &domain.WaitlistTarget{
+		EventID:  req.EventID,
+		PacketID: req.PacketID,
+	}
+}
+
+type HttpFilterWaitlist struct {
+	Status *string `json:"status,omitempty" form:"status"`
+}
+
+type httpResponseWaitlistEntry struct {
+	ID             string               `json:"id"`
+	EventID        *int                 `json:"event_id,omitempty"`
+	PacketID       *int                 `json:"packet_id,omitempty"`
+	Status         string               `json:"status"`
+	Position       *int                 `json:"position,omitempty"`
+	JoinedAt       time.Time            `json:"joined_at"`
+	OfferedAt      *time.Time           `json:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time           `json:"offer_expires_at,omitempty"`
+	ClosedAt       *time.Time           `json:"closed_at,omitempty"`
+	Links          map[string]http.Link `json:"_links"`
+}
+
+type HttpResponseWaitlistEntry struct {
+	Entry *httpResponseWaitlistEntry `json:"entry"`
+}
+
+type HttpResponseWaitlist struct {
+	Entries []*httpResponseWaitlistEntry `json:"entries"`
+	Links   map[string]http.Link         `json:"_links"`
+}
+
+func toHttpWaitlistEntry(entry *domain.WaitlistEntry, serviceURLs *config.ServiceURLs) *httpResponseWaitlistEntry {
+	links := map[string]http.Link{
+		"self": hateoas.BuildSelfLink(serviceURLs.UserManager, fmt.Sprintf("/users/%d/waitlist/%s", entry.UserID, entry.ID)),
+	}
+	if entry.Open() {
+		links["leave"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/users/%d/waitlist/%s/leave", serviceURLs.UserManager, entry.UserID, entry.ID),
+			"leave",
+			"POST",
+			"Leave the waitlist",
+		)
+	}
+	if entry.Status == domain.WaitlistOffered {
+		links["purchase"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/clients/%d/tickets", serviceURLs.UserManager, entry.UserID),
+			"purchase",
+			"POST",
+			"Buy the offered seat before the offer expires",
+		)
+	}
+	if entry.EventID != nil {
+		links["event"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/events/%d", serviceURLs.EventManager, *entry.EventID),
+			"event",
+			"GET",
+			"Get the event waited for",
+		)
+	}
+	if entry.PacketID != nil {
+		links["packet"] = hateoas.BuildRelatedLink(
+			fmt.Sprintf("%s/event-packets/%d", serviceURLs.EventManager, *entry.PacketID),
+			"packet",
+			"GET",
+			"Get the packet waited for",
+		)
+	}
+
+	return &httpResponseWaitlistEntry{
+		ID:             entry.ID,
+		EventID:        entry.EventID,
+		PacketID:       entry.PacketID,
+		Status:         entry.Status,
+		Position:       entry.Position,
+		JoinedAt:       entry.JoinedAt,
+		OfferedAt:      entry.OfferedAt,
+		OfferExpiresAt: entry.OfferExpiresAt,
+		ClosedAt:       entry.ClosedAt,
+		Links:          links,
+	}
+}
+
+func ToHttpResponseWaitlistEntry(entry *domain.WaitlistEntry, serviceURLs *config.ServiceURLs) *HttpResponseWaitlistEntry {
+	return &HttpResponseWaitlistEntry{
+		Entry: toHttpWaitlistEntry(entry, serviceURLs),
+	}
+}
+
+func ToHttpResponseWaitlist(userID int, entries []*domain.WaitlistEntry, status *string, serviceURLs *config.ServiceURLs) *HttpResponseWaitlist {
+	httpEntries := make([]*httpResponseWaitlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		httpEntries = append(httpEntries, toHttpWaitlistEntry(entry, serviceURLs))
+	}
+
+	selfPath := fmt.Sprintf("/users/%d/waitlist", userID)
+	query := url.Values{}
+	if status != nil {
+		query.Add("status", *status)
+	}
+
+	return &HttpResponseWaitlist{
+		Entries: httpEntries,
+		Links: map[string]http.Link{
+			"self":   hateoas.BuildPaginationLink(serviceURLs.UserManager, selfPath, query.Encode(), "self", "Current listing"),
+			"create": hateoas.BuildCreateLink(serviceURLs.UserManager, selfPath),
+		},
+	}
+}
@@ -0,0 +1,303 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+	"userService/application/domain"
+
+	"github.com/gin-gonic/gin"
+	"shared/idempotency"
+)
+
+// ContentType is the media type of RFC 7807 problem details.
+const ContentType = "application/problem+json"
+
+// TraceIDKey is the gin context key holding the request trace id.
+const TraceIDKey = "trace_id"
+
+// Problem type URIs, relative to the service root.
+const (
+	TypeValidation            = "/problems/validation-error"
+	TypeInvalidRequest        = "/problems/invalid-request"
+	TypeUnauthorized          = "/problems/unauthorized"
+	TypeForbidden             = "/problems/forbidden"
+	TypeNotFound              = "/problems/not-found"
+	TypeConflict              = "/problems/conflict"
+	TypeSoldOut               = "/problems/sold-out"
+	TypeCapacityNotConfigured = "/problems/capacity-not-configured"
+	TypeEventCancelled        = "/problems/event-cancelled"
+	TypeTransfersBlocked      = "/problems/transfers-blocked"
+	TypeResaleNotAllowed      = "/problems/resale-not-allowed"
+	TypeResalePriceAboveCap   = "/problems/resale-price-above-cap"
+	TypeRefundNotAllowed      = "/problems/refund-not-allowed"
+	TypePromoCode             = "/problems/promo-code"
+	TypePurchaseLimitReached  = "/problems/purchase-limit-reached"
+	TypePaymentDeclined       = "/problems/payment-declined"
+	TypePaymentGateway        = "/problems/payment-gateway-error"
+	TypeIdempotencyKeyReused  = "/problems/idempotency-key-reused"
+	TypeIdempotencyInProgress = "/problems/idempotency-in-progress"
+	TypeUnsupportedMediaType  = "/problems/unsupported-media-type"
+	TypeServiceUnavailable    = "/problems/service-unavailable"
+	TypeInternal              = "/problems/internal-error"
+)
+
+// FieldError describes one invalid field of a request.
+type FieldError struct {
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// SeatAvailability is the seat count attached to sold-out problems.
+type SeatAvailability struct {
+	Resource  string `json:"resource"`
+	ID        int    `json:"id"`
+	Capacity  int    `json:"capacity"`
+	Sold      int    `json:"sold"`
+	Reserved  int    `json:"reserved,omitempty"`
+	Remaining int    `json:"remaining"`
+}
+
+// Problem is an RFC 7807 problem details body. Code is a machine-readable
+// reason for failures clients need to tell apart. Error repeats Detail for
+// clients that still read the old {"error": "..."} shape.
+type Problem struct {
+	Type         string            `json:"type"`
+	Title        string            `json:"title"`
+	Status       int               `json:"status"`
+	Detail       string            `json:"detail,omitempty"`
+	Instance     string            `json:"instance,omitempty"`
+	Code         string            `json:"code,omitempty"`
+	Availability *SeatAvailability `json:"availability,omitempty"`
+	Errors       []FieldError      `json:"errors,omitempty"`
+	TraceID      string            `json:"trace_id,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+func New(status int, problemType string, detail string) *Problem {
+	return &Problem{
+		Type:   problemType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// FromError maps a domain error to its problem details. Internal errors keep
+// their cause out of the response.
+func FromError(err error) *Problem {
+	var soldOutErr *domain.SoldOutError
+	if errors.As(err, &soldOutErr) {
+		p := New(http.StatusConflict, TypeSoldOut, soldOutErr.Error())
+		p.Code = soldOutErr.Code
+		p.Availability = &SeatAvailability{
+			Resource:  soldOutErr.Resource,
+			ID:        soldOutErr.ID,
+			Capacity:  soldOutErr.Capacity,
+			Sold:      soldOutErr.Sold,
+			Reserved:  soldOutErr.Reserved,
+			Remaining: soldOutErr.Remaining,
+		}
+		return p
+	}
+
+	var capacityErr *domain.CapacityNotConfiguredError
+	if errors.As(err, &capacityErr) {
+		p := New(http.StatusConflict, TypeCapacityNotConfigured, capacityErr.Error())
+		p.Code = domain.CodeCapacityNotConfigured
+		return p
+	}
+
+	var cancelledErr *domain.EventCancelledError
+	if errors.As(err, &cancelledErr) {
+		p := New(http.StatusConflict, TypeEventCancelled, cancelledErr.Error())
+		p.Code = domain.CodeEventCancelled
+		return p
+	}
+
+	var transfersErr *domain.TransfersBlockedError
+	if errors.As(err, &transfersErr) {
+		p := New(http.StatusConflict, TypeTransfersBlocked, transfersErr.Error())
+		p.Code = domain.CodeTransfersBlocked
+		return p
+	}
+
+	var resaleErr *domain.ResaleNotAllowedError
+	if errors.As(err, &resaleErr) {
+		p := New(http.StatusConflict, TypeResaleNotAllowed, resaleErr.Error())
+		p.Code = domain.CodeResaleNotAllowed
+		return p
+	}
+
+	var priceCapErr *domain.ResalePriceAboveCapError
+	if errors.As(err, &priceCapErr) {
+		p := New(http.StatusUnprocessableEntity, TypeResalePriceAboveCap, priceCapErr.Error())
+		p.Code = domain.CodeResalePriceAboveCap
+		return p
+	}
+
+	var refundErr *domain.RefundNotAllowedError
+	if errors.As(err, &refundErr) {
+		p := New(http.StatusConflict, TypeRefundNotAllowed, refundErr.Error())
+		p.Code = domain.CodeRefundNotAllowed
+		return p
+	}
+
+	var limitErr *domain.PurchaseLimitError
+	if errors.As(err, &limitErr) {
+		p := New(http.StatusConflict, TypePurchaseLimitReached, limitErr.Error())
+		p.Code = domain.CodePurchaseLimitReached
+		return p
+	}
+
+	var promoErr *domain.PromoCodeNotApplicableError
+	if errors.As(err, &promoErr) {
+		p := New(http.StatusConflict, TypePromoCode, promoErr.Error())
+		p.Code = domain.CodePromoCodeNotApplicable
+		return p
+	}
+
+	var exhaustedErr *domain.PromoCodeExhaustedError
+	if errors.As(err, &exhaustedErr) {
+		p := New(http.StatusConflict, TypePromoCode, exhaustedErr.Error())
+		p.Code = domain.CodePromoCodeExhausted
+		return p
+	}
+
+	var declinedErr *domain.PaymentDeclinedError
+	if errors.As(err, &declinedErr) {
+		p := New(http.StatusPaymentRequired, TypePaymentDeclined, declinedErr.Error())
+		p.Code = domain.CodePaymentDeclined
+		return p
+	}
+
+	var gatewayErr *domain.PaymentGatewayError
+	if errors.As(err, &gatewayErr) {
+		return New(http.StatusBadGateway, TypePaymentGateway, gatewayErr.Error())
+	}
+
+	var keyReusedErr *idempotency.KeyReusedError
+	if errors.As(err, &keyReusedErr) {
+		return New(http.StatusUnprocessableEntity, TypeIdempotencyKeyReused, keyReusedErr.Error())
+	}
+
+	var inProgressErr *idempotency.InProgressError
+	if errors.As(err, &inProgressErr) {
+		return New(http.StatusConflict, TypeIdempotencyInProgress, inProgressErr.Error())
+	}
+
+	var invalidKeyErr *idempotency.InvalidKeyError
+	if errors.As(err, &invalidKeyErr) {
+		return FromError(&domain.ValidationError{Field: idempotency.KeyHeader, Reason: invalidKeyErr.Reason})
+	}
+
+	var bodyErr *idempotency.RequestBodyError
+	if errors.As(err, &bodyErr) {
+		return FromError(&domain.InvalidRequestError{Reason: bodyErr.Error()})
+	}
+
+	var validationErrs *domain.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		p := New(http.StatusUnprocessableEntity, TypeValidation, "the request contains invalid fields")
+		for _, fieldErr := range validationErrs.Errors {
+			p.Errors = append(p.Errors, FieldError{Field: fieldErr.Field, Reason: fieldErr.Reason})
+		}
+		return p
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		p := New(http.StatusUnprocessableEntity, TypeValidation, validationErr.Error())
+		p.Errors = []FieldError{{Field: validationErr.Field, Reason: validationErr.Reason}}
+		return p
+	}
+
+	var invalidReqErr *domain.InvalidRequestError
+	if errors.As(err, &invalidReqErr) {
+		return New(http.StatusBadRequest, TypeInvalidRequest, invalidReqErr.Error())
+	}
+
+	var unauthorizedErr *domain.UnauthorizedError
+	if errors.As(err, &unauthorizedErr) {
+		return New(http.StatusUnauthorized, TypeUnauthorized, unauthorizedErr.Error())
+	}
+
+	var forbiddenErr *domain.ForbiddenError
+	if errors.As(err, &forbiddenErr) {
+		return New(http.StatusForbidden, TypeForbidden, forbiddenErr.Error())
+	}
+
+	var notFoundErr *domain.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return New(http.StatusNotFound, TypeNotFound, notFoundErr.Error())
+	}
+
+	var resourceNotFoundErr *domain.ResourceNotFoundError
+	if errors.As(err, &resourceNotFoundErr) {
+		return New(http.StatusNotFound, TypeNotFound, resourceNotFoundErr.Error())
+	}
+
+	var existsErr *domain.AlreadyExistsError
+	if errors.As(err, &existsErr) {
+		return New(http.StatusConflict, TypeConflict, existsErr.Error())
+	}
+
+	var transferStateErr *domain.TransferStateError
+	if errors.As(err, &transferStateErr) {
+		return New(http.StatusConflict, TypeConflict, transferStateErr.Error())
+	}
+
+	var listingStateErr *domain.ListingStateError
+	if errors.As(err, &listingStateErr) {
+		return New(http.StatusConflict, TypeConflict, listingStateErr.Error())
+	}
+
+	var refundStateErr *domain.RefundStateError
+	if errors.As(err, &refundStateErr) {
+		return New(http.StatusConflict, TypeConflict, refundStateErr.Error())
+	}
+
+	var promoTakenErr *domain.PromoCodeTakenError
+	if errors.As(err, &promoTakenErr) {
+		return New(http.StatusConflict, TypeConflict, promoTakenErr.Error())
+	}
+
+	var promoInUseErr *domain.PromoCodeInUseError
+	if errors.As(err, &promoInUseErr) {
+		return New(http.StatusConflict, TypeConflict, promoInUseErr.Error())
+	}
+
+	var paymentStateErr *domain.PaymentStateError
+	if errors.As(err, &paymentStateErr) {
+		return New(http.StatusConflict, TypeConflict, paymentStateErr.Error())
+	}
+
+	var waitlistStateErr *domain.WaitlistStateError
+	if errors.As(err, &waitlistStateErr) {
+		return New(http.StatusConflict, TypeConflict, waitlistStateErr.Error())
+	}
+
+	var unavailableErr *domain.ServiceUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return New(http.StatusServiceUnavailable, TypeServiceUnavailable, unavailableErr.Error())
+	}
+
+	return New(http.StatusInternalServerError, TypeInternal, "An unexpected error occurred")
+}
+
+// Write sends the problem and aborts the request.
+func Write(c *gin.Context, p *Problem) {
+	p.Instance = c.Request.URL.Path
+	p.TraceID = c.GetString(TraceIDKey)
+	p.Error = p.Detail
+	if p.Error == "" {
+		p.Error = p.Title
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// WriteError maps err to a problem and sends it.
+func WriteError(c *gin.Context, err error) {
+	Write(c, FromError(err))
+}
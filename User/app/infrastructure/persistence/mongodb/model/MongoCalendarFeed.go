@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+type MongoCalendarFeed struct {
+	UserID    int       `bson:"user_id"`
+	Token     string    `bson:"token"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (mf *MongoCalendarFeed) ToDomain() *domain.CalendarFeed {
+	return &domain.CalendarFeed{
+		UserID:    mf.UserID,
+		Token:     mf.Token,
+		CreatedAt: mf.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoCustomer is a row of the customer aggregation: a user document merged
+// with the totals of the tickets the user bought. SortKey is the value the
+// row was ordered by, kept to build the page cursors.
+type MongoCustomer struct {
+	MongoUser        `bson:",inline"`
+	TicketCount      int         `bson:"ticket_count"`
+	FirstPurchasedAt time.Time   `bson:"first_purchased_at"`
+	LastPurchasedAt  time.Time   `bson:"last_purchased_at"`
+	SortKey          interface{} `bson:"sort_key,omitempty"`
+}
+
+func (mc *MongoCustomer) ToDomain() *domain.Customer {
+	return &domain.Customer{
+		User:             *mc.MongoUser.ToDomain(),
+		TicketCount:      mc.TicketCount,
+		FirstPurchasedAt: mc.FirstPurchasedAt,
+		LastPurchasedAt:  mc.LastPurchasedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"shared/idempotency"
+)
+
+type MongoIdempotencyKey struct {
+	Scope        string    `bson:"scope"`
+	Key          string    `bson:"key"`
+	Fingerprint  string    `bson:"fingerprint"`
+	StatusCode   int       `bson:"status_code"`
+	ContentType  string    `bson:"content_type,omitempty"`
+	ResponseBody []byte    `bson:"response_body,omitempty"`
+	CreatedAt    time.Time `bson:"created_at"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+}
+
+func (mk *MongoIdempotencyKey) ToDomain() *idempotency.Record {
+	return &idempotency.Record{
+		Key:          mk.Key,
+		Scope:        mk.Scope,
+		Fingerprint:  mk.Fingerprint,
+		StatusCode:   mk.StatusCode,
+		ContentType:  mk.ContentType,
+		ResponseBody: mk.ResponseBody,
+		CreatedAt:    mk.CreatedAt,
+		ExpiresAt:    mk.ExpiresAt,
+	}
+}
+
+func FromIdempotencyRecord(r *idempotency.Record) *MongoIdempotencyKey {
+	return &MongoIdempotencyKey{
+		Scope:        r.Scope,
+		Key:          r.Key,
+		Fingerprint:  r.Fingerprint,
+		StatusCode:   r.StatusCode,
+		ContentType:  r.ContentType,
+		ResponseBody: r.ResponseBody,
+		CreatedAt:    r.CreatedAt,
+		ExpiresAt:    r.ExpiresAt,
+	}
+}
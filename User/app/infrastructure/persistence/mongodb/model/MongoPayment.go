@@ -0,0 +1,131 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoOrder is an order document of the orders collection.
+type MongoOrder struct {
+	ID            string     `bson:"id"`
+	UserID        int        `bson:"user_id"`
+	EventID       *int       `bson:"event_id,omitempty"`
+	PacketID      *int       `bson:"packet_id,omitempty"`
+	Quantity      int        `bson:"quantity"`
+	UnitPrice     *int       `bson:"unit_price,omitempty"`
+	Discount      int        `bson:"discount"`
+	Total         *int       `bson:"total,omitempty"`
+	PromoCode     *string    `bson:"promo_code,omitempty"`
+	TicketCodes   []string   `bson:"ticket_codes"`
+	Status        string     `bson:"status"`
+	FailureReason string     `bson:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	CompletedAt   *time.Time `bson:"completed_at,omitempty"`
+}
+
+func (mo *MongoOrder) ToDomain() *domain.Order {
+	return &domain.Order{
+		ID:            mo.ID,
+		UserID:        mo.UserID,
+		EventID:       mo.EventID,
+		PacketID:      mo.PacketID,
+		Quantity:      mo.Quantity,
+		UnitPrice:     mo.UnitPrice,
+		Discount:      mo.Discount,
+		Total:         mo.Total,
+		PromoCode:     mo.PromoCode,
+		TicketCodes:   mo.TicketCodes,
+		Status:        mo.Status,
+		FailureReason: mo.FailureReason,
+		CreatedAt:     mo.CreatedAt,
+		CompletedAt:   mo.CompletedAt,
+	}
+}
+
+func FromOrder(o *domain.Order) *MongoOrder {
+	ticketCodes := o.TicketCodes
+	if ticketCodes == nil {
+		ticketCodes = []string{}
+	}
+	return &MongoOrder{
+		ID:            o.ID,
+		UserID:        o.UserID,
+		EventID:       o.EventID,
+		PacketID:      o.PacketID,
+		Quantity:      o.Quantity,
+		UnitPrice:     o.UnitPrice,
+		Discount:      o.Discount,
+		Total:         o.Total,
+		PromoCode:     o.PromoCode,
+		TicketCodes:   ticketCodes,
+		Status:        o.Status,
+		FailureReason: o.FailureReason,
+		CreatedAt:     o.CreatedAt,
+		CompletedAt:   o.CompletedAt,
+	}
+}
+
+// MongoPayment is a payment document of the payments collection. The
+// ticket codes are kept so a refunded ticket finds the payment to pay back.
+type MongoPayment struct {
+	ID            string     `bson:"id"`
+	OrderID       string     `bson:"order_id"`
+	UserID        int        `bson:"user_id"`
+	Amount        int        `bson:"amount"`
+	Currency      string     `bson:"currency"`
+	Status        string     `bson:"status"`
+	Reference     string     `bson:"reference,omitempty"`
+	TicketCodes   []string   `bson:"ticket_codes"`
+	Refunded      int        `bson:"refunded"`
+	RefundIDs     []string   `bson:"refund_ids"`
+	FailureReason string     `bson:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at"`
+	CapturedAt    *time.Time `bson:"captured_at,omitempty"`
+}
+
+func (mp *MongoPayment) ToDomain() *domain.Payment {
+	return &domain.Payment{
+		ID:            mp.ID,
+		OrderID:       mp.OrderID,
+		UserID:        mp.UserID,
+		Amount:        mp.Amount,
+		Currency:      mp.Currency,
+		Status:        mp.Status,
+		Reference:     mp.Reference,
+		TicketCodes:   mp.TicketCodes,
+		Refunded:      mp.Refunded,
+		RefundIDs:     mp.RefundIDs,
+		FailureReason: mp.FailureReason,
+		CreatedAt:     mp.CreatedAt,
+		UpdatedAt:     mp.UpdatedAt,
+		CapturedAt:    mp.CapturedAt,
+	}
+}
+
+func FromPayment(p *domain.Payment) *MongoPayment {
+	ticketCodes := p.TicketCodes
+	if ticketCodes == nil {
+		ticketCodes = []string{}
+	}
+	refundIDs := p.RefundIDs
+	if refundIDs == nil {
+		refundIDs = []string{}
+	}
+	return &MongoPayment{
+		ID:            p.ID,
+		OrderID:       p.OrderID,
+		UserID:        p.UserID,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		Status:        p.Status,
+		Reference:     p.Reference,
+		TicketCodes:   ticketCodes,
+		Refunded:      p.Refunded,
+		RefundIDs:     refundIDs,
+		FailureReason: p.FailureReason,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		CapturedAt:    p.CapturedAt,
+	}
+}
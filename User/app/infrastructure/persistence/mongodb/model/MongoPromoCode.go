@@ -0,0 +1,104 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoPromoCode is a promo code document. The limits are stored as null
+// when unset so redemptions can compare against them in a single write, and
+// PerUser counts the uses of each user, keyed by user id.
+type MongoPromoCode struct {
+	ID             string         `bson:"id"`
+	Code           string         `bson:"code"`
+	OwnerID        int            `bson:"owner_id"`
+	EventIDs       []int          `bson:"event_ids"`
+	PacketIDs      []int          `bson:"packet_ids"`
+	DiscountType   string         `bson:"discount_type"`
+	DiscountValue  int            `bson:"discount_value"`
+	MaxRedemptions *int           `bson:"max_redemptions"`
+	MaxPerUser     *int           `bson:"max_per_user"`
+	MinQuantity    int            `bson:"min_quantity"`
+	ValidFrom      *time.Time     `bson:"valid_from,omitempty"`
+	ValidUntil     *time.Time     `bson:"valid_until,omitempty"`
+	Active         bool           `bson:"active"`
+	Redemptions    int            `bson:"redemptions"`
+	PerUser        map[string]int `bson:"per_user"`
+	CreatedAt      time.Time      `bson:"created_at"`
+	UpdatedAt      time.Time      `bson:"updated_at"`
+}
+
+func (mp *MongoPromoCode) ToDomain() *domain.PromoCode {
+	return &domain.PromoCode{
+		ID:             mp.ID,
+		Code:           mp.Code,
+		OwnerID:        mp.OwnerID,
+		EventIDs:       mp.EventIDs,
+		PacketIDs:      mp.PacketIDs,
+		DiscountType:   mp.DiscountType,
+		DiscountValue:  mp.DiscountValue,
+		MaxRedemptions: mp.MaxRedemptions,
+		MaxPerUser:     mp.MaxPerUser,
+		MinQuantity:    mp.MinQuantity,
+		ValidFrom:      mp.ValidFrom,
+		ValidUntil:     mp.ValidUntil,
+		Active:         mp.Active,
+		Redemptions:    mp.Redemptions,
+		CreatedAt:      mp.CreatedAt,
+		UpdatedAt:      mp.UpdatedAt,
+	}
+}
+
+func FromPromoCode(p *domain.PromoCode) *MongoPromoCode {
+	return &MongoPromoCode{
+		ID:             p.ID,
+		Code:           p.Code,
+		OwnerID:        p.OwnerID,
+		EventIDs:       p.EventIDs,
+		PacketIDs:      p.PacketIDs,
+		DiscountType:   p.DiscountType,
+		DiscountValue:  p.DiscountValue,
+		MaxRedemptions: p.MaxRedemptions,
+		MaxPerUser:     p.MaxPerUser,
+		MinQuantity:    p.MinQuantity,
+		ValidFrom:      p.ValidFrom,
+		ValidUntil:     p.ValidUntil,
+		Active:         p.Active,
+		Redemptions:    p.Redemptions,
+		PerUser:        map[string]int{},
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+type MongoPromoRedemption struct {
+	ID          string     `bson:"id"`
+	PromoCodeID string     `bson:"promo_code_id"`
+	Code        string     `bson:"code"`
+	UserID      int        `bson:"user_id"`
+	EventID     *int       `bson:"event_id,omitempty"`
+	PacketID    *int       `bson:"packet_id,omitempty"`
+	Quantity    int        `bson:"quantity"`
+	Discount    int        `bson:"discount"`
+	TicketCodes []string   `bson:"ticket_codes,omitempty"`
+	Status      string     `bson:"status"`
+	RedeemedAt  time.Time  `bson:"redeemed_at"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty"`
+}
+
+func FromPromoRedemption(r *domain.PromoRedemption) *MongoPromoRedemption {
+	return &MongoPromoRedemption{
+		ID:          r.ID,
+		PromoCodeID: r.PromoCodeID,
+		Code:        r.Code,
+		UserID:      r.UserID,
+		EventID:     r.EventID,
+		PacketID:    r.PacketID,
+		Quantity:    r.Quantity,
+		Discount:    r.Discount,
+		TicketCodes: r.TicketCodes,
+		Status:      r.Status,
+		RedeemedAt:  r.RedeemedAt,
+		CompletedAt: r.CompletedAt,
+	}
+}
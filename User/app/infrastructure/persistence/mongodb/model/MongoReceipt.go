@@ -0,0 +1,134 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+type MongoReceiptItem struct {
+	EventID  *int       `bson:"event_id,omitempty"`
+	PacketID *int       `bson:"packet_id,omitempty"`
+	Name     string     `bson:"name"`
+	Location *string    `bson:"location,omitempty"`
+	City     *string    `bson:"city,omitempty"`
+	Country  *string    `bson:"country,omitempty"`
+	StartsAt *time.Time `bson:"starts_at,omitempty"`
+	EndsAt   *time.Time `bson:"ends_at,omitempty"`
+}
+
+type MongoReceiptLine struct {
+	Description string `bson:"description"`
+	Quantity    int    `bson:"quantity"`
+	UnitPrice   int    `bson:"unit_price"`
+	Amount      int    `bson:"amount"`
+}
+
+// MongoReceipt is a receipt document. The number is unique within the
+// organizer's series, so the insert that stores a receipt also claims its
+// number.
+type MongoReceipt struct {
+	ID          string             `bson:"id"`
+	OrderID     string             `bson:"order_id"`
+	OrganizerID int                `bson:"organizer_id"`
+	Number      int                `bson:"number"`
+	UserID      int                `bson:"user_id"`
+	BuyerName   string             `bson:"buyer_name"`
+	BuyerEmail  string             `bson:"buyer_email"`
+	Item        MongoReceiptItem   `bson:"item"`
+	Lines       []MongoReceiptLine `bson:"lines"`
+	Subtotal    int                `bson:"subtotal"`
+	Discount    int                `bson:"discount"`
+	Total       int                `bson:"total"`
+	TaxRate     int                `bson:"tax_rate"`
+	TaxAmount   int                `bson:"tax_amount"`
+	Currency    string             `bson:"currency"`
+	TicketCodes []string           `bson:"ticket_codes"`
+	PaymentID   *string            `bson:"payment_id,omitempty"`
+	IssuedAt    time.Time          `bson:"issued_at"`
+}
+
+func (mr *MongoReceipt) ToDomain() *domain.Receipt {
+	lines := make([]domain.ReceiptLine, 0, len(mr.Lines))
+	for _, line := range mr.Lines {
+		lines = append(lines, domain.ReceiptLine{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      line.Amount,
+		})
+	}
+	return &domain.Receipt{
+		ID:          mr.ID,
+		OrderID:     mr.OrderID,
+		OrganizerID: mr.OrganizerID,
+		Number:      mr.Number,
+		UserID:      mr.UserID,
+		BuyerName:   mr.BuyerName,
+		BuyerEmail:  mr.BuyerEmail,
+		Item: domain.ReceiptItem{
+			EventID:  mr.Item.EventID,
+			PacketID: mr.Item.PacketID,
+			Name:     mr.Item.Name,
+			Location: mr.Item.Location,
+			City:     mr.Item.City,
+			Country:  mr.Item.Country,
+			StartsAt: mr.Item.StartsAt,
+			EndsAt:   mr.Item.EndsAt,
+		},
+		Lines:       lines,
+		Subtotal:    mr.Subtotal,
+		Discount:    mr.Discount,
+		Total:       mr.Total,
+		TaxRate:     mr.TaxRate,
+		TaxAmount:   mr.TaxAmount,
+		Currency:    mr.Currency,
+		TicketCodes: mr.TicketCodes,
+		PaymentID:   mr.PaymentID,
+		IssuedAt:    mr.IssuedAt,
+	}
+}
+
+func FromReceipt(r *domain.Receipt) *MongoReceipt {
+	lines := make([]MongoReceiptLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, MongoReceiptLine{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      line.Amount,
+		})
+	}
+	ticketCodes := r.TicketCodes
+	if ticketCodes == nil {
+		ticketCodes = []string{}
+	}
+	return &MongoReceipt{
+		ID:          r.ID,
+		OrderID:     r.OrderID,
+		OrganizerID: r.OrganizerID,
+		Number:      r.Number,
+		UserID:      r.UserID,
+		BuyerName:   r.BuyerName,
+		BuyerEmail:  r.BuyerEmail,
+		Item: MongoReceiptItem{
+			EventID:  r.Item.EventID,
+			PacketID: r.Item.PacketID,
+			Name:     r.Item.Name,
+			Location: r.Item.Location,
+			City:     r.Item.City,
+			Country:  r.Item.Country,
+			StartsAt: r.Item.StartsAt,
+			EndsAt:   r.Item.EndsAt,
+		},
+		Lines:       lines,
+		Subtotal:    r.Subtotal,
+		Discount:    r.Discount,
+		Total:       r.Total,
+		TaxRate:     r.TaxRate,
+		TaxAmount:   r.TaxAmount,
+		Currency:    r.Currency,
+		TicketCodes: ticketCodes,
+		PaymentID:   r.PaymentID,
+		IssuedAt:    r.IssuedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoRefundRequest is a refund request document. Open mirrors whether the
+// refund still holds its ticket; a unique index over the open ones keeps a
+// ticket in at most one refund at a time.
+type MongoRefundRequest struct {
+	ID           string     `bson:"id"`
+	TicketCode   string     `bson:"ticket_code"`
+	EventID      *int       `bson:"event_id,omitempty"`
+	PacketID     *int       `bson:"packet_id,omitempty"`
+	UserID       int        `bson:"user_id"`
+	Reason       string     `bson:"reason,omitempty"`
+	Status       string     `bson:"status"`
+	Open         bool       `bson:"open"`
+	Amount       *int       `bson:"amount,omitempty"`
+	DecisionNote string     `bson:"decision_note,omitempty"`
+	DecidedBy    *int       `bson:"decided_by,omitempty"`
+	RequestedAt  time.Time  `bson:"requested_at"`
+	DecidedAt    *time.Time `bson:"decided_at,omitempty"`
+}
+
+func (mr *MongoRefundRequest) ToDomain() *domain.RefundRequest {
+	return &domain.RefundRequest{
+		ID:           mr.ID,
+		TicketCode:   mr.TicketCode,
+		EventID:      mr.EventID,
+		PacketID:     mr.PacketID,
+		UserID:       mr.UserID,
+		Reason:       mr.Reason,
+		Status:       mr.Status,
+		Amount:       mr.Amount,
+		DecisionNote: mr.DecisionNote,
+		DecidedBy:    mr.DecidedBy,
+		RequestedAt:  mr.RequestedAt,
+		DecidedAt:    mr.DecidedAt,
+	}
+}
+
+func FromRefundRequest(r *domain.RefundRequest) *MongoRefundRequest {
+	return &MongoRefundRequest{
+		ID:           r.ID,
+		TicketCode:   r.TicketCode,
+		EventID:      r.EventID,
+		PacketID:     r.PacketID,
+		UserID:       r.UserID,
+		Reason:       r.Reason,
+		Status:       r.Status,
+		Open:         r.Open(),
+		Amount:       r.Amount,
+		DecisionNote: r.DecisionNote,
+		DecidedBy:    r.DecidedBy,
+		RequestedAt:  r.RequestedAt,
+		DecidedAt:    r.DecidedAt,
+	}
+}
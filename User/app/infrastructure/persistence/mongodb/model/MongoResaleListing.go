@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoResaleListing is a resale listing document. Open mirrors whether the
+// listing still holds its ticket; a unique index over the open ones keeps a
+// ticket in at most one listing at a time.
+type MongoResaleListing struct {
+	ID         string     `bson:"id"`
+	TicketCode string     `bson:"ticket_code"`
+	NewCode    string     `bson:"new_code,omitempty"`
+	EventID    *int       `bson:"event_id,omitempty"`
+	PacketID   *int       `bson:"packet_id,omitempty"`
+	SellerID   int        `bson:"seller_id"`
+	BuyerID    *int       `bson:"buyer_id,omitempty"`
+	Price      int        `bson:"price"`
+	Status     string     `bson:"status"`
+	Open       bool       `bson:"open"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	SoldAt     *time.Time `bson:"sold_at,omitempty"`
+}
+
+func (ml *MongoResaleListing) ToDomain() *domain.ResaleListing {
+	return &domain.ResaleListing{
+		ID:         ml.ID,
+		TicketCode: ml.TicketCode,
+		NewCode:    ml.NewCode,
+		EventID:    ml.EventID,
+		PacketID:   ml.PacketID,
+		SellerID:   ml.SellerID,
+		BuyerID:    ml.BuyerID,
+		Price:      ml.Price,
+		Status:     ml.Status,
+		CreatedAt:  ml.CreatedAt,
+		SoldAt:     ml.SoldAt,
+	}
+}
+
+func FromResaleListing(l *domain.ResaleListing) *MongoResaleListing {
+	return &MongoResaleListing{
+		ID:         l.ID,
+		TicketCode: l.TicketCode,
+		NewCode:    l.NewCode,
+		EventID:    l.EventID,
+		PacketID:   l.PacketID,
+		SellerID:   l.SellerID,
+		BuyerID:    l.BuyerID,
+		Price:      l.Price,
+		Status:     l.Status,
+		Open:       l.Open(),
+		CreatedAt:  l.CreatedAt,
+		SoldAt:     l.SoldAt,
+	}
+}
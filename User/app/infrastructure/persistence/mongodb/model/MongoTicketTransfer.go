@@ -0,0 +1,103 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoTicketTransfer is a transfer document. Open mirrors whether the
+// transfer still holds its ticket; a unique index over the open ones keeps a
+// ticket in at most one transfer at a time.
+type MongoTicketTransfer struct {
+	ID             string     `bson:"id"`
+	TicketCode     string     `bson:"ticket_code"`
+	NewCode        string     `bson:"new_code"`
+	EventID        *int       `bson:"event_id,omitempty"`
+	PacketID       *int       `bson:"packet_id,omitempty"`
+	FromUserID     int        `bson:"from_user_id"`
+	ToUserID       int        `bson:"to_user_id"`
+	RecipientEmail string     `bson:"recipient_email"`
+	Status         string     `bson:"status"`
+	Open           bool       `bson:"open"`
+	CreatedAt      time.Time  `bson:"created_at"`
+	ExpiresAt      time.Time  `bson:"expires_at"`
+	CompletedAt    *time.Time `bson:"completed_at,omitempty"`
+}
+
+func (mt *MongoTicketTransfer) ToDomain() *domain.TicketTransfer {
+	return &domain.TicketTransfer{
+		ID:             mt.ID,
+		TicketCode:     mt.TicketCode,
+		NewCode:        mt.NewCode,
+		EventID:        mt.EventID,
+		PacketID:       mt.PacketID,
+		FromUserID:     mt.FromUserID,
+		ToUserID:       mt.ToUserID,
+		RecipientEmail: mt.RecipientEmail,
+		Status:         mt.Status,
+		CreatedAt:      mt.CreatedAt,
+		ExpiresAt:      mt.ExpiresAt,
+		CompletedAt:    mt.CompletedAt,
+	}
+}
+
+func FromTicketTransfer(t *domain.TicketTransfer) *MongoTicketTransfer {
+	return &MongoTicketTransfer{
+		ID:             t.ID,
+		TicketCode:     t.TicketCode,
+		NewCode:        t.NewCode,
+		EventID:        t.EventID,
+		PacketID:       t.PacketID,
+		FromUserID:     t.FromUserID,
+		ToUserID:       t.ToUserID,
+		RecipientEmail: t.RecipientEmail,
+		Status:         t.Status,
+		Open:           t.Open(),
+		CreatedAt:      t.CreatedAt,
+		ExpiresAt:      t.ExpiresAt,
+		CompletedAt:    t.CompletedAt,
+	}
+}
+
+type MongoTicketAuditEntry struct {
+	ID             string    `bson:"id"`
+	UserID         int       `bson:"user_id"`
+	Action         string    `bson:"action"`
+	Direction      string    `bson:"direction"`
+	TicketCode     string    `bson:"ticket_code,omitempty"`
+	EventID        *int      `bson:"event_id,omitempty"`
+	PacketID       *int      `bson:"packet_id,omitempty"`
+	TransferID     string    `bson:"transfer_id"`
+	CounterpartyID int       `bson:"counterparty_id"`
+	At             time.Time `bson:"at"`
+}
+
+func (me *MongoTicketAuditEntry) ToDomain() *domain.TicketAuditEntry {
+	return &domain.TicketAuditEntry{
+		ID:             me.ID,
+		UserID:         me.UserID,
+		Action:         me.Action,
+		Direction:      me.Direction,
+		TicketCode:     me.TicketCode,
+		EventID:        me.EventID,
+		PacketID:       me.PacketID,
+		TransferID:     me.TransferID,
+		CounterpartyID: me.CounterpartyID,
+		At:             me.At,
+	}
+}
+
+func FromTicketAuditEntry(e *domain.TicketAuditEntry) *MongoTicketAuditEntry {
+	return &MongoTicketAuditEntry{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		Action:         e.Action,
+		Direction:      e.Direction,
+		TicketCode:     e.TicketCode,
+		EventID:        e.EventID,
+		PacketID:       e.PacketID,
+		TransferID:     e.TransferID,
+		CounterpartyID: e.CounterpartyID,
+		At:             e.At,
+	}
+}
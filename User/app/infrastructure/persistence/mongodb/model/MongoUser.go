@@ -4,6 +4,9 @@ import (
 	"userService/application/domain"
 )
 
+// MongoTicket is a ticket embedded in the ticket_list of a user document,
+// where tickets lived before they moved to the user_tickets collection. It is
+// only read by the migration.
 type MongoTicket struct {
 	PacketID *int   `bson:"packet_id,omitempty"`
 	EventID  *int   `bson:"event_id,omitempty"`
@@ -11,56 +14,36 @@ type MongoTicket struct {
 }
 
 type MongoUser struct {
-	ID               int           `bson:"id"`
-	Email            string        `bson:"email"`
-	FirstName        string        `bson:"first_name"`
-	LastName         string        `bson:"last_name"`
-	SocialMediaLinks *string       `bson:"social_media_links,omitempty"`
-	TicketList       []MongoTicket `bson:"ticket_list,omitempty"`
+	ID               int     `bson:"id"`
+	Email            string  `bson:"email"`
+	FirstName        string  `bson:"first_name"`
+	LastName         string  `bson:"last_name"`
+	SocialMediaLinks *string `bson:"social_media_links,omitempty"`
 
 	FirstNamePrivate bool `bson:"first_name_private"`
 	LastNamePrivate  bool `bson:"last_name_private"`
 }
 
+// ToDomain leaves TicketList empty; tickets are loaded from user_tickets.
 func (mu *MongoUser) ToDomain() *domain.User {
-	domainTickets := make([]domain.Ticket, len(mu.TicketList))
-	for i, mt := range mu.TicketList {
-		domainTickets[i] = domain.Ticket{
-			PacketID: mt.PacketID,
-			EventID:  mt.EventID,
-			Code:     mt.Code,
-		}
-	}
-
 	return &domain.User{
 		ID:               mu.ID,
 		Email:            mu.Email,
 		FirstName:        mu.FirstName,
 		LastName:         mu.LastName,
 		SocialMediaLinks: mu.SocialMediaLinks,
-		TicketList:       domainTickets,
 		FirstNamePrivate: mu.FirstNamePrivate,
 		LastNamePrivate:  mu.LastNamePrivate,
 	}
 }
 
 func FromUser(u *domain.User) *MongoUser {
-	mongoTickets := make([]MongoTicket, len(u.TicketList))
-	for i, dt := range u.TicketList {
-		mongoTickets[i] = MongoTicket{
-			PacketID: dt.PacketID,
-			EventID:  dt.EventID,
-			Code:     dt.Code,
-		}
-	}
-
 	return &MongoUser{
 		ID:               u.ID,
 		Email:            u.Email,
 		FirstName:        u.FirstName,
 		LastName:         u.LastName,
 		SocialMediaLinks: u.SocialMediaLinks,
-		TicketList:       mongoTickets,
 		FirstNamePrivate: u.FirstNamePrivate,
 		LastNamePrivate:  u.LastNamePrivate,
 	}
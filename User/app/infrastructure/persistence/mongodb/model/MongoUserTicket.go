@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+type MongoUserTicket struct {
+	UserID      int       `bson:"user_id"`
+	Code        string    `bson:"code"`
+	EventID     *int      `bson:"event_id,omitempty"`
+	PacketID    *int      `bson:"packet_id,omitempty"`
+	PurchasedAt time.Time `bson:"purchased_at"`
+}
+
+func (mt *MongoUserTicket) ToDomain() domain.Ticket {
+	return domain.Ticket{
+		PacketID:    mt.PacketID,
+		EventID:     mt.EventID,
+		Code:        mt.Code,
+		PurchasedAt: mt.PurchasedAt,
+	}
+}
+
+func FromUserTicket(userID int, t *domain.Ticket) *MongoUserTicket {
+	return &MongoUserTicket{
+		UserID:      userID,
+		Code:        t.Code,
+		EventID:     t.EventID,
+		PacketID:    t.PacketID,
+		PurchasedAt: t.PurchasedAt,
+	}
+}
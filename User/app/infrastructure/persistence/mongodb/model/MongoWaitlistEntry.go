@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+	"userService/application/domain"
+)
+
+// MongoWaitlistEntry is a waitlist document. Open mirrors whether the user
+// is still in line or holds an offer; a unique index over the open ones
+// keeps a user in one line per event or packet.
+type MongoWaitlistEntry struct {
+	ID             string     `bson:"id"`
+	EventID        *int       `bson:"event_id"`
+	PacketID       *int       `bson:"packet_id"`
+	UserID         int        `bson:"user_id"`
+	Status         string     `bson:"status"`
+	Open           bool       `bson:"open"`
+	JoinedAt       time.Time  `bson:"joined_at"`
+	OfferedAt      *time.Time `bson:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time `bson:"offer_expires_at,omitempty"`
+	ClosedAt       *time.Time `bson:"closed_at,omitempty"`
+}
+
+func (mw *MongoWaitlistEntry) ToDomain() *domain.WaitlistEntry {
+	return &domain.WaitlistEntry{
+		ID:             mw.ID,
+		EventID:        mw.EventID,
+		PacketID:       mw.PacketID,
+		UserID:         mw.UserID,
+		Status:         mw.Status,
+		JoinedAt:       mw.JoinedAt,
+		OfferedAt:      mw.OfferedAt,
+		OfferExpiresAt: mw.OfferExpiresAt,
+		ClosedAt:       mw.ClosedAt,
+	}
+}
+
+func FromWaitlistEntry(e *domain.WaitlistEntry) *MongoWaitlistEntry {
+	return &MongoWaitlistEntry{
+		ID:             e.ID,
+		EventID:        e.EventID,
+		PacketID:       e.PacketID,
+		UserID:         e.UserID,
+		Status:         e.Status,
+		Open:           e.Open(),
+		JoinedAt:       e.JoinedAt,
+		OfferedAt:      e.OfferedAt,
+		OfferExpiresAt: e.OfferExpiresAt,
+		ClosedAt:       e.ClosedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoCalendarFeedRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoCalendarFeedRepository(db *mongo.Database) *MongoCalendarFeedRepository {
+	return &MongoCalendarFeedRepository{
+		Collection: db.Collection("calendar_feeds"),
+	}
+}
+
+func (r *MongoCalendarFeedRepository) GetByUserID(ctx context.Context, userID int) (*domain.CalendarFeed, error) {
+	var feed model.MongoCalendarFeed
+	err := r.Collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&feed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "calendar", ID: strconv.Itoa(userID)}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve calendar feed", Err: err}
+	}
+	return feed.ToDomain(), nil
+}
+
+// Create upserts with $setOnInsert, so of two first requests for a feed
+// both get the token of the one that won.
+func (r *MongoCalendarFeedRepository) Create(ctx context.Context, feed *domain.CalendarFeed) (*domain.CalendarFeed, error) {
+	update := bson.M{"$setOnInsert": bson.M{
+		"user_id":    feed.UserID,
+		"token":      feed.Token,
+		"created_at": feed.CreatedAt,
+	}}
+	return r.upsert(ctx, feed.UserID, update)
+}
+
+func (r *MongoCalendarFeedRepository) Replace(ctx context.Context, feed *domain.CalendarFeed) (*domain.CalendarFeed, error) {
+	update := bson.M{"$set": bson.M{
+		"token":      feed.Token,
+		"created_at": feed.CreatedAt,
+	}}
+	return r.upsert(ctx, feed.UserID, update)
+}
+
+func (r *MongoCalendarFeedRepository) upsert(ctx context.Context, userID int, update bson.M) (*domain.CalendarFeed, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var stored model.MongoCalendarFeed
+	err := r.Collection.FindOneAndUpdate(ctx, bson.M{"user_id": userID}, update, opts).Decode(&stored)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to store calendar feed", Err: err}
+	}
+	return stored.ToDomain(), nil
+}
+
+// CreateIndexes keeps one feed per user and tokens unique.
+func (r *MongoCalendarFeedRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "token", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
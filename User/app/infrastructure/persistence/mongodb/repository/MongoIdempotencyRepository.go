@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"shared/idempotency"
+)
+
+type MongoIdempotencyRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoIdempotencyRepository(db *mongo.Database) *MongoIdempotencyRepository {
+	return &MongoIdempotencyRepository{
+		Collection: db.Collection("idempotency_keys"),
+	}
+}
+
+// Reserve first drops the key if it expired or its request was abandoned, so
+// a stale document the TTL monitor has not removed yet never blocks a retry.
+func (r *MongoIdempotencyRepository) Reserve(ctx context.Context, record *idempotency.Record) (*idempotency.Record, bool, error) {
+	now := time.Now()
+	_, err := r.Collection.DeleteOne(ctx, bson.M{
+		"scope": record.Scope,
+		"key":   record.Key,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lte": now}},
+			bson.M{"status_code": 0, "created_at": bson.M{"$lte": now.Add(-idempotency.LockTimeout)}},
+		},
+	})
+	if err != nil {
+		return nil, false, &domain.InternalError{Msg: "failed to reserve idempotency key", Err: err}
+	}
+
+	_, err = r.Collection.InsertOne(ctx, model.FromIdempotencyRecord(record))
+	if err == nil {
+		return nil, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, &domain.InternalError{Msg: "failed to reserve idempotency key", Err: err}
+	}
+
+	var existing model.MongoIdempotencyKey
+	err = r.Collection.FindOne(ctx, bson.M{"scope": record.Scope, "key": record.Key}).Decode(&existing)
+	if err != nil {
+		return nil, false, &domain.InternalError{Msg: "failed to load idempotency key", Err: err}
+	}
+
+	return existing.ToDomain(), false, nil
+}
+
+func (r *MongoIdempotencyRepository) Complete(ctx context.Context, record *idempotency.Record) error {
+	_, err := r.Collection.UpdateOne(ctx,
+		bson.M{"scope": record.Scope, "key": record.Key},
+		bson.M{"$set": bson.M{
+			"status_code":   record.StatusCode,
+			"content_type":  record.ContentType,
+			"response_body": record.ResponseBody,
+			"expires_at":    record.ExpiresAt,
+		}},
+	)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to store idempotent response", Err: err}
+	}
+	return nil
+}
+
+func (r *MongoIdempotencyRepository) Release(ctx context.Context, scope string, key string) error {
+	_, err := r.Collection.DeleteOne(ctx, bson.M{"scope": scope, "key": key})
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to release idempotency key", Err: err}
+	}
+	return nil
+}
+
+// CreateIndexes makes keys unique per scope and lets MongoDB expire stored
+// responses once their retention window is over.
+func (r *MongoIdempotencyRepository) CreateIndexes(ctx context.Context) error {
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "scope", Value: 1}, {Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		return err
+	}
+
+	indexModel = mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	_, err = r.Collection.Indexes().CreateOne(ctx, indexModel)
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		return err
+	}
+
+	return nil
+}
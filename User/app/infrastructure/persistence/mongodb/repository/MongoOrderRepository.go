@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoOrderRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoOrderRepository(db *mongo.Database) *MongoOrderRepository {
+	return &MongoOrderRepository{
+		Collection: db.Collection("orders"),
+	}
+}
+
+func (r *MongoOrderRepository) Create(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	if _, err := r.Collection.InsertOne(ctx, model.FromOrder(order)); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to store order", Err: err}
+	}
+	return order, nil
+}
+
+func (r *MongoOrderRepository) GetByID(ctx context.Context, id string) (*domain.Order, error) {
+	var order model.MongoOrder
+	err := r.Collection.FindOne(ctx, bson.M{"id": id}).Decode(&order)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "order", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve order", Err: err}
+	}
+	return order.ToDomain(), nil
+}
+
+// GetByUserID lists the orders of a user, newest first.
+func (r *MongoOrderRepository) GetByUserID(ctx context.Context, userID int) ([]*domain.Order, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve orders", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoOrders []model.MongoOrder
+	if err := cursor.All(ctx, &mongoOrders); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode orders", Err: err}
+	}
+
+	orders := make([]*domain.Order, 0, len(mongoOrders))
+	for i := range mongoOrders {
+		orders = append(orders, mongoOrders[i].ToDomain())
+	}
+	return orders, nil
+}
+
+func (r *MongoOrderRepository) Complete(ctx context.Context, id string, ticketCodes []string, at time.Time) (*domain.Order, error) {
+	return r.settle(ctx, id, bson.M{
+		"status":       domain.OrderCompleted,
+		"ticket_codes": ticketCodes,
+		"completed_at": at,
+	})
+}
+
+func (r *MongoOrderRepository) Fail(ctx context.Context, id string, reason string) (*domain.Order, error) {
+	return r.settle(ctx, id, bson.M{
+		"status":         domain.OrderFailed,
+		"failure_reason": reason,
+	})
+}
+
+// settle applies set to the order while it is still pending.
+func (r *MongoOrderRepository) settle(ctx context.Context, id string, set bson.M) (*domain.Order, error) {
+	var updated model.MongoOrder
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": domain.OrderPending},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to update order", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &domain.InternalError{Msg: fmt.Sprintf("order %s is already %s", id, current.Status)}
+}
+
+// CreateIndexes backs the order listing of a user.
+func (r *MongoOrderRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoPaymentRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoPaymentRepository(db *mongo.Database) *MongoPaymentRepository {
+	return &MongoPaymentRepository{
+		Collection: db.Collection("payments"),
+	}
+}
+
+func (r *MongoPaymentRepository) Create(ctx context.Context, payment *domain.Payment) (*domain.Payment, error) {
+	if _, err := r.Collection.InsertOne(ctx, model.FromPayment(payment)); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to store payment", Err: err}
+	}
+	return payment, nil
+}
+
+func (r *MongoPaymentRepository) GetByID(ctx context.Context, id string) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"id": id}, id)
+}
+
+func (r *MongoPaymentRepository) GetByOrderID(ctx context.Context, orderID string) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"order_id": orderID}, orderID)
+}
+
+func (r *MongoPaymentRepository) GetByReference(ctx context.Context, reference string) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"reference": reference}, reference)
+}
+
+func (r *MongoPaymentRepository) GetByTicketCode(ctx context.Context, code string) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"ticket_codes": code, "status": domain.PaymentCaptured}, code)
+}
+
+func (r *MongoPaymentRepository) findOne(ctx context.Context, filter bson.M, id string) (*domain.Payment, error) {
+	var payment model.MongoPayment
+	err := r.Collection.FindOne(ctx, filter).Decode(&payment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "payment", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve payment", Err: err}
+	}
+	return payment.ToDomain(), nil
+}
+
+// UpdateStatus is a single conditional write, so a webhook and the purchase
+// racing over the same payment cannot both move it.
+func (r *MongoPaymentRepository) UpdateStatus(ctx context.Context, id string, from []string, status string, ticketCodes []string, reason string, at time.Time) (*domain.Payment, error) {
+	set := bson.M{
+		"status":     status,
+		"updated_at": at,
+	}
+	if ticketCodes != nil {
+		set["ticket_codes"] = ticketCodes
+	}
+	if reason != "" {
+		set["failure_reason"] = reason
+	}
+	if status == domain.PaymentCaptured {
+		set["captured_at"] = at
+	}
+
+	var updated model.MongoPayment
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": bson.M{"$in": from}},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to update payment", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(from, current.Status) {
+		return nil, &domain.InternalError{Msg: "payment changed while being updated"}
+	}
+	return nil, &domain.PaymentStateError{ID: id, Status: current.Status}
+}
+
+// AddRefund adds amount to what was paid back unless refundID already did,
+// or the payment would pay back more than was captured.
+func (r *MongoPaymentRepository) AddRefund(ctx context.Context, id string, refundID string, amount int, at time.Time) (*domain.Payment, error) {
+	var updated model.MongoPayment
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"id":         id,
+			"status":     domain.PaymentCaptured,
+			"refund_ids": bson.M{"$ne": refundID},
+			"$expr":      bson.M{"$lte": bson.A{bson.M{"$add": bson.A{"$refunded", amount}}, "$amount"}},
+		},
+		bson.M{
+			"$inc":  bson.M{"refunded": amount},
+			"$push": bson.M{"refund_ids": refundID},
+			"$set":  bson.M{"updated_at": at},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to record refund", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case slices.Contains(current.RefundIDs, refundID):
+		return current, nil
+	case current.Status != domain.PaymentCaptured:
+		return nil, &domain.PaymentStateError{ID: id, Status: current.Status}
+	}
+	return nil, &domain.ValidationError{Field: "amount", Reason: fmt.Sprintf("only %d of the payment can still be refunded", current.Refundable())}
+}
+
+func (r *MongoPaymentRepository) SyncRefunded(ctx context.Context, id string, refunded int, at time.Time) (*domain.Payment, error) {
+	var updated model.MongoPayment
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id},
+		bson.M{
+			"$max": bson.M{"refunded": refunded},
+			"$set": bson.M{"updated_at": at},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "payment", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to update payment", Err: err}
+	}
+	return updated.ToDomain(), nil
+}
+
+// CreateIndexes keeps one payment per order and gateway reference and backs
+// the lookup of a refunded ticket's payment.
+func (r *MongoPaymentRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "reference", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"reference": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys: bson.D{{Key: "ticket_codes", Value: 1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
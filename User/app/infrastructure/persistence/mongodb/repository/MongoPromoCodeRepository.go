@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoPromoCodeRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoPromoCodeRepository(db *mongo.Database) *MongoPromoCodeRepository {
+	return &MongoPromoCodeRepository{
+		Collection: db.Collection("promo_codes"),
+	}
+}
+
+func (r *MongoPromoCodeRepository) Create(ctx context.Context, promo *domain.PromoCode) (*domain.PromoCode, error) {
+	_, err := r.Collection.InsertOne(ctx, model.FromPromoCode(promo))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, &domain.PromoCodeTakenError{Code: promo.Code}
+		}
+		return nil, &domain.InternalError{Msg: "failed to store promo code", Err: err}
+	}
+	return promo, nil
+}
+
+func (r *MongoPromoCodeRepository) GetByID(ctx context.Context, id string) (*domain.PromoCode, error) {
+	return r.findOne(ctx, bson.M{"id": id}, id)
+}
+
+func (r *MongoPromoCodeRepository) GetByCode(ctx context.Context, code string) (*domain.PromoCode, error) {
+	return r.findOne(ctx, bson.M{"code": code}, code)
+}
+
+func (r *MongoPromoCodeRepository) findOne(ctx context.Context, filter bson.M, id string) (*domain.PromoCode, error) {
+	var promo model.MongoPromoCode
+	err := r.Collection.FindOne(ctx, filter).Decode(&promo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "promo code", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve promo code", Err: err}
+	}
+	return promo.ToDomain(), nil
+}
+
+// GetByOwnerID lists the codes of an owner, newest first.
+func (r *MongoPromoCodeRepository) GetByOwnerID(ctx context.Context, ownerID int) ([]*domain.PromoCode, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Collection.Find(ctx, bson.M{"owner_id": ownerID}, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve promo codes", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoPromos []model.MongoPromoCode
+	if err := cursor.All(ctx, &mongoPromos); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode promo codes", Err: err}
+	}
+
+	promos := make([]*domain.PromoCode, 0, len(mongoPromos))
+	for i := range mongoPromos {
+		promos = append(promos, mongoPromos[i].ToDomain())
+	}
+	return promos, nil
+}
+
+func (r *MongoPromoCodeRepository) Update(ctx context.Context, id string, updates map[string]interface{}, updatedAt time.Time) (*domain.PromoCode, error) {
+	set := bson.M{"updated_at": updatedAt}
+	for key, value := range updates {
+		set[key] = value
+	}
+
+	var updated model.MongoPromoCode
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "promo code", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to update promo code", Err: err}
+	}
+	return updated.ToDomain(), nil
+}
+
+func (r *MongoPromoCodeRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.Collection.DeleteOne(ctx, bson.M{"id": id, "redemptions": 0})
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to delete promo code", Err: err}
+	}
+	if result.DeletedCount == 1 {
+		return nil
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return &domain.PromoCodeInUseError{Code: current.Code}
+}
+
+func (r *MongoPromoCodeRepository) Redeem(ctx context.Context, id string, userID int) (*domain.PromoCode, error) {
+	userUses := "per_user." + strconv.Itoa(userID)
+	filter := bson.M{
+		"id":     id,
+		"active": true,
+		"$expr": bson.M{"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"$eq": bson.A{"$max_redemptions", nil}},
+				bson.M{"$lt": bson.A{"$redemptions", "$max_redemptions"}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"$eq": bson.A{"$max_per_user", nil}},
+				bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$" + userUses, 0}}, "$max_per_user"}},
+			}},
+		}},
+	}
+
+	var updated model.MongoPromoCode
+	err := r.Collection.FindOneAndUpdate(ctx,
+		filter,
+		bson.M{"$inc": bson.M{"redemptions": 1, userUses: 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to redeem promo code", Err: err}
+	}
+
+	var current model.MongoPromoCode
+	if err := r.Collection.FindOne(ctx, bson.M{"id": id}).Decode(&current); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "promo code", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve promo code", Err: err}
+	}
+	if !current.Active {
+		return nil, &domain.PromoCodeNotApplicableError{Detail: "promo code " + current.Code + " is no longer active"}
+	}
+	perUser := current.MaxPerUser != nil && current.PerUser[strconv.Itoa(userID)] >= *current.MaxPerUser
+	return nil, &domain.PromoCodeExhaustedError{Code: current.Code, PerUser: perUser}
+}
+
+func (r *MongoPromoCodeRepository) Release(ctx context.Context, id string, userID int) error {
+	userUses := "per_user." + strconv.Itoa(userID)
+	_, err := r.Collection.UpdateOne(ctx,
+		bson.M{"id": id, "redemptions": bson.M{"$gt": 0}, userUses: bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"redemptions": -1, userUses: -1}},
+	)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to release promo code", Err: err}
+	}
+	return nil
+}
+
+// CreateIndexes keeps codes unique across owners and backs the listing of
+// an owner's codes.
+func (r *MongoPromoCodeRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
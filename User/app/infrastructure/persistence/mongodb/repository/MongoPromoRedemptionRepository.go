@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoPromoRedemptionRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoPromoRedemptionRepository(db *mongo.Database) *MongoPromoRedemptionRepository {
+	return &MongoPromoRedemptionRepository{
+		Collection: db.Collection("promo_redemptions"),
+	}
+}
+
+func (r *MongoPromoRedemptionRepository) Create(ctx context.Context, redemption *domain.PromoRedemption) (*domain.PromoRedemption, error) {
+	if _, err := r.Collection.InsertOne(ctx, model.FromPromoRedemption(redemption)); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to store promo redemption", Err: err}
+	}
+	return redemption, nil
+}
+
+func (r *MongoPromoRedemptionRepository) UpdateStatus(ctx context.Context, id string, status string, ticketCodes []string, at time.Time) error {
+	set := bson.M{"status": status}
+	if status == domain.RedemptionCompleted {
+		set["ticket_codes"] = ticketCodes
+		set["completed_at"] = at
+	}
+
+	result, err := r.Collection.UpdateOne(ctx,
+		bson.M{"id": id, "status": domain.RedemptionReserved},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to update promo redemption", Err: err}
+	}
+	if result.MatchedCount == 0 {
+		return &domain.ResourceNotFoundError{Resource: "promo redemption", ID: id}
+	}
+	return nil
+}
+
+type promoUserUsage struct {
+	UserID      int       `bson:"_id"`
+	Redemptions int       `bson:"redemptions"`
+	Tickets     int       `bson:"tickets"`
+	Discount    int       `bson:"discount"`
+	LastUsedAt  time.Time `bson:"last_used_at"`
+}
+
+// GetUsage groups the completed redemptions by user, the most recent users
+// first; the totals are summed from the groups.
+func (r *MongoPromoRedemptionRepository) GetUsage(ctx context.Context, promoCodeID string) (*domain.PromoCodeUsage, error) {
+	cursor, err := r.Collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"promo_code_id": promoCodeID, "status": domain.RedemptionCompleted}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$user_id",
+			"redemptions":  bson.M{"$sum": 1},
+			"tickets":      bson.M{"$sum": "$quantity"},
+			"discount":     bson.M{"$sum": "$discount"},
+			"last_used_at": bson.M{"$max": "$completed_at"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "last_used_at", Value: -1}, {Key: "_id", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to aggregate promo redemptions", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var groups []promoUserUsage
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode promo redemptions", Err: err}
+	}
+
+	usage := &domain.PromoCodeUsage{Users: make([]*domain.PromoUserUsage, 0, len(groups))}
+	for _, group := range groups {
+		usage.Redemptions += group.Redemptions
+		usage.Tickets += group.Tickets
+		usage.Discount += group.Discount
+		usage.Users = append(usage.Users, &domain.PromoUserUsage{
+			UserID:      group.UserID,
+			Redemptions: group.Redemptions,
+			Tickets:     group.Tickets,
+			Discount:    group.Discount,
+			LastUsedAt:  group.LastUsedAt,
+		})
+	}
+	return usage, nil
+}
+
+// CreateIndexes backs the usage reports.
+func (r *MongoPromoRedemptionRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "promo_code_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxIssueAttempts bounds how often Issue moves on to the next number when
+// other receipts of the same organizer keep taking it first.
+const maxIssueAttempts = 10
+
+type MongoReceiptRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoReceiptRepository(db *mongo.Database) *MongoReceiptRepository {
+	return &MongoReceiptRepository{
+		Collection: db.Collection("receipts"),
+	}
+}
+
+// Issue numbers the receipt one past the highest number of its organizer
+// and inserts it. The receipt and its number are a single document, so a
+// number is only ever taken by a stored receipt and the series has no gaps;
+// the unique index on organizer and number turns a race for the same
+// number into a retry with the next one.
+func (r *MongoReceiptRepository) Issue(ctx context.Context, receipt *domain.Receipt) (*domain.Receipt, error) {
+	var notFound *domain.ResourceNotFoundError
+	for attempt := 0; attempt < maxIssueAttempts; attempt++ {
+		last, err := r.lastNumber(ctx, receipt.OrganizerID)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Number = last + 1
+
+		_, err = r.Collection.InsertOne(ctx, model.FromReceipt(receipt))
+		if err == nil {
+			return receipt, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, &domain.InternalError{Msg: "failed to store receipt", Err: err}
+		}
+
+		existing, err := r.GetByOrderID(ctx, receipt.OrderID)
+		if err == nil {
+			return existing, nil
+		} else if !errors.As(err, &notFound) {
+			return nil, err
+		}
+	}
+	return nil, &domain.InternalError{Msg: "failed to assign a receipt number"}
+}
+
+func (r *MongoReceiptRepository) lastNumber(ctx context.Context, organizerID int) (int, error) {
+	var last model.MongoReceipt
+	err := r.Collection.FindOne(ctx,
+		bson.M{"organizer_id": organizerID},
+		options.FindOne().SetSort(bson.D{{Key: "number", Value: -1}}).SetProjection(bson.M{"number": 1}),
+	).Decode(&last)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	} else if err != nil {
+		return 0, &domain.InternalError{Msg: "failed to read receipt numbers", Err: err}
+	}
+	return last.Number, nil
+}
+
+func (r *MongoReceiptRepository) GetByID(ctx context.Context, id string) (*domain.Receipt, error) {
+	return r.findOne(ctx, bson.M{"id": id}, id)
+}
+
+func (r *MongoReceiptRepository) GetByOrderID(ctx context.Context, orderID string) (*domain.Receipt, error) {
+	return r.findOne(ctx, bson.M{"order_id": orderID}, orderID)
+}
+
+func (r *MongoReceiptRepository) findOne(ctx context.Context, filter bson.M, id string) (*domain.Receipt, error) {
+	var receipt model.MongoReceipt
+	err := r.Collection.FindOne(ctx, filter).Decode(&receipt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "receipt", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve receipt", Err: err}
+	}
+	return receipt.ToDomain(), nil
+}
+
+// GetByUserID lists the receipts of a user, newest first.
+func (r *MongoReceiptRepository) GetByUserID(ctx context.Context, userID int) ([]*domain.Receipt, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "issued_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve receipts", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoReceipts []model.MongoReceipt
+	if err := cursor.All(ctx, &mongoReceipts); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode receipts", Err: err}
+	}
+
+	receipts := make([]*domain.Receipt, 0, len(mongoReceipts))
+	for i := range mongoReceipts {
+		receipts = append(receipts, mongoReceipts[i].ToDomain())
+	}
+	return receipts, nil
+}
+
+// CreateIndexes keeps the numbers of each organizer unique and one receipt
+// per order.
+func (r *MongoReceiptRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "organizer_id", Value: 1}, {Key: "number", Value: -1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "issued_at", Value: -1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRefundRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoRefundRepository(db *mongo.Database) *MongoRefundRepository {
+	return &MongoRefundRepository{
+		Collection: db.Collection("refund_requests"),
+	}
+}
+
+func (r *MongoRefundRepository) Create(ctx context.Context, refund *domain.RefundRequest) (*domain.RefundRequest, error) {
+	_, err := r.Collection.InsertOne(ctx, model.FromRefundRequest(refund))
+	if err == nil {
+		return refund, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, &domain.InternalError{Msg: "failed to store refund request", Err: err}
+	}
+
+	open, err := r.GetOpenByTicketCode(ctx, refund.TicketCode)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &domain.RefundStateError{ID: open.ID, Status: open.Status}
+}
+
+func (r *MongoRefundRepository) GetByID(ctx context.Context, id string) (*domain.RefundRequest, error) {
+	return r.findOne(ctx, bson.M{"id": id}, id)
+}
+
+func (r *MongoRefundRepository) GetOpenByTicketCode(ctx context.Context, code string) (*domain.RefundRequest, error) {
+	return r.findOne(ctx, bson.M{"ticket_code": code, "open": true}, code)
+}
+
+func (r *MongoRefundRepository) findOne(ctx context.Context, filter bson.M, id string) (*domain.RefundRequest, error) {
+	var refund model.MongoRefundRequest
+	err := r.Collection.FindOne(ctx, filter).Decode(&refund)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "refund", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve refund request", Err: err}
+	}
+	return refund.ToDomain(), nil
+}
+
+// GetByUserID lists the refunds a user asked for, newest first.
+func (r *MongoRefundRepository) GetByUserID(ctx context.Context, userID int, status *string) ([]*domain.RefundRequest, error) {
+	match := bson.M{"user_id": userID}
+	if status != nil {
+		match["status"] = *status
+	}
+	return r.find(ctx, match)
+}
+
+// GetByFilter lists the refunds of an event or packet, newest first.
+func (r *MongoRefundRepository) GetByFilter(ctx context.Context, filter *domain.RefundFilter) ([]*domain.RefundRequest, error) {
+	match := bson.M{}
+	if filter.EventID != nil {
+		match["event_id"] = *filter.EventID
+	}
+	if filter.PacketID != nil {
+		match["packet_id"] = *filter.PacketID
+	}
+	if filter.Status != nil {
+		match["status"] = *filter.Status
+	}
+	return r.find(ctx, match)
+}
+
+func (r *MongoRefundRepository) find(ctx context.Context, match bson.M) ([]*domain.RefundRequest, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "requested_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Collection.Find(ctx, match, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve refund requests", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoRefunds []model.MongoRefundRequest
+	if err := cursor.All(ctx, &mongoRefunds); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode refund requests", Err: err}
+	}
+
+	refunds := make([]*domain.RefundRequest, 0, len(mongoRefunds))
+	for i := range mongoRefunds {
+		refunds = append(refunds, mongoRefunds[i].ToDomain())
+	}
+	return refunds, nil
+}
+
+// UpdateStatus is a single conditional write, so two deciders racing over
+// the same refund cannot both get it.
+func (r *MongoRefundRepository) UpdateStatus(ctx context.Context, id string, from []string, status string, decision *domain.RefundDecision, decidedAt *time.Time) (*domain.RefundRequest, error) {
+	set := bson.M{
+		"status": status,
+		"open":   status == domain.RefundRequested || status == domain.RefundApproving,
+	}
+	if decision != nil {
+		set["decided_by"] = decision.DecidedBy
+		set["decision_note"] = decision.Note
+		if decision.Amount != nil {
+			set["amount"] = *decision.Amount
+		}
+	}
+	if decidedAt != nil {
+		set["decided_at"] = *decidedAt
+	}
+
+	var updated model.MongoRefundRequest
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": bson.M{"$in": from}},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to update refund request", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(from, current.Status) {
+		return nil, &domain.InternalError{Msg: "refund request changed while being updated"}
+	}
+	return nil, &domain.RefundStateError{ID: id, Status: current.Status}
+}
+
+// CreateIndexes keeps one open refund per ticket and backs the listings of
+// requesters and deciders.
+func (r *MongoRefundRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "ticket_code", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"open": true}),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "requested_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "requested_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "packet_id", Value: 1}, {Key: "requested_at", Value: -1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoResaleListingRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoResaleListingRepository(db *mongo.Database) *MongoResaleListingRepository {
+	return &MongoResaleListingRepository{
+		Collection: db.Collection("resale_listings"),
+	}
+}
+
+func (r *MongoResaleListingRepository) Create(ctx context.Context, listing *domain.ResaleListing) (*domain.ResaleListing, error) {
+	_, err := r.Collection.InsertOne(ctx, model.FromResaleListing(listing))
+	if err == nil {
+		return listing, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, &domain.InternalError{Msg: "failed to store resale listing", Err: err}
+	}
+
+	open, err := r.GetOpenByTicketCode(ctx, listing.TicketCode)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &domain.ListingStateError{ID: open.ID, Status: open.Status}
+}
+
+func (r *MongoResaleListingRepository) GetByID(ctx context.Context, id string) (*domain.ResaleListing, error) {
+	return r.findOne(ctx, bson.M{"id": id}, id)
+}
+
+func (r *MongoResaleListingRepository) GetOpenByTicketCode(ctx context.Context, code string) (*domain.ResaleListing, error) {
+	return r.findOne(ctx, bson.M{"ticket_code": code, "open": true}, code)
+}
+
+func (r *MongoResaleListingRepository) findOne(ctx context.Context, filter bson.M, id string) (*domain.ResaleListing, error) {
+	var listing model.MongoResaleListing
+	err := r.Collection.FindOne(ctx, filter).Decode(&listing)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "listing", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve resale listing", Err: err}
+	}
+	return listing.ToDomain(), nil
+}
+
+// GetBySellerID lists the listings of a seller, newest first.
+func (r *MongoResaleListingRepository) GetBySellerID(ctx context.Context, sellerID int, status *string) ([]*domain.ResaleListing, error) {
+	match := bson.M{"seller_id": sellerID}
+	if status != nil {
+		match["status"] = *status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	return r.find(ctx, match, opts)
+}
+
+func (r *MongoResaleListingRepository) GetActive(ctx context.Context, filter *domain.ResaleListingFilter) ([]*domain.ResaleListing, error) {
+	match := bson.M{"status": domain.ListingActive}
+	if filter.EventID != nil {
+		match["event_id"] = *filter.EventID
+	}
+	if filter.PacketID != nil {
+		match["packet_id"] = *filter.PacketID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "price", Value: 1}, {Key: "created_at", Value: 1}})
+	return r.find(ctx, match, opts)
+}
+
+func (r *MongoResaleListingRepository) find(ctx context.Context, match bson.M, opts *options.FindOptions) ([]*domain.ResaleListing, error) {
+	cursor, err := r.Collection.Find(ctx, match, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve resale listings", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoListings []model.MongoResaleListing
+	if err := cursor.All(ctx, &mongoListings); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode resale listings", Err: err}
+	}
+
+	listings := make([]*domain.ResaleListing, 0, len(mongoListings))
+	for i := range mongoListings {
+		listings = append(listings, mongoListings[i].ToDomain())
+	}
+	return listings, nil
+}
+
+// Claim is a single conditional write, so two buyers racing for the same
+// listing cannot both get it.
+func (r *MongoResaleListingRepository) Claim(ctx context.Context, id string, buyerID int, newCode string) (*domain.ResaleListing, error) {
+	var claimed model.MongoResaleListing
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": domain.ListingActive},
+		bson.M{"$set": bson.M{"status": domain.ListingSelling, "buyer_id": buyerID, "new_code": newCode}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&claimed)
+	if err == nil {
+		return claimed.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to claim resale listing", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == domain.ListingSelling && current.BuyerID != nil && *current.BuyerID == buyerID {
+		return current, nil
+	}
+	return nil, &domain.ListingStateError{ID: id, Status: current.Status}
+}
+
+func (r *MongoResaleListingRepository) Release(ctx context.Context, id string) (*domain.ResaleListing, error) {
+	return r.update(ctx, id, []string{domain.ListingSelling}, bson.M{
+		"$set":   bson.M{"status": domain.ListingActive, "open": true},
+		"$unset": bson.M{"buyer_id": "", "new_code": ""},
+	})
+}
+
+// UpdateStatus is a single conditional write, like Claim.
+func (r *MongoResaleListingRepository) UpdateStatus(ctx context.Context, id string, from []string, status string, soldAt *time.Time) (*domain.ResaleListing, error) {
+	set := bson.M{
+		"status": status,
+		"open":   status == domain.ListingActive || status == domain.ListingSelling,
+	}
+	if soldAt != nil {
+		set["sold_at"] = *soldAt
+	}
+	return r.update(ctx, id, from, bson.M{"$set": set})
+}
+
+func (r *MongoResaleListingRepository) update(ctx context.Context, id string, from []string, update bson.M) (*domain.ResaleListing, error) {
+	var updated model.MongoResaleListing
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": bson.M{"$in": from}},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to update resale listing", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(from, current.Status) {
+		return nil, &domain.InternalError{Msg: "resale listing changed while being updated"}
+	}
+	return nil, &domain.ListingStateError{ID: id, Status: current.Status}
+}
+
+// CreateIndexes keeps one open listing per ticket and backs the sellers'
+// listings and the browsing of what is on sale.
+func (r *MongoResaleListingRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "ticket_code", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"open": true}),
+		},
+		{
+			Keys: bson.D{{Key: "seller_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "status", Value: 1}, {Key: "price", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "packet_id", Value: 1}, {Key: "status", Value: 1}, {Key: "price", Value: 1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
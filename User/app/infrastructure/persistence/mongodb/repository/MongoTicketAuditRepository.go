@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoTicketAuditRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoTicketAuditRepository(db *mongo.Database) *MongoTicketAuditRepository {
+	return &MongoTicketAuditRepository{
+		Collection: db.Collection("ticket_audit"),
+	}
+}
+
+// Add inserts every entry it can; entries already recorded for the same
+// transfer, action and user are skipped.
+func (r *MongoTicketAuditRepository) Add(ctx context.Context, entries []*domain.TicketAuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		docs = append(docs, model.FromTicketAuditEntry(entry))
+	}
+
+	_, err := r.Collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !onlyDuplicateKeys(err) {
+		return &domain.InternalError{Msg: "failed to record ticket audit", Err: err}
+	}
+	return nil
+}
+
+func (r *MongoTicketAuditRepository) GetByUserID(ctx context.Context, userID int) ([]*domain.TicketAuditEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve ticket audit", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoEntries []model.MongoTicketAuditEntry
+	if err := cursor.All(ctx, &mongoEntries); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode ticket audit", Err: err}
+	}
+
+	entries := make([]*domain.TicketAuditEntry, 0, len(mongoEntries))
+	for i := range mongoEntries {
+		entries = append(entries, mongoEntries[i].ToDomain())
+	}
+	return entries, nil
+}
+
+func (r *MongoTicketAuditRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "transfer_id", Value: 1}, {Key: "action", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "at", Value: -1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoTicketTransferRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoTicketTransferRepository(db *mongo.Database) *MongoTicketTransferRepository {
+	return &MongoTicketTransferRepository{
+		Collection: db.Collection("ticket_transfers"),
+	}
+}
+
+func (r *MongoTicketTransferRepository) Create(ctx context.Context, transfer *domain.TicketTransfer) (*domain.TicketTransfer, error) {
+	_, err := r.Collection.InsertOne(ctx, model.FromTicketTransfer(transfer))
+	if err == nil {
+		return transfer, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, &domain.InternalError{Msg: "failed to store ticket transfer", Err: err}
+	}
+
+	open, err := r.GetOpenByTicketCode(ctx, transfer.TicketCode)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &domain.TransferStateError{ID: open.ID, Status: open.Status}
+}
+
+func (r *MongoTicketTransferRepository) GetByID(ctx context.Context, id string) (*domain.TicketTransfer, error) {
+	var transfer model.MongoTicketTransfer
+	err := r.Collection.FindOne(ctx, bson.M{"id": id}).Decode(&transfer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "transfer", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve ticket transfer", Err: err}
+	}
+	return transfer.ToDomain(), nil
+}
+
+func (r *MongoTicketTransferRepository) GetOpenByTicketCode(ctx context.Context, code string) (*domain.TicketTransfer, error) {
+	var transfer model.MongoTicketTransfer
+	err := r.Collection.FindOne(ctx, bson.M{"ticket_code": code, "open": true}).Decode(&transfer)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "transfer", ID: code}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve ticket transfer", Err: err}
+	}
+	return transfer.ToDomain(), nil
+}
+
+// GetByUserID lists the transfers the user sent or received, newest first.
+func (r *MongoTicketTransferRepository) GetByUserID(ctx context.Context, userID int, filter *domain.TicketTransferFilter) ([]*domain.TicketTransfer, error) {
+	match := bson.M{"$or": bson.A{bson.M{"from_user_id": userID}, bson.M{"to_user_id": userID}}}
+	if filter != nil && filter.Direction != nil {
+		if *filter.Direction == domain.TransferOutgoing {
+			match = bson.M{"from_user_id": userID}
+		} else {
+			match = bson.M{"to_user_id": userID}
+		}
+	}
+	if filter != nil && filter.Status != nil {
+		match["status"] = *filter.Status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Collection.Find(ctx, match, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve ticket transfers", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoTransfers []model.MongoTicketTransfer
+	if err := cursor.All(ctx, &mongoTransfers); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode ticket transfers", Err: err}
+	}
+
+	transfers := make([]*domain.TicketTransfer, 0, len(mongoTransfers))
+	for i := range mongoTransfers {
+		transfers = append(transfers, mongoTransfers[i].ToDomain())
+	}
+	return transfers, nil
+}
+
+// UpdateStatus is a single conditional write, so two callers racing to
+// answer the same transfer cannot both win.
+func (r *MongoTicketTransferRepository) UpdateStatus(ctx context.Context, id string, from []string, status string, completedAt *time.Time) (*domain.TicketTransfer, error) {
+	set := bson.M{
+		"status": status,
+		"open":   status == domain.TransferPending || status == domain.TransferAccepting,
+	}
+	if completedAt != nil {
+		set["completed_at"] = *completedAt
+	}
+
+	var updated model.MongoTicketTransfer
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": bson.M{"$in": from}},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to update ticket transfer", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(from, current.Status) {
+		return nil, &domain.InternalError{Msg: "ticket transfer changed while being updated"}
+	}
+	return nil, &domain.TransferStateError{ID: id, Status: current.Status}
+}
+
+func (r *MongoTicketTransferRepository) ExpirePending(ctx context.Context, now time.Time) error {
+	_, err := r.Collection.UpdateMany(ctx,
+		bson.M{"status": domain.TransferPending, "expires_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"status": domain.TransferExpired, "open": false, "completed_at": now}},
+	)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to expire ticket transfers", Err: err}
+	}
+	return nil
+}
+
+// CreateIndexes keeps one open transfer per ticket and backs the listings of
+// both parties and the expiry sweep.
+func (r *MongoTicketTransferRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "ticket_code", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"open": true}),
+		},
+		{
+			Keys: bson.D{{Key: "from_user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "to_user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
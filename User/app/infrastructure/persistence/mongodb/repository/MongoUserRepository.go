@@ -2,22 +2,28 @@ package repository
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"time"
 	"userService/application/domain"
 	"userService/infrastructure/persistence/mongodb/model"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoUserRepository struct {
 	Collection *mongo.Collection
+	// Tickets is the user_tickets collection holding what each user owns.
+	Tickets *mongo.Collection
 }
 
 func NewMongoUserRepository(db *mongo.Database) *MongoUserRepository {
 	return &MongoUserRepository{
 		Collection: db.Collection("users"),
+		Tickets:    db.Collection(userTicketsCollection),
 	}
 }
 
@@ -49,7 +55,32 @@ func (r *MongoUserRepository) GetByID(ctx context.Context, id int) (*domain.User
 		return nil, &domain.InternalError{Msg: "failed to retrieve user", Err: err}
 	}
 
-	return mongoUser.ToDomain(), nil
+	user := mongoUser.ToDomain()
+	user.TicketList, err = findUserTickets(ctx, r.Tickets, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *MongoUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var mongoUser model.MongoUser
+	err := r.Collection.FindOne(ctx, bson.M{"email": email}).Decode(&mongoUser)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "user", ID: email}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve user", Err: err}
+	}
+
+	user := mongoUser.ToDomain()
+	user.TicketList, err = findUserTickets(ctx, r.Tickets, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
 
 func (r *MongoUserRepository) Update(ctx context.Context, id int, updates map[string]interface{}) (*domain.User, error) {
@@ -69,20 +100,6 @@ func (r *MongoUserRepository) Update(ctx context.Context, id int, updates map[st
 			mongoUpdates["first_name_private"] = value
 		case "last_name_private":
 			mongoUpdates["last_name_private"] = value
-		case "ticket_list":
-			if domainTickets, ok := value.([]domain.Ticket); ok {
-				mongoTickets := make([]model.MongoTicket, len(domainTickets))
-				for i, dt := range domainTickets {
-					mongoTickets[i] = model.MongoTicket{
-						PacketID: dt.PacketID,
-						EventID:  dt.EventID,
-						Code:     dt.Code,
-					}
-				}
-				mongoUpdates["ticket_list"] = mongoTickets
-			} else {
-				mongoUpdates["ticket_list"] = value
-			}
 		}
 	}
 
@@ -125,6 +142,10 @@ func (r *MongoUserRepository) Delete(ctx context.Context, id int) (*domain.User,
 		return nil, &domain.NotFoundError{ID: id}
 	}
 
+	if _, err := r.Tickets.DeleteMany(ctx, bson.M{"user_id": id}); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to delete user tickets", Err: err}
+	}
+
 	return user, nil
 }
 
@@ -174,58 +195,263 @@ func (r *MongoUserRepository) CreateIndexes(ctx context.Context) error {
 	return nil
 }
 
-func (r *MongoUserRepository) GetUsersByEventID(ctx context.Context, eventID int) ([]*domain.User, error) {
-	filter := bson.M{
-		"ticket_list": bson.M{
-			"$elemMatch": bson.M{
-				"event_id": eventID,
-			},
-		},
+func (r *MongoUserRepository) GetUsersByEventID(ctx context.Context, eventID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error) {
+	return r.findCustomersPage(ctx, bson.M{"event_id": eventID}, filter, "failed to query users by event ID")
+}
+
+func (r *MongoUserRepository) GetUsersByPacketID(ctx context.Context, packetID int, filter *domain.CustomerFilter) ([]*domain.Customer, *domain.PageInfo, error) {
+	return r.findCustomersPage(ctx, bson.M{"packet_id": packetID}, filter, "failed to query users by packet ID")
+}
+
+func (r *MongoUserRepository) EachUserByEventID(ctx context.Context, eventID int, orderBy *string, fn func(*domain.Customer) error) error {
+	return r.eachCustomer(ctx, bson.M{"event_id": eventID}, orderBy, fn, "failed to query users by event ID")
+}
+
+func (r *MongoUserRepository) EachUserByPacketID(ctx context.Context, packetID int, orderBy *string, fn func(*domain.Customer) error) error {
+	return r.eachCustomer(ctx, bson.M{"packet_id": packetID}, orderBy, fn, "failed to query users by packet ID")
+}
+
+// customerOrder is how a customer listing is sorted. Ties, and listings
+// without a sort key, are ordered by user id.
+type customerOrder struct {
+	// sortKey is evaluated on a group of tickets joined to its "user"; nil
+	// sorts by user id alone.
+	sortKey interface{}
+	desc    bool
+	// joinFirst is set when sortKey reads the user document, so the join has
+	// to happen before the page is cut instead of after.
+	joinFirst bool
+	// time tells the cursor key is a purchase time rather than a string.
+	time bool
+}
+
+// displayedName sorts private names the way owners see them, so the order of
+// a listing does not give away a name that is masked in it.
+func displayedName(field, privateField string) bson.M {
+	return bson.M{"$cond": bson.A{"$user." + privateField, domain.PrivateNamePlaceholder, "$user." + field}}
+}
+
+var (
+	customerNameKey = bson.M{"$toLower": bson.M{"$concat": bson.A{
+		displayedName("last_name", "last_name_private"), " ", displayedName("first_name", "first_name_private"),
+	}}}
+	customerEmailKey = bson.M{"$toLower": "$user.email"}
+)
+
+var customerOrderings = map[string]customerOrder{
+	domain.CustomerOrderNameAsc:         {sortKey: customerNameKey, joinFirst: true},
+	domain.CustomerOrderNameDesc:        {sortKey: customerNameKey, desc: true, joinFirst: true},
+	domain.CustomerOrderEmailAsc:        {sortKey: customerEmailKey, joinFirst: true},
+	domain.CustomerOrderEmailDesc:       {sortKey: customerEmailKey, desc: true, joinFirst: true},
+	domain.CustomerOrderPurchasedAtAsc:  {sortKey: "$last_purchased_at", time: true},
+	domain.CustomerOrderPurchasedAtDesc: {sortKey: "$last_purchased_at", desc: true, time: true},
+}
+
+func resolveCustomerOrder(orderBy *string) customerOrder {
+	if orderBy == nil {
+		return customerOrder{}
 	}
+	return customerOrderings[*orderBy]
+}
 
-	cursor, err := r.Collection.Find(ctx, filter)
+// cursorKey turns the key of a decoded cursor back into the type the sort key
+// is stored as; JSON hands purchase times back as strings.
+func (order customerOrder) cursorKey(cursor *domain.PageCursor) (interface{}, error) {
+	invalid := &domain.ValidationError{Field: "cursor", Reason: "invalid cursor"}
+	key, ok := cursor.Key.(string)
+	if !ok {
+		return nil, invalid
+	}
+	if !order.time {
+		return key, nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, key)
 	if err != nil {
-		return nil, &domain.InternalError{Msg: "failed to query users by event ID", Err: err}
+		return nil, invalid
 	}
-	defer cursor.Close(ctx)
+	return parsed, nil
+}
 
-	var mongoUsers []model.MongoUser
-	if err = cursor.All(ctx, &mongoUsers); err != nil {
-		return nil, &domain.InternalError{Msg: "failed to decode users", Err: err}
+// customerPipeline groups the tickets matching match by owner and joins the
+// owners' user documents. after, when set, is a $match stage applied once the
+// sort key is known, and limit cuts the result before the remaining join.
+func (r *MongoUserRepository) customerPipeline(match bson.M, order customerOrder, backward bool, after bson.M, limit int) mongo.Pipeline {
+	sortDir := 1
+	if order.desc != backward {
+		sortDir = -1
 	}
 
-	users := make([]*domain.User, 0, len(mongoUsers))
-	for _, mu := range mongoUsers {
-		users = append(users, mu.ToDomain())
+	join := []bson.D{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.Collection.Name(),
+			"localField":   "_id",
+			"foreignField": "id",
+			"as":           "user",
+		}}},
+		{{Key: "$unwind", Value: "$user"}},
 	}
 
-	return users, nil
-}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                "$user_id",
+			"ticket_count":       bson.M{"$sum": 1},
+			"first_purchased_at": bson.M{"$min": "$purchased_at"},
+			"last_purchased_at":  bson.M{"$max": "$purchased_at"},
+		}}},
+	}
+	if order.joinFirst {
+		pipeline = append(pipeline, join...)
+	}
 
-func (r *MongoUserRepository) GetUsersByPacketID(ctx context.Context, packetID int) ([]*domain.User, error) {
-	filter := bson.M{
-		"ticket_list": bson.M{
-			"$elemMatch": bson.M{
-				"packet_id": packetID,
-			},
+	sort := bson.D{{Key: "_id", Value: sortDir}}
+	if order.sortKey != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"sort_key": order.sortKey}}})
+		sort = bson.D{{Key: "sort_key", Value: sortDir}, {Key: "_id", Value: sortDir}}
+	}
+	if after != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: after}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sort}})
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	if !order.joinFirst {
+		pipeline = append(pipeline, join...)
+	}
+
+	return append(pipeline, bson.D{{Key: "$replaceRoot", Value: bson.M{"newRoot": bson.M{"$mergeObjects": bson.A{
+		"$user",
+		bson.M{
+			"ticket_count":       "$ticket_count",
+			"first_purchased_at": "$first_purchased_at",
+			"last_purchased_at":  "$last_purchased_at",
+			"sort_key":           "$sort_key",
 		},
+	}}}}})
+}
+
+// findCustomersPage pages through the distinct owners of the tickets matching
+// match by sort key and user id, fetching one extra owner to know whether
+// another page exists.
+func (r *MongoUserRepository) findCustomersPage(ctx context.Context, match bson.M, filter *domain.CustomerFilter, errMsg string) ([]*domain.Customer, *domain.PageInfo, error) {
+	if filter == nil || filter.PerPage == nil {
+		return nil, nil, &domain.ValidationError{Field: "per_page", Reason: "per_page cannot be nil"}
 	}
 
-	cursor, err := r.Collection.Find(ctx, filter)
+	cursor, err := filter.PageCursor()
 	if err != nil {
-		return nil, &domain.InternalError{Msg: "failed to query users by packet ID", Err: err}
+		return nil, nil, err
 	}
-	defer cursor.Close(ctx)
 
-	var mongoUsers []model.MongoUser
-	if err = cursor.All(ctx, &mongoUsers); err != nil {
-		return nil, &domain.InternalError{Msg: "failed to decode users", Err: err}
+	order := resolveCustomerOrder(filter.OrderBy)
+	backward := cursor != nil && cursor.Backward
+
+	var after bson.M
+	if cursor != nil {
+		op := "$gt"
+		if order.desc != backward {
+			op = "$lt"
+		}
+		after = bson.M{"_id": bson.M{op: cursor.ID}}
+		if order.sortKey != nil {
+			key, err := order.cursorKey(cursor)
+			if err != nil {
+				return nil, nil, err
+			}
+			after = bson.M{"$or": bson.A{
+				bson.M{"sort_key": bson.M{op: key}},
+				bson.M{"sort_key": key, "_id": bson.M{op: cursor.ID}},
+			}}
+		}
+	}
+
+	limit := *filter.PerPage
+	mongoCursor, err := r.Tickets.Aggregate(ctx, r.customerPipeline(match, order, backward, after, limit+1))
+	if err != nil {
+		return nil, nil, &domain.InternalError{Msg: errMsg, Err: err}
+	}
+	defer mongoCursor.Close(ctx)
+	var rows []model.MongoCustomer
+	if err = mongoCursor.All(ctx, &rows); err != nil {
+		return nil, nil, &domain.InternalError{Msg: "failed to decode users", Err: err}
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	customers := make([]*domain.Customer, 0, len(rows))
+	for i := range rows {
+		customers = append(customers, rows[i].ToDomain())
+	}
+
+	pageInfo := &domain.PageInfo{}
+	if len(rows) == 0 {
+		return customers, pageInfo, nil
+	}
+
+	orderName := ""
+	if filter.OrderBy != nil {
+		orderName = *filter.OrderBy
+	}
+	cursorAt := func(row *model.MongoCustomer, backward bool) *string {
+		pageCursor := &domain.PageCursor{OrderBy: orderName, ID: row.ID, Backward: backward}
+		switch key := row.SortKey.(type) {
+		case string:
+			pageCursor.Key = key
+		case primitive.DateTime:
+			pageCursor.Key = key.Time().UTC().Format(time.RFC3339Nano)
+		}
+		encoded := pageCursor.Encode()
+		return &encoded
+	}
+
+	first, last := &rows[0], &rows[len(rows)-1]
+	if backward {
+		if hasMore {
+			pageInfo.PrevCursor = cursorAt(first, true)
+		}
+		pageInfo.NextCursor = cursorAt(last, false)
+	} else {
+		if hasMore {
+			pageInfo.NextCursor = cursorAt(last, false)
+		}
+		if cursor != nil {
+			pageInfo.PrevCursor = cursorAt(first, true)
+		}
+	}
+
+	return customers, pageInfo, nil
+}
+
+// eachCustomer streams every owner of the tickets matching match, letting
+// the server spill the sort to disk for large events.
+func (r *MongoUserRepository) eachCustomer(ctx context.Context, match bson.M, orderBy *string, fn func(*domain.Customer) error, errMsg string) error {
+	pipeline := r.customerPipeline(match, resolveCustomerOrder(orderBy), false, nil, 0)
+
+	mongoCursor, err := r.Tickets.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return &domain.InternalError{Msg: errMsg, Err: err}
 	}
+	defer mongoCursor.Close(ctx)
 
-	users := make([]*domain.User, 0, len(mongoUsers))
-	for _, mu := range mongoUsers {
-		users = append(users, mu.ToDomain())
+	for mongoCursor.Next(ctx) {
+		var row model.MongoCustomer
+		if err := mongoCursor.Decode(&row); err != nil {
+			return &domain.InternalError{Msg: "failed to decode users", Err: err}
+		}
+		if err := fn(row.ToDomain()); err != nil {
+			return err
+		}
+	}
+	if err := mongoCursor.Err(); err != nil {
+		return &domain.InternalError{Msg: errMsg, Err: err}
 	}
 
-	return users, nil
+	return nil
 }
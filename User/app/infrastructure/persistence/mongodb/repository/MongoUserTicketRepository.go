@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const userTicketsCollection = "user_tickets"
+
+type MongoUserTicketRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoUserTicketRepository(db *mongo.Database) *MongoUserTicketRepository {
+	return &MongoUserTicketRepository{
+		Collection: db.Collection(userTicketsCollection),
+	}
+}
+
+// Add inserts a single ownership record, so concurrent purchases of the same
+// user never overwrite each other.
+func (r *MongoUserTicketRepository) Add(ctx context.Context, userID int, ticket *domain.Ticket) error {
+	_, err := r.Collection.InsertOne(ctx, model.FromUserTicket(userID, ticket))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &domain.AlreadyExistsError{Name: ticket.Code}
+		}
+		return &domain.InternalError{Msg: "failed to store user ticket", Err: err}
+	}
+	return nil
+}
+
+func (r *MongoUserTicketRepository) GetByUserID(ctx context.Context, userID int) ([]domain.Ticket, error) {
+	return findUserTickets(ctx, r.Collection, userID)
+}
+
+// Move rewrites owner and code of the one document holding the ticket, so
+// the ticket is never owned by both users or by neither.
+func (r *MongoUserTicketRepository) Move(ctx context.Context, code string, fromUserID int, newCode string, toUserID int) error {
+	result, err := r.Collection.UpdateOne(ctx,
+		bson.M{"code": code, "user_id": fromUserID},
+		bson.M{"$set": bson.M{
+			"code":           newCode,
+			"user_id":        toUserID,
+			"transferred_at": time.Now().UTC(),
+		}},
+	)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to move user ticket", Err: err}
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	moved, err := r.Collection.CountDocuments(ctx, bson.M{"code": newCode, "user_id": toUserID})
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to move user ticket", Err: err}
+	}
+	if moved == 0 {
+		return &domain.ResourceNotFoundError{Resource: "ticket", ID: code}
+	}
+	return nil
+}
+
+func (r *MongoUserTicketRepository) Remove(ctx context.Context, code string, userID int) error {
+	if _, err := r.Collection.DeleteOne(ctx, bson.M{"code": code, "user_id": userID}); err != nil {
+		return &domain.InternalError{Msg: "failed to remove user ticket", Err: err}
+	}
+	return nil
+}
+
+// CreateIndexes backs the lookups by owner and the customer listings, which
+// page through the buyers of an event or packet by user id.
+func (r *MongoUserTicketRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "purchased_at", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "packet_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findUserTickets(ctx context.Context, collection *mongo.Collection, userID int) ([]domain.Ticket, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "purchased_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve user tickets", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoTickets []model.MongoUserTicket
+	if err := cursor.All(ctx, &mongoTickets); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode user tickets", Err: err}
+	}
+
+	tickets := make([]domain.Ticket, 0, len(mongoTickets))
+	for i := range mongoTickets {
+		tickets = append(tickets, mongoTickets[i].ToDomain())
+	}
+	return tickets, nil
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"userService/application/domain"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoWaitlistRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoWaitlistRepository(db *mongo.Database) *MongoWaitlistRepository {
+	return &MongoWaitlistRepository{
+		Collection: db.Collection("waitlist_entries"),
+	}
+}
+
+// targetFilter matches the entries of target; both ids are always stored,
+// so an event and a packet with the same id never mix.
+func targetFilter(target *domain.WaitlistTarget) bson.M {
+	return bson.M{"event_id": target.EventID, "packet_id": target.PacketID}
+}
+
+func (r *MongoWaitlistRepository) Create(ctx context.Context, entry *domain.WaitlistEntry) (*domain.WaitlistEntry, error) {
+	_, err := r.Collection.InsertOne(ctx, model.FromWaitlistEntry(entry))
+	if err == nil {
+		return entry, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, &domain.InternalError{Msg: "failed to store waitlist entry", Err: err}
+	}
+
+	open, err := r.GetOpen(ctx, entry.UserID, entry.Target())
+	if err != nil {
+		return nil, err
+	}
+	return nil, &domain.WaitlistStateError{ID: open.ID, Status: open.Status}
+}
+
+func (r *MongoWaitlistRepository) GetByID(ctx context.Context, id string) (*domain.WaitlistEntry, error) {
+	return r.findOne(ctx, bson.M{"id": id}, id)
+}
+
+func (r *MongoWaitlistRepository) GetOpen(ctx context.Context, userID int, target *domain.WaitlistTarget) (*domain.WaitlistEntry, error) {
+	filter := targetFilter(target)
+	filter["user_id"] = userID
+	filter["open"] = true
+	return r.findOne(ctx, filter, "")
+}
+
+func (r *MongoWaitlistRepository) findOne(ctx context.Context, filter bson.M, id string) (*domain.WaitlistEntry, error) {
+	var entry model.MongoWaitlistEntry
+	err := r.Collection.FindOne(ctx, filter).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &domain.ResourceNotFoundError{Resource: "waitlist entry", ID: id}
+		}
+		return nil, &domain.InternalError{Msg: "failed to retrieve waitlist entry", Err: err}
+	}
+	return entry.ToDomain(), nil
+}
+
+// GetByUserID lists the entries of a user, newest first.
+func (r *MongoWaitlistRepository) GetByUserID(ctx context.Context, userID int, status *string) ([]*domain.WaitlistEntry, error) {
+	match := bson.M{"user_id": userID}
+	if status != nil {
+		match["status"] = *status
+	}
+
+	cursor, err := r.Collection.Find(ctx, match, options.Find().SetSort(bson.D{{Key: "joined_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve waitlist entries", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var mongoEntries []model.MongoWaitlistEntry
+	if err := cursor.All(ctx, &mongoEntries); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode waitlist entries", Err: err}
+	}
+
+	entries := make([]*domain.WaitlistEntry, 0, len(mongoEntries))
+	for i := range mongoEntries {
+		entries = append(entries, mongoEntries[i].ToDomain())
+	}
+	return entries, nil
+}
+
+func (r *MongoWaitlistRepository) CountAhead(ctx context.Context, entry *domain.WaitlistEntry) (int64, error) {
+	filter := targetFilter(entry.Target())
+	filter["status"] = domain.WaitlistWaiting
+	filter["joined_at"] = bson.M{"$lt": entry.JoinedAt}
+
+	count, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, &domain.InternalError{Msg: "failed to count waitlist entries", Err: err}
+	}
+	return count, nil
+}
+
+func (r *MongoWaitlistRepository) CountOffered(ctx context.Context, target *domain.WaitlistTarget) (int64, error) {
+	filter := targetFilter(target)
+	filter["status"] = domain.WaitlistOffered
+
+	count, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, &domain.InternalError{Msg: "failed to count waitlist offers", Err: err}
+	}
+	return count, nil
+}
+
+func (r *MongoWaitlistRepository) GetTargets(ctx context.Context) ([]*domain.WaitlistTarget, error) {
+	cursor, err := r.Collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"open": true}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"event_id": "$event_id", "packet_id": "$packet_id"}}}},
+	})
+	if err != nil {
+		return nil, &domain.InternalError{Msg: "failed to retrieve waitlists", Err: err}
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		ID struct {
+			EventID  *int `bson:"event_id"`
+			PacketID *int `bson:"packet_id"`
+		} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, &domain.InternalError{Msg: "failed to decode waitlists", Err: err}
+	}
+
+	targets := make([]*domain.WaitlistTarget, 0, len(groups))
+	for _, group := range groups {
+		targets = append(targets, &domain.WaitlistTarget{EventID: group.ID.EventID, PacketID: group.ID.PacketID})
+	}
+	return targets, nil
+}
+
+// OfferNext makes one conditional write per seat, so two sweeps racing over
+// the same waitlist never offer an entry twice.
+func (r *MongoWaitlistRepository) OfferNext(ctx context.Context, target *domain.WaitlistTarget, n int, now time.Time, expiresAt time.Time) ([]*domain.WaitlistEntry, error) {
+	filter := targetFilter(target)
+	filter["status"] = domain.WaitlistWaiting
+
+	var offered []*domain.WaitlistEntry
+	for range n {
+		var entry model.MongoWaitlistEntry
+		err := r.Collection.FindOneAndUpdate(ctx,
+			filter,
+			bson.M{"$set": bson.M{"status": domain.WaitlistOffered, "offered_at": now, "offer_expires_at": expiresAt}},
+			options.FindOneAndUpdate().
+				SetSort(bson.D{{Key: "joined_at", Value: 1}, {Key: "_id", Value: 1}}).
+				SetReturnDocument(options.After),
+		).Decode(&entry)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return offered, &domain.InternalError{Msg: "failed to offer waitlist seat", Err: err}
+		}
+		offered = append(offered, entry.ToDomain())
+	}
+	return offered, nil
+}
+
+func (r *MongoWaitlistRepository) ExpireOffers(ctx context.Context, now time.Time) error {
+	_, err := r.Collection.UpdateMany(ctx,
+		bson.M{"status": domain.WaitlistOffered, "offer_expires_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"status": domain.WaitlistExpired, "open": false, "closed_at": now}},
+	)
+	if err != nil {
+		return &domain.InternalError{Msg: "failed to expire waitlist offers", Err: err}
+	}
+	return nil
+}
+
+// UpdateStatus is a single conditional write, like OfferNext.
+func (r *MongoWaitlistRepository) UpdateStatus(ctx context.Context, id string, from []string, status string, closedAt time.Time) (*domain.WaitlistEntry, error) {
+	open := status == domain.WaitlistWaiting || status == domain.WaitlistOffered
+	set := bson.M{"status": status, "open": open}
+	if !open {
+		set["closed_at"] = closedAt
+	}
+
+	var updated model.MongoWaitlistEntry
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id, "status": bson.M{"$in": from}},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == nil {
+		return updated.ToDomain(), nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, &domain.InternalError{Msg: "failed to update waitlist entry", Err: err}
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(from, current.Status) {
+		return nil, &domain.InternalError{Msg: "waitlist entry changed while being updated"}
+	}
+	return nil, &domain.WaitlistStateError{ID: id, Status: current.Status}
+}
+
+// CreateIndexes keeps a user in one line per event or packet and backs the
+// FIFO offers, the expiry sweep and the users' listings.
+func (r *MongoWaitlistRepository) CreateIndexes(ctx context.Context) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "packet_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"open": true}),
+		},
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "packet_id", Value: 1}, {Key: "status", Value: 1}, {Key: "joined_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "offer_expires_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "joined_at", Value: -1}},
+		},
+	}
+
+	for _, indexModel := range indexModels {
+		_, err := r.Collection.Indexes().CreateOne(ctx, indexModel)
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"userService/infrastructure/persistence/mongodb/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// legacyUser is the part of a user document written before tickets moved out
+// of the embedded ticket_list.
+type legacyUser struct {
+	ObjectID   primitive.ObjectID  `bson:"_id"`
+	ID         int                 `bson:"id"`
+	TicketList []model.MongoTicket `bson:"ticket_list"`
+}
+
+// MigrateEmbeddedTickets copies every ticket still embedded in a user
+// document into user_tickets and then drops the embedded list. Tickets that
+// were already copied are skipped, so a run that stopped half way can simply
+// be repeated. The purchase time of a migrated ticket is unknown and is set
+// to the creation time of the user document. It returns how many users were
+// migrated.
+func (r *MongoUserRepository) MigrateEmbeddedTickets(ctx context.Context) (int, error) {
+	cursor, err := r.Collection.Find(ctx,
+		bson.M{"ticket_list": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"_id": 1, "id": 1, "ticket_list": 1}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var user legacyUser
+		if err := cursor.Decode(&user); err != nil {
+			return migrated, err
+		}
+
+		purchasedAt := user.ObjectID.Timestamp()
+		if user.ObjectID.IsZero() {
+			purchasedAt = time.Now().UTC()
+		}
+
+		if len(user.TicketList) > 0 {
+			documents := make([]interface{}, 0, len(user.TicketList))
+			for _, ticket := range user.TicketList {
+				documents = append(documents, &model.MongoUserTicket{
+					UserID:      user.ID,
+					Code:        ticket.Code,
+					EventID:     ticket.EventID,
+					PacketID:    ticket.PacketID,
+					PurchasedAt: purchasedAt,
+				})
+			}
+
+			_, err := r.Tickets.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+			if err != nil && !onlyDuplicateKeys(err) {
+				return migrated, err
+			}
+		}
+
+		if _, err := r.Collection.UpdateOne(ctx, bson.M{"_id": user.ObjectID}, bson.M{"$unset": bson.M{"ticket_list": ""}}); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, cursor.Err()
+}
+
+func onlyDuplicateKeys(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr) {
+			return false
+		}
+	}
+	return true
+}
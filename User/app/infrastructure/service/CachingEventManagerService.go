@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// maxCachedSummaries bounds each cache; once it is full, expired entries are
+// dropped and, if that is not enough, the cache starts over.
+const maxCachedSummaries = 10000
+
+// CachingEventManagerService keeps the events and packets looked up through
+// an EventManagerService for ttl, so listing tickets only asks EventManager
+// about ids it has not seen recently. Ticket creation is passed through.
+type CachingEventManagerService struct {
+	service.EventManagerService
+	events  *summaryCache[domain.EventSummary]
+	packets *summaryCache[domain.PacketSummary]
+}
+
+func NewCachingEventManagerService(inner service.EventManagerService, ttl time.Duration) service.EventManagerService {
+	return &CachingEventManagerService{
+		EventManagerService: inner,
+		events:              newSummaryCache[domain.EventSummary](ttl),
+		packets:             newSummaryCache[domain.PacketSummary](ttl),
+	}
+}
+
+func (s *CachingEventManagerService) GetEventsByIDs(ctx context.Context, ids []int) ([]*domain.EventSummary, error) {
+	return s.events.lookup(ids, func(missing []int) (map[int]*domain.EventSummary, error) {
+		events, err := s.EventManagerService.GetEventsByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[int]*domain.EventSummary, len(events))
+		for _, event := range events {
+			found[event.ID] = event
+		}
+		return found, nil
+	})
+}
+
+func (s *CachingEventManagerService) GetPacketsByIDs(ctx context.Context, ids []int) ([]*domain.PacketSummary, error) {
+	return s.packets.lookup(ids, func(missing []int) (map[int]*domain.PacketSummary, error) {
+		packets, err := s.EventManagerService.GetPacketsByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[int]*domain.PacketSummary, len(packets))
+		for _, packet := range packets {
+			found[packet.ID] = packet
+		}
+		return found, nil
+	})
+}
+
+type cachedSummary[T any] struct {
+	value     *T
+	expiresAt time.Time
+}
+
+type summaryCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[int]cachedSummary[T]
+}
+
+func newSummaryCache[T any](ttl time.Duration) *summaryCache[T] {
+	return &summaryCache[T]{ttl: ttl, entries: make(map[int]cachedSummary[T])}
+}
+
+// lookup returns the cached values of ids and fetches the rest. Ids fetch
+// does not return are not cached, so they are asked for again next time.
+func (c *summaryCache[T]) lookup(ids []int, fetch func(missing []int) (map[int]*T, error)) ([]*T, error) {
+	now := time.Now()
+	values := make(map[int]*T, len(ids))
+	var missing []int
+
+	c.mu.Lock()
+	for _, id := range ids {
+		if entry, ok := c.entries[id]; ok && now.Before(entry.expiresAt) {
+			values[id] = entry.value
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(missing) > 0 {
+		fetched, err := fetch(missing)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.makeRoom(now, len(fetched))
+		for id, value := range fetched {
+			c.entries[id] = cachedSummary[T]{value: value, expiresAt: now.Add(c.ttl)}
+			values[id] = value
+		}
+		c.mu.Unlock()
+	}
+
+	ret := make([]*T, 0, len(values))
+	for _, id := range ids {
+		if value, ok := values[id]; ok {
+			ret = append(ret, value)
+		}
+	}
+	return ret, nil
+}
+
+func (c *summaryCache[T]) makeRoom(now time.Time, incoming int) {
+	if len(c.entries)+incoming <= maxCachedSummaries {
+		return
+	}
+	for id, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+	if len(c.entries)+incoming > maxCachedSummaries {
+		c.entries = make(map[int]cachedSummary[T])
+	}
+}
@@ -47,6 +47,20 @@ func (s *DummyAuthorizationService) CanUserViewPacketCustomers(ctx context.Conte
 	return identity.Role == "owner-event", nil
 }
 
+func (s *DummyAuthorizationService) CanUserDecideRefund(ctx context.Context, identity *service.UserIdentity, ownerID int) (bool, error) {
+	if identity.Role == "serviciu_clienti" {
+		return true, nil
+	}
+	return identity.Role == "owner-event" && identity.UserID == uint(ownerID), nil
+}
+
+func (s *DummyAuthorizationService) CanUserManagePromoCodes(ctx context.Context, identity *service.UserIdentity, ownerID int) (bool, error) {
+	if identity.Role == "serviciu_clienti" {
+		return true, nil
+	}
+	return identity.Role == "owner-event" && identity.UserID == uint(ownerID), nil
+}
+
 func (s *DummyAuthorizationService) userOwnsTicket(ctx context.Context, userID uint, ticketCode string) (bool, error) {
 	user, err := s.userRepo.GetByID(ctx, int(userID))
 	if err != nil {
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"userService/application/domain"
 	"userService/application/service"
 	"userService/infrastructure/http"
 )
@@ -28,3 +29,130 @@ func (a *EventManagerHTTPAdapter) CreateTicket(ctx context.Context, code string,
 		EventID:  resp.EventID,
 	}, nil
 }
+
+func (a *EventManagerHTTPAdapter) RotateTicketCode(ctx context.Context, code string, newCode string) (*service.TicketResponse, error) {
+	resp, err := a.client.RotateTicketCode(ctx, code, newCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.TicketResponse{
+		Code:     resp.Code,
+		PacketID: resp.PacketID,
+		EventID:  resp.EventID,
+	}, nil
+}
+
+func (a *EventManagerHTTPAdapter) ResellTicket(ctx context.Context, code string, newCode string, price int) (*service.TicketResponse, error) {
+	resp, err := a.client.ResellTicket(ctx, code, newCode, price)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.TicketResponse{
+		Code:     resp.Code,
+		PacketID: resp.PacketID,
+		EventID:  resp.EventID,
+	}, nil
+}
+
+func (a *EventManagerHTTPAdapter) VoidTicket(ctx context.Context, code string) error {
+	return a.client.VoidTicket(ctx, code)
+}
+
+func (a *EventManagerHTTPAdapter) GetRemainingSeats(ctx context.Context, target *domain.WaitlistTarget) (*int, error) {
+	if target.PacketID != nil {
+		return a.client.GetPacketRemainingSeats(ctx, *target.PacketID)
+	}
+	return a.client.GetEventRemainingSeats(ctx, *target.EventID)
+}
+
+func (a *EventManagerHTTPAdapter) GetEventsByIDs(ctx context.Context, ids []int) ([]*domain.EventSummary, error) {
+	resp, err := a.client.GetEventsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return toEventSummaries(resp), nil
+}
+
+func (a *EventManagerHTTPAdapter) GetPacketsByIDs(ctx context.Context, ids []int) ([]*domain.PacketSummary, error) {
+	resp, err := a.client.GetPacketsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return toPacketSummaries(resp), nil
+}
+
+func (a *EventManagerHTTPAdapter) GetEventsOfPacket(ctx context.Context, packetID int) ([]*domain.EventSummary, error) {
+	resp, err := a.client.GetEventsOfPacket(ctx, packetID)
+	if err != nil {
+		return nil, err
+	}
+	return toEventSummaries(resp), nil
+}
+
+func (a *EventManagerHTTPAdapter) GetPacketsIncludingEvent(ctx context.Context, eventID int) ([]*domain.PacketSummary, error) {
+	resp, err := a.client.GetPacketsIncludingEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return toPacketSummaries(resp), nil
+}
+
+func toEventSummaries(resp []*http.EventResponse) []*domain.EventSummary {
+	events := make([]*domain.EventSummary, 0, len(resp))
+	for _, event := range resp {
+		events = append(events, &domain.EventSummary{
+			ID:       event.ID,
+			Name:     event.Name,
+			Location: event.Location,
+			Address:  event.Address,
+			City:     event.City,
+			Country:  event.Country,
+			StartsAt: event.StartsAt,
+			EndsAt:   event.EndsAt,
+
+			CancelledAt: event.CancelledAt,
+			Sequence:    event.Sequence,
+
+			TransfersBlocked: event.TransfersBlocked,
+
+			Price:                  event.Price,
+			ResaleAllowed:          event.ResaleAllowed,
+			ResaleMaxMarkupPercent: event.ResaleMaxMarkupPercent,
+
+			OwnerID:           event.OwnerID,
+			RefundWindowHours: event.RefundWindowHours,
+
+			MaxTicketsPerUser: event.MaxTicketsPerUser,
+		})
+	}
+	return events
+}
+
+func toPacketSummaries(resp []*http.EventResponse) []*domain.PacketSummary {
+	packets := make([]*domain.PacketSummary, 0, len(resp))
+	for _, packet := range resp {
+		packets = append(packets, &domain.PacketSummary{
+			ID:       packet.ID,
+			Name:     packet.Name,
+			Location: packet.Location,
+			City:     packet.City,
+			Country:  packet.Country,
+			StartsAt: packet.StartsAt,
+			EndsAt:   packet.EndsAt,
+
+			TransfersBlocked: packet.TransfersBlocked,
+
+			Price:                  packet.Price,
+			ResaleAllowed:          packet.ResaleAllowed,
+			ResaleMaxMarkupPercent: packet.ResaleMaxMarkupPercent,
+
+			OwnerID:           packet.OwnerID,
+			RefundWindowHours: packet.RefundWindowHours,
+
+			MaxTicketsPerUser: packet.MaxTicketsPerUser,
+		})
+	}
+	return packets
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"userService/application/domain"
+	"userService/application/service"
+)
+
+// Card tokens the fake gateway treats specially; any other token, an empty
+// one included, stands for a card that pays.
+const (
+	FakeTokenDeclined          = "tok_declined"
+	FakeTokenInsufficientFunds = "tok_insufficient_funds"
+	FakeTokenGatewayError      = "tok_gateway_error"
+	FakeTokenCaptureFails      = "tok_capture_fails"
+)
+
+type fakePayment struct {
+	token    string
+	amount   int
+	captured int
+	refunded int
+	voided   bool
+	refunds  map[string]int
+}
+
+// FakePaymentGateway is a payment gateway kept in memory, for development
+// and tests: it never talks to a provider and forgets everything on
+// restart. Webhook calls are signed with secret, the same way
+// SignPaymentWebhook does; without a secret every webhook call is refused.
+type FakePaymentGateway struct {
+	mu       sync.Mutex
+	secret   []byte
+	seq      int
+	payments map[string]*fakePayment
+	byKey    map[string]string
+}
+
+func NewFakePaymentGateway(secret string) service.PaymentGateway {
+	return &FakePaymentGateway{
+		secret:   []byte(secret),
+		payments: make(map[string]*fakePayment),
+		byKey:    make(map[string]string),
+	}
+}
+
+func (g *FakePaymentGateway) Authorize(ctx context.Context, request *domain.PaymentRequest) (*domain.PaymentAuthorization, error) {
+	switch request.Token {
+	case FakeTokenDeclined:
+		return nil, &domain.PaymentDeclinedError{Reason: "card declined"}
+	case FakeTokenInsufficientFunds:
+		return nil, &domain.PaymentDeclinedError{Reason: "insufficient funds"}
+	case FakeTokenGatewayError:
+		return nil, &domain.PaymentGatewayError{Operation: "authorize", Detail: "gateway unavailable"}
+	}
+	if request.Amount <= 0 {
+		return nil, &domain.PaymentGatewayError{Operation: "authorize", Detail: "amount must be positive"}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if reference, ok := g.byKey[request.IdempotencyKey]; ok && request.IdempotencyKey != "" {
+		return &domain.PaymentAuthorization{Reference: reference, Amount: g.payments[reference].amount}, nil
+	}
+
+	g.seq++
+	reference := fmt.Sprintf("fake_pay_%06d", g.seq)
+	g.payments[reference] = &fakePayment{
+		token:   request.Token,
+		amount:  request.Amount,
+		refunds: make(map[string]int),
+	}
+	if request.IdempotencyKey != "" {
+		g.byKey[request.IdempotencyKey] = reference
+	}
+	return &domain.PaymentAuthorization{Reference: reference, Amount: request.Amount}, nil
+}
+
+func (g *FakePaymentGateway) Capture(ctx context.Context, reference string, amount int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, err := g.payment("capture", reference)
+	if err != nil {
+		return err
+	}
+	switch {
+	case payment.token == FakeTokenCaptureFails:
+		return &domain.PaymentGatewayError{Operation: "capture", Detail: "capture refused by the issuer"}
+	case payment.voided:
+		return &domain.PaymentGatewayError{Operation: "capture", Detail: "authorization was voided"}
+	case payment.captured > 0:
+		return nil
+	case amount <= 0 || amount > payment.amount:
+		return &domain.PaymentGatewayError{Operation: "capture", Detail: fmt.Sprintf("amount must be between 1 and %d", payment.amount)}
+	}
+	payment.captured = amount
+	return nil
+}
+
+func (g *FakePaymentGateway) Void(ctx context.Context, reference string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, err := g.payment("void", reference)
+	if err != nil {
+		return err
+	}
+	if payment.captured > 0 {
+		return &domain.PaymentGatewayError{Operation: "void", Detail: "payment was already captured"}
+	}
+	payment.voided = true
+	return nil
+}
+
+func (g *FakePaymentGateway) Refund(ctx context.Context, reference string, amount int, idempotencyKey string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, err := g.payment("refund", reference)
+	if err != nil {
+		return err
+	}
+	if _, ok := payment.refunds[idempotencyKey]; ok {
+		return nil
+	}
+	if payment.captured == 0 {
+		return &domain.PaymentGatewayError{Operation: "refund", Detail: "payment was not captured"}
+	}
+	if amount <= 0 || payment.refunded+amount > payment.captured {
+		return &domain.PaymentGatewayError{Operation: "refund", Detail: fmt.Sprintf("amount must be between 1 and %d", payment.captured-payment.refunded)}
+	}
+	payment.refunded += amount
+	payment.refunds[idempotencyKey] = amount
+	return nil
+}
+
+func (g *FakePaymentGateway) payment(operation string, reference string) (*fakePayment, error) {
+	payment, ok := g.payments[reference]
+	if !ok {
+		return nil, &domain.PaymentGatewayError{Operation: operation, Detail: fmt.Sprintf("unknown payment %s", reference)}
+	}
+	return payment, nil
+}
+
+type fakeWebhookEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Reference string `json:"reference"`
+	Amount    int    `json:"amount"`
+}
+
+// VerifyWebhook accepts a JSON body of id, type, reference and amount,
+// signed as SignPaymentWebhook signs it.
+func (g *FakePaymentGateway) VerifyWebhook(payload []byte, signature string) (*domain.PaymentEvent, error) {
+	// an empty key would let anyone sign a forged event
+	if len(g.secret) == 0 {
+		return nil, &domain.UnauthorizedError{Reason: "payment webhooks are disabled, no webhook secret is configured"}
+	}
+	expected := SignPaymentWebhook(string(g.secret), payload)
+	if !hmac.Equal([]byte(strings.TrimSpace(signature)), []byte(expected)) {
+		return nil, &domain.UnauthorizedError{Reason: "invalid webhook signature"}
+	}
+
+	var event fakeWebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, &domain.InvalidRequestError{Reason: "webhook body is not a payment event"}
+	}
+	if event.Type == "" || event.Reference == "" {
+		return nil, &domain.InvalidRequestError{Reason: "webhook event needs a type and a reference"}
+	}
+	return &domain.PaymentEvent{ID: event.ID, Type: event.Type, Reference: event.Reference, Amount: event.Amount}, nil
+}
+
+// SignPaymentWebhook is "sha256=" followed by the hex HMAC-SHA256 of body.
+func SignPaymentWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 	appservice "userService/application/service"
 	"userService/application/usecase"
@@ -11,6 +12,7 @@ import (
 	"userService/infrastructure/http"
 	"userService/infrastructure/http/config"
 	"userService/infrastructure/http/gin/handler"
+	"userService/infrastructure/http/gin/middleware"
 	"userService/infrastructure/http/gin/router"
 	"userService/infrastructure/persistence/mongodb"
 	mongorepository "userService/infrastructure/persistence/mongodb/repository"
@@ -22,6 +24,7 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"shared/debugvars"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -47,6 +50,67 @@ func main() {
 		fmt.Printf("Warning: Failed to create indexes: %v\n", err)
 	}
 
+	userTicketRepo := mongorepository.NewMongoUserTicketRepository(db)
+	if err := userTicketRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create user ticket indexes: %v\n", err)
+	}
+	if migrated, err := userRepo.MigrateEmbeddedTickets(ctx); err != nil {
+		fmt.Printf("Warning: Failed to migrate embedded tickets: %v\n", err)
+	} else if migrated > 0 {
+		fmt.Printf("Moved the tickets of %d users to user_tickets\n", migrated)
+	}
+
+	ticketTransferRepo := mongorepository.NewMongoTicketTransferRepository(db)
+	if err := ticketTransferRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create ticket transfer indexes: %v\n", err)
+	}
+	ticketAuditRepo := mongorepository.NewMongoTicketAuditRepository(db)
+	if err := ticketAuditRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create ticket audit indexes: %v\n", err)
+	}
+	resaleListingRepo := mongorepository.NewMongoResaleListingRepository(db)
+	if err := resaleListingRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create resale listing indexes: %v\n", err)
+	}
+	refundRepo := mongorepository.NewMongoRefundRepository(db)
+	if err := refundRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create refund indexes: %v\n", err)
+	}
+	waitlistRepo := mongorepository.NewMongoWaitlistRepository(db)
+	if err := waitlistRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create waitlist indexes: %v\n", err)
+	}
+	promoCodeRepo := mongorepository.NewMongoPromoCodeRepository(db)
+	if err := promoCodeRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create promo code indexes: %v\n", err)
+	}
+	promoRedemptionRepo := mongorepository.NewMongoPromoRedemptionRepository(db)
+	if err := promoRedemptionRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create promo redemption indexes: %v\n", err)
+	}
+
+	orderRepo := mongorepository.NewMongoOrderRepository(db)
+	if err := orderRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create order indexes: %v\n", err)
+	}
+	paymentRepo := mongorepository.NewMongoPaymentRepository(db)
+	if err := paymentRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create payment indexes: %v\n", err)
+	}
+	receiptRepo := mongorepository.NewMongoReceiptRepository(db)
+	if err := receiptRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create receipt indexes: %v\n", err)
+	}
+	calendarFeedRepo := mongorepository.NewMongoCalendarFeedRepository(db)
+	if err := calendarFeedRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create calendar feed indexes: %v\n", err)
+	}
+
+	idempotencyRepo := mongorepository.NewMongoIdempotencyRepository(db)
+	if err := idempotencyRepo.CreateIndexes(ctx); err != nil {
+		fmt.Printf("Warning: Failed to create idempotency indexes: %v\n", err)
+	}
+
 	idmHost := os.Getenv("IDM_HOST")
 	idmPort := os.Getenv("IDM_PORT")
 
@@ -73,7 +137,12 @@ func main() {
 	}
 
 	eventManagerClient := http.NewEventManagerClient(serviceAuthClient)
-	eventManagerService := infrastructureservice.NewEventManagerHTTPAdapter(eventManagerClient)
+	// event and packet details shown next to tickets may lag a minute behind
+	// EventManager
+	eventManagerService := infrastructureservice.NewCachingEventManagerService(
+		infrastructureservice.NewEventManagerHTTPAdapter(eventManagerClient),
+		time.Minute,
+	)
 
 	authenService, err := infrastructureservice.NewRealAuthenticationService(idmHost, idmPort)
 	if err != nil {
@@ -88,21 +157,74 @@ func main() {
 
 	authzService := infrastructureservice.NewDummyAuthorizationService(userRepo)
 
-	userService := appservice.NewUserService(userRepo)
+	userService := appservice.NewUserService(userRepo, userTicketRepo)
+
+	webhookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if webhookSecret == "" {
+		fmt.Println("Warning: PAYMENT_WEBHOOK_SECRET not set, payment webhooks are refused")
+	}
+	// no real provider is integrated yet; the fake gateway approves every
+	// card but its test tokens
+	paymentGateway := infrastructureservice.NewFakePaymentGateway(webhookSecret)
+	paymentService := appservice.NewPaymentService(paymentGateway, orderRepo, paymentRepo)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentService, userService, authenService)
+
+	// ticket prices include VAT, 21% unless RECEIPT_VAT_PERCENT says otherwise
+	vatPercent := 21
+	if value := os.Getenv("RECEIPT_VAT_PERCENT"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			vatPercent = parsed
+		} else {
+			fmt.Printf("Warning: Invalid RECEIPT_VAT_PERCENT %q, using %d\n", value, vatPercent)
+		}
+	}
+	receiptService := appservice.NewReceiptService(receiptRepo, userRepo, paymentRepo, vatPercent)
+	receiptUsecase := usecase.NewReceiptUsecase(receiptService, paymentService, userService, eventManagerService, authenService)
+
+	calendarService := appservice.NewCalendarService(calendarFeedRepo, userRepo)
+	calendarUsecase := usecase.NewCalendarUsecase(calendarService, userService, eventManagerService, authenService)
+
+	waitlistService := appservice.NewWaitlistService(waitlistRepo)
+	waitlistUsecase := usecase.NewWaitlistUsecase(waitlistService, userService, eventManagerService, authenService)
+
+	promoCodeService := appservice.NewPromoCodeService(promoCodeRepo, promoRedemptionRepo)
+	promoCodeUsecase := usecase.NewPromoCodeUsecase(promoCodeService, eventManagerService, authenService, authzService)
+
+	userUsecase := usecase.NewUserUsecase(userService, waitlistService, promoCodeService, paymentService, receiptService, eventManagerService, authenService, authzService)
+
+	ticketTransferService := appservice.NewTicketTransferService(userRepo, userTicketRepo, ticketTransferRepo, ticketAuditRepo, resaleListingRepo, refundRepo)
+	ticketTransferUsecase := usecase.NewTicketTransferUsecase(ticketTransferService, userService, eventManagerService, authenService)
+
+	resaleService := appservice.NewResaleService(userTicketRepo, resaleListingRepo, ticketTransferRepo, refundRepo)
+	resaleUsecase := usecase.NewResaleUsecase(resaleService, paymentService, userService, eventManagerService, authenService)
 
-	userUsecase := usecase.NewUserUsecase(userService, eventManagerService, authenService, authzService)
+	refundService := appservice.NewRefundService(userTicketRepo, refundRepo, ticketTransferRepo, resaleListingRepo)
+	refundUsecase := usecase.NewRefundUsecase(refundService, paymentService, waitlistService, userService, eventManagerService, authenService, authzService)
 
 	serviceURLs := config.NewServiceURLs()
 
 	userHandler := handler.NewGinUserHandler(userUsecase, serviceURLs)
+	ticketTransferHandler := handler.NewGinTicketTransferHandler(ticketTransferUsecase, serviceURLs)
+	resaleHandler := handler.NewGinResaleHandler(resaleUsecase, serviceURLs)
+	refundHandler := handler.NewGinRefundHandler(refundUsecase, serviceURLs)
+	waitlistHandler := handler.NewGinWaitlistHandler(waitlistUsecase, serviceURLs)
+	promoCodeHandler := handler.NewGinPromoCodeHandler(promoCodeUsecase, serviceURLs)
+	paymentHandler := handler.NewGinPaymentHandler(paymentUsecase, serviceURLs)
+	receiptHandler := handler.NewGinReceiptHandler(receiptUsecase, serviceURLs)
+	calendarHandler := handler.NewGinCalendarHandler(calendarUsecase, serviceURLs)
+
+	// call, retry and circuit breaker counters of the outgoing HTTP clients,
+	// kept off the public router
+	debugvars.Start(os.Getenv("USER_DEBUG_PORT"))
 
 	r := gin.Default()
 
+	r.Use(middleware.RequestID())
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:5173"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "User-Agent", "Cache-Control", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "User-Agent", "Cache-Control", "X-Requested-With", "X-Request-ID", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "X-Request-ID", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * 3600,
 	}))
@@ -110,7 +232,19 @@ func main() {
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	userAPI := r.Group("/api/user-manager")
-	router.RegisterUserRoutes(userAPI, userHandler)
+	router.RegisterUserRoutes(userAPI, userHandler, middleware.Idempotency(idempotencyRepo, authenService))
+	router.RegisterTicketTransferRoutes(userAPI, ticketTransferHandler)
+	router.RegisterResaleRoutes(userAPI, resaleHandler)
+	router.RegisterRefundRoutes(userAPI, refundHandler)
+	router.RegisterWaitlistRoutes(userAPI, waitlistHandler)
+	router.RegisterPromoCodeRoutes(userAPI, promoCodeHandler)
+	router.RegisterPaymentRoutes(userAPI, paymentHandler)
+	router.RegisterReceiptRoutes(userAPI, receiptHandler)
+	router.RegisterCalendarRoutes(userAPI, calendarHandler)
+
+	// picks up seats freed outside the User service, such as a capacity
+	// increase, and moves expired offers on to the next user
+	go waitlistService.Run(context.Background(), 30*time.Second, eventManagerService)
 
 	port := os.Getenv("USER_PORT")
 
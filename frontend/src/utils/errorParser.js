@@ -19,7 +19,7 @@ export const parseErrorMessage = (error, defaultMessage = 'An error occurred') =
         case 404:
             return parseNotFound(data) || 'The requested resource was not found.';
         case 409:
-            return 'This item already exists or conflicts with existing data.';
+            return parseConflict(data) || 'This item already exists or conflicts with existing data.';
         case 422:
             return parseValidationError(data) || 'Invalid data provided. Please check your input.';
         case 500:
@@ -34,8 +34,8 @@ export const parseErrorMessage = (error, defaultMessage = 'An error occurred') =
         return cleanErrorMessage(data);
     }
 
-    if (data?.error) {
-        return cleanErrorMessage(data.error);
+    if (data?.detail || data?.error) {
+        return cleanErrorMessage(data.detail || data.error);
     }
 
     if (data?.message) {
@@ -46,6 +46,31 @@ export const parseErrorMessage = (error, defaultMessage = 'An error occurred') =
 };
 
 
+// problemMessage reads an application/problem+json body (detail and
+// field-level errors[]), falling back to the older {error} / {message} shape.
+const problemMessage = (data) => {
+    if (Array.isArray(data?.errors) && data.errors.length > 0) {
+        return data.errors
+            .map((err) => (err.field ? `${err.field}: ${err.reason}` : err.reason))
+            .join('; ');
+    }
+    return data?.detail || data?.error || data?.message || data?.title || '';
+};
+
+// parseConflict uses the machine-readable code of seat-availability problems.
+const parseConflict = (data) => {
+    switch (data?.code) {
+        case 'EVENT_SOLD_OUT':
+            return 'This event is sold out.';
+        case 'PACKET_SOLD_OUT':
+            return 'This packet is sold out.';
+        case 'CAPACITY_NOT_CONFIGURED':
+            return 'Tickets are not on sale yet: no seats have been configured.';
+        default:
+            return null;
+    }
+};
+
 const parseBadRequest = (data) => {
     if (typeof data === 'string' && data.includes('validation error')) {
         return parseValidationError(data);
@@ -54,7 +79,7 @@ const parseBadRequest = (data) => {
 };
 
 const parseNotFound = (data) => {
-    const message = typeof data === 'string' ? data : (data?.error || data?.message || '');
+    const message = typeof data === 'string' ? data : problemMessage(data);
 
     if (message.includes('User') || message.includes('user')) {
         return 'User not found.';
@@ -77,7 +102,7 @@ const parseNotFound = (data) => {
 
 
 const parseValidationError = (data) => {
-    const message = typeof data === 'string' ? data : (data?.error || data?.message || '');
+    const message = typeof data === 'string' ? data : problemMessage(data);
 
     let cleanMessage = message;
     const jsonMatch = message.match(/\{.*\}/);
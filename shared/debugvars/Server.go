@@ -0,0 +1,28 @@
+// Package debugvars serves the expvar metrics of a service on a listener of
+// its own, kept off the public API router.
+package debugvars
+
+import (
+	"expvar"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// Start serves GET /debug/vars on port in the background. An empty port
+// leaves the listener off; the port is meant to stay inside the service
+// network and must not be published.
+func Start(port string) {
+	if port == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	go func() {
+		if err := http.ListenAndServe(":"+port, mux); err != nil {
+			log.Printf("Warning: debug server on port %s stopped: %v", port, err)
+		}
+	}()
+	fmt.Printf("Serving /debug/vars on port %s\n", port)
+}
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the remote service while the
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker opens after FailureThreshold consecutive failures and
+// rejects calls for OpenTimeout. It then lets HalfOpenProbes calls through:
+// one success closes it again, one failure reopens it.
+type CircuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	halfOpenProbes   int
+	onStateChange    func(from State, to State)
+
+	mu             sync.Mutex
+	state          State
+	failures       int
+	openedAt       time.Time
+	probesInFlight int
+	now            func() time.Time
+}
+
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration, halfOpenProbes int, onStateChange func(from State, to State)) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	if halfOpenProbes < 1 {
+		halfOpenProbes = 1
+	}
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		halfOpenProbes:   halfOpenProbes,
+		onStateChange:    onStateChange,
+		now:              time.Now,
+	}
+}
+
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow reports whether a call may go out; every allowed call must be
+// followed by exactly one Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.transition(StateHalfOpen)
+		b.probesInFlight = 1
+		return nil
+	case StateHalfOpen:
+		if b.probesInFlight >= b.halfOpenProbes {
+			return ErrCircuitOpen
+		}
+		b.probesInFlight++
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *CircuitBreaker) Record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateHalfOpen:
+		b.probesInFlight--
+		if success {
+			b.failures = 0
+			b.transition(StateClosed)
+			return
+		}
+		b.open()
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.now()
+	b.probesInFlight = 0
+	b.transition(StateOpen)
+}
+
+func (b *CircuitBreaker) transition(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	if b.onStateChange != nil {
+		b.onStateChange(from, to)
+	}
+}
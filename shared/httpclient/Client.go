@@ -0,0 +1,238 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Config tunes a Client. Zero values fall back to DefaultConfig.
+type Config struct {
+	// Name identifies the remote service in logs and metrics.
+	Name string
+	// AttemptTimeout bounds each attempt; the caller's context deadline
+	// still wins when it is sooner.
+	AttemptTimeout time.Duration
+	// MaxAttempts bounds the attempts of an idempotent request, first one
+	// included. Other requests are sent once.
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// FailureThreshold consecutive failures open the breaker for
+	// OpenTimeout, after which HalfOpenProbes calls test the service.
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	HalfOpenProbes   int
+}
+
+func DefaultConfig(name string) Config {
+	return Config{
+		Name:             name,
+		AttemptTimeout:   3 * time.Second,
+		MaxAttempts:      3,
+		BaseBackoff:      100 * time.Millisecond,
+		MaxBackoff:       2 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenProbes:   1,
+	}
+}
+
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig(c.Name)
+	if c.AttemptTimeout <= 0 {
+		c.AttemptTimeout = defaults.AttemptTimeout
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = defaults.BaseBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaults.MaxBackoff
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaults.FailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = defaults.OpenTimeout
+	}
+	if c.HalfOpenProbes <= 0 {
+		c.HalfOpenProbes = defaults.HalfOpenProbes
+	}
+	return c
+}
+
+// Request is a call to send through a Client. Idempotent requests are
+// retried on network errors and 502/503/504 responses.
+type Request struct {
+	Method     string
+	URL        string
+	Header     http.Header
+	Body       []byte
+	Idempotent bool
+}
+
+// Response is a fully read response; the body is already closed.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Client sends requests to one remote service with per-attempt deadlines,
+// jittered retries and a circuit breaker shared by every call.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	breaker    *CircuitBreaker
+	metrics    *metrics
+}
+
+func New(config Config) *Client {
+	config = config.withDefaults()
+
+	client := &Client{
+		config:     config,
+		httpClient: &http.Client{},
+		metrics:    &metrics{},
+	}
+	client.breaker = NewCircuitBreaker(config.FailureThreshold, config.OpenTimeout, config.HalfOpenProbes, client.onStateChange)
+
+	clientMetrics.Set(config.Name, expvar.Func(func() any { return client.Metrics() }))
+	return client
+}
+
+func (c *Client) Metrics() Metrics {
+	return c.metrics.snapshot(c.breaker.State())
+}
+
+func (c *Client) onStateChange(from State, to State) {
+	if to == StateOpen {
+		c.metrics.breakerOpened.Add(1)
+	}
+	log.Printf("httpclient %s: circuit breaker %s -> %s", c.config.Name, from, to)
+}
+
+// Do sends req, retrying idempotent requests while the caller's context
+// allows it. The last response is returned as is, whatever its status;
+// only network failures and an open breaker are returned as errors.
+func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
+	c.metrics.calls.Add(1)
+
+	attempts := 1
+	if req.Idempotent {
+		attempts = c.config.MaxAttempts
+	}
+
+	var lastResp *Response
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if !c.sleep(ctx, attempt) {
+				break
+			}
+			c.metrics.retries.Add(1)
+		}
+
+		if err := c.breaker.Allow(); err != nil {
+			c.metrics.shortCircuited.Add(1)
+			return nil, fmt.Errorf("%s: %w", c.config.Name, err)
+		}
+
+		resp, err := c.attempt(ctx, req)
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		c.breaker.Record(!failed)
+		if failed {
+			c.metrics.failures.Add(1)
+		} else {
+			c.metrics.successes.Add(1)
+		}
+
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		lastResp, lastErr = resp, err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if lastResp != nil {
+		return lastResp, nil
+	}
+	if ctx.Err() != nil {
+		lastErr = ctx.Err()
+	}
+	return nil, fmt.Errorf("%s: %w", c.config.Name, lastErr)
+}
+
+func (c *Client) attempt(ctx context.Context, req *Request) (*Response, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, c.config.AttemptTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(attemptCtx, req.Method, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range req.Header {
+		httpReq.Header[key] = values
+	}
+
+	c.metrics.requests.Add(1)
+	start := time.Now()
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.metrics.observeLatency(time.Since(start))
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.metrics.timeouts.Add(1)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	c.metrics.observeLatency(time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// sleep waits a full-jitter backoff before the given attempt and reports
+// false when the caller's context ends first or has no time left for it.
+func (c *Client) sleep(ctx context.Context, attempt int) bool {
+	backoff := c.config.BaseBackoff << (attempt - 2)
+	if backoff <= 0 || backoff > c.config.MaxBackoff {
+		backoff = c.config.MaxBackoff
+	}
+	wait := rand.N(backoff + 1)
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusBadGateway ||
+		status == http.StatusServiceUnavailable ||
+		status == http.StatusGatewayTimeout
+}
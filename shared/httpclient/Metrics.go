@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// clientMetrics is published under the "httpclient" expvar map, one entry per
+// client name, and can be read with Client.Metrics.
+var clientMetrics = expvar.NewMap("httpclient")
+
+// Metrics counts the calls made through a Client. Requests counts attempts
+// that reached the network; Calls counts Do invocations.
+type Metrics struct {
+	Calls          int64  `json:"calls"`
+	Requests       int64  `json:"requests"`
+	Successes      int64  `json:"successes"`
+	Failures       int64  `json:"failures"`
+	Retries        int64  `json:"retries"`
+	ShortCircuited int64  `json:"short_circuited"`
+	Timeouts       int64  `json:"timeouts"`
+	BreakerOpened  int64  `json:"breaker_opened"`
+	LatencyMsTotal int64  `json:"latency_ms_total"`
+	BreakerState   string `json:"breaker_state"`
+}
+
+type metrics struct {
+	calls          atomic.Int64
+	requests       atomic.Int64
+	successes      atomic.Int64
+	failures       atomic.Int64
+	retries        atomic.Int64
+	shortCircuited atomic.Int64
+	timeouts       atomic.Int64
+	breakerOpened  atomic.Int64
+	latencyMs      atomic.Int64
+}
+
+func (m *metrics) observeLatency(d time.Duration) {
+	m.latencyMs.Add(d.Milliseconds())
+}
+
+func (m *metrics) snapshot(state State) Metrics {
+	return Metrics{
+		Calls:          m.calls.Load(),
+		Requests:       m.requests.Load(),
+		Successes:      m.successes.Load(),
+		Failures:       m.failures.Load(),
+		Retries:        m.retries.Load(),
+		ShortCircuited: m.shortCircuited.Load(),
+		Timeouts:       m.timeouts.Load(),
+		BreakerOpened:  m.breakerOpened.Load(),
+		LatencyMsTotal: m.latencyMs.Load(),
+		BreakerState:   state.String(),
+	}
+}
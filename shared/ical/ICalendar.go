@@ -0,0 +1,70 @@
+// Package ical writes iCalendar (RFC 5545) documents: content lines, the
+// escaping of TEXT values and the folding of long lines.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar documents.
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets is the longest content line RFC 5545 allows before it has
+// to be folded.
+const maxLineOctets = 75
+
+// EventUID identifies an EventManager event across every calendar it is
+// published in, so adding the same event from two calendars does not
+// duplicate it.
+func EventUID(id int) string {
+	return fmt.Sprintf("event-%d@event-manager", id)
+}
+
+// FormatTime formats a DATE-TIME value in UTC.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// EscapeText escapes a TEXT value: backslashes, semicolons, commas and line
+// breaks.
+func EscapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// Writer buffers a document one content line at a time.
+type Writer struct {
+	out bytes.Buffer
+}
+
+// Line writes "name:value" ending with CRLF, folding it into lines of at
+// most 75 octets without splitting a UTF-8 character; every continuation
+// starts with a space. TEXT values have to be escaped with EscapeText first.
+func (w *Writer) Line(name string, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.out.WriteString(line[:cut])
+		w.out.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts towards the continuation line
+		limit = maxLineOctets - 1
+	}
+	w.out.WriteString(line)
+	w.out.WriteString("\r\n")
+}
+
+// WriteTo writes the document written so far to out.
+func (w *Writer) WriteTo(out io.Writer) (int64, error) {
+	n, err := out.Write(w.out.Bytes())
+	return int64(n), err
+}
@@ -0,0 +1,161 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	KeyHeader      = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// CallerFunc names the authenticated caller of a request, or reports false
+// when the request does not authenticate.
+type CallerFunc func(c *gin.Context) (string, bool)
+
+// ErrorWriter sends err as the response and aborts the request. It receives
+// the errors of this package and those of the Store.
+type ErrorWriter func(c *gin.Context, err error)
+
+// recordingWriter keeps a copy of the response body so it can be replayed.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Middleware makes a route safe to retry when the caller sends an
+// Idempotency-Key header: the first response is stored and replayed for the
+// same key, caller and body; reusing the key with another body is rejected.
+// Requests without the header, or whose caller does not authenticate, pass
+// through untouched.
+func Middleware(store Store, caller CallerFunc, writeError ErrorWriter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(KeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if err := ValidateKey(key); err != nil {
+			writeError(c, err)
+			return
+		}
+
+		callerID, ok := caller(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			writeError(c, &RequestBodyError{Err: err})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		record := &Record{
+			Key:         key,
+			Scope:       fmt.Sprintf("%s %s %s", callerID, c.Request.Method, c.Request.URL.Path),
+			Fingerprint: requestFingerprint(body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(LockTimeout),
+		}
+
+		existing, reserved, err := store.Reserve(c.Request.Context(), record)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		if !reserved {
+			replay(c, record, existing, writeError)
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// the outcome is stored even if the caller hung up meanwhile
+		ctx := context.WithoutCancel(c.Request.Context())
+		status := writer.Status()
+		if !replayableStatus(status) {
+			if err := store.Release(ctx, record.Scope, record.Key); err != nil {
+				log.Printf("idempotency: %v", err)
+			}
+			return
+		}
+
+		record.StatusCode = status
+		record.ContentType = writer.Header().Get("Content-Type")
+		record.ResponseBody = writer.body.Bytes()
+		record.ExpiresAt = time.Now().Add(Retention)
+		if err := store.Complete(ctx, record); err != nil {
+			log.Printf("idempotency: %v", err)
+		}
+	}
+}
+
+func replay(c *gin.Context, record *Record, existing *Record, writeError ErrorWriter) {
+	if existing.Fingerprint != record.Fingerprint {
+		writeError(c, &KeyReusedError{Key: record.Key})
+		return
+	}
+	if !existing.Completed() {
+		writeError(c, &InProgressError{Key: record.Key})
+		return
+	}
+
+	c.Header(ReplayedHeader, strconv.FormatBool(true))
+	if len(existing.ResponseBody) == 0 {
+		c.AbortWithStatus(existing.StatusCode)
+		return
+	}
+	c.Data(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+	c.Abort()
+}
+
+// replayableStatus leaves out server errors, which are worth retrying for
+// real, and auth failures, which depend on the token rather than the request.
+func replayableStatus(status int) bool {
+	return status < http.StatusInternalServerError &&
+		status != http.StatusUnauthorized &&
+		status != http.StatusForbidden
+}
+
+// requestFingerprint hashes the body in canonical JSON form, so retries that
+// only reorder keys or change whitespace still match.
+func requestFingerprint(body []byte) string {
+	canonical := body
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if encoded, err := json.Marshal(payload); err == nil {
+			canonical = encoded
+		}
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,98 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Retention is how long a stored response can be replayed.
+	Retention = 24 * time.Hour
+	// LockTimeout releases keys whose first request never completed, e.g.
+	// because the process crashed mid-request.
+	LockTimeout  = time.Minute
+	MaxKeyLength = 255
+)
+
+// Record is the stored outcome of a request sent with an Idempotency-Key
+// header. Scope ties the key to one caller and route, and Fingerprint to one
+// request body. A record without StatusCode is still in flight.
+type Record struct {
+	Key          string
+	Scope        string
+	Fingerprint  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Store keeps the records of a service.
+type Store interface {
+	// Reserve stores record as in flight unless its key is already taken in
+	// the scope, in which case the existing record is returned with false.
+	Reserve(ctx context.Context, record *Record) (*Record, bool, error)
+	Complete(ctx context.Context, record *Record) error
+	Release(ctx context.Context, scope string, key string) error
+}
+
+func ValidateKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return &InvalidKeyError{Reason: "must not be empty"}
+	}
+	if len(key) > MaxKeyLength {
+		return &InvalidKeyError{Reason: fmt.Sprintf("must be at most %d characters", MaxKeyLength)}
+	}
+	return nil
+}
+
+// InvalidKeyError is returned for an Idempotency-Key header that is blank or
+// too long.
+type InvalidKeyError struct {
+	Reason string
+}
+
+func (e *InvalidKeyError) Error() string {
+	return fmt.Sprintf("invalid Idempotency-Key: %s", e.Reason)
+}
+
+// RequestBodyError is returned when the body of a request sent with a key
+// cannot be read.
+type RequestBodyError struct {
+	Err error
+}
+
+func (e *RequestBodyError) Error() string {
+	return "failed to read request body"
+}
+
+func (e *RequestBodyError) Unwrap() error {
+	return e.Err
+}
+
+// KeyReusedError is returned when a key comes back with a different request
+// body than the one it was first used with.
+type KeyReusedError struct {
+	Key string
+}
+
+func (e *KeyReusedError) Error() string {
+	return fmt.Sprintf("idempotency key '%s' was already used with a different request", e.Key)
+}
+
+// InProgressError is returned while the first request sent with a key is
+// still being processed.
+type InProgressError struct {
+	Key string
+}
+
+func (e *InProgressError) Error() string {
+	return fmt.Sprintf("a request with idempotency key '%s' is still being processed", e.Key)
+}